@@ -5,23 +5,69 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"os"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/wailsapp/wails/v2/pkg/runtime"
 	_ "modernc.org/sqlite"
 )
 
 // App struct
 type App struct {
-	ctx        context.Context
-	db         *sql.DB
-	cache      *GlyphCache
-	history    *SearchHistory
-	favorites  *Favorites
-	categories *CategoryManager
+	ctx      context.Context
+	db       *sql.DB // single-connection writer
+	readDB   *sql.DB // read-only pool for searches and cache loads
+	dbPath   string
+	dataPath string
+	userDB   *sql.DB // favorites, collections, and settings of the active profile
+	profile  string
+
+	// fallbackReason is set when gylte.db failed to open and embedded data is served
+	fallbackReason string
+	forceReadOnly  bool   // set by the --readonly flag
+	sharedDBPath   string // set by the --shared-db flag
+	localDB        *sql.DB
+	portable       bool   // set by the --portable flag; skips OS integration
+	launchURL      string // gylte:// link the app was started with
+	headless       bool   // running a command line mode without a window
+	debug          bool   // set by the --debug flag; serves pprof and logs startup timings
+	cache          *GlyphCache
+	history        *SearchHistory
+	favorites      FavoritesStore
+	settings       *Settings
+	i18n           *Localizer
+	updater        *UpdateChecker
+	commands       *CommandRegistry
+	exporters      *ExporterRegistry
+	plugins        *Plugins
+	hooks          *Hooks
+	localAPI       *LocalAPI
+	bridge         *EventBridge
+	renderer       *GlyphRenderer
+	logFile        *os.File
+	operations     *Operations
+	notifications  *Notifications
+	session        *Session
+	importer       *Importer
+	writes         *WriteQueue
+	searchStats    *SearchStats
+	startedAt      time.Time
+	hotkeys        *GlyphHotkeys
+	clipWatch      *ClipboardWatcher
+	scratchpad     *Scratchpad
+	compareTray    *CompareTray
+	smartCopy      *SmartCopy
+	jumpList       *JumpList
+	progressBadge  *ProgressBadge
+	bulkEdits      *BulkEdits
+	clickThrough   *ClickThrough
+	frecency       *FrecencyCache
+	maintenance    *Maintenance
+	vault          *Vault
+	deprecations   *Deprecations
+	startupTimer   *StartupTimer
 }
 
 // Glyph struct for database results
@@ -31,6 +77,14 @@ type Glyph struct {
 	Glyph    string `json:"glyph"`
 	Category string `json:"category,omitempty"`
 	Tags     string `json:"tags,omitempty"`
+	Block    string `json:"block,omitempty"`
+	Width    string `json:"width,omitempty"`
+
+	// Description is a human readable label for screen readers
+	Description string `json:"description,omitempty"`
+
+	// Source names the database the glyph was loaded from
+	Source string `json:"source,omitempty"`
 }
 
 // GlyphMatch represents a glyph with its fuzzy match score
@@ -38,91 +92,185 @@ type GlyphMatch struct {
 	Glyph
 	Score      int  `json:"score"`
 	IsFavorite bool `json:"isFavorite"`
-}
 
-// GlyphCache provides in-memory caching for faster searches
-type GlyphCache struct {
-	mu     sync.RWMutex
-	glyphs []Glyph
-	loaded bool
+	// FavoritedAt and TimesUsed are set on favorites: when the glyph was
+	// favorited and how many times it has been copied since
+	FavoritedAt string `json:"favoritedAt,omitempty"`
+	TimesUsed   int    `json:"timesUsed,omitempty"`
+
+	// Deprecated is set when a newer icon set release removed or renamed the glyph
+	Deprecated *GlyphDeprecation `json:"deprecated,omitempty"`
+
+	// Explain itemizes Score when GetGlyphs is called with debug set
+	Explain *ScoreBreakdown `json:"explain,omitempty"`
 }
 
 // SearchHistory tracks recent searches
 type SearchHistory struct {
-	mu      sync.RWMutex
-	history []string
-	maxSize int
-}
-
-// Favorites manages user favorites
-type Favorites struct {
-	mu        sync.RWMutex
-	favorites map[int]bool
-	db        *sql.DB
+	mu         sync.RWMutex
+	history    []string
+	searchedAt map[string]time.Time
+	prunedAt   time.Time
 }
 
-// CategoryManager handles glyph categorization
-type CategoryManager struct {
-	mu         sync.RWMutex
-	categories map[string][]int
+// SearchRequest holds the options of a search. Every field is optional: the zero
+// value searches everything with the configured page size, so new options can be
+// added without changing the GetGlyphs signature.
+type SearchRequest struct {
+	// Query is the search term; empty lists glyphs in their natural order
+	Query    string `json:"query"`
+	Category string `json:"category,omitempty"`
+	// Limit is the page size; 0 uses the configured page size
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+	// Scope is "all" (or empty), "favorites", "collection:<name>", or "smart:<name>"
+	Scope string `json:"scope,omitempty"`
+	// Profile picks a scoring profile for this query; empty uses the configured one
+	Profile string `json:"profile,omitempty"`
+	// Debug attaches a score breakdown to every match
+	Debug bool `json:"debug,omitempty"`
 }
 
-// SearchResult wraps results with metadata
-type SearchResult struct {
+// SearchResponse wraps results with metadata
+type SearchResponse struct {
 	Glyphs     []GlyphMatch `json:"glyphs"`
 	Total      int          `json:"total"`
 	SearchTime float64      `json:"searchTime"`
 	HasMore    bool         `json:"hasMore"`
 	Categories []string     `json:"categories,omitempty"`
+
+	// DidYouMean lists corrected terms when the results come from typo tolerance
+	DidYouMean []string `json:"didYouMean,omitempty"`
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{
+	writes := &WriteQueue{}
+	a := &App{
+		dbPath:     "./gylte.db",
+		dataPath:   ".",
 		cache:      &GlyphCache{},
-		history:    &SearchHistory{maxSize: 20},
-		favorites:  &Favorites{favorites: make(map[int]bool)},
-		categories: &CategoryManager{categories: make(map[string][]int)},
+		history:    &SearchHistory{searchedAt: make(map[string]time.Time)},
+		favorites:  newSQLiteFavorites(writes),
+		settings:   &Settings{values: make(map[string]string)},
+		i18n:       NewLocalizer(),
+		updater:    NewUpdateChecker(),
+		commands:   NewCommandRegistry(),
+		exporters:  NewExporterRegistry(),
+		plugins:    &Plugins{},
+		hooks:      &Hooks{},
+		localAPI:   &LocalAPI{},
+		bridge:     &EventBridge{},
+		renderer:   &GlyphRenderer{},
+		operations: NewOperations(),
+
+		notifications: &Notifications{},
+		session:       &Session{},
+		importer:      &Importer{},
+		writes:        writes,
+		searchStats:   &SearchStats{},
+		startedAt:     time.Now(),
+		hotkeys:       &GlyphHotkeys{},
+		clipWatch:     &ClipboardWatcher{},
+		scratchpad:    &Scratchpad{},
+		compareTray:   &CompareTray{},
+		smartCopy:     &SmartCopy{},
+		jumpList:      &JumpList{},
+		progressBadge: &ProgressBadge{},
+		bulkEdits:     &BulkEdits{},
+		clickThrough:  &ClickThrough{},
+		frecency:      &FrecencyCache{},
+		maintenance:   &Maintenance{},
+		vault:         &Vault{},
+		deprecations:  &Deprecations{},
+		startupTimer:  &StartupTimer{},
 	}
+	a.registerCommands()
+	a.registerExporters()
+	return a
 }
 
 // startup is called when the app starts
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	a.setupLogging()
+	if a.debug {
+		a.startDebugServer()
+	}
 
-	var err error
-	a.db, err = sql.Open("sqlite", "./gylte.db")
-	if err != nil {
+	start := time.Now()
+	if err := a.openDatabases(); err != nil {
+		// Keep search working from the embedded data instead of showing an empty grid
 		log.Printf("Failed to open database: %v", err)
+		a.startFallback(err)
+		a.loadLocale()
 		return
 	}
-
-	// Initialize favorites table
-	if err := a.initFavoritesTable(); err != nil {
-		log.Printf("Failed to initialize favorites: %v", err)
+	a.recordStartup(StartupPhaseDatabase, start)
+
+	// Open the active profile's user data before anything reads settings;
+	// encrypted profiles start locked, so drop any decrypted copy a crash left
+	removeStaleUnlocked()
+	start = time.Now()
+	if err := a.openProfile(a.activeProfile()); err != nil {
+		log.Printf("Failed to open profile: %v", err)
 	}
-
-	a.favorites.db = a.db
-
-	// Preload cache in background
-	go a.preloadCache()
-
-	// Load favorites
-	go a.loadFavorites()
+	a.recordStartup(StartupPhaseProfile, start)
+
+	// Preload cache in background, then check which first-run steps are already satisfied
+	go func() {
+		start := time.Now()
+		a.classifyGlyphWidths()
+		a.preloadCache()
+		a.recordStartup(StartupPhaseCache, start)
+		a.detectOnboardingSteps()
+	}()
+
+	// Periodic jobs, including the opt-in update check (the clipboard watch
+	// starts with the profile)
+	a.startMaintenance()
+	if a.settings.GetBool("api.enabled", false) {
+		if err := a.startLocalAPI(); err != nil {
+			log.Printf("%v", err)
+		}
+	}
+	a.setupIntegration()
+	go a.updateJumpList()
 
 	log.Println("App started successfully")
 }
 
 // shutdown cleanup
 func (a *App) shutdown(ctx context.Context) {
+	a.stopDebugServer()
+	a.stopMaintenance()
+	a.stopClipboardWatch()
+	a.stopLocalAPI()
+	a.stopClickThrough()
+	a.closeHotkeys()
+	a.flushSession()
+	a.flushJumpList()
+	if err := a.sealProfile(); err != nil {
+		log.Printf("Failed to save encrypted profile: %v", err)
+	}
+	a.closeUserDB()
+	a.forgetVault()
+
+	if a.readDB != nil && a.readDB != a.db {
+		a.readDB.Close()
+	}
 	if a.db != nil {
 		a.db.Close()
 	}
+	if a.localDB != nil {
+		a.localDB.Close()
+	}
+	a.closeLogging()
 }
 
 // initFavoritesTable creates the favorites table if it doesn't exist
 func (a *App) initFavoritesTable() error {
-	_, err := a.db.Exec(`
+	_, err := a.userDB.Exec(`
 		CREATE TABLE IF NOT EXISTS favorites (
 			glyph_id INTEGER PRIMARY KEY,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
@@ -134,159 +282,100 @@ func (a *App) initFavoritesTable() error {
 
 // preloadCache loads all glyphs into memory
 func (a *App) preloadCache() {
-	rows, err := a.db.Query("SELECT id, name, glyph FROM glyphs ORDER BY name")
+	a.cache.refreshMu.Lock()
+	defer a.cache.refreshMu.Unlock()
+
+	if a.settings.Get("search.mode", SearchModeCache) == SearchModeDirect {
+		a.useDirectSearch("selected in settings")
+		a.loadDeprecations()
+		return
+	}
+
+	start := time.Now()
+	glyphs, err := queryGlyphs(a.readDB, 0, sourceName(a.dbPath))
 	if err != nil {
 		log.Printf("Failed to preload cache: %v", err)
 		return
 	}
-	defer rows.Close()
-
-	a.cache.mu.Lock()
-	defer a.cache.mu.Unlock()
-
-	a.cache.glyphs = nil
-	for rows.Next() {
-		var g Glyph
-		if err := rows.Scan(&g.ID, &g.Name, &g.Glyph); err != nil {
-			log.Printf("Error scanning glyph: %v", err)
-			continue
-		}
-		a.cache.glyphs = append(a.cache.glyphs, g)
-
-		// Extract category from name (e.g., "nf-cod-account" -> "cod")
-		a.categorizeGlyph(&g)
+	timings := &CacheLoadTimings{QueryMs: millisSince(start)}
+
+	phase := time.Now()
+	glyphs = a.loadAttachedGlyphs(glyphs)
+	glyphs = a.loadPluginGlyphs(glyphs)
+	a.applyGlyphMetadata(glyphs)
+	timings.MergeMs = millisSince(phase)
+
+	// Build the new snapshot without blocking readers, then swap it in
+	phase = time.Now()
+	snap := newCacheSnapshot(glyphs)
+	timings.IndexMs = millisSince(phase)
+
+	if limit := a.cacheMemoryLimit(); limit > 0 && snap.memoryEstimate() > limit {
+		a.useDirectSearch(fmt.Sprintf("the cache needs %d KB, over the %d MB limit", snap.memoryEstimate()>>10, limit>>20))
+		a.loadDeprecations()
+		return
 	}
 
-	a.cache.loaded = true
-	log.Printf("Cache loaded: %d glyphs", len(a.cache.glyphs))
-}
-
-// categorizeGlyph extracts category from glyph name
-func (a *App) categorizeGlyph(g *Glyph) {
-	parts := strings.Split(g.Name, "-")
-	if len(parts) >= 2 {
-		category := parts[1] // e.g., "nf-cod-account" -> "cod"
+	a.cache.direct.Store(false)
+	a.cache.Swap(snap)
+	a.loadDeprecations()
+	timings.TotalMs = millisSince(start)
+	timings.Glyphs = len(glyphs)
+	timings.LoadedAt = time.Now()
+	a.cache.timings.Store(timings)
 
-		a.categories.mu.Lock()
-		a.categories.categories[category] = append(a.categories.categories[category], g.ID)
-		a.categories.mu.Unlock()
-	}
+	log.Printf("Cache loaded: %d glyphs in %.1fms (query %.1fms, merge %.1fms, index %.1fms)",
+		len(glyphs), timings.TotalMs, timings.QueryMs, timings.MergeMs, timings.IndexMs)
 }
 
-// loadFavorites loads favorites from database
-func (a *App) loadFavorites() {
-	rows, err := a.db.Query("SELECT glyph_id FROM favorites")
-	if err != nil {
-		log.Printf("Failed to load favorites: %v", err)
-		return
-	}
-	defer rows.Close()
-
-	a.favorites.mu.Lock()
-	defer a.favorites.mu.Unlock()
-
-	for rows.Next() {
-		var id int
-		if err := rows.Scan(&id); err != nil {
-			continue
-		}
-		a.favorites.favorites[id] = true
+// RebuildCache reloads all glyphs and categories from the database
+func (a *App) RebuildCache() error {
+	op := a.startOperation("rebuild", "Rebuilding glyph cache…")
+	if a.db == nil {
+		return op.Fail("Could not rebuild the glyph cache", newAppError(ErrCodeDBMissing, "database not open"))
 	}
+	a.preloadCache()
 
-	log.Printf("Loaded %d favorites", len(a.favorites.favorites))
+	count := a.cache.Len()
+	a.emit("cache:rebuilt", count)
+	op.Succeed(fmt.Sprintf("Glyph cache rebuilt: %d glyphs", count))
+	return nil
 }
 
-// fuzzyMatch implements fzf-style fuzzy matching
-func fuzzyMatch(pattern, text string) (int, bool) {
-	pattern = strings.ToLower(pattern)
-	text = strings.ToLower(text)
-
-	if pattern == "" {
-		return 0, true
+// matchGlyphs filters cached glyphs by category, scope and search term, returning
+// every match sorted by score. Without a direct hit and with typo tolerance enabled,
+// near misses are added along with the corrected terms. profile names a scoring
+// profile (empty uses the configured one) and explain attaches score breakdowns.
+func (a *App) matchGlyphs(searchTerm string, category string, scope string, profileName string, explain bool) ([]GlyphMatch, []string, error) {
+	// Direct search mode keeps no glyphs to match against; SQLite ranks them
+	// instead, without fuzzy matching (a limit of -1 is no limit)
+	if a.searchMode() == SearchModeDirect {
+		matches, _, err := a.directSearch(searchTerm, category, scope, -1, 0)
+		return matches, nil, err
 	}
-
-	// Exact match gets highest score
-	if pattern == text {
-		return 10000, true
+	if err := validateSearch(searchTerm, category); err != nil {
+		return nil, nil, err
 	}
-
-	// Exact substring match
-	if idx := strings.Index(text, pattern); idx != -1 {
-		score := 5000
-		if idx == 0 {
-			score += 2000 // Bonus for prefix match
-		}
-		score -= len(text) * 2 // Penalty for length
-		return score, true
-	}
-
-	// Fuzzy matching
-	score := 0
-	textIdx := 0
-	consecutiveMatches := 0
-	lastMatchIdx := -1
-
-	for i := 0; i < len(pattern); i++ {
-		found := false
-		for textIdx < len(text) {
-			if pattern[i] == text[textIdx] {
-				found = true
-				score += 100
-
-				// Bonus for consecutive matches
-				if textIdx == lastMatchIdx+1 {
-					consecutiveMatches++
-					score += consecutiveMatches * 50
-				} else {
-					consecutiveMatches = 0
-				}
-
-				// Bonus for word boundary matches
-				if textIdx == 0 || text[textIdx-1] == '-' || text[textIdx-1] == '_' {
-					score += 200
-				}
-
-				lastMatchIdx = textIdx
-				textIdx++
-				break
-			}
-			textIdx++
-		}
-
-		if !found {
-			return 0, false
-		}
+	query, err := parseSearchQuery(searchTerm)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	// Penalty for length difference
-	score -= (len(text) - len(pattern)) * 3
-
-	return score, true
-}
-
-// GetGlyphs retrieves glyphs with advanced filtering
-func (a *App) GetGlyphs(searchTerm string, category string, limit int, offset int) (*SearchResult, error) {
-	startTime := time.Now()
-
-	// Wait for cache to load if not ready
-	for i := 0; i < 50 && !a.cache.loaded; i++ {
-		time.Sleep(10 * time.Millisecond)
+	scopeIDs, err := a.scopeIDs(scope)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	a.cache.mu.RLock()
-	allGlyphs := a.cache.glyphs
-	a.cache.mu.RUnlock()
-
-	if len(allGlyphs) == 0 {
-		return &SearchResult{Glyphs: []GlyphMatch{}, Total: 0}, nil
+	profile, err := a.scoringProfile(profileName)
+	if err != nil {
+		return nil, nil, err
 	}
 
+	snap := a.cache.Snapshot()
+	allGlyphs := snap.glyphs
+
 	// Filter by category if specified
 	var filtered []Glyph
 	if category != "" {
-		a.categories.mu.RLock()
-		categoryIDs := a.categories.categories[category]
-		a.categories.mu.RUnlock()
+		categoryIDs, _ := snap.categoryIDs(a.categoryMembers(category))
 
 		idMap := make(map[int]bool)
 		for _, id := range categoryIDs {
@@ -298,151 +387,344 @@ func (a *App) GetGlyphs(searchTerm string, category string, limit int, offset in
 				filtered = append(filtered, g)
 			}
 		}
+	} else if disabled := a.disabledCategories(); len(disabled) > 0 {
+		for _, g := range allGlyphs {
+			if !disabled[categoryOf(g)] {
+				filtered = append(filtered, g)
+			}
+		}
 	} else {
 		filtered = allGlyphs
 	}
 
+	// Restrict to the requested scope
+	if scopeIDs != nil {
+		scoped := make([]Glyph, 0, len(scopeIDs))
+		for _, g := range filtered {
+			if scopeIDs[g.ID] {
+				scoped = append(scoped, g)
+			}
+		}
+		filtered = scoped
+	}
+
+	// Restrict to a code point range ("cp:f000..f2ff")
+	if query.Range != nil {
+		filtered = filterCodepoints(filtered, *query.Range)
+	}
+	// and to a width class ("width:single")
+	if query.Width != "" {
+		filtered = filterWidth(filtered, query.Width)
+	}
+
 	// Apply search term
-	searchTerm = strings.TrimSpace(searchTerm)
-	var matches []GlyphMatch
+	searchTerm = query.Text
+	matches := []GlyphMatch{}
 
 	if searchTerm == "" {
 		// No search term - return all with favorites marked
-		a.favorites.mu.RLock()
+		favorites := a.favorites.Snapshot()
 		for _, g := range filtered {
 			matches = append(matches, GlyphMatch{
 				Glyph:      g,
 				Score:      0,
-				IsFavorite: a.favorites.favorites[g.ID],
+				IsFavorite: favorites[g.ID],
 			})
 		}
-		a.favorites.mu.RUnlock()
-	} else {
-		// Apply fuzzy matching
-		a.favorites.mu.RLock()
-		for _, g := range filtered {
-			score, ok := fuzzyMatch(searchTerm, g.Name)
-			if ok {
-				matches = append(matches, GlyphMatch{
-					Glyph:      g,
-					Score:      score,
-					IsFavorite: a.favorites.favorites[g.ID],
-				})
-			}
+		if query.Range != nil {
+			sortByCodepoint(matches)
 		}
-		a.favorites.mu.RUnlock()
+		return matches, nil, nil
+	}
+
+	// Localized keywords (e.g. German "pfeil") also match their English aliases
+	stripDiacritics := a.settings.GetBool("search.foldDiacritics", true)
+	terms := []string{searchTerm}
+	if a.settings.GetBool("search.localizedKeywords", true) {
+		terms = append(terms, a.i18n.Aliases(searchTerm)...)
+	}
+	patterns := make([][]rune, len(terms))
+	for i, t := range terms {
+		patterns[i] = foldText(t, stripDiacritics)
+	}
 
-		// Sort by favorites first, then by score
-		sort.Slice(matches, func(i, j int) bool {
-			if matches[i].IsFavorite != matches[j].IsFavorite {
-				return matches[i].IsFavorite
+	// Apply fuzzy matching; user tags match when the name doesn't
+	direct := false
+	var breakdowns []ScoreBreakdown
+	for _, g := range filtered {
+		name := foldText(g.Name, stripDiacritics)
+		b, ok := matchAny(profile, patterns, name)
+		if ok && b.MatchedOn == "" {
+			b.MatchedOn = "name"
+		}
+		if !ok && g.Tags != "" {
+			if b, ok = matchAny(profile, patterns, foldText(g.Tags, stripDiacritics)); ok {
+				b.MatchedOn = "tags"
 			}
-			return matches[i].Score > matches[j].Score
-		})
+		}
+		if ok {
+			direct = direct || b.MatchType != MatchFuzzy
+			matches = append(matches, GlyphMatch{Glyph: g})
+			breakdowns = append(breakdowns, b)
+		}
+	}
+
+	// Loose subsequence matches alone usually mean a misspelling, so look for near misses
+	var didYouMean []string
+	if profile.WantTypos(direct) && a.settings.GetBool("search.typoTolerance", true) {
+		var typos []GlyphMatch
+		var typoBreakdowns []ScoreBreakdown
+		typos, typoBreakdowns, didYouMean = a.typoMatches(searchTerm, filtered, stripDiacritics)
+		matches, breakdowns = mergeMatches(matches, breakdowns, typos, typoBreakdowns)
+	}
 
-		// Add to search history
-		if searchTerm != "" {
-			a.history.Add(searchTerm)
+	// Boost often copied glyphs and favorites, then sort by score
+	boosts := a.rankingBoosts(profile)
+	favorites := a.favorites.Snapshot()
+	for i := range matches {
+		b := &breakdowns[i]
+		matches[i].IsFavorite = favorites[matches[i].ID]
+		b.Frequency = boosts.frequency(matches[i].ID)
+		if matches[i].IsFavorite {
+			b.Favorite = favoriteBoost
+		}
+		matches[i].Score = b.total()
+		if explain {
+			matches[i].Explain = b
 		}
 	}
 
-	// Apply pagination
-	total := len(matches)
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches, didYouMean, nil
+}
+
+// GetGlyphs retrieves one page of glyphs matching a search request
+func (a *App) GetGlyphs(req SearchRequest) (*SearchResponse, error) {
+	startTime := time.Now()
+	searchTerm, category, scope := req.Query, req.Category, req.Scope
+	limit, offset := req.Limit, req.Offset
+	// The query itself is validated by matchGlyphs or directSearch
+	if err := validatePage(limit, offset); err != nil {
+		return nil, err
+	}
+
+	// Wait for cache to load if not ready
+	for i := 0; i < 50 && !a.cache.Loaded(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
 	if limit <= 0 {
-		limit = 50 // Default limit
+		limit = a.pageSize()
 	}
 
-	start := offset
-	end := offset + limit
-	if start > len(matches) {
-		start = len(matches)
+	var matches []GlyphMatch
+	var didYouMean []string
+	var total, start, end int
+	if a.searchMode() == SearchModeDirect {
+		page, n, err := a.directSearch(searchTerm, category, scope, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		matches, total, start, end = page, n, 0, len(page)
+	} else {
+		if a.cache.Len() == 0 {
+			if _, err := a.scopeIDs(scope); err != nil {
+				return nil, err
+			}
+			return &SearchResponse{Glyphs: []GlyphMatch{}, Total: 0}, nil
+		}
+
+		var err error
+		matches, didYouMean, err = a.matchGlyphs(searchTerm, category, scope, req.Profile, req.Debug)
+		if err != nil {
+			return nil, err
+		}
+
+		// Apply pagination
+		total = len(matches)
+		start = min(offset, total)
+		end = min(offset+limit, total)
 	}
-	if end > len(matches) {
-		end = len(matches)
+
+	if category != "" && offset == 0 {
+		a.recordCategoryUse(category)
 	}
 
-	result := &SearchResult{
-		Glyphs:     matches[start:end],
-		Total:      total,
-		SearchTime: time.Since(startTime).Seconds(),
-		HasMore:    end < total,
+	// Add to search history
+	searchTerm = strings.TrimSpace(searchTerm)
+	if searchTerm != "" {
+		a.recordSearch(searchTerm)
 	}
 
-	return result, nil
-}
+	// Incognito searches leave no trace of the term: no session to restore,
+	// no bridge event, and a redacted slow-search log line
+	incognito := a.historySettings().Incognito
+	if !incognito {
+		a.recordSession(searchTerm, category, scope, limit, offset)
+	}
+
+	elapsed := time.Since(startTime)
+	loggedTerm := searchTerm
+	if incognito && searchTerm != "" {
+		loggedTerm = redactedSearchTerm
+	}
+	a.recordSearchLatency(elapsed, loggedTerm, category, scope, limit, offset, total)
+	if searchTerm != "" && offset == 0 && !incognito {
+		a.broadcast(BridgeEventSearch, map[string]any{"query": searchTerm, "scope": scope, "total": total})
+	}
 
-// GetCategories returns all available categories with counts
-func (a *App) GetCategories() map[string]int {
-	a.categories.mu.RLock()
-	defer a.categories.mu.RUnlock()
+	if scope == "favorites" {
+		meta := a.favoriteMetadata()
+		for i := start; i < end; i++ {
+			matches[i].FavoritedAt = meta[matches[i].ID].addedAt
+			matches[i].TimesUsed = meta[matches[i].ID].uses
+		}
+	}
 
-	result := make(map[string]int)
-	for cat, ids := range a.categories.categories {
-		result[cat] = len(ids)
+	a.annotateDeprecations(matches[start:end])
+	result := &SearchResponse{
+		Glyphs:     matches[start:end],
+		Total:      total,
+		SearchTime: elapsed.Seconds(),
+		HasMore:    offset+end-start < total,
+		DidYouMean: didYouMean,
 	}
-	return result
+
+	return result, nil
 }
 
 // ToggleFavorite adds or removes a glyph from favorites
 func (a *App) ToggleFavorite(glyphID int) error {
-	a.favorites.mu.Lock()
-	defer a.favorites.mu.Unlock()
-
-	if a.favorites.favorites[glyphID] {
-		// Remove from favorites
-		_, err := a.db.Exec("DELETE FROM favorites WHERE glyph_id = ?", glyphID)
-		if err != nil {
-			return fmt.Errorf("failed to remove favorite: %w", err)
-		}
-		delete(a.favorites.favorites, glyphID)
-	} else {
-		// Add to favorites
-		_, err := a.db.Exec("INSERT INTO favorites (glyph_id) VALUES (?)", glyphID)
-		if err != nil {
-			return fmt.Errorf("failed to add favorite: %w", err)
+	if err := a.checkWritable("change favorites"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+	// Stale favorites of removed glyphs can still be cleared
+	if !a.favorites.Contains(glyphID) {
+		if err := a.validateGlyphID(glyphID); err != nil {
+			return err
 		}
-		a.favorites.favorites[glyphID] = true
 	}
 
+	favorite, err := a.favorites.Toggle(glyphID)
+	if err != nil {
+		return err
+	}
+
+	value := "0"
+	if favorite {
+		value = "1"
+	}
+	a.runHook(HookOnFavorite, glyphID, map[string]string{"GLYPH_FAVORITE": value})
+	a.broadcastGlyph(BridgeEventFavoriteChanged, glyphID, map[string]any{"favorite": favorite})
 	return nil
 }
 
-// GetFavorites returns all favorited glyphs
-func (a *App) GetFavorites() ([]GlyphMatch, error) {
-	a.favorites.mu.RLock()
-	favoriteIDs := make([]int, 0, len(a.favorites.favorites))
-	for id := range a.favorites.favorites {
-		favoriteIDs = append(favoriteIDs, id)
+// favoriteGlyphs returns all favorited glyphs
+func (a *App) favoriteGlyphs() []GlyphMatch {
+	idMap := a.favorites.Snapshot()
+	if len(idMap) == 0 {
+		return []GlyphMatch{}
 	}
-	a.favorites.mu.RUnlock()
 
-	if len(favoriteIDs) == 0 {
-		return []GlyphMatch{}, nil
+	var favorites []GlyphMatch
+	meta := a.favoriteMetadata()
+	for _, g := range a.glyphsIn(idMap) {
+		favorites = append(favorites, GlyphMatch{
+			Glyph:       g,
+			IsFavorite:  true,
+			FavoritedAt: meta[g.ID].addedAt,
+			TimesUsed:   meta[g.ID].uses,
+		})
 	}
+	a.annotateDeprecations(favorites)
 
-	a.cache.mu.RLock()
-	defer a.cache.mu.RUnlock()
+	return favorites
+}
 
-	var favorites []GlyphMatch
-	idMap := make(map[int]bool)
-	for _, id := range favoriteIDs {
-		idMap[id] = true
+// favoriteMeta is when a glyph was favorited and how often it was copied since
+type favoriteMeta struct {
+	addedAt string
+	uses    int
+}
+
+// favoriteMetadata returns the favorited time and copies since for every favorite
+func (a *App) favoriteMetadata() map[int]favoriteMeta {
+	result := make(map[int]favoriteMeta)
+	if a.userDB == nil {
+		return result
 	}
 
-	for _, g := range a.cache.glyphs {
-		if idMap[g.ID] {
-			favorites = append(favorites, GlyphMatch{
-				Glyph:      g,
-				IsFavorite: true,
-			})
+	rows, err := a.userDB.Query(`
+		SELECT f.glyph_id, f.created_at,
+			(SELECT COUNT(*) FROM copy_history c WHERE c.glyph_id = f.glyph_id AND c.copied_at >= f.created_at)
+		FROM favorites f
+	`)
+	if err != nil {
+		log.Printf("Failed to load favorite metadata: %v", err)
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var meta favoriteMeta
+		if err := rows.Scan(&id, &meta.addedAt, &meta.uses); err == nil {
+			result[id] = meta
 		}
 	}
+	return result
+}
+
+// Favorites sort criteria; the default keeps glyph order
+const (
+	FavoritesSortRecent   = "recent"
+	FavoritesSortMostUsed = "used"
+)
+
+// sortFavorites orders favorites by the given criteria, keeping glyph order for ties
+func sortFavorites(favorites []GlyphMatch, criteria string) {
+	sort.SliceStable(favorites, func(i, j int) bool {
+		a, b := favorites[i], favorites[j]
+		switch criteria {
+		case FavoritesSortRecent:
+			return a.FavoritedAt > b.FavoritedAt
+		case FavoritesSortMostUsed:
+			return a.TimesUsed > b.TimesUsed
+		}
+		return false
+	})
+}
+
+// GetFavorites returns one page of favorited glyphs ordered by sortBy ("recent",
+// "used", or empty for glyph order); a limit of 0 uses the configured page size
+func (a *App) GetFavorites(limit int, offset int, sortBy string) ([]GlyphMatch, error) {
+	if err := validatePage(limit, offset); err != nil {
+		return nil, err
+	}
+	if err := validateChoice("sort", sortBy, "", FavoritesSortRecent, FavoritesSortMostUsed); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = a.pageSize()
+	}
 
-	return favorites, nil
+	favorites := a.favoriteGlyphs()
+	sortFavorites(favorites, sortBy)
+	start := min(offset, len(favorites))
+	end := min(start+limit, len(favorites))
+	return favorites[start:end], nil
 }
 
 // GetSearchHistory returns recent searches
 func (a *App) GetSearchHistory() []string {
+	if days := a.historySettings().RetentionDays; days > 0 {
+		a.history.expire(time.Now().AddDate(0, 0, -days))
+	}
+
 	a.history.mu.RLock()
 	defer a.history.mu.RUnlock()
 
@@ -452,41 +734,25 @@ func (a *App) GetSearchHistory() []string {
 }
 
 // ClearSearchHistory clears the search history
-func (a *App) ClearSearchHistory() {
+func (a *App) ClearSearchHistory() error {
 	a.history.mu.Lock()
-	defer a.history.mu.Unlock()
 	a.history.history = nil
-}
-
-// CopyToClipboard copies text to clipboard
-func (a *App) CopyToClipboard(text string) {
-	runtime.ClipboardSetText(a.ctx, text)
-}
+	a.history.searchedAt = make(map[string]time.Time)
+	a.history.mu.Unlock()
 
-// GetStats returns app statistics
-func (a *App) GetStats() map[string]interface{} {
-	a.cache.mu.RLock()
-	totalGlyphs := len(a.cache.glyphs)
-	a.cache.mu.RUnlock()
-
-	a.favorites.mu.RLock()
-	totalFavorites := len(a.favorites.favorites)
-	a.favorites.mu.RUnlock()
-
-	a.categories.mu.RLock()
-	totalCategories := len(a.categories.categories)
-	a.categories.mu.RUnlock()
-
-	return map[string]interface{}{
-		"totalGlyphs":     totalGlyphs,
-		"totalFavorites":  totalFavorites,
-		"totalCategories": totalCategories,
-		"cacheLoaded":     a.cache.loaded,
+	// Read-only runs never save searches, so there is nothing else to clear
+	if a.userDB == nil || a.isReadOnly() {
+		return nil
 	}
+	if err := a.writes.Exec(a.userDB, "DELETE FROM search_history"); err != nil {
+		return fmt.Errorf("failed to clear search history: %w", err)
+	}
+	a.scheduleJumpListUpdate()
+	return nil
 }
 
 // Add method for SearchHistory
-func (sh *SearchHistory) Add(term string) {
+func (sh *SearchHistory) Add(term string, at time.Time, maxSize int) {
 	sh.mu.Lock()
 	defer sh.mu.Unlock()
 
@@ -500,9 +766,11 @@ func (sh *SearchHistory) Add(term string) {
 
 	// Add to front
 	sh.history = append([]string{term}, sh.history...)
+	sh.searchedAt[term] = at
 
 	// Trim to max size
-	if len(sh.history) > sh.maxSize {
-		sh.history = sh.history[:sh.maxSize]
+	for len(sh.history) > maxSize {
+		delete(sh.searchedAt, sh.history[len(sh.history)-1])
+		sh.history = sh.history[:len(sh.history)-1]
 	}
 }
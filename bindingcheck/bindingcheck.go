@@ -0,0 +1,101 @@
+// Command bindingcheck regenerates the Wails TypeScript bindings and fails when
+// they differ from the ones committed under frontend/wailsjs/go, so a change to
+// a bound method or DTO can't ship without the models the frontend compiles
+// against. It runs from the repository root via go generate:
+//
+//	go generate .
+//
+// The regenerated files are left in place; commit them to clear the failure.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+)
+
+// bindingsDir holds the generated models and method stubs
+var bindingsDir = filepath.Join("frontend", "wailsjs", "go")
+
+// readTree reads every file under dir, keyed by slash-separated relative path
+func readTree(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return files, nil
+}
+
+// drift lists the files that were added, removed, or changed
+func drift(before, after map[string][]byte) []string {
+	var changed []string
+	for name, data := range after {
+		old, ok := before[name]
+		switch {
+		case !ok:
+			changed = append(changed, "added:    "+name)
+		case !bytes.Equal(old, data):
+			changed = append(changed, "modified: "+name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changed = append(changed, "removed:  "+name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func main() {
+	wails := flag.String("wails", "wails", "path to the wails CLI")
+	flag.Parse()
+	log.SetFlags(0)
+
+	before, err := readTree(bindingsDir)
+	if err != nil {
+		log.Fatalf("failed to read bindings: %v", err)
+	}
+
+	cmd := exec.Command(*wails, "generate", "module")
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		log.Fatalf("failed to generate bindings: %v", err)
+	}
+
+	after, err := readTree(bindingsDir)
+	if err != nil {
+		log.Fatalf("failed to read bindings: %v", err)
+	}
+	if changed := drift(before, after); len(changed) > 0 {
+		fmt.Fprintf(os.Stderr, "TypeScript bindings are out of date with the Go bindings:\n")
+		for _, c := range changed {
+			fmt.Fprintf(os.Stderr, "  %s\n", c)
+		}
+		fmt.Fprintf(os.Stderr, "The regenerated files are in %s; review and commit them.\n", filepath.ToSlash(bindingsDir))
+		os.Exit(1)
+	}
+	log.Printf("TypeScript bindings are up to date")
+}
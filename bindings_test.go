@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+	timeType  = reflect.TypeOf(time.Time{})
+)
+
+// untypedFields reports the places in t that Wails would generate as `any`
+func untypedFields(t reflect.Type, path string, seen map[reflect.Type]bool, found *[]string) {
+	switch t.Kind() {
+	case reflect.Interface:
+		*found = append(*found, path+" is "+t.String())
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		untypedFields(t.Elem(), path+"[]", seen, found)
+	case reflect.Map:
+		untypedFields(t.Key(), path+"{key}", seen, found)
+		untypedFields(t.Elem(), path+"{}", seen, found)
+	case reflect.Struct:
+		if t == timeType || seen[t] {
+			return
+		}
+		seen[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() || f.Tag.Get("json") == "-" {
+				continue
+			}
+			if f.Type == timeType && f.Tag.Get("ts_type") == "" {
+				*found = append(*found, path+"."+f.Name+" is time.Time without a ts_type tag")
+				continue
+			}
+			untypedFields(f.Type, path+"."+f.Name, seen, found)
+		}
+	}
+}
+
+func TestBindingsAreTyped(t *testing.T) {
+	app := reflect.TypeOf(&App{})
+	for i := 0; i < app.NumMethod(); i++ {
+		m := app.Method(i)
+		var found []string
+		seen := make(map[reflect.Type]bool)
+		for j := 1; j < m.Type.NumIn(); j++ {
+			untypedFields(m.Type.In(j), m.Name+" arg", seen, &found)
+		}
+		for j := 0; j < m.Type.NumOut(); j++ {
+			if out := m.Type.Out(j); out != errorType {
+				untypedFields(out, m.Name+" result", seen, &found)
+			}
+		}
+		for _, f := range found {
+			t.Errorf("%s, which Wails generates as any", f)
+		}
+	}
+}
+
+func TestBindingsMatchFrontend(t *testing.T) {
+	data, err := os.ReadFile("frontend/wailsjs/go/main/App.d.ts")
+	if err != nil {
+		t.Skipf("no generated bindings: %v", err)
+	}
+	declared := make(map[string]int)
+	for _, m := range regexp.MustCompile(`export function (\w+)\(([^)]*)\)`).FindAllStringSubmatch(string(data), -1) {
+		declared[m[1]] = 0
+		if m[2] != "" {
+			declared[m[1]] = strings.Count(m[2], ",") + 1
+		}
+	}
+
+	app := reflect.TypeOf(&App{})
+	for i := 0; i < app.NumMethod(); i++ {
+		m := app.Method(i)
+		args, ok := declared[m.Name]
+		switch {
+		case !ok:
+			t.Errorf("%s is missing from App.d.ts; run go generate", m.Name)
+		case args != m.Type.NumIn()-1:
+			t.Errorf("%s takes %d args but App.d.ts declares %d; run go generate", m.Name, m.Type.NumIn()-1, args)
+		}
+		delete(declared, m.Name)
+	}
+	for name := range declared {
+		t.Errorf("App.d.ts declares %s, which is no longer bound; run go generate", name)
+	}
+}
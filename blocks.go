@@ -0,0 +1,152 @@
+package main
+
+import (
+	"database/sql"
+	"log"
+	"sort"
+	"strings"
+
+	"Gylte/internal/glyphdata"
+)
+
+// blockPageSize is the number of glyphs per GetGlyphsByBlock page
+const blockPageSize = 100
+
+// browseBlock is the block a glyph is listed under; private use blocks are
+// split per icon set (e.g. "Private Use Area: Font Awesome")
+func browseBlock(g Glyph) string {
+	block := g.Block
+	if block == "" {
+		block = glyphdata.BlockOf(codepointOf(g.Glyph))
+	}
+	if strings.Contains(block, "Private Use Area") {
+		if category := glyphCategory(g.Name); category != "" {
+			return block + ": " + iconSetName(category)
+		}
+	}
+	return block
+}
+
+// backfillBlocks stores the block of glyphs imported before the block column existed
+func backfillBlocks(db *sql.DB) error {
+	rows, err := db.Query("SELECT id, glyph FROM glyphs WHERE block IS NULL")
+	if err != nil {
+		return err
+	}
+	blocks := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var glyph string
+		if err := rows.Scan(&id, &glyph); err == nil {
+			blocks[id] = glyphdata.BlockOf(codepointOf(glyph))
+		}
+	}
+	rows.Close()
+	if len(blocks) == 0 {
+		return rows.Err()
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("UPDATE glyphs SET block = ? WHERE id = ?")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for id, block := range blocks {
+		if _, err := stmt.Exec(block, id); err != nil {
+			return err
+		}
+	}
+	log.Printf("Computed Unicode blocks for %d glyphs", len(blocks))
+	return tx.Commit()
+}
+
+// UnicodeBlockInfo describes a block for the block browser
+type UnicodeBlockInfo struct {
+	Name   string `json:"name"`
+	Start  string `json:"start"` // first code point in use, e.g. "U+F000"
+	End    string `json:"end"`
+	Count  int    `json:"count"`
+	Sample string `json:"sample"`
+}
+
+// GetUnicodeBlocks returns the blocks that contain glyphs, in code point order
+func (a *App) GetUnicodeBlocks() []UnicodeBlockInfo {
+	type span struct {
+		start, end rune
+		count      int
+		sample     string
+	}
+	spans := make(map[string]*span)
+	for _, g := range a.allGlyphs() {
+		r := codepointOf(g.Glyph)
+		name := browseBlock(g)
+		s, ok := spans[name]
+		if !ok {
+			spans[name] = &span{start: r, end: r, count: 1, sample: g.Glyph}
+			continue
+		}
+		s.start, s.end = min(s.start, r), max(s.end, r)
+		s.count++
+	}
+
+	blocks := make([]UnicodeBlockInfo, 0, len(spans))
+	starts := make(map[string]rune, len(spans))
+	for name, s := range spans {
+		blocks = append(blocks, UnicodeBlockInfo{
+			Name:   name,
+			Start:  formatCodepoint(s.start),
+			End:    formatCodepoint(s.end),
+			Count:  s.count,
+			Sample: s.sample,
+		})
+		starts[name] = s.start
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		if starts[blocks[i].Name] != starts[blocks[j].Name] {
+			return starts[blocks[i].Name] < starts[blocks[j].Name]
+		}
+		return blocks[i].Name < blocks[j].Name
+	})
+	return blocks
+}
+
+// GetGlyphsByBlock returns one page (0-based) of a block's glyphs in code point order
+func (a *App) GetGlyphsByBlock(block string, page int) (*SearchResponse, error) {
+	if page < 0 {
+		return nil, newAppError(ErrCodeInvalid, "invalid page %d", page)
+	}
+
+	var glyphs []Glyph
+	for _, g := range a.allGlyphs() {
+		if browseBlock(g) == block {
+			glyphs = append(glyphs, g)
+		}
+	}
+	if len(glyphs) == 0 {
+		return nil, newAppError(ErrCodeInvalid, "unknown block: %s", block)
+	}
+	sort.SliceStable(glyphs, func(i, j int) bool {
+		return codepointOf(glyphs[i].Glyph) < codepointOf(glyphs[j].Glyph)
+	})
+
+	start := min(page*blockPageSize, len(glyphs))
+	end := min(start+blockPageSize, len(glyphs))
+
+	matches := make([]GlyphMatch, 0, end-start)
+	for _, g := range glyphs[start:end] {
+		matches = append(matches, GlyphMatch{Glyph: g, IsFavorite: a.favorites.Contains(g.ID)})
+	}
+
+	a.annotateDeprecations(matches)
+	return &SearchResponse{
+		Glyphs:  matches,
+		Total:   len(glyphs),
+		HasMore: end < len(glyphs),
+	}, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// cacheSnapshot is an immutable view of the loaded glyphs. Reloads build a new
+// snapshot off to the side and swap it in, so searches never wait on a refresh.
+type cacheSnapshot struct {
+	glyphs     []Glyph
+	index      map[int]int      // glyph id -> position in glyphs
+	categories map[string][]int // category -> glyph ids
+}
+
+// emptySnapshot is served before the first load completes
+var emptySnapshot = &cacheSnapshot{
+	index:      map[int]int{},
+	categories: map[string][]int{},
+}
+
+// CacheLoadTimings records how long each phase of the last cache load took
+type CacheLoadTimings struct {
+	QueryMs  float64   `json:"queryMs"`
+	MergeMs  float64   `json:"mergeMs"`
+	IndexMs  float64   `json:"indexMs"`
+	TotalMs  float64   `json:"totalMs"`
+	Glyphs   int       `json:"glyphs"`
+	LoadedAt time.Time `json:"loadedAt" ts_type:"string"`
+}
+
+// GlyphCache provides in-memory caching for faster searches
+type GlyphCache struct {
+	snap    atomic.Pointer[cacheSnapshot]
+	loaded  atomic.Bool
+	timings atomic.Pointer[CacheLoadTimings]
+
+	// direct is set when searches bypass the cache (see directsearch.go)
+	direct atomic.Bool
+
+	// refreshMu serializes rebuilds; readers never take it
+	refreshMu sync.Mutex
+}
+
+// newCacheSnapshot indexes and categorizes glyphs
+func newCacheSnapshot(glyphs []Glyph) *cacheSnapshot {
+	snap := &cacheSnapshot{
+		glyphs:     glyphs,
+		index:      make(map[int]int, len(glyphs)),
+		categories: make(map[string][]int),
+	}
+	for i, g := range glyphs {
+		snap.index[g.ID] = i
+
+		// Extract category from name (e.g., "nf-cod-account" -> "cod") unless the user assigned one
+		if category := categoryOf(g); category != "" {
+			snap.categories[category] = append(snap.categories[category], g.ID)
+		}
+	}
+	return snap
+}
+
+// Snapshot returns the current cache contents; the result must not be modified
+func (c *GlyphCache) Snapshot() *cacheSnapshot {
+	if snap := c.snap.Load(); snap != nil {
+		return snap
+	}
+	return emptySnapshot
+}
+
+// Swap atomically replaces the cache contents
+func (c *GlyphCache) Swap(snap *cacheSnapshot) {
+	c.snap.Store(snap)
+	c.loaded.Store(true)
+}
+
+// Loaded reports whether the cache has been populated at least once
+func (c *GlyphCache) Loaded() bool {
+	return c.loaded.Load()
+}
+
+// Len returns the number of cached glyphs
+func (c *GlyphCache) Len() int {
+	return len(c.Snapshot().glyphs)
+}
+
+// Glyph looks up a cached glyph by id
+func (s *cacheSnapshot) Glyph(id int) (Glyph, bool) {
+	if i, ok := s.index[id]; ok {
+		return s.glyphs[i], true
+	}
+	return Glyph{}, false
+}
+
+// categoryIDs returns the ids of glyphs in any of categories, in cache order
+func (s *cacheSnapshot) categoryIDs(categories []string) ([]int, bool) {
+	if len(categories) == 1 {
+		ids, ok := s.categories[categories[0]]
+		return ids, ok
+	}
+	var ids []int
+	found := false
+	for _, c := range categories {
+		if members, ok := s.categories[c]; ok {
+			ids = append(ids, members...)
+			found = true
+		}
+	}
+	slices.SortFunc(ids, func(x, y int) int { return s.index[x] - s.index[y] })
+	return ids, found
+}
+
+// Timings returns the phase timings of the last load, or nil before the first load
+func (c *GlyphCache) Timings() *CacheLoadTimings {
+	return c.timings.Load()
+}
+
+// millisSince returns the elapsed time since t in fractional milliseconds
+func millisSince(t time.Time) float64 {
+	return float64(time.Since(t).Microseconds()) / 1000
+}
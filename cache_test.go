@@ -0,0 +1,61 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+)
+
+// openBenchDB opens the bundled gylte.db read-only for benchmarks
+func openBenchDB(b *testing.B) *sql.DB {
+	b.Helper()
+
+	db, err := sql.Open("sqlite", "file:gylte.db?mode=ro")
+	if err != nil {
+		b.Fatalf("open database: %v", err)
+	}
+	if err := validateGlyphDatabase(db); err != nil {
+		b.Skipf("gylte.db not available: %v", err)
+	}
+	b.Cleanup(func() { db.Close() })
+	return db
+}
+
+func BenchmarkQueryGlyphs(b *testing.B) {
+	db := openBenchDB(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := queryGlyphs(db, 0, "gylte"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewCacheSnapshot(b *testing.B) {
+	glyphs, err := queryGlyphs(openBenchDB(b), 0, "gylte")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newCacheSnapshot(glyphs)
+	}
+}
+
+func BenchmarkPreloadCache(b *testing.B) {
+	a := NewApp()
+	a.db = openBenchDB(b)
+	a.readDB = a.db
+	a.dbPath = "gylte.db"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.preloadCache()
+	}
+	b.StopTimer()
+
+	if t := a.cache.Timings(); t != nil {
+		b.ReportMetric(t.TotalMs, "ms/load")
+	}
+}
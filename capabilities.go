@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	goruntime "runtime"
+	"slices"
+)
+
+// Optional features reported by GetCapabilities
+const (
+	CapabilityGlobalHotkeys  = "globalHotkeys"
+	CapabilityTray           = "tray"
+	CapabilityClipboardImage = "clipboardImage"
+	CapabilityAcrylic        = "acrylic"
+	CapabilityVibrancy       = "vibrancy"
+	CapabilityClickThrough   = "clickThrough"
+	CapabilityNotifications  = "notifications"
+)
+
+// errTrayUnsupported explains why no build has a tray icon yet
+var errTrayUnsupported = errors.New("the system tray isn't supported by this build")
+
+// Capability tells whether an optional feature works here and, if not, why
+type Capability struct {
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Capabilities lists the optional features available on this OS and build,
+// so the UI can hide what doesn't work instead of failing on use
+type Capabilities struct {
+	OS       string                `json:"os"`
+	Arch     string                `json:"arch"`
+	Features map[string]Capability `json:"features"`
+	// WindowEffects are the effects SetWindowEffect accepts here
+	WindowEffects []string `json:"windowEffects"`
+}
+
+// capabilityFrom turns a support check into a Capability
+func capabilityFrom(err error) Capability {
+	if err != nil {
+		return Capability{Reason: err.Error()}
+	}
+	return Capability{Available: true}
+}
+
+// windowEffectSupport reports whether the window can show effect here
+func windowEffectSupport(effect string) error {
+	if !slices.Contains(windowEffects, effect) {
+		return fmt.Errorf("the %s window effect isn't available on %s", effect, goruntime.GOOS)
+	}
+	return nil
+}
+
+// featureSupport reports why a feature is unavailable, or nil if it works
+func featureSupport(name string) error {
+	switch name {
+	case CapabilityGlobalHotkeys:
+		if !hotkeysSupported {
+			return errHotkeysUnsupported
+		}
+	case CapabilityClickThrough:
+		if !clickThroughSupported {
+			return errClickThroughUnsupported
+		}
+	case CapabilityTray:
+		return errTrayUnsupported
+	case CapabilityClipboardImage:
+		return clipboardImageSupport()
+	case CapabilityNotifications:
+		return notificationSupport()
+	case CapabilityAcrylic:
+		return windowEffectSupport(WindowEffectAcrylic)
+	case CapabilityVibrancy:
+		return windowEffectSupport(WindowEffectVibrancy)
+	}
+	return nil
+}
+
+// requireCapability fails with ErrCodeUnsupported when a feature isn't available here
+func requireCapability(name string) error {
+	if err := featureSupport(name); err != nil {
+		return newAppError(ErrCodeUnsupported, "%w", err)
+	}
+	return nil
+}
+
+// GetCapabilities reports which optional features work on this OS and build
+func (a *App) GetCapabilities() Capabilities {
+	names := []string{
+		CapabilityGlobalHotkeys,
+		CapabilityTray,
+		CapabilityClipboardImage,
+		CapabilityAcrylic,
+		CapabilityVibrancy,
+		CapabilityClickThrough,
+		CapabilityNotifications,
+	}
+	features := make(map[string]Capability, len(names))
+	for _, name := range names {
+		features[name] = capabilityFrom(featureSupport(name))
+	}
+	return Capabilities{
+		OS:            goruntime.GOOS,
+		Arch:          goruntime.GOARCH,
+		Features:      features,
+		WindowEffects: slices.Clone(windowEffects),
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !windows && !darwin
+
+package main
+
+// windowEffects are the window effects this platform can show. Translucency
+// depends on the compositor; there are no backdrop materials.
+var windowEffects = []string{WindowEffectAuto, WindowEffectNone}
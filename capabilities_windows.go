@@ -0,0 +1,4 @@
+package main
+
+// windowEffects are the window effects this platform can show
+var windowEffects = []string{WindowEffectAuto, WindowEffectNone, WindowEffectMica, WindowEffectAcrylic, WindowEffectTabbed}
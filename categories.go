@@ -0,0 +1,295 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"strings"
+)
+
+// Category sort criteria
+const (
+	CategorySortCount        = "count"
+	CategorySortAlphabetical = "alphabetical"
+	CategorySortRecent       = "recent"
+)
+
+// CategoryInfo describes a category for display in the sidebar
+type CategoryInfo struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Count       int    `json:"count"`
+	Enabled     bool   `json:"enabled"`
+	Sample      string `json:"sample"`
+	LastUsed    string `json:"lastUsed,omitempty"`
+
+	// License is unset for categories outside the known icon sets
+	License *IconSetLicense `json:"license,omitempty"`
+
+	// Members lists the categories a group combines; unset for plain categories
+	Members []string `json:"members,omitempty"`
+}
+
+// initCategoryUsageTable creates the table recording when categories were browsed
+func (a *App) initCategoryUsageTable() error {
+	_, err := a.userDB.Exec(`
+		CREATE TABLE IF NOT EXISTS category_usage (
+			category TEXT PRIMARY KEY,
+			uses INTEGER NOT NULL DEFAULT 0,
+			last_used DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	return err
+}
+
+// recordCategoryUse bumps the usage counter for a category
+func (a *App) recordCategoryUse(category string) {
+	if a.userDB == nil || category == "" || a.isReadOnly() {
+		return
+	}
+
+	err := a.writes.Exec(a.userDB, `
+		INSERT INTO category_usage (category, uses, last_used) VALUES (?, 1, CURRENT_TIMESTAMP)
+		ON CONFLICT(category) DO UPDATE SET uses = uses + 1, last_used = CURRENT_TIMESTAMP
+	`, category)
+	if err != nil {
+		log.Printf("Failed to record category use: %v", err)
+		return
+	}
+	a.scheduleJumpListUpdate()
+}
+
+// categoryLastUsed returns the last time each category was browsed
+func (a *App) categoryLastUsed() map[string]string {
+	result := make(map[string]string)
+	if a.userDB == nil {
+		return result
+	}
+
+	rows, err := a.userDB.Query("SELECT category, last_used FROM category_usage")
+	if err != nil {
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var category, lastUsed string
+		if err := rows.Scan(&category, &lastUsed); err == nil {
+			result[category] = lastUsed
+		}
+	}
+	return result
+}
+
+// disabledCategories returns the set of categories the user has hidden
+func (a *App) disabledCategories() map[string]bool {
+	disabled := make(map[string]bool)
+	for _, name := range strings.Split(a.settings.Get("categories.disabled", ""), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			disabled[name] = true
+		}
+	}
+	return disabled
+}
+
+// sortCategories orders categories by the given criteria, falling back to name
+func sortCategories(categories []CategoryInfo, criteria string) {
+	sort.SliceStable(categories, func(i, j int) bool {
+		a, b := categories[i], categories[j]
+		switch criteria {
+		case CategorySortAlphabetical:
+			// Name order is the tie-breaker below
+		case CategorySortRecent:
+			if a.LastUsed != b.LastUsed {
+				return a.LastUsed > b.LastUsed
+			}
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+		default:
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+		}
+		return a.DisplayName < b.DisplayName
+	})
+}
+
+// categoryCounts returns the number of glyphs in each category and the
+// category's first glyph
+func (a *App) categoryCounts() (map[string]int, map[string]Glyph) {
+	if a.searchMode() == SearchModeDirect {
+		return a.directCategories()
+	}
+
+	snap := a.cache.Snapshot()
+	counts := make(map[string]int, len(snap.categories))
+	samples := make(map[string]Glyph, len(snap.categories))
+	for cat, ids := range snap.categories {
+		counts[cat] = len(ids)
+		samples[cat], _ = snap.Glyph(ids[0])
+	}
+	return counts, samples
+}
+
+// GetCategories returns all categories ordered by the configured sort criteria
+func (a *App) GetCategories() []CategoryInfo {
+	disabled := a.disabledCategories()
+	lastUsed := a.categoryLastUsed()
+	licenses, err := a.loadIconSetLicenses()
+	if err != nil {
+		log.Printf("%v", err)
+	}
+
+	counts, samples := a.categoryCounts()
+	present := make([]string, 0, len(counts))
+	for cat := range counts {
+		present = append(present, cat)
+	}
+
+	// Grouped categories are shown as one, under the group's name
+	shown := a.groupCategories(present)
+	result := make([]CategoryInfo, 0, len(shown))
+	for name, members := range shown {
+		info := CategoryInfo{
+			Name:        name,
+			DisplayName: iconSetName(name),
+			LastUsed:    lastUsed[name],
+		}
+		if len(members) > 1 || members[0] != name {
+			info.DisplayName = a.categoryGroupTitle(name)
+			info.Members = members
+		}
+		for _, cat := range members {
+			info.Count += counts[cat]
+			info.Enabled = info.Enabled || !disabled[cat]
+			if info.Sample == "" {
+				info.Sample = samples[cat].Glyph
+			}
+			if lastUsed[cat] > info.LastUsed {
+				info.LastUsed = lastUsed[cat]
+			}
+			if l, ok := licenses[cat]; ok && info.License == nil {
+				info.License = &l
+			}
+		}
+		result = append(result, info)
+	}
+
+	sortCategories(result, a.settings.Get("categories.sort", CategorySortCount))
+	return result
+}
+
+// maxCategorySamples caps the glyphs GetCategorySamples returns per category
+const maxCategorySamples = 16
+
+// GetCategorySamples returns up to n representative glyphs per category (or
+// category group) for sidebar previews: the most used first, then the
+// category's first glyphs
+func (a *App) GetCategorySamples(n int) (map[string][]Glyph, error) {
+	if n < 1 || n > maxCategorySamples {
+		return nil, newAppError(ErrCodeInvalid, "sample count must be between 1 and %d", maxCategorySamples)
+	}
+
+	var scores map[int]float64
+	if a.userDB != nil {
+		var err error
+		if scores, err = a.cachedFrecency(); err != nil {
+			log.Printf("Failed to rank category samples: %v", err)
+		}
+	}
+
+	// Direct search mode has no cached categories; ask the database instead
+	direct := a.searchMode() == SearchModeDirect
+	snap := a.cache.Snapshot()
+	counts := make(map[string]int, len(snap.categories))
+	for cat, ids := range snap.categories {
+		counts[cat] = len(ids)
+	}
+	if direct {
+		counts, _ = a.directCategories()
+	}
+	present := make([]string, 0, len(counts))
+	for cat := range counts {
+		present = append(present, cat)
+	}
+	shown := a.groupCategories(present)
+	result := make(map[string][]Glyph, len(shown))
+	for cat, members := range shown {
+		ids, _ := snap.categoryIDs(members)
+		if direct {
+			var err error
+			if ids, err = a.directCategoryIDs(cat); err != nil {
+				return nil, err
+			}
+		}
+		used := make([]int, 0, n)
+		for _, id := range ids {
+			if scores[id] > 0 {
+				used = append(used, id)
+			}
+		}
+		sort.SliceStable(used, func(i, j int) bool { return scores[used[i]] > scores[used[j]] })
+
+		picked := make(map[int]bool, n)
+		chosen := make([]int, 0, n)
+		for _, id := range append(used, ids...) {
+			if len(chosen) == n {
+				break
+			}
+			if !picked[id] {
+				picked[id] = true
+				chosen = append(chosen, id)
+			}
+		}
+		found := a.lookupGlyphs(chosen)
+		samples := make([]Glyph, 0, n)
+		for _, id := range chosen {
+			if g, ok := found[id]; ok {
+				samples = append(samples, g)
+			}
+		}
+		result[cat] = samples
+	}
+	return result, nil
+}
+
+// SetCategorySort changes how GetCategories orders its results
+func (a *App) SetCategorySort(criteria string) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+
+	switch criteria {
+	case CategorySortCount, CategorySortAlphabetical, CategorySortRecent:
+		return a.settings.Set("categories.sort", criteria)
+	}
+	return newAppError(ErrCodeInvalid, "unknown category sort: %s", criteria)
+}
+
+// SetCategoryEnabled shows or hides a category; hidden categories are left out of unfiltered results
+func (a *App) SetCategoryEnabled(category string, enabled bool) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+
+	disabled := a.disabledCategories()
+	for _, member := range a.categoryMembers(category) {
+		if enabled {
+			delete(disabled, member)
+		} else {
+			disabled[member] = true
+		}
+	}
+
+	names := make([]string, 0, len(disabled))
+	for name := range disabled {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := a.settings.Set("categories.disabled", strings.Join(names, ",")); err != nil {
+		return err
+	}
+	a.emit("categories:changed", category)
+	return nil
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// EventCategoryGroupsChanged is emitted after a category group is saved or deleted
+const EventCategoryGroupsChanged = "categoryGroups:changed"
+
+// CategoryGroup presents several categories as one, e.g. md and mdi as "Material Design"
+type CategoryGroup struct {
+	Name    string   `json:"name"`
+	Title   string   `json:"title"`
+	Members []string `json:"members"`
+}
+
+// defaultCategoryGroups are created with a profile's user data; users can edit or delete them
+var defaultCategoryGroups = []CategoryGroup{
+	{Name: "material", Title: "Material Design", Members: []string{"md", "mdi"}},
+	{Name: "powerline", Title: "Powerline", Members: []string{"pl", "ple"}},
+	{Name: "indentation", Title: "Indentation", Members: []string{"indent", "indentation"}},
+}
+
+// categoryGroupName restricts group names to what category filters accept
+var categoryGroupName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// initCategoryGroupsTable creates the category groups table, seeding the
+// defaults the first time
+func (a *App) initCategoryGroupsTable() error {
+	exists, err := tableExists(a.userDB, "category_groups")
+	if err != nil {
+		return err
+	}
+	if _, err := a.userDB.Exec(`
+		CREATE TABLE IF NOT EXISTS category_groups (
+			name TEXT PRIMARY KEY,
+			title TEXT NOT NULL,
+			members TEXT NOT NULL
+		);
+	`); err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	for _, g := range defaultCategoryGroups {
+		if _, err := a.userDB.Exec("INSERT OR IGNORE INTO category_groups (name, title, members) VALUES (?, ?, ?)",
+			g.Name, g.Title, strings.Join(g.Members, ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCategoryGroups returns the category groups ordered by name
+func (a *App) GetCategoryGroups() ([]CategoryGroup, error) {
+	groups := []CategoryGroup{}
+	if a.userDB == nil {
+		return groups, nil
+	}
+
+	rows, err := a.userDB.Query("SELECT name, title, members FROM category_groups ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list category groups: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var g CategoryGroup
+		var members string
+		if err := rows.Scan(&g.Name, &g.Title, &members); err != nil {
+			log.Printf("Error scanning category group: %v", err)
+			continue
+		}
+		g.Members = strings.Split(members, ",")
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
+
+// categoryGroups returns the groups, logging instead of failing so a broken
+// table only disables grouping
+func (a *App) categoryGroups() []CategoryGroup {
+	groups, err := a.GetCategoryGroups()
+	if err != nil {
+		log.Printf("%v", err)
+	}
+	return groups
+}
+
+// categoryMembers expands a category filter: a group name stands for its
+// members, anything else for itself
+func (a *App) categoryMembers(category string) []string {
+	for _, g := range a.categoryGroups() {
+		if g.Name == category {
+			return g.Members
+		}
+	}
+	return []string{category}
+}
+
+// groupCategories maps each present category to the name it's shown under,
+// its group's or its own, and returns the members behind every shown name
+func (a *App) groupCategories(categories []string) map[string][]string {
+	groupOf := make(map[string]string)
+	for _, g := range a.categoryGroups() {
+		for _, m := range g.Members {
+			groupOf[m] = g.Name
+		}
+	}
+
+	shown := make(map[string][]string)
+	for _, cat := range categories {
+		name := cat
+		if group, ok := groupOf[cat]; ok {
+			name = group
+		}
+		shown[name] = append(shown[name], cat)
+	}
+	for _, members := range shown {
+		sort.Strings(members)
+	}
+	return shown
+}
+
+// categoryGroupTitle returns the display name of a group, or "" if name isn't one
+func (a *App) categoryGroupTitle(name string) string {
+	for _, g := range a.categoryGroups() {
+		if g.Name == name {
+			return g.Title
+		}
+	}
+	return ""
+}
+
+// normalizeMembers trims, lowercases and deduplicates category names
+func normalizeMembers(members []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, m := range members {
+		m = strings.ToLower(strings.TrimSpace(m))
+		if m != "" && !seen[m] {
+			seen[m] = true
+			result = append(result, m)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// SaveCategoryGroup creates or replaces a group showing members as one
+// category named name and titled title. A category belongs to at most one group.
+func (a *App) SaveCategoryGroup(name, title string, members []string) error {
+	if err := a.checkWritable("change category groups"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !categoryGroupName.MatchString(name) {
+		return invalidArgument("name", "group names use lowercase letters, digits, '-' and '_'")
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return invalidArgument("title", "title is empty")
+	}
+	members = normalizeMembers(members)
+	if len(members) < 2 {
+		return invalidArgument("members", "a group needs at least two categories")
+	}
+
+	for _, g := range a.categoryGroups() {
+		if g.Name == name {
+			continue
+		}
+		for _, m := range g.Members {
+			if m == name || slices.Contains(members, m) {
+				return newAppError(ErrCodeConflict, "category %s is already in group %s", m, g.Name)
+			}
+		}
+	}
+	// A group can't hide a category of the same name that isn't one of its members
+	if counts, _ := a.categoryCounts(); counts[name] > 0 && !slices.Contains(members, name) {
+		return newAppError(ErrCodeConflict, "a category named %s already exists", name)
+	}
+
+	if _, err := a.userDB.Exec(`
+		INSERT INTO category_groups (name, title, members) VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET title = excluded.title, members = excluded.members
+	`, name, title, strings.Join(members, ",")); err != nil {
+		return fmt.Errorf("failed to save category group: %w", err)
+	}
+
+	log.Printf("Category group %s: %s", name, strings.Join(members, ", "))
+	a.emit(EventCategoryGroupsChanged, name)
+	return nil
+}
+
+// DeleteCategoryGroup removes a group; its categories are listed separately again
+func (a *App) DeleteCategoryGroup(name string) error {
+	if err := a.checkWritable("change category groups"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+
+	res, err := a.userDB.Exec("DELETE FROM category_groups WHERE name = ?", name)
+	if err != nil {
+		return fmt.Errorf("failed to delete category group: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return newAppError(ErrCodeNotFound, "category group %q not found", name)
+	}
+
+	a.emit(EventCategoryGroupsChanged, name)
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Cheat sheet layout in millimetres on A4 portrait
+const (
+	cheatSheetMargin  = 12.0
+	cheatSheetColumns = 6
+	cheatSheetRowH    = 22.0
+	cheatSheetGlyphMM = 9.0
+)
+
+// cheatSheetGlyphPx is the pixel size glyph images are rendered at, about 270 dpi
+const cheatSheetGlyphPx = 96
+
+// scopeTitle describes a scope for headings (e.g. "collection:Prompt" -> "Collection: Prompt")
+func scopeTitle(scope string) string {
+	switch {
+	case scope == "" || scope == ScopeAll:
+		return "All glyphs"
+	case scope == ScopeFavorites:
+		return "Favorites"
+	case strings.HasPrefix(scope, ScopeCollectionPrefix):
+		return "Collection: " + strings.TrimPrefix(scope, ScopeCollectionPrefix)
+	case strings.HasPrefix(scope, ScopeCategoryPrefix):
+		return iconSetName(strings.TrimPrefix(scope, ScopeCategoryPrefix))
+	case strings.HasPrefix(scope, ScopeSmartPrefix):
+		return "Smart filter: " + strings.TrimPrefix(scope, ScopeSmartPrefix)
+	}
+	return scope
+}
+
+// truncateToWidth shortens s with an ellipsis until it fits width at the current font
+func truncateToWidth(pdf *fpdf.Fpdf, s string, width float64) string {
+	if pdf.GetStringWidth(s) <= width {
+		return s
+	}
+	for len(s) > 1 && pdf.GetStringWidth(s+"...") > width {
+		s = s[:len(s)-1]
+	}
+	return s + "..."
+}
+
+// renderCheatSheet lays glyphs out in a grid with their names and codepoints.
+// Glyphs are placed as images: fpdf's font embedding only reads the BMP cmap,
+// so supplementary plane icons (nf-md) would print blank, and it can't load
+// CFF fonts at all. Without a Nerd Font (render is nil) or for glyphs the font
+// lacks, the glyph cells stay empty but names and codepoints still print.
+func renderCheatSheet(title string, glyphs []Glyph, render func(Glyph, int) ([]byte, error), attribution []IconSetLicense) *fpdf.Fpdf {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("Gylte", false)
+	pdf.SetMargins(cheatSheetMargin, cheatSheetMargin, cheatSheetMargin)
+	pdf.SetAutoPageBreak(false, cheatSheetMargin)
+
+	pageW, pageH := pdf.GetPageSize()
+	cellW := (pageW - 2*cheatSheetMargin) / cheatSheetColumns
+
+	header := func() {
+		pdf.AddPage()
+		pdf.SetFont("Helvetica", "B", 14)
+		pdf.CellFormat(0, 8, title, "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.CellFormat(0, 8, fmt.Sprintf("%d glyphs - %s", len(glyphs), time.Now().Format("2006-01-02")), "", 1, "R", false, 0, "")
+		pdf.Ln(2)
+	}
+	header()
+
+	for i, g := range glyphs {
+		col := i % cheatSheetColumns
+		if col == 0 && i > 0 {
+			pdf.SetY(pdf.GetY() + cheatSheetRowH)
+		}
+		if pdf.GetY()+cheatSheetRowH > pageH-cheatSheetMargin {
+			header()
+		}
+
+		x, y := cheatSheetMargin+float64(col)*cellW, pdf.GetY()
+		pdf.SetDrawColor(210, 210, 210)
+		pdf.Rect(x, y, cellW, cheatSheetRowH, "D")
+
+		if render != nil {
+			if data, err := render(g, cheatSheetGlyphPx); err != nil {
+				log.Printf("Cheat sheet without %s: %v", g.Name, err)
+			} else {
+				name := fmt.Sprintf("glyph-%d", i)
+				opts := fpdf.ImageOptions{ImageType: "PNG"}
+				pdf.RegisterImageOptionsReader(name, opts, bytes.NewReader(data))
+				pdf.ImageOptions(name, x+(cellW-cheatSheetGlyphMM)/2, y+2, cheatSheetGlyphMM, cheatSheetGlyphMM, false, opts, 0, "")
+			}
+		}
+
+		pdf.SetFont("Helvetica", "", 6)
+		pdf.SetXY(x+1, y+13)
+		pdf.CellFormat(cellW-2, 3.5, truncateToWidth(pdf, g.Name, cellW-2), "", 0, "C", false, 0, "")
+		pdf.SetTextColor(120, 120, 120)
+		pdf.SetXY(x+1, y+16.5)
+		pdf.CellFormat(cellW-2, 3.5, formatCodepoint(codepointOf(g.Glyph)), "", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetXY(cheatSheetMargin, y)
+	}
+
+	// Credits follow the grid, on a new page if they don't fit
+	if len(attribution) > 0 {
+		lines := append([]string{attributionHeading}, attributionLines(attribution)...)
+		pdf.SetY(pdf.GetY() + cheatSheetRowH + 4)
+		if pdf.GetY()+float64(len(lines))*3.5 > pageH-cheatSheetMargin {
+			header()
+		}
+		pdf.SetFont("Helvetica", "", 7)
+		pdf.SetTextColor(100, 100, 100)
+		for _, line := range lines {
+			pdf.SetX(cheatSheetMargin)
+			pdf.MultiCell(0, 3.5, line, "", "L", false)
+		}
+		pdf.SetTextColor(0, 0, 0)
+	}
+	return pdf
+}
+
+// pdfCheatSheetExporter renders a printable grid, drawing glyphs with the Nerd Font when one is installed
+var pdfCheatSheetExporter = exporterFunc{
+	name:       "pdf",
+	extensions: []string{".pdf"},
+	export: func(w io.Writer, glyphs []Glyph, opts ExportOptions) error {
+		if opts.RenderGlyph == nil {
+			log.Printf("Cheat sheet without glyph images: no Nerd Font found")
+		}
+		return renderCheatSheet("Gylte - "+opts.Title, glyphs, opts.RenderGlyph, opts.Attribution).Output(w)
+	},
+}
+
+// ExportCheatSheet renders the glyphs of a scope ("favorites", "collection:<name>",
+// "category:<name>") to a printable PDF and returns its path. An empty path
+// exports to the default exports directory.
+func (a *App) ExportCheatSheet(scope string, path string) (string, error) {
+	op := a.startOperation("export", "Creating cheat sheet…")
+
+	glyphs, err := a.scopeGlyphs(scope)
+	if err != nil {
+		return "", op.Fail("Could not create the cheat sheet", err)
+	}
+	title := scopeTitle(scope)
+	return a.exportGlyphs(op, "pdf", "cheatsheet-"+sanitizeFileName(title), path, title, glyphs)
+}
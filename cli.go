@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// glyphFormats render a resolved glyph for command line output
+var glyphFormats = map[string]func(g Glyph) string{
+	"char":      func(g Glyph) string { return g.Glyph },
+	"codepoint": func(g Glyph) string { return formatCodepoint(codepointOf(g.Glyph)) },
+	"hex":       func(g Glyph) string { return fmt.Sprintf("%x", codepointOf(g.Glyph)) },
+	"html":      func(g Glyph) string { return fmt.Sprintf("&#x%X;", codepointOf(g.Glyph)) },
+	"name":      func(g Glyph) string { return g.Name },
+	"tsv": func(g Glyph) string {
+		return g.Name + "\t" + g.Glyph + "\t" + formatCodepoint(codepointOf(g.Glyph))
+	},
+}
+
+// glyphFormatNames lists the output formats for flag help
+func glyphFormatNames() string {
+	names := make([]string, 0, len(glyphFormats))
+	for name := range glyphFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// parseCodepoint reads "U+F135" or "0xf135"
+func parseCodepoint(s string) (rune, bool) {
+	s = strings.ToLower(s)
+	hex, ok := strings.CutPrefix(s, "u+")
+	if !ok {
+		if hex, ok = strings.CutPrefix(s, "0x"); !ok {
+			return 0, false
+		}
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || n > 0x10FFFF {
+		return 0, false
+	}
+	return rune(n), true
+}
+
+// resolveGlyph finds the glyph a command line argument refers to: an exact
+// name, a codepoint, or else the best search match
+func (a *App) resolveGlyph(query string) (Glyph, bool) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return Glyph{}, false
+	}
+	if g, ok := a.findGlyphByName(query); ok {
+		return g, true
+	}
+	if r, ok := parseCodepoint(query); ok {
+		for _, g := range a.allGlyphs() {
+			if codepointOf(g.Glyph) == r {
+				return g, true
+			}
+		}
+	}
+
+	matches, _, err := a.matchGlyphs(query, "", ScopeAll, "", false)
+	if err != nil || len(matches) == 0 {
+		return Glyph{}, false
+	}
+	return matches[0].Glyph, true
+}
+
+// runFilter reads glyph names or queries from in, one per line, and writes
+// each resolved glyph to out. Unresolved lines are reported on stderr and
+// make the run fail once all input has been read.
+func runFilter(a *App, in io.Reader, out, errOut io.Writer, format string) error {
+	render, ok := glyphFormats[format]
+	if !ok {
+		return fmt.Errorf("unknown format %q (want one of %s)", format, glyphFormatNames())
+	}
+
+	a.startHeadless()
+	defer a.shutdown(a.ctx)
+
+	w := bufio.NewWriter(out)
+	defer w.Flush()
+
+	missing := 0
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		g, ok := a.resolveGlyph(line)
+		if !ok {
+			fmt.Fprintf(errOut, "gylte: no glyph matches %q\n", line)
+			missing++
+			continue
+		}
+		fmt.Fprintln(w, render(g))
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if missing > 0 {
+		log.Printf("Filter: %d unresolved lines", missing)
+		return fmt.Errorf("no glyph found for %d of the input lines", missing)
+	}
+	return nil
+}
+
+// cliRunner runs a subcommand with its positional arguments once flags are parsed
+type cliRunner func(a *App, args []string, out, errOut io.Writer) error
+
+// cliCommand is a subcommand such as `gylte list`. define registers the
+// command's flags and returns the function that runs it; usage text, shell
+// completions, and the man page are all generated from these definitions.
+type cliCommand struct {
+	summary string
+	args    string // positional arguments for usage, e.g. "<name>..."
+	choices []string
+	define  func(fs *flag.FlagSet) cliRunner
+}
+
+// cliCommands are the subcommands that run without a window
+var cliCommands map[string]cliCommand
+
+func init() {
+	cliCommands = map[string]cliCommand{
+		"list": {
+			summary: "Print every glyph, one per line",
+			define:  defineList,
+		},
+		"resolve": {
+			summary: "Print the glyph for each name, query, or fzf line",
+			args:    "<name>...",
+			define:  defineResolve,
+		},
+		"menu": {
+			summary: "Pick a glyph with rofi, wofi, dmenu, or fzf and copy it",
+			define:  defineMenu,
+		},
+		"completion": {
+			summary: "Print a shell completion script",
+			args:    "<shell>",
+			choices: completionShells,
+			define:  defineCompletion,
+		},
+		"man": {
+			summary: "Print the gylte(1) manual page",
+			define:  defineMan,
+		},
+		"mcp": {
+			summary: "Serve glyph search to AI assistants over MCP on stdio",
+			define:  defineMCP,
+		},
+		"uninstall": {
+			summary: "Remove gylte:// links, shortcuts, and user data from this computer",
+			define:  defineUninstall,
+		},
+	}
+}
+
+// newCLIFlags creates a flag set for a subcommand that reports errors instead of exiting
+func newCLIFlags(name string, errOut io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("gylte "+name, flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	return fs
+}
+
+// commandFlags returns a subcommand's flag set without running it
+func commandFlags(name string) *flag.FlagSet {
+	fs := newCLIFlags(name, io.Discard)
+	cliCommands[name].define(fs)
+	return fs
+}
+
+// runCommand parses a subcommand's flags and runs it
+func runCommand(a *App, name string, args []string, out, errOut io.Writer) error {
+	cmd := cliCommands[name]
+	fs := newCLIFlags(name, errOut)
+	run := cmd.define(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(errOut, "Usage: %s\n\n%s\n\nFlags:\n", commandUsage(name), cmd.summary)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return run(a, fs.Args(), out, errOut)
+}
+
+// commandUsage builds a usage line such as "gylte list [--fzf] [--format F]"
+func commandUsage(name string) string {
+	parts := []string{"gylte", name}
+	commandFlags(name).VisitAll(func(f *flag.Flag) {
+		if isBoolFlag(f) {
+			parts = append(parts, "[--"+f.Name+"]")
+		} else {
+			parts = append(parts, "[--"+f.Name+" "+strings.ToUpper(f.Name[:1])+"]")
+		}
+	})
+	if args := cliCommands[name].args; args != "" {
+		parts = append(parts, args)
+	}
+	return strings.Join(parts, " ")
+}
+
+// isBoolFlag reports whether a flag takes no value
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// commandNames returns the subcommand names in sorted order
+func commandNames() []string {
+	names := make([]string, 0, len(cliCommands))
+	for name := range cliCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// defineList prints every glyph, one per line. --fzf prints
+// "name<TAB>glyph<TAB>codepoint" for fzf, rofi, or dmenu.
+func defineList(fs *flag.FlagSet) cliRunner {
+	fzf := fs.Bool("fzf", false, "print name<TAB>glyph<TAB>codepoint lines for fzf/rofi/dmenu")
+	format := fs.String("format", "name", "output format: "+glyphFormatNames())
+	category := fs.String("category", "", "only list one icon set (e.g. fa, dev)")
+	favorites := fs.Bool("favorites", false, "only list favorites")
+
+	return func(a *App, args []string, out, errOut io.Writer) error {
+		if *fzf {
+			*format = "tsv"
+		}
+		render, ok := glyphFormats[*format]
+		if !ok {
+			return fmt.Errorf("unknown format %q (want one of %s)", *format, glyphFormatNames())
+		}
+
+		a.startHeadless()
+		defer a.shutdown(a.ctx)
+
+		scope := ScopeAll
+		if *favorites {
+			scope = ScopeFavorites
+		}
+		matches, _, err := a.matchGlyphs("", *category, scope, "", false)
+		if err != nil {
+			return err
+		}
+
+		w := bufio.NewWriter(out)
+		defer w.Flush()
+		for _, m := range matches {
+			fmt.Fprintln(w, render(m.Glyph))
+		}
+		return nil
+	}
+}
+
+// defineResolve prints the glyph for each argument. Whole lines selected from
+// `gylte list --fzf` are accepted; only the name before the first tab is used.
+func defineResolve(fs *flag.FlagSet) cliRunner {
+	format := fs.String("format", "char", "output format: "+glyphFormatNames())
+
+	return func(a *App, args []string, out, errOut io.Writer) error {
+		if len(args) == 0 {
+			return fmt.Errorf("usage: %s", commandUsage("resolve"))
+		}
+
+		lines := make([]string, len(args))
+		for i, arg := range args {
+			name, _, _ := strings.Cut(arg, "\t")
+			lines[i] = name
+		}
+		return runFilter(a, strings.NewReader(strings.Join(lines, "\n")), out, errOut, *format)
+	}
+}
+
+// printUsage describes the flags and subcommands for -h
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: gylte [flags] [command]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, name := range commandNames() {
+		fmt.Fprintf(w, "  %-12s %s\n", name, cliCommands[name].summary)
+	}
+	fmt.Fprintln(w, "\nFlags:")
+	flag.CommandLine.SetOutput(w)
+	flag.PrintDefaults()
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// clickThroughPoll is how often the cursor is hit-tested against glyph tiles
+const clickThroughPoll = 40 * time.Millisecond
+
+// defaultClickThroughOpacity is the window opacity while click-through is on
+const defaultClickThroughOpacity = 0.5
+
+// EventClickThroughChanged is emitted when click-through palette mode is toggled
+const EventClickThroughChanged = "window:clickthrough"
+
+// errClickThroughUnsupported is returned by platforms without a click-through backend
+var errClickThroughUnsupported = errors.New("click-through mode is not supported on this platform")
+
+// clickThroughBackend switches whether the OS delivers mouse input to the window
+type clickThroughBackend interface {
+	// Cursor returns the cursor position in device pixels relative to the
+	// window's content area
+	Cursor() (x, y float64, ok bool)
+
+	// SetIgnoreMouse makes clicks fall through to the window below
+	SetIgnoreMouse(ignore bool) error
+
+	Close()
+}
+
+// ClickRegion is a rectangle in device pixels, relative to the content area,
+// that keeps receiving clicks in click-through mode (a glyph tile)
+type ClickRegion struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// contains reports whether the point lies inside the region
+func (r ClickRegion) contains(x, y float64) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// ClickThrough tracks palette mode, where the translucent window passes clicks
+// to the window underneath except over glyph tiles
+type ClickThrough struct {
+	mu      sync.Mutex
+	backend clickThroughBackend
+	cancel  context.CancelFunc
+	regions []ClickRegion
+	ignored bool
+}
+
+// enabled reports whether click-through mode is on
+func (c *ClickThrough) enabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.backend != nil
+}
+
+// hit reports whether the point lies over a clickable region
+func (c *ClickThrough) hit(x, y float64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range c.regions {
+		if r.contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// update tells the backend to ignore the mouse unless it is over a region
+func (c *ClickThrough) update() {
+	c.mu.Lock()
+	backend := c.backend
+	c.mu.Unlock()
+	if backend == nil {
+		return
+	}
+
+	x, y, ok := backend.Cursor()
+	ignore := !ok || !c.hit(x, y)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.backend != backend || ignore == c.ignored {
+		return
+	}
+	if err := backend.SetIgnoreMouse(ignore); err != nil {
+		log.Printf("Failed to update click-through: %v", err)
+		return
+	}
+	c.ignored = ignore
+}
+
+// clickThroughOpacity returns the window opacity used in click-through mode
+func (a *App) clickThroughOpacity() float64 {
+	if v, err := strconv.ParseFloat(a.settings.Get("window.clickThroughOpacity", ""), 64); err == nil {
+		return clampOpacity(v)
+	}
+	return defaultClickThroughOpacity
+}
+
+// GetClickThrough reports whether click-through palette mode is on
+func (a *App) GetClickThrough() bool {
+	return a.clickThrough.enabled()
+}
+
+// SetClickThrough turns click-through palette mode on or off. While on, the
+// window is translucent and always on top, and only glyph tiles take clicks.
+func (a *App) SetClickThrough(enabled bool) error {
+	if err := a.requireWindow("window"); err != nil {
+		return err
+	}
+	if enabled == a.clickThrough.enabled() {
+		return nil
+	}
+	if enabled {
+		if err := requireCapability(CapabilityClickThrough); err != nil {
+			return err
+		}
+	}
+
+	if !enabled {
+		a.stopClickThrough()
+		prefs := a.GetWindowPrefs()
+		runtime.WindowSetAlwaysOnTop(a.ctx, prefs.AlwaysOnTop)
+		a.emit(EventWindowOpacity, prefs.Opacity)
+		a.emit(EventClickThroughChanged, false)
+		log.Println("Click-through mode off")
+		return nil
+	}
+
+	backend, err := newClickThroughBackend()
+	if err != nil {
+		return a.runtimeFailure("window", fmt.Sprintf("Click-through mode isn't available: %v", err), err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	a.clickThrough.mu.Lock()
+	a.clickThrough.backend = backend
+	a.clickThrough.cancel = cancel
+	a.clickThrough.ignored = false
+	a.clickThrough.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(clickThroughPoll)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.clickThrough.update()
+			}
+		}
+	}()
+
+	runtime.WindowSetAlwaysOnTop(a.ctx, true)
+	a.emit(EventWindowOpacity, a.clickThroughOpacity())
+	a.emit(EventClickThroughChanged, true)
+	log.Println("Click-through mode on")
+	return nil
+}
+
+// SetClickThroughRegions replaces the rectangles that stay clickable; the
+// frontend reports its visible glyph tiles whenever the grid scrolls or changes
+func (a *App) SetClickThroughRegions(regions []ClickRegion) {
+	a.clickThrough.mu.Lock()
+	defer a.clickThrough.mu.Unlock()
+	a.clickThrough.regions = regions
+}
+
+// stopClickThrough restores normal mouse input and stops hit-testing
+func (a *App) stopClickThrough() {
+	a.clickThrough.mu.Lock()
+	defer a.clickThrough.mu.Unlock()
+
+	if a.clickThrough.backend == nil {
+		return
+	}
+	a.clickThrough.cancel()
+	if err := a.clickThrough.backend.SetIgnoreMouse(false); err != nil {
+		log.Printf("Failed to restore mouse input: %v", err)
+	}
+	a.clickThrough.backend.Close()
+	a.clickThrough.backend = nil
+	a.clickThrough.cancel = nil
+}
@@ -0,0 +1,84 @@
+//go:build darwin && cgo
+
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+#import <Cocoa/Cocoa.h>
+
+// gylte_window returns the app's main window (Gylte has a single window)
+static NSWindow *gylte_window(void) {
+	NSArray *windows = [NSApp windows];
+	return windows.count > 0 ? windows[0] : nil;
+}
+
+static int gylte_has_window(void) {
+	__block int found = 0;
+	dispatch_sync(dispatch_get_main_queue(), ^{
+		found = gylte_window() != nil;
+	});
+	return found;
+}
+
+static void gylte_set_ignores_mouse(int ignore) {
+	dispatch_async(dispatch_get_main_queue(), ^{
+		[gylte_window() setIgnoresMouseEvents:ignore ? YES : NO];
+	});
+}
+
+// gylte_cursor converts the mouse location to device pixels from the top-left
+// of the window's content area
+static int gylte_cursor(double *x, double *y) {
+	__block int ok = 0;
+	dispatch_sync(dispatch_get_main_queue(), ^{
+		NSWindow *w = gylte_window();
+		if (w == nil) {
+			return;
+		}
+		NSPoint p = [NSEvent mouseLocation];
+		NSRect content = [w contentRectForFrameRect:[w frame]];
+		CGFloat scale = [w backingScaleFactor];
+		*x = (p.x - content.origin.x) * scale;
+		*y = (content.size.height - (p.y - content.origin.y)) * scale;
+		ok = 1;
+	});
+	return ok;
+}
+*/
+import "C"
+
+import "fmt"
+
+// clickThroughSupported reports that this build has a click-through backend
+const clickThroughSupported = true
+
+// macClickThrough uses NSWindow's ignoresMouseEvents
+type macClickThrough struct{}
+
+// newClickThroughBackend checks that the main window exists
+func newClickThroughBackend() (clickThroughBackend, error) {
+	if C.gylte_has_window() == 0 {
+		return nil, newAppError(ErrCodeNotFound, "main window not found")
+	}
+	return macClickThrough{}, nil
+}
+
+func (macClickThrough) Cursor() (float64, float64, bool) {
+	var x, y C.double
+	if C.gylte_cursor(&x, &y) == 0 {
+		return 0, 0, false
+	}
+	return float64(x), float64(y), true
+}
+
+func (macClickThrough) SetIgnoreMouse(ignore bool) error {
+	flag := C.int(0)
+	if ignore {
+		flag = 1
+	}
+	C.gylte_set_ignores_mouse(flag)
+	return nil
+}
+
+func (macClickThrough) Close() {}
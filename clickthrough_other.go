@@ -0,0 +1,11 @@
+//go:build !windows && !(darwin && cgo)
+
+package main
+
+// clickThroughSupported reports that this build has a click-through backend
+const clickThroughSupported = false
+
+// newClickThroughBackend reports that click-through mode isn't available on this platform
+func newClickThroughBackend() (clickThroughBackend, error) {
+	return nil, errClickThroughUnsupported
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// clickThroughSupported reports that this build has a click-through backend
+const clickThroughSupported = true
+
+var (
+	procFindWindowW                = user32.NewProc("FindWindowW")
+	procGetWindowLongPtrW          = user32.NewProc("GetWindowLongPtrW")
+	procSetWindowLongPtrW          = user32.NewProc("SetWindowLongPtrW")
+	procSetLayeredWindowAttributes = user32.NewProc("SetLayeredWindowAttributes")
+	procGetCursorPos               = user32.NewProc("GetCursorPos")
+	procScreenToClient             = user32.NewProc("ScreenToClient")
+)
+
+const (
+	gwlExStyle       = ^uintptr(19) // GWL_EXSTYLE (-20)
+	wsExLayered      = 0x00080000
+	wsExTransparent  = 0x00000020
+	lwaAlpha         = 0x2
+	windowTitleGylte = "Gylte"
+)
+
+// winClickThrough toggles WS_EX_TRANSPARENT on the main window; layered
+// windows with that style let clicks through to whatever is below
+type winClickThrough struct {
+	hwnd  uintptr
+	style uintptr
+}
+
+// newClickThroughBackend finds the main window by its title
+func newClickThroughBackend() (clickThroughBackend, error) {
+	title, err := syscall.UTF16PtrFromString(windowTitleGylte)
+	if err != nil {
+		return nil, err
+	}
+	hwnd, _, _ := procFindWindowW.Call(0, uintptr(unsafe.Pointer(title)))
+	if hwnd == 0 {
+		return nil, newAppError(ErrCodeNotFound, "main window not found")
+	}
+	style, _, _ := procGetWindowLongPtrW.Call(hwnd, gwlExStyle)
+	return &winClickThrough{hwnd: hwnd, style: style}, nil
+}
+
+func (w *winClickThrough) Cursor() (float64, float64, bool) {
+	var pt struct{ x, y int32 }
+	if ret, _, _ := procGetCursorPos.Call(uintptr(unsafe.Pointer(&pt))); ret == 0 {
+		return 0, 0, false
+	}
+	if ret, _, _ := procScreenToClient.Call(w.hwnd, uintptr(unsafe.Pointer(&pt))); ret == 0 {
+		return 0, 0, false
+	}
+	return float64(pt.x), float64(pt.y), true
+}
+
+func (w *winClickThrough) SetIgnoreMouse(ignore bool) error {
+	style := w.style
+	if ignore {
+		style |= wsExLayered | wsExTransparent
+	}
+	procSetWindowLongPtrW.Call(w.hwnd, gwlExStyle, style)
+	if ignore && w.style&wsExLayered == 0 {
+		// A newly layered window stays invisible until its alpha is set
+		if ret, _, err := procSetLayeredWindowAttributes.Call(w.hwnd, 0, 255, lwaAlpha); ret == 0 {
+			return fmt.Errorf("failed to make window layered: %w", err)
+		}
+	}
+	return nil
+}
+
+func (w *winClickThrough) Close() {}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image/color"
+	"log"
+	"os"
+	"os/exec"
+	goruntime "runtime"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Clipboard backend names
+const (
+	ClipboardAuto    = "auto"
+	ClipboardRuntime = "runtime"
+	ClipboardWlCopy  = "wl-copy"
+	ClipboardXclip   = "xclip"
+	ClipboardXsel    = "xsel"
+	ClipboardOSC52   = "osc52"
+)
+
+// clipboardBackend is one way of putting text on the system clipboard
+type clipboardBackend struct {
+	name      string
+	available func() bool
+	setText   func(text string) error
+}
+
+// CopyResult tells the UI whether text reached the clipboard and how
+type CopyResult struct {
+	Success bool   `json:"success"`
+	Backend string `json:"backend,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ClipboardBackendInfo describes a backend for settings screens
+type ClipboardBackendInfo struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+	Active    bool   `json:"active"`
+}
+
+// hasCommand reports whether an executable is on PATH
+func hasCommand(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// pipeWaitDelay is how long pipeCommand waits for output once the command has
+// exited. wl-copy and xclip fork a child that keeps serving the selection with
+// stderr still open, so waiting for EOF would never return.
+const pipeWaitDelay = 200 * time.Millisecond
+
+// pipeCommand runs a command with text on stdin
+func pipeCommand(text, name string, args ...string) error {
+	var stderr strings.Builder
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = pipeWaitDelay
+	err := cmd.Run()
+	if errors.Is(err, exec.ErrWaitDelay) {
+		// The command succeeded; only its forked child still holds stderr
+		err = nil
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// inSSHSession reports whether the app was started from an SSH login
+func inSSHSession() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// osc52Sequence builds the terminal escape that asks the terminal emulator to
+// set its clipboard, wrapped for tmux passthrough when needed
+func osc52Sequence(text string) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	if os.Getenv("TMUX") != "" {
+		seq = "\x1bPtmux;\x1b" + seq + "\x1b\\"
+	}
+	return seq
+}
+
+// writeOSC52 sends the clipboard escape to the controlling terminal
+func writeOSC52(text string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("no terminal for OSC 52: %w", err)
+	}
+	defer tty.Close()
+	_, err = tty.WriteString(osc52Sequence(text))
+	return err
+}
+
+// clipboardBackends returns every backend in the order auto mode tries them.
+// On Linux the native tools come first since the webview clipboard is
+// unreliable on some Wayland and X11 setups.
+func (a *App) clipboardBackends() []clipboardBackend {
+	rt := clipboardBackend{
+		name:      ClipboardRuntime,
+		available: a.hasRuntime,
+		setText: func(text string) error {
+			return runtime.ClipboardSetText(a.ctx, text)
+		},
+	}
+	native := []clipboardBackend{
+		{
+			name:      ClipboardWlCopy,
+			available: func() bool { return os.Getenv("WAYLAND_DISPLAY") != "" && hasCommand("wl-copy") },
+			setText:   func(text string) error { return pipeCommand(text, "wl-copy") },
+		},
+		{
+			name:      ClipboardXclip,
+			available: func() bool { return os.Getenv("DISPLAY") != "" && hasCommand("xclip") },
+			setText:   func(text string) error { return pipeCommand(text, "xclip", "-selection", "clipboard") },
+		},
+		{
+			name:      ClipboardXsel,
+			available: func() bool { return os.Getenv("DISPLAY") != "" && hasCommand("xsel") },
+			setText:   func(text string) error { return pipeCommand(text, "xsel", "--clipboard", "--input") },
+		},
+	}
+	osc52 := clipboardBackend{
+		name:      ClipboardOSC52,
+		available: inSSHSession,
+		setText:   writeOSC52,
+	}
+
+	if goruntime.GOOS == "linux" {
+		return append(append(native, rt), osc52)
+	}
+	return []clipboardBackend{rt, osc52}
+}
+
+// setClipboard copies text with the configured backend, or in auto mode with
+// the first available backend that succeeds. It returns the backend used.
+func (a *App) setClipboard(text string) (string, error) {
+	a.clipWatch.markOwn(text)
+
+	preferred := a.settings.Get("clipboard.backend", ClipboardAuto)
+	var errs []error
+	for _, b := range a.clipboardBackends() {
+		if preferred != ClipboardAuto && b.name != preferred {
+			continue
+		}
+		if !b.available() {
+			errs = append(errs, fmt.Errorf("%s is not available", b.name))
+			continue
+		}
+		err := b.setText(text)
+		if err == nil {
+			return b.name, nil
+		}
+		log.Printf("Clipboard backend %s failed: %v", b.name, err)
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return "", newAppError(ErrCodeInvalid, "unknown clipboard backend: %s", preferred)
+	}
+	message := "Nothing was copied: no clipboard is available"
+	if preferred != ClipboardAuto {
+		message = fmt.Sprintf("Nothing was copied: the %s clipboard isn't working", preferred)
+	}
+	return "", a.runtimeFailure("copy", message, errors.Join(errs...))
+}
+
+// CopyToClipboard copies text to clipboard and reports whether it worked
+func (a *App) CopyToClipboard(text string) CopyResult {
+	backend, err := a.setClipboard(text)
+	if err != nil {
+		return CopyResult{Error: err.Error()}
+	}
+	return CopyResult{Success: true, Backend: backend}
+}
+
+// defaultClipboardImageSize is used by CopyGlyphImage when no size is given
+const defaultClipboardImageSize = 128
+
+// CopyGlyphImage renders a glyph as a PNG on a transparent background and
+// puts the image on the clipboard for pasting into chat or design tools.
+// hexColor is the glyph color, black by default; emoji keep their own colors unless one is given.
+func (a *App) CopyGlyphImage(id int, size int, hexColor string) error {
+	g, ok := a.findGlyph(id)
+	if !ok {
+		return newAppError(ErrCodeNotFound, "glyph %d not found", id)
+	}
+	if err := requireCapability(CapabilityClipboardImage); err != nil {
+		return err
+	}
+	if size == 0 {
+		size = defaultClipboardImageSize
+	}
+
+	opts := RenderOptions{Size: size, Padding: 0.05}
+	if hexColor != "" {
+		fg, err := parseHexColor(hexColor)
+		if err != nil {
+			return err
+		}
+		opts.Foreground, opts.Monochrome = fg, true
+	} else {
+		opts.Foreground = color.Black
+	}
+	data, err := a.renderGlyphPNG(g, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := setClipboardImage(data); err != nil {
+		return a.runtimeFailure("copy", "The image wasn't copied", err)
+	}
+	a.recordCopy(id)
+	return nil
+}
+
+// GetClipboardBackends lists the clipboard backends and which ones work here
+func (a *App) GetClipboardBackends() []ClipboardBackendInfo {
+	preferred := a.settings.Get("clipboard.backend", ClipboardAuto)
+	result := []ClipboardBackendInfo{{Name: ClipboardAuto, Available: true, Active: preferred == ClipboardAuto}}
+	for _, b := range a.clipboardBackends() {
+		result = append(result, ClipboardBackendInfo{Name: b.name, Available: b.available(), Active: b.name == preferred})
+	}
+	return result
+}
+
+// SetClipboardBackend forces a clipboard backend, or "auto" to pick one per copy
+func (a *App) SetClipboardBackend(name string) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if name != ClipboardAuto {
+		known := false
+		for _, b := range a.clipboardBackends() {
+			known = known || b.name == name
+		}
+		if !known {
+			return newAppError(ErrCodeInvalid, "unknown clipboard backend: %s", name)
+		}
+	}
+	return a.settings.Set("clipboard.backend", name)
+}
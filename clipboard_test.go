@@ -0,0 +1,27 @@
+//go:build linux || freebsd || openbsd || netbsd
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPipeCommandReturnsWhileForkedChildHoldsStderr(t *testing.T) {
+	start := time.Now()
+	// Like xclip: exit at once, leaving a child that keeps stderr open
+	if err := pipeCommand("text", "sh", "-c", "cat >/dev/null; sleep 5 >&2 &"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("pipeCommand waited %v for the forked child", elapsed)
+	}
+}
+
+func TestPipeCommandReportsStderr(t *testing.T) {
+	err := pipeCommand("", "sh", "-c", "echo no display >&2; exit 1")
+	if err == nil || !strings.Contains(err.Error(), "no display") {
+		t.Errorf("err = %v, want the command's stderr", err)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// clipboardImageSupport reports why images can't be copied, or nil if they can
+func clipboardImageSupport() error {
+	if !hasCommand("osascript") {
+		return fmt.Errorf("copying images needs osascript")
+	}
+	return nil
+}
+
+// setClipboardImage puts a PNG on the pasteboard through osascript, which
+// reads it from a temporary file
+func setClipboardImage(png []byte) error {
+	f, err := os.CreateTemp("", "gylte-*.png")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary image: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(png); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write temporary image: %w", err)
+	}
+	f.Close()
+
+	script := "set the clipboard to (read (POSIX file " + appleScriptString(f.Name()) + ") as «class PNGf»)"
+	if out, err := exec.Command("osascript", "-e", script).CombinedOutput(); err != nil {
+		return fmt.Errorf("osascript: %w: %s", err, out)
+	}
+	return nil
+}
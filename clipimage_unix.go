@@ -0,0 +1,27 @@
+//go:build !windows && !darwin
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// clipboardImageSupport reports why images can't be copied, or nil if they can
+func clipboardImageSupport() error {
+	if (os.Getenv("WAYLAND_DISPLAY") != "" && hasCommand("wl-copy")) || (os.Getenv("DISPLAY") != "" && hasCommand("xclip")) {
+		return nil
+	}
+	return fmt.Errorf("copying images needs wl-copy or xclip")
+}
+
+// setClipboardImage puts a PNG on the clipboard with wl-copy or xclip
+func setClipboardImage(png []byte) error {
+	switch {
+	case os.Getenv("WAYLAND_DISPLAY") != "" && hasCommand("wl-copy"):
+		return pipeCommand(string(png), "wl-copy", "--type", "image/png")
+	case os.Getenv("DISPLAY") != "" && hasCommand("xclip"):
+		return pipeCommand(string(png), "xclip", "-selection", "clipboard", "-target", "image/png", "-in")
+	}
+	return fmt.Errorf("copying images needs wl-copy or xclip")
+}
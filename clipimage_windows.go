@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// clipboardImageScript offers the image both as "PNG", which keeps
+// transparency for apps that read it, and as a bitmap for the rest. The path
+// is passed via an environment variable so it never needs PowerShell escaping.
+const clipboardImageScript = `
+Add-Type -AssemblyName System.Windows.Forms, System.Drawing
+$bytes = [IO.File]::ReadAllBytes($env:GYLTE_IMAGE)
+$stream = New-Object IO.MemoryStream(,$bytes)
+$data = New-Object Windows.Forms.DataObject
+$data.SetData("PNG", $stream)
+$data.SetImage([Drawing.Image]::FromStream($stream))
+[Windows.Forms.Clipboard]::SetDataObject($data, $true)
+`
+
+// clipboardImageSupport reports why images can't be copied, or nil if they can
+func clipboardImageSupport() error {
+	if !hasCommand("powershell") {
+		return fmt.Errorf("copying images needs PowerShell")
+	}
+	return nil
+}
+
+// setClipboardImage puts a PNG on the clipboard through PowerShell
+func setClipboardImage(png []byte) error {
+	f, err := os.CreateTemp("", "gylte-*.png")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary image: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(png); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write temporary image: %w", err)
+	}
+	f.Close()
+
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-STA", "-Command", clipboardImageScript)
+	cmd.Env = append(os.Environ(), "GYLTE_IMAGE="+f.Name())
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("powershell: %w: %s", err, out)
+	}
+	return nil
+}
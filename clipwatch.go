@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// clipboardPollInterval is how often the clipboard is read while watching.
+// A change is only reported once it has been stable for one more poll.
+const clipboardPollInterval = 500 * time.Millisecond
+
+// maxClipboardGlyphs caps how many glyphs one clipboard change reports
+const maxClipboardGlyphs = 20
+
+// EventClipboardGlyphs is emitted with a ClipboardGlyphs when copied text contains known glyphs
+const EventClipboardGlyphs = "clipboard:glyphs"
+
+// ClipboardGlyphs describes glyphs found in text copied by another app
+type ClipboardGlyphs struct {
+	Text   string       `json:"text"`
+	Glyphs []GlyphMatch `json:"glyphs"`
+}
+
+// ClipboardWatcher polls the system clipboard for private-use characters
+type ClipboardWatcher struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+
+	// last is the most recent text handled, including text Gylte copied itself
+	last string
+}
+
+// markOwn records text Gylte put on the clipboard so the watcher doesn't report it
+func (w *ClipboardWatcher) markOwn(text string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.last = text
+}
+
+// accept reports whether text is a new, stable clipboard value and marks it handled
+func (w *ClipboardWatcher) accept(text, previous string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if text == w.last || text != previous {
+		return false
+	}
+	w.last = text
+	return true
+}
+
+// clipboardGlyphs looks up the private-use characters in text
+func (a *App) clipboardGlyphs(text string) []GlyphMatch {
+	var byRune map[rune]Glyph
+	seen := make(map[rune]bool)
+	var matches []GlyphMatch
+
+	for _, r := range text {
+		if !isPrivateUse(r) || seen[r] {
+			continue
+		}
+		seen[r] = true
+		if byRune == nil {
+			byRune = a.glyphsByRune()
+		}
+		if g, ok := byRune[r]; ok {
+			matches = append(matches, GlyphMatch{Glyph: g, IsFavorite: a.favorites.Contains(g.ID)})
+		}
+		if len(matches) == maxClipboardGlyphs {
+			break
+		}
+	}
+	return matches
+}
+
+// startClipboardWatch begins polling the clipboard until stopClipboardWatch is called
+func (a *App) startClipboardWatch() {
+	if !a.hasRuntime() {
+		return
+	}
+
+	a.clipWatch.mu.Lock()
+	if a.clipWatch.cancel != nil {
+		a.clipWatch.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.clipWatch.cancel = cancel
+	a.clipWatch.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(clipboardPollInterval)
+		defer ticker.Stop()
+
+		// Start from the current contents so old clipboard text isn't reported
+		previous, _ := runtime.ClipboardGetText(a.ctx)
+		a.clipWatch.markOwn(previous)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			text, err := runtime.ClipboardGetText(a.ctx)
+			if err != nil {
+				continue
+			}
+			if a.clipWatch.accept(text, previous) {
+				if glyphs := a.clipboardGlyphs(text); len(glyphs) > 0 {
+					a.emit(EventClipboardGlyphs, ClipboardGlyphs{Text: text, Glyphs: glyphs})
+				}
+			}
+			previous = text
+		}
+	}()
+	log.Println("Clipboard watch started")
+}
+
+// stopClipboardWatch stops polling the clipboard
+func (a *App) stopClipboardWatch() {
+	a.clipWatch.mu.Lock()
+	defer a.clipWatch.mu.Unlock()
+
+	if a.clipWatch.cancel != nil {
+		a.clipWatch.cancel()
+		a.clipWatch.cancel = nil
+		log.Println("Clipboard watch stopped")
+	}
+}
+
+// restartClipboardWatch applies the active profile's clipboard watch setting
+// after a profile opens, restarting a running watch so it works from the new
+// profile's settings and glyphs
+func (a *App) restartClipboardWatch() {
+	if a.GetClipboardWatch() {
+		a.startClipboardWatch()
+	} else {
+		a.stopClipboardWatch()
+	}
+}
+
+// GetClipboardWatch reports whether the clipboard watch is enabled
+func (a *App) GetClipboardWatch() bool {
+	return a.settings.GetBool("clipboard.watch", false)
+}
+
+// SetClipboardWatch enables or disables identifying glyphs copied in other apps (opt-in).
+// Matches are emitted as clipboard:glyphs events.
+func (a *App) SetClipboardWatch(enabled bool) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if err := a.settings.Set("clipboard.watch", strconv.FormatBool(enabled)); err != nil {
+		return err
+	}
+
+	if enabled {
+		a.startClipboardWatch()
+	} else {
+		a.stopClipboardWatch()
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// cssEscape writes a glyph as CSS string escapes (e.g. "\f135"), separating
+// consecutive escapes with the space CSS consumes as a terminator
+func cssEscape(glyph string) string {
+	parts := make([]string, 0, len(glyph))
+	for _, r := range glyph {
+		parts = append(parts, fmt.Sprintf(`\%x`, r))
+	}
+	return strings.Join(parts, " ")
+}
+
+// cssIdent makes a glyph name safe as a CSS class or SCSS key
+func cssIdent(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '-'
+	}, name)
+}
+
+// constName converts a glyph name to an exported identifier, e.g. "nf-fa-rocket" -> "NfFaRocket"
+func constName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	ident := b.String()
+	if ident == "" || unicode.IsDigit(rune(ident[0])) {
+		ident = "G" + ident
+	}
+	return ident
+}
+
+// uniqueNames maps each glyph to a name from naming, numbering collisions
+func uniqueNames(glyphs []Glyph, naming func(string) string) []string {
+	seen := make(map[string]int, len(glyphs))
+	names := make([]string, len(glyphs))
+	for i, g := range glyphs {
+		name := naming(g.Name)
+		if n := seen[name]; n > 0 {
+			seen[name]++
+			name = fmt.Sprintf("%s%d", name, n+1)
+		} else {
+			seen[name] = 1
+		}
+		names[i] = name
+	}
+	return names
+}
+
+// cssExporter writes one ::before class per glyph, like the Nerd Fonts cheat sheet
+var cssExporter = exporterFunc{
+	name:       "css",
+	extensions: []string{".css"},
+	export: func(w io.Writer, glyphs []Glyph, opts ExportOptions) error {
+		if _, err := fmt.Fprintf(w, "/* Generated by Gylte from %s */\n", opts.Title); err != nil {
+			return err
+		}
+		if len(opts.Attribution) > 0 {
+			if _, err := fmt.Fprintf(w, "/*\n%s */\n", attributionComment(" * ", opts.Attribution)); err != nil {
+				return err
+			}
+		}
+		for i, name := range uniqueNames(glyphs, cssIdent) {
+			if _, err := fmt.Fprintf(w, ".%s::before { content: \"%s\"; }\n", name, cssEscape(glyphs[i].Glyph)); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// scssExporter writes a map from glyph name to content string
+var scssExporter = exporterFunc{
+	name:       "scss",
+	extensions: []string{".scss"},
+	export: func(w io.Writer, glyphs []Glyph, opts ExportOptions) error {
+		if _, err := fmt.Fprintf(w, "// Generated by Gylte from %s\n%s$nerd-glyphs: (\n", opts.Title, attributionComment("// ", opts.Attribution)); err != nil {
+			return err
+		}
+		for i, name := range uniqueNames(glyphs, cssIdent) {
+			if _, err := fmt.Fprintf(w, "  \"%s\": \"%s\",\n", name, cssEscape(glyphs[i].Glyph)); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, ");\n")
+		return err
+	},
+}
+
+// goConstExporter writes a Go source file with one string constant per glyph
+var goConstExporter = exporterFunc{
+	name:       "go",
+	extensions: []string{".go"},
+	export: func(w io.Writer, glyphs []Glyph, opts ExportOptions) error {
+		if _, err := fmt.Fprintf(w, "// Code generated by Gylte from %s. DO NOT EDIT.\n%s\npackage glyphs\n\nconst (\n", opts.Title, attributionComment("// ", opts.Attribution)); err != nil {
+			return err
+		}
+		for i, name := range uniqueNames(glyphs, constName) {
+			if _, err := fmt.Fprintf(w, "\t%s = %s // %s\n", name, strconv.QuoteToASCII(glyphs[i].Glyph), glyphs[i].Name); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, ")\n")
+		return err
+	},
+}
+
+// typeScriptConstExporter writes a TypeScript module exporting a readonly name-to-glyph map
+var typeScriptConstExporter = exporterFunc{
+	name:       "typescript",
+	extensions: []string{".ts"},
+	export: func(w io.Writer, glyphs []Glyph, opts ExportOptions) error {
+		if _, err := fmt.Fprintf(w, "// Generated by Gylte from %s\n%sexport const glyphs = {\n", opts.Title, attributionComment("// ", opts.Attribution)); err != nil {
+			return err
+		}
+		for _, g := range glyphs {
+			var escaped strings.Builder
+			for _, r := range g.Glyph {
+				fmt.Fprintf(&escaped, `\u{%x}`, r)
+			}
+			if _, err := fmt.Fprintf(w, "  %s: \"%s\",\n", strconv.Quote(g.Name), escaped.String()); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, "} as const;\n\nexport type GlyphName = keyof typeof glyphs;\n")
+		return err
+	},
+}
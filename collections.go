@@ -0,0 +1,218 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Collection is a named, ordered set of glyphs
+type Collection struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Count     int    `json:"count"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// initCollectionsTables creates the collection tables if they don't exist
+func (a *App) initCollectionsTables() error {
+	_, err := a.userDB.Exec(`
+		CREATE TABLE IF NOT EXISTS collections (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS collection_glyphs (
+			collection_id INTEGER NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+			glyph_id INTEGER NOT NULL,
+			position INTEGER NOT NULL DEFAULT 0,
+			added_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (collection_id, glyph_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_collection_glyphs_position ON collection_glyphs(collection_id, position);
+	`)
+	return err
+}
+
+// collectionID resolves a collection name to its id
+func (a *App) collectionID(name string) (int, error) {
+	if a.userDB == nil {
+		return 0, newAppError(ErrCodeDBMissing, "database not open")
+	}
+
+	var id int
+	err := a.userDB.QueryRow("SELECT id FROM collections WHERE name = ?", name).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, newAppError(ErrCodeNotFound, "collection %q not found", name)
+	}
+	return id, err
+}
+
+// collectionGlyphIDs returns the glyph ids in a collection in their saved order
+func (a *App) collectionGlyphIDs(name string) ([]int, error) {
+	id, err := a.collectionID(name)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := a.userDB.Query("SELECT glyph_id FROM collection_glyphs WHERE collection_id = ? ORDER BY position, added_at", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var glyphID int
+		if err := rows.Scan(&glyphID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, glyphID)
+	}
+	return ids, rows.Err()
+}
+
+// ListCollections returns all collections with their glyph counts
+func (a *App) ListCollections() ([]Collection, error) {
+	if a.userDB == nil {
+		return nil, newAppError(ErrCodeDBMissing, "database not open")
+	}
+
+	rows, err := a.userDB.Query(`
+		SELECT c.id, c.name, c.created_at, COUNT(cg.glyph_id)
+		FROM collections c
+		LEFT JOIN collection_glyphs cg ON cg.collection_id = c.id
+		GROUP BY c.id
+		ORDER BY c.name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	defer rows.Close()
+
+	collections := []Collection{}
+	for rows.Next() {
+		var c Collection
+		if err := rows.Scan(&c.ID, &c.Name, &c.CreatedAt, &c.Count); err != nil {
+			return nil, err
+		}
+		collections = append(collections, c)
+	}
+	return collections, rows.Err()
+}
+
+// CreateCollection creates an empty collection
+func (a *App) CreateCollection(name string) error {
+	if err := a.checkWritable("manage collections"); err != nil {
+		return err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return newAppError(ErrCodeInvalid, "collection name is required")
+	}
+	if a.userDB == nil {
+		return newAppError(ErrCodeDBMissing, "database not open")
+	}
+
+	if _, err := a.userDB.Exec("INSERT INTO collections (name) VALUES (?)", name); err != nil {
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+	a.emit("collections:changed", name)
+	return nil
+}
+
+// DeleteCollection removes a collection and its memberships
+func (a *App) DeleteCollection(name string) error {
+	if err := a.checkWritable("manage collections"); err != nil {
+		return err
+	}
+
+	id, err := a.collectionID(name)
+	if err != nil {
+		return err
+	}
+
+	tx, err := a.userDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM collection_glyphs WHERE collection_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM collections WHERE id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	a.emit("collections:changed", name)
+	return nil
+}
+
+// AddToCollection appends a glyph to a collection
+func (a *App) AddToCollection(name string, glyphID int) error {
+	if err := a.checkWritable("manage collections"); err != nil {
+		return err
+	}
+
+	id, err := a.collectionID(name)
+	if err != nil {
+		return err
+	}
+	if _, ok := a.findGlyph(glyphID); !ok {
+		return newAppError(ErrCodeNotFound, "glyph %d not found", glyphID)
+	}
+
+	_, err = a.userDB.Exec(`
+		INSERT OR IGNORE INTO collection_glyphs (collection_id, glyph_id, position)
+		VALUES (?, ?, (SELECT COALESCE(MAX(position), -1) + 1 FROM collection_glyphs WHERE collection_id = ?))
+	`, id, glyphID, id)
+	if err != nil {
+		return fmt.Errorf("failed to add to collection: %w", err)
+	}
+
+	a.emit("collections:changed", name)
+	return nil
+}
+
+// RemoveFromCollection removes a glyph from a collection
+func (a *App) RemoveFromCollection(name string, glyphID int) error {
+	if err := a.checkWritable("manage collections"); err != nil {
+		return err
+	}
+
+	id, err := a.collectionID(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.userDB.Exec("DELETE FROM collection_glyphs WHERE collection_id = ? AND glyph_id = ?", id, glyphID); err != nil {
+		return fmt.Errorf("failed to remove from collection: %w", err)
+	}
+
+	a.emit("collections:changed", name)
+	return nil
+}
+
+// GetCollection returns the glyphs in a collection in their saved order
+func (a *App) GetCollection(name string) ([]GlyphMatch, error) {
+	ids, err := a.collectionGlyphIDs(name)
+	if err != nil {
+		return nil, err
+	}
+
+	favorites := a.favorites.Snapshot()
+	glyphs := make([]GlyphMatch, 0, len(ids))
+	for _, id := range ids {
+		if g, ok := a.findGlyph(id); ok {
+			glyphs = append(glyphs, GlyphMatch{Glyph: g, IsFavorite: favorites[id]})
+		}
+	}
+	a.annotateDeprecations(glyphs)
+	return glyphs, nil
+}
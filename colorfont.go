@@ -0,0 +1,328 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// colorLayer is one COLR layer: an outline glyph filled with a palette color
+type colorLayer struct {
+	glyph   sfnt.GlyphIndex
+	palette uint16
+}
+
+// foregroundPaletteIndex marks a COLR layer drawn in the text color
+const foregroundPaletteIndex = 0xFFFF
+
+// sbixStrike is the set of bitmaps an sbix table holds for one pixel size
+type sbixStrike struct {
+	ppem uint16
+	data []byte
+}
+
+// colorTables are the color glyph tables of a font. sfnt only reads
+// monochrome outlines, so these are parsed from the raw font data.
+type colorTables struct {
+	layers  map[sfnt.GlyphIndex][]colorLayer
+	palette []color.NRGBA
+	strikes []sbixStrike
+}
+
+// hasColor reports whether a glyph has a color version
+func (t *colorTables) hasColor(idx sfnt.GlyphIndex) bool {
+	if t == nil {
+		return false
+	}
+	if _, ok := t.layers[idx]; ok {
+		return true
+	}
+	for _, s := range t.strikes {
+		if _, _, ok := s.bitmap(idx); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// errTruncatedTable is returned for color tables shorter than their headers claim
+var errTruncatedTable = errors.New("truncated table")
+
+// fontTables returns the tables of the first font in data, which may be a
+// TrueType collection, keyed by tag
+func fontTables(data []byte) (map[string][]byte, error) {
+	offset := 0
+	if len(data) >= 16 && string(data[:4]) == "ttcf" {
+		offset = int(binary.BigEndian.Uint32(data[12:]))
+	}
+	if len(data) < offset+12 {
+		return nil, errTruncatedTable
+	}
+	numTables := int(binary.BigEndian.Uint16(data[offset+4:]))
+	tables := make(map[string][]byte, numTables)
+	for i := range numTables {
+		rec := offset + 12 + 16*i
+		if len(data) < rec+16 {
+			return nil, errTruncatedTable
+		}
+		start := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		if start+length > len(data) {
+			return nil, errTruncatedTable
+		}
+		tables[string(data[rec:rec+4])] = data[start : start+length]
+	}
+	return tables, nil
+}
+
+// parseColorTables reads the COLR/CPAL and sbix tables of a font. It returns
+// nil when the font has neither.
+func parseColorTables(data []byte, numGlyphs int) (*colorTables, error) {
+	tables, err := fontTables(data)
+	if err != nil {
+		return nil, err
+	}
+	t := &colorTables{}
+	if colr, cpal := tables["COLR"], tables["CPAL"]; colr != nil && cpal != nil {
+		if t.layers, err = parseCOLR(colr); err != nil {
+			return nil, fmt.Errorf("invalid COLR table: %w", err)
+		}
+		if t.palette, err = parseCPAL(cpal); err != nil {
+			return nil, fmt.Errorf("invalid CPAL table: %w", err)
+		}
+	}
+	if sbix := tables["sbix"]; sbix != nil {
+		if t.strikes, err = parseSbix(sbix, numGlyphs); err != nil {
+			return nil, fmt.Errorf("invalid sbix table: %w", err)
+		}
+	}
+	if len(t.layers) == 0 && len(t.strikes) == 0 {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// parseCOLR reads the version 0 base glyph and layer records. Glyphs that
+// only have version 1 paint graphs render monochrome.
+func parseCOLR(b []byte) (map[sfnt.GlyphIndex][]colorLayer, error) {
+	if len(b) < 14 {
+		return nil, errTruncatedTable
+	}
+	numBase := int(binary.BigEndian.Uint16(b[2:]))
+	baseOffset := int(binary.BigEndian.Uint32(b[4:]))
+	layerOffset := int(binary.BigEndian.Uint32(b[8:]))
+	numLayers := int(binary.BigEndian.Uint16(b[12:]))
+	if len(b) < baseOffset+6*numBase || len(b) < layerOffset+4*numLayers {
+		return nil, errTruncatedTable
+	}
+
+	layers := make(map[sfnt.GlyphIndex][]colorLayer, numBase)
+	for i := range numBase {
+		rec := b[baseOffset+6*i:]
+		glyph := sfnt.GlyphIndex(binary.BigEndian.Uint16(rec))
+		first := int(binary.BigEndian.Uint16(rec[2:]))
+		count := int(binary.BigEndian.Uint16(rec[4:]))
+		if first+count > numLayers {
+			return nil, errTruncatedTable
+		}
+		for j := first; j < first+count; j++ {
+			l := b[layerOffset+4*j:]
+			layers[glyph] = append(layers[glyph], colorLayer{
+				glyph:   sfnt.GlyphIndex(binary.BigEndian.Uint16(l)),
+				palette: binary.BigEndian.Uint16(l[2:]),
+			})
+		}
+	}
+	return layers, nil
+}
+
+// parseCPAL reads the first palette
+func parseCPAL(b []byte) ([]color.NRGBA, error) {
+	if len(b) < 14 {
+		return nil, errTruncatedTable
+	}
+	numEntries := int(binary.BigEndian.Uint16(b[2:]))
+	recordsOffset := int(binary.BigEndian.Uint32(b[8:]))
+	first := int(binary.BigEndian.Uint16(b[12:]))
+	start := recordsOffset + 4*first
+	if len(b) < start+4*numEntries {
+		return nil, errTruncatedTable
+	}
+
+	palette := make([]color.NRGBA, numEntries)
+	for i := range palette {
+		// Color records are stored as BGRA
+		c := b[start+4*i:]
+		palette[i] = color.NRGBA{R: c[2], G: c[1], B: c[0], A: c[3]}
+	}
+	return palette, nil
+}
+
+// parseSbix reads the strike directory; bitmaps are decoded when drawn
+func parseSbix(b []byte, numGlyphs int) ([]sbixStrike, error) {
+	if len(b) < 8 {
+		return nil, errTruncatedTable
+	}
+	numStrikes := int(binary.BigEndian.Uint32(b[4:]))
+	if len(b) < 8+4*numStrikes {
+		return nil, errTruncatedTable
+	}
+
+	strikes := make([]sbixStrike, 0, numStrikes)
+	for i := range numStrikes {
+		start := int(binary.BigEndian.Uint32(b[8+4*i:]))
+		if len(b) < start+4+4*(numGlyphs+1) {
+			return nil, errTruncatedTable
+		}
+		strikes = append(strikes, sbixStrike{ppem: binary.BigEndian.Uint16(b[start:]), data: b[start:]})
+	}
+	return strikes, nil
+}
+
+// bitmap returns the graphic type and data of a glyph in the strike,
+// following "dupe" references
+func (s sbixStrike) bitmap(idx sfnt.GlyphIndex) (string, []byte, bool) {
+	for range 2 {
+		at := 4 + 4*int(idx)
+		if len(s.data) < at+8 {
+			return "", nil, false
+		}
+		start := int(binary.BigEndian.Uint32(s.data[at:]))
+		end := int(binary.BigEndian.Uint32(s.data[at+4:]))
+		// Each record starts with a 4 byte origin offset and the graphic type
+		if end-start < 8 || end > len(s.data) {
+			return "", nil, false
+		}
+		kind, data := string(s.data[start+4:start+8]), s.data[start+8:end]
+		if kind != "dupe" {
+			return kind, data, true
+		}
+		if len(data) < 2 {
+			return "", nil, false
+		}
+		idx = sfnt.GlyphIndex(binary.BigEndian.Uint16(data))
+	}
+	return "", nil, false
+}
+
+// drawColorGlyph draws the color version of a glyph centered in img, scaled
+// to fit avail pixels. It reports false when the glyph has no usable color data.
+func drawColorGlyph(img *image.RGBA, f *opentype.Font, t *colorTables, idx sfnt.GlyphIndex, avail float64, fg color.Color) (bool, error) {
+	if layers, ok := t.layers[idx]; ok {
+		return true, drawColorLayers(img, f, t.palette, layers, avail, fg)
+	}
+	return drawSbixBitmap(img, t.strikes, idx, avail)
+}
+
+// drawColorLayers fills each COLR layer's outline with its palette color
+func drawColorLayers(img *image.RGBA, f *opentype.Font, palette []color.NRGBA, layers []colorLayer, avail float64, fg color.Color) error {
+	ppem := fixed.I(int(avail))
+	outlines := make([]sfnt.Segments, len(layers))
+	var bounds fixed.Rectangle26_6
+	for i, l := range layers {
+		segs, err := f.LoadGlyph(nil, l.glyph, ppem, nil)
+		if err != nil {
+			return fmt.Errorf("failed to load color layer: %w", err)
+		}
+		outlines[i] = segs
+		if i == 0 {
+			bounds = segs.Bounds()
+		} else {
+			bounds = bounds.Union(segs.Bounds())
+		}
+	}
+	w := float64(bounds.Max.X-bounds.Min.X) / 64
+	h := float64(bounds.Max.Y-bounds.Min.Y) / 64
+	if w <= 0 || h <= 0 {
+		return nil
+	}
+
+	size := img.Bounds().Dx()
+	scale := min(avail/w, avail/h)
+	offX := (float64(size) - w*scale) / 2
+	offY := (float64(size) - h*scale) / 2
+	point := func(p fixed.Point26_6) (float32, float32) {
+		return float32(float64(p.X-bounds.Min.X)/64*scale + offX), float32(float64(p.Y-bounds.Min.Y)/64*scale + offY)
+	}
+
+	for i, l := range layers {
+		var src color.Color = fg
+		if l.palette != foregroundPaletteIndex && int(l.palette) < len(palette) {
+			src = palette[l.palette]
+		}
+		r := vector.NewRasterizer(size, size)
+		for _, seg := range outlines[i] {
+			switch seg.Op {
+			case sfnt.SegmentOpMoveTo:
+				r.ClosePath()
+				r.MoveTo(point(seg.Args[0]))
+			case sfnt.SegmentOpLineTo:
+				r.LineTo(point(seg.Args[0]))
+			case sfnt.SegmentOpQuadTo:
+				x1, y1 := point(seg.Args[0])
+				x2, y2 := point(seg.Args[1])
+				r.QuadTo(x1, y1, x2, y2)
+			case sfnt.SegmentOpCubeTo:
+				x1, y1 := point(seg.Args[0])
+				x2, y2 := point(seg.Args[1])
+				x3, y3 := point(seg.Args[2])
+				r.CubeTo(x1, y1, x2, y2, x3, y3)
+			}
+		}
+		r.ClosePath()
+		r.Draw(img, img.Bounds(), image.NewUniform(src), image.Point{})
+	}
+	return nil
+}
+
+// drawSbixBitmap scales the PNG from the closest sbix strike into img
+func drawSbixBitmap(img *image.RGBA, strikes []sbixStrike, idx sfnt.GlyphIndex, avail float64) (bool, error) {
+	// Prefer the smallest strike at least as large as the output
+	var best *sbixStrike
+	for i := range strikes {
+		s := &strikes[i]
+		if kind, _, ok := s.bitmap(idx); !ok || kind != "png " {
+			continue
+		}
+		switch {
+		case best == nil:
+			best = s
+		case float64(best.ppem) < avail:
+			if s.ppem > best.ppem {
+				best = s
+			}
+		case float64(s.ppem) >= avail && s.ppem < best.ppem:
+			best = s
+		}
+	}
+	if best == nil {
+		return false, nil
+	}
+
+	_, data, _ := best.bitmap(idx)
+	bitmap, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return false, fmt.Errorf("failed to decode color bitmap: %w", err)
+	}
+	b := bitmap.Bounds()
+	if b.Dx() == 0 || b.Dy() == 0 {
+		return true, nil
+	}
+
+	size := img.Bounds().Dx()
+	scale := min(avail/float64(b.Dx()), avail/float64(b.Dy()))
+	w, h := int(float64(b.Dx())*scale), int(float64(b.Dy())*scale)
+	x, y := (size-w)/2, (size-h)/2
+	xdraw.CatmullRom.Scale(img, image.Rect(x, y, x+w, y+h), bitmap, b, xdraw.Over, nil)
+	return true, nil
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"sort"
+	"sync"
+)
+
+// Command describes an app action that can be invoked by id from the command palette
+type Command struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// commandEntry pairs a command with its handler
+type commandEntry struct {
+	Command
+	run func() error
+}
+
+// CommandRegistry holds every command features have registered
+type CommandRegistry struct {
+	mu       sync.RWMutex
+	commands map[string]*commandEntry
+}
+
+// NewCommandRegistry creates an empty registry
+func NewCommandRegistry() *CommandRegistry {
+	return &CommandRegistry{commands: make(map[string]*commandEntry)}
+}
+
+// Register adds a command, replacing any existing command with the same id
+func (r *CommandRegistry) Register(cmd Command, run func() error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commands[cmd.ID] = &commandEntry{Command: cmd, run: run}
+}
+
+// List returns all commands sorted by title
+func (r *CommandRegistry) List() []Command {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]Command, 0, len(r.commands))
+	for _, entry := range r.commands {
+		result = append(result, entry.Command)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Title < result[j].Title
+	})
+	return result
+}
+
+// Execute runs the command with the given id
+func (r *CommandRegistry) Execute(id string) error {
+	r.mu.RLock()
+	entry, ok := r.commands[id]
+	r.mu.RUnlock()
+
+	if !ok {
+		return newAppError(ErrCodeInvalid, "unknown command: %s", id)
+	}
+	return entry.run()
+}
+
+// registerCommands registers the built-in app commands
+func (a *App) registerCommands() {
+	a.commands.Register(Command{
+		ID:       "theme.toggle",
+		Title:    "Toggle theme",
+		Keywords: []string{"dark", "light", "appearance"},
+	}, func() error {
+		_, err := a.ToggleTheme()
+		return err
+	})
+
+	a.commands.Register(Command{
+		ID:       "history.clear",
+		Title:    "Clear search history",
+		Keywords: []string{"history", "recent", "forget"},
+	}, a.ClearSearchHistory)
+
+	a.commands.Register(Command{
+		ID:       "history.toggleIncognito",
+		Title:    "Toggle incognito search",
+		Keywords: []string{"history", "private", "privacy", "pause"},
+	}, func() error {
+		return a.SetIncognito(!a.historySettings().Incognito)
+	})
+
+	a.commands.Register(Command{
+		ID:       "favorites.export",
+		Title:    "Export favorites",
+		Keywords: []string{"favorites", "backup", "save", "json"},
+	}, func() error {
+		_, err := a.ExportFavorites("")
+		return err
+	})
+
+	a.commands.Register(Command{
+		ID:       "data.openFolder",
+		Title:    "Open data folder",
+		Keywords: []string{"database", "files", "explorer", "finder"},
+	}, a.OpenDataFolder)
+
+	a.commands.Register(Command{
+		ID:       "data.openLog",
+		Title:    "Open log file",
+		Keywords: []string{"logs", "debug", "errors"},
+	}, a.OpenLogFile)
+
+	a.commands.Register(Command{
+		ID:       "data.compact",
+		Title:    "Compact database",
+		Keywords: []string{"vacuum", "shrink", "size", "disk", "space"},
+	}, func() error {
+		_, err := a.CompactDatabase()
+		return err
+	})
+
+	a.commands.Register(Command{
+		ID:       "profile.lock",
+		Title:    "Lock encrypted profile",
+		Keywords: []string{"encrypt", "passphrase", "vault"},
+	}, a.LockProfile)
+
+	a.commands.Register(Command{
+		ID:       "data.maintenance",
+		Title:    "Run database maintenance",
+		Keywords: []string{"optimize", "analyze", "cleanup", "prune"},
+	}, func() error {
+		_, err := a.RunMaintenanceNow("")
+		return err
+	})
+
+	a.commands.Register(Command{
+		ID:       "plugins.openFolder",
+		Title:    "Open plugins folder",
+		Keywords: []string{"plugins", "extensions", "exporters", "sources"},
+	}, a.OpenPluginsFolder)
+
+	a.commands.Register(Command{
+		ID:       "plugins.reload",
+		Title:    "Reload plugins",
+		Keywords: []string{"plugins", "extensions", "refresh"},
+	}, func() error {
+		_, err := a.ReloadPlugins()
+		return err
+	})
+
+	a.commands.Register(Command{
+		ID:       "cache.rebuild",
+		Title:    "Rebuild glyph cache",
+		Keywords: []string{"reload", "refresh", "cache"},
+	}, func() error {
+		return a.RebuildCache()
+	})
+
+	a.commands.Register(Command{
+		ID:       "view.zoomIn",
+		Title:    "Zoom in",
+		Keywords: []string{"larger", "scale", "bigger"},
+	}, func() error {
+		return a.adjustZoom(1)
+	})
+
+	a.commands.Register(Command{
+		ID:       "view.zoomOut",
+		Title:    "Zoom out",
+		Keywords: []string{"smaller", "scale"},
+	}, func() error {
+		return a.adjustZoom(-1)
+	})
+
+	a.commands.Register(Command{
+		ID:       "view.zoomReset",
+		Title:    "Reset zoom",
+		Keywords: []string{"scale", "100%", "default"},
+	}, func() error {
+		_, err := a.SetZoomLevel(defaultZoom)
+		return err
+	})
+
+	a.commands.Register(Command{
+		ID:       "grid.toggleNames",
+		Title:    "Toggle glyph names",
+		Keywords: []string{"labels", "grid", "layout"},
+	}, func() error {
+		return a.updateGridPrefs(func(p *GridPrefs) { p.ShowNames = !p.ShowNames })
+	})
+
+	a.commands.Register(Command{
+		ID:       "grid.toggleCodepoints",
+		Title:    "Toggle codepoints",
+		Keywords: []string{"unicode", "hex", "grid", "layout"},
+	}, func() error {
+		return a.updateGridPrefs(func(p *GridPrefs) { p.ShowCodepoints = !p.ShowCodepoints })
+	})
+
+	a.commands.Register(Command{
+		ID:       "grid.cycleTileSize",
+		Title:    "Change tile size",
+		Keywords: []string{"small", "large", "grid", "layout"},
+	}, func() error {
+		return a.updateGridPrefs(func(p *GridPrefs) { p.TileSize = nextOf(p.TileSize, gridTileSizes) })
+	})
+
+	a.commands.Register(Command{
+		ID:       "grid.cycleDensity",
+		Title:    "Change grid density",
+		Keywords: []string{"compact", "spacing", "grid", "layout"},
+	}, func() error {
+		return a.updateGridPrefs(func(p *GridPrefs) { p.Density = nextOf(p.Density, gridDensities) })
+	})
+
+	a.commands.Register(Command{
+		ID:       "window.toggleClickThrough",
+		Title:    "Toggle click-through palette",
+		Keywords: []string{"overlay", "transparent", "reference", "pin"},
+	}, func() error {
+		return a.SetClickThrough(!a.GetClickThrough())
+	})
+
+	a.commands.Register(Command{
+		ID:       "scratchpad.copy",
+		Title:    "Copy scratchpad",
+		Keywords: []string{"clipboard", "picked", "string"},
+	}, func() error {
+		_, err := a.CopyScratchpad("")
+		return err
+	})
+
+	a.commands.Register(Command{
+		ID:       "scratchpad.clear",
+		Title:    "Clear scratchpad",
+		Keywords: []string{"picked", "reset"},
+	}, func() error {
+		a.ClearScratchpad()
+		return nil
+	})
+}
+
+// ListCommands returns all commands available to the command palette
+func (a *App) ListCommands() []Command {
+	return a.commands.List()
+}
+
+// ExecuteCommand runs a command by id
+func (a *App) ExecuteCommand(id string) error {
+	if err := a.commands.Execute(id); err != nil {
+		return err
+	}
+	a.emit("command:executed", id)
+	return nil
+}
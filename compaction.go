@@ -0,0 +1,212 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultCompactFreePercent is how much of a database may be free pages before
+// the scheduled compaction rebuilds it
+const defaultCompactFreePercent = 20
+
+// DatabaseSize reports how much disk a database uses and how much of it is free pages
+type DatabaseSize struct {
+	Name        string  `json:"name"`
+	Path        string  `json:"path"`
+	SizeBytes   int64   `json:"sizeBytes"`
+	WALBytes    int64   `json:"walBytes"`
+	FreeBytes   int64   `json:"freeBytes"`
+	FreePercent float64 `json:"freePercent"`
+}
+
+// DatabaseCompaction is the outcome of compacting one database
+type DatabaseCompaction struct {
+	Before     DatabaseSize `json:"before"`
+	After      DatabaseSize `json:"after"`
+	Reclaimed  int64        `json:"reclaimedBytes"`
+	DurationMs float64      `json:"durationMs"`
+}
+
+// compactTarget is a database CompactDatabase rebuilds
+type compactTarget struct {
+	name string
+	path string
+	db   *sql.DB
+}
+
+// compactTargets returns gylte.db unless it is shared and, when it is separate, the profile database
+func (a *App) compactTargets() []compactTarget {
+	var targets []compactTarget
+	// A shared glyph database is read-only
+	if a.db != nil && !a.isShared() {
+		targets = append(targets, compactTarget{"gylte.db", a.GetCurrentDatabase(), a.db})
+	}
+	if a.userDB != nil && a.userDB != a.db {
+		path := a.homeDBPath()
+		if a.profile != defaultProfile {
+			profilePath, err := a.profileDBPath(a.profile)
+			if err != nil {
+				return targets
+			}
+			path = profilePath
+		}
+		targets = append(targets, compactTarget{"profile " + a.profile, path, a.userDB})
+	}
+	return targets
+}
+
+// measureDatabase reads a database's file sizes and free page count
+func measureDatabase(t compactTarget) (DatabaseSize, error) {
+	size := DatabaseSize{Name: t.name, Path: t.path}
+	if info, err := os.Stat(t.path); err == nil {
+		size.SizeBytes = info.Size()
+	}
+	if info, err := os.Stat(t.path + "-wal"); err == nil {
+		size.WALBytes = info.Size()
+	}
+
+	var pageSize, pageCount, freePages int64
+	for _, q := range []struct {
+		pragma string
+		dest   *int64
+	}{
+		{"page_size", &pageSize},
+		{"page_count", &pageCount},
+		{"freelist_count", &freePages},
+	} {
+		if err := t.db.QueryRow("PRAGMA " + q.pragma).Scan(q.dest); err != nil {
+			return size, fmt.Errorf("failed to read pragma %s: %w", q.pragma, err)
+		}
+	}
+	size.FreeBytes = freePages * pageSize
+	if pageCount > 0 {
+		size.FreePercent = float64(freePages) / float64(pageCount) * 100
+	}
+	return size, nil
+}
+
+// optimizeFTS merges the segments of every FTS5 index in a database
+func optimizeFTS(db *sql.DB) error {
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND sql LIKE 'CREATE VIRTUAL TABLE%USING fts5%'")
+	if err != nil {
+		return err
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err == nil {
+			tables = append(tables, name)
+		}
+	}
+	rows.Close()
+
+	for _, table := range tables {
+		quoted := `"` + strings.ReplaceAll(table, `"`, `""`) + `"`
+		if _, err := db.Exec(fmt.Sprintf("INSERT INTO %s(%s) VALUES ('optimize')", quoted, quoted)); err != nil {
+			return fmt.Errorf("failed to optimize %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// compact optimizes full-text indexes, rebuilds the file, and truncates the WAL
+func compact(t compactTarget) (DatabaseCompaction, error) {
+	start := time.Now()
+	result := DatabaseCompaction{}
+
+	before, err := measureDatabase(t)
+	if err != nil {
+		return result, err
+	}
+	result.Before = before
+
+	if err := optimizeFTS(t.db); err != nil {
+		return result, err
+	}
+	if _, err := t.db.Exec("VACUUM"); err != nil {
+		return result, fmt.Errorf("failed to vacuum %s: %w", t.name, err)
+	}
+	if _, err := t.db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		log.Printf("Failed to checkpoint %s: %v", t.name, err)
+	}
+
+	after, err := measureDatabase(t)
+	if err != nil {
+		return result, err
+	}
+	result.After = after
+	result.Reclaimed = before.SizeBytes + before.WALBytes - after.SizeBytes - after.WALBytes
+	result.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+	log.Printf("Compacted %s: %d -> %d bytes", t.name, before.SizeBytes+before.WALBytes, after.SizeBytes+after.WALBytes)
+	return result, nil
+}
+
+// GetDatabaseSizes reports the disk use and free space of gylte.db and the profile database
+func (a *App) GetDatabaseSizes() ([]DatabaseSize, error) {
+	if a.db == nil {
+		return nil, newAppError(ErrCodeDBMissing, "database not open")
+	}
+	var sizes []DatabaseSize
+	for _, t := range a.compactTargets() {
+		size, err := measureDatabase(t)
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+// CompactDatabase optimizes full-text indexes and vacuums gylte.db and the
+// profile database, reporting their sizes before and after
+func (a *App) CompactDatabase() ([]DatabaseCompaction, error) {
+	if err := a.checkWritable("compact the database"); err != nil {
+		return nil, err
+	}
+	if a.db == nil {
+		return nil, newAppError(ErrCodeDBMissing, "database not open")
+	}
+
+	op := a.startOperation("compact", "Compacting the database…")
+	var results []DatabaseCompaction
+	for _, t := range a.compactTargets() {
+		result, err := compact(t)
+		if err != nil {
+			return nil, op.Fail("Could not compact the database", err)
+		}
+		results = append(results, result)
+	}
+
+	var reclaimed int64
+	for _, r := range results {
+		reclaimed += r.Reclaimed
+	}
+	op.Succeed(fmt.Sprintf("Database compacted (%d KB reclaimed)", (max(reclaimed, 0)+1023)/1024))
+	return results, nil
+}
+
+// autoCompact compacts the databases whose free pages exceed the
+// "maintenance.compact.freePercent" setting
+func (a *App) autoCompact() error {
+	if a.isReadOnly() {
+		return nil
+	}
+	threshold := float64(a.settings.GetInt("maintenance.compact.freePercent", defaultCompactFreePercent))
+	for _, t := range a.compactTargets() {
+		size, err := measureDatabase(t)
+		if err != nil {
+			return err
+		}
+		if size.FreePercent < threshold {
+			continue
+		}
+		if _, err := compact(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
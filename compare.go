@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// feedbackTie records that neither ranking was better
+const feedbackTie = "tie"
+
+// Ranking is one side of a CompareSearch
+type Ranking struct {
+	Profile string       `json:"profile"`
+	Glyphs  []GlyphMatch `json:"glyphs"`
+	Total   int          `json:"total"`
+}
+
+// SearchComparison holds the rankings two scoring profiles give the same query
+type SearchComparison struct {
+	Query string  `json:"query"`
+	A     Ranking `json:"a"`
+	B     Ranking `json:"b"`
+
+	// Overlap counts glyphs present in both top lists
+	Overlap int `json:"overlap"`
+}
+
+// ScoringFeedback totals the local comparison verdicts for one profile
+type ScoringFeedback struct {
+	Profile string `json:"profile"`
+	Wins    int    `json:"wins"`
+	Losses  int    `json:"losses"`
+	Ties    int    `json:"ties"`
+}
+
+// initSearchFeedbackTable creates the table recording which ranking users preferred
+func (a *App) initSearchFeedbackTable() error {
+	_, err := a.userDB.Exec(`
+		CREATE TABLE IF NOT EXISTS search_feedback (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			query TEXT NOT NULL,
+			profile_a TEXT NOT NULL,
+			profile_b TEXT NOT NULL,
+			preferred TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	return err
+}
+
+// rankWith returns the top page of a query's matches under one scoring profile
+func (a *App) rankWith(query, profile string) (Ranking, error) {
+	if _, err := a.scoringProfile(profile); err != nil {
+		return Ranking{}, err
+	}
+	matches, _, err := a.matchGlyphs(query, "", ScopeAll, profile, false)
+	if err != nil {
+		return Ranking{}, err
+	}
+	return Ranking{
+		Profile: profile,
+		Glyphs:  matches[:min(a.pageSize(), len(matches))],
+		Total:   len(matches),
+	}, nil
+}
+
+// CompareSearch runs a query under two scoring profiles and returns both rankings
+// side by side, without recording the searches in history
+func (a *App) CompareSearch(query, profileA, profileB string) (*SearchComparison, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, newAppError(ErrCodeInvalid, "query is required")
+	}
+	if profileA == profileB {
+		return nil, newAppError(ErrCodeInvalid, "choose two different scoring profiles")
+	}
+
+	rankingA, err := a.rankWith(query, profileA)
+	if err != nil {
+		return nil, err
+	}
+	rankingB, err := a.rankWith(query, profileB)
+	if err != nil {
+		return nil, err
+	}
+
+	inA := make(map[int]bool, len(rankingA.Glyphs))
+	for _, m := range rankingA.Glyphs {
+		inA[m.ID] = true
+	}
+	overlap := 0
+	for _, m := range rankingB.Glyphs {
+		if inA[m.ID] {
+			overlap++
+		}
+	}
+
+	return &SearchComparison{Query: query, A: rankingA, B: rankingB, Overlap: overlap}, nil
+}
+
+// RecordSearchFeedback stores locally which of two rankings was better for a query;
+// preferred is profileA, profileB, or "tie"
+func (a *App) RecordSearchFeedback(query, profileA, profileB, preferred string) error {
+	if err := a.checkWritable("record feedback"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+	if err := validateQuery("query", query); err != nil {
+		return err
+	}
+	if preferred != profileA && preferred != profileB && preferred != feedbackTie {
+		return newAppError(ErrCodeInvalid, "preferred must be %s, %s, or %s", profileA, profileB, feedbackTie)
+	}
+
+	_, err := a.userDB.Exec(`
+		INSERT INTO search_feedback (query, profile_a, profile_b, preferred) VALUES (?, ?, ?, ?)
+	`, strings.TrimSpace(query), profileA, profileB, preferred)
+	if err != nil {
+		return fmt.Errorf("failed to record feedback: %w", err)
+	}
+	return nil
+}
+
+// GetScoringFeedback totals the recorded verdicts per scoring profile, most wins first
+func (a *App) GetScoringFeedback() ([]ScoringFeedback, error) {
+	result := []ScoringFeedback{}
+	if a.userDB == nil {
+		return result, nil
+	}
+
+	rows, err := a.userDB.Query("SELECT profile_a, profile_b, preferred FROM search_feedback")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feedback: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make(map[string]*ScoringFeedback)
+	tally := func(profile string) *ScoringFeedback {
+		if totals[profile] == nil {
+			totals[profile] = &ScoringFeedback{Profile: profile}
+		}
+		return totals[profile]
+	}
+	for rows.Next() {
+		var profileA, profileB, preferred string
+		if err := rows.Scan(&profileA, &profileB, &preferred); err != nil {
+			continue
+		}
+		switch preferred {
+		case feedbackTie:
+			tally(profileA).Ties++
+			tally(profileB).Ties++
+		case profileA:
+			tally(profileA).Wins++
+			tally(profileB).Losses++
+		case profileB:
+			tally(profileB).Wins++
+			tally(profileA).Losses++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, t := range totals {
+		result = append(result, *t)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Wins != result[j].Wins {
+			return result[i].Wins > result[j].Wins
+		}
+		return result[i].Profile < result[j].Profile
+	})
+	return result, nil
+}
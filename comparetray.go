@@ -0,0 +1,99 @@
+package main
+
+import (
+	"slices"
+	"sync"
+)
+
+// maxCompareGlyphs caps how many glyphs fit side by side in the compare tray
+const maxCompareGlyphs = 6
+
+// compareSizes are the pixel sizes the tray shows each glyph at
+var compareSizes = []int{12, 16, 24, 32, 48}
+
+// EventCompareChanged is emitted with the compare tray after every change
+const EventCompareChanged = "compare:changed"
+
+// CompareTray holds candidate glyphs pinned for a side-by-side comparison.
+// It lasts for the session only.
+type CompareTray struct {
+	mu  sync.Mutex
+	ids []int
+}
+
+// CompareView is the compare tray as shown to the frontend
+type CompareView struct {
+	Glyphs []GlyphMatch `json:"glyphs"`
+	Sizes  []int        `json:"sizes"`
+	Max    int          `json:"max"`
+}
+
+// compareView resolves the pinned ids, skipping glyphs no longer in the
+// database; callers hold compareTray.mu
+func (a *App) compareView() *CompareView {
+	view := &CompareView{Glyphs: []GlyphMatch{}, Sizes: compareSizes, Max: maxCompareGlyphs}
+	favorites := a.favorites.Snapshot()
+	for _, id := range a.compareTray.ids {
+		if g, ok := a.findGlyph(id); ok {
+			view.Glyphs = append(view.Glyphs, GlyphMatch{Glyph: g, IsFavorite: favorites[id]})
+		}
+	}
+	a.annotateDeprecations(view.Glyphs)
+	return view
+}
+
+// compareChanged notifies the frontend; callers hold compareTray.mu
+func (a *App) compareChanged() *CompareView {
+	view := a.compareView()
+	a.emit(EventCompareChanged, view)
+	return view
+}
+
+// GetCompare returns the pinned glyphs in the order they were added
+func (a *App) GetCompare() *CompareView {
+	a.compareTray.mu.Lock()
+	defer a.compareTray.mu.Unlock()
+
+	return a.compareView()
+}
+
+// AddToCompare pins a glyph to the compare tray; pinning it again does nothing
+func (a *App) AddToCompare(glyphID int) (*CompareView, error) {
+	if _, ok := a.findGlyph(glyphID); !ok {
+		return nil, newAppError(ErrCodeNotFound, "glyph %d not found", glyphID)
+	}
+
+	a.compareTray.mu.Lock()
+	defer a.compareTray.mu.Unlock()
+
+	if slices.Contains(a.compareTray.ids, glyphID) {
+		return a.compareView(), nil
+	}
+	if len(a.compareTray.ids) >= maxCompareGlyphs {
+		return nil, newAppError(ErrCodeInvalid, "compare tray is full (%d glyphs)", maxCompareGlyphs)
+	}
+	a.compareTray.ids = append(a.compareTray.ids, glyphID)
+	return a.compareChanged(), nil
+}
+
+// RemoveFromCompare unpins a glyph
+func (a *App) RemoveFromCompare(glyphID int) (*CompareView, error) {
+	a.compareTray.mu.Lock()
+	defer a.compareTray.mu.Unlock()
+
+	i := slices.Index(a.compareTray.ids, glyphID)
+	if i < 0 {
+		return nil, newAppError(ErrCodeNotFound, "glyph %d is not in the compare tray", glyphID)
+	}
+	a.compareTray.ids = slices.Delete(a.compareTray.ids, i, i+1)
+	return a.compareChanged(), nil
+}
+
+// ClearCompare empties the compare tray
+func (a *App) ClearCompare() {
+	a.compareTray.mu.Lock()
+	defer a.compareTray.mu.Unlock()
+
+	a.compareTray.ids = nil
+	a.compareChanged()
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// completionShells are the shells `gylte completion` can generate scripts for
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// sortedKeys returns the keys of m in order, so generated scripts are stable
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// flagChoices returns the fixed values a flag accepts, for completion
+func flagChoices(f *flag.Flag) []string {
+	if f.Name == "format" {
+		return strings.Split(glyphFormatNames(), ", ")
+	}
+	return nil
+}
+
+// cliFlag is a flag as seen by the completion and man page generators
+type cliFlag struct {
+	name, usage string
+	takesValue  bool
+	choices     []string
+}
+
+// flagsOf lists the flags of a flag set in name order
+func flagsOf(fs *flag.FlagSet) []cliFlag {
+	var flags []cliFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		flags = append(flags, cliFlag{
+			name:       f.Name,
+			usage:      f.Usage,
+			takesValue: !isBoolFlag(f),
+			choices:    flagChoices(f),
+		})
+	})
+	return flags
+}
+
+// defineCompletion prints a completion script for the named shell
+func defineCompletion(fs *flag.FlagSet) cliRunner {
+	return func(a *App, args []string, out, errOut io.Writer) error {
+		if len(args) != 1 {
+			return fmt.Errorf("usage: %s (one of %s)", commandUsage("completion"), strings.Join(completionShells, ", "))
+		}
+		switch args[0] {
+		case "bash":
+			writeBashCompletion(out)
+		case "zsh":
+			writeZshCompletion(out)
+		case "fish":
+			writeFishCompletion(out)
+		case "powershell":
+			writePowerShellCompletion(out)
+		default:
+			return fmt.Errorf("unsupported shell %q (want one of %s)", args[0], strings.Join(completionShells, ", "))
+		}
+		return nil
+	}
+}
+
+// completionWords lists what may follow a subcommand: its flags and fixed arguments
+func completionWords(name string) []string {
+	var words []string
+	for _, f := range flagsOf(commandFlags(name)) {
+		words = append(words, "--"+f.name)
+	}
+	return append(words, cliCommands[name].choices...)
+}
+
+// globalWords lists the subcommands and top-level flags
+func globalWords() []string {
+	words := commandNames()
+	for _, f := range flagsOf(flag.CommandLine) {
+		words = append(words, "--"+f.name)
+	}
+	return words
+}
+
+// valueFlags maps each flag with fixed values to those values, across all commands
+func valueFlags() map[string][]string {
+	result := make(map[string][]string)
+	add := func(fs *flag.FlagSet) {
+		for _, f := range flagsOf(fs) {
+			if len(f.choices) > 0 {
+				result["--"+f.name] = f.choices
+			}
+		}
+	}
+	add(flag.CommandLine)
+	for _, name := range commandNames() {
+		add(commandFlags(name))
+	}
+	return result
+}
+
+func writeBashCompletion(w io.Writer) {
+	fmt.Fprintln(w, "# bash completion for gylte; load with: source <(gylte completion bash)")
+	fmt.Fprintln(w, "_gylte() {")
+	fmt.Fprintln(w, `    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}" cmd="" opts="" word`)
+	fmt.Fprintln(w, `    for word in "${COMP_WORDS[@]:1:COMP_CWORD-1}"; do`)
+	fmt.Fprintf(w, "        case \"$word\" in %s) cmd=\"$word\"; break ;; esac\n", strings.Join(commandNames(), "|"))
+	fmt.Fprintln(w, "    done")
+	fmt.Fprintln(w, `    case "$prev" in`)
+	values := valueFlags()
+	for _, name := range sortedKeys(values) {
+		fmt.Fprintf(w, "        %s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", name, strings.Join(values[name], " "))
+	}
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w, `    case "$cmd" in`)
+	for _, name := range commandNames() {
+		fmt.Fprintf(w, "        %s) opts=%q ;;\n", name, strings.Join(completionWords(name), " "))
+	}
+	fmt.Fprintf(w, "        *) opts=%q ;;\n", strings.Join(globalWords(), " "))
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w, `    COMPREPLY=($(compgen -W "$opts" -- "$cur"))`)
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "complete -F _gylte gylte")
+}
+
+// zshSpec formats a flag as an _arguments spec
+func zshSpec(f cliFlag) string {
+	desc := strings.NewReplacer("[", `\[`, "]", `\]`, ":", `\:`, "'", `'\''`).Replace(f.usage)
+	spec := "--" + f.name + "[" + desc + "]"
+	if f.takesValue {
+		spec += ":" + f.name + ":"
+		if len(f.choices) > 0 {
+			spec += "(" + strings.Join(f.choices, " ") + ")"
+		}
+	}
+	return "'" + spec + "'"
+}
+
+func writeZshCompletion(w io.Writer) {
+	fmt.Fprintln(w, "#compdef gylte")
+	fmt.Fprintln(w, "# zsh completion for gylte; save as _gylte in your $fpath")
+	fmt.Fprintln(w, "_gylte() {")
+	fmt.Fprintln(w, "    local state")
+	fmt.Fprintln(w, "    local -a commands")
+	fmt.Fprintln(w, "    commands=(")
+	for _, name := range commandNames() {
+		fmt.Fprintf(w, "        '%s:%s'\n", name, strings.ReplaceAll(cliCommands[name].summary, "'", `'\''`))
+	}
+	fmt.Fprintln(w, "    )")
+	fmt.Fprintln(w, "    _arguments -C \\")
+	for _, f := range flagsOf(flag.CommandLine) {
+		fmt.Fprintf(w, "        %s \\\n", zshSpec(f))
+	}
+	fmt.Fprintln(w, "        '1: :->command' \\")
+	fmt.Fprintln(w, "        '*:: :->args'")
+	fmt.Fprintln(w, "    case $state in")
+	fmt.Fprintln(w, "        command) _describe 'command' commands ;;")
+	fmt.Fprintln(w, "        args)")
+	fmt.Fprintln(w, "            case $words[1] in")
+	for _, name := range commandNames() {
+		specs := []string{}
+		for _, f := range flagsOf(commandFlags(name)) {
+			specs = append(specs, zshSpec(f))
+		}
+		if choices := cliCommands[name].choices; len(choices) > 0 {
+			specs = append(specs, "'1:"+strings.Trim(cliCommands[name].args, "<>")+":("+strings.Join(choices, " ")+")'")
+		}
+		if len(specs) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "                %s) _arguments %s ;;\n", name, strings.Join(specs, " "))
+	}
+	fmt.Fprintln(w, "            esac ;;")
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, `_gylte "$@"`)
+}
+
+// fishQuote quotes a string for fish
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+func writeFishCompletion(w io.Writer) {
+	fmt.Fprintln(w, "# fish completion for gylte; save as ~/.config/fish/completions/gylte.fish")
+	fmt.Fprintln(w, "complete -c gylte -f")
+	writeFlags := func(condition string, flags []cliFlag) {
+		for _, f := range flags {
+			line := fmt.Sprintf("complete -c gylte -n %s -l %s", fishQuote(condition), f.name)
+			if len(f.choices) > 0 {
+				line += " -x -a " + fishQuote(strings.Join(f.choices, " "))
+			} else if f.takesValue {
+				line += " -r"
+			}
+			fmt.Fprintln(w, line+" -d "+fishQuote(f.usage))
+		}
+	}
+	writeFlags("__fish_use_subcommand", flagsOf(flag.CommandLine))
+	for _, name := range commandNames() {
+		fmt.Fprintf(w, "complete -c gylte -n __fish_use_subcommand -a %s -d %s\n", name, fishQuote(cliCommands[name].summary))
+	}
+	for _, name := range commandNames() {
+		condition := "__fish_seen_subcommand_from " + name
+		writeFlags(condition, flagsOf(commandFlags(name)))
+		if choices := cliCommands[name].choices; len(choices) > 0 {
+			fmt.Fprintf(w, "complete -c gylte -n %s -a %s\n", fishQuote(condition), fishQuote(strings.Join(choices, " ")))
+		}
+	}
+}
+
+// psList formats words as a PowerShell array literal
+func psList(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = "'" + strings.ReplaceAll(word, "'", "''") + "'"
+	}
+	return "@(" + strings.Join(quoted, ", ") + ")"
+}
+
+func writePowerShellCompletion(w io.Writer) {
+	fmt.Fprintln(w, "# PowerShell completion for gylte; add to $PROFILE: gylte completion powershell | Out-String | Invoke-Expression")
+	fmt.Fprintln(w, "Register-ArgumentCompleter -Native -CommandName gylte -ScriptBlock {")
+	fmt.Fprintln(w, "    param($wordToComplete, $commandAst, $cursorPosition)")
+	fmt.Fprintln(w, "    $commands = @{")
+	for _, name := range commandNames() {
+		fmt.Fprintf(w, "        '%s' = %s\n", name, psList(completionWords(name)))
+	}
+	fmt.Fprintln(w, "    }")
+	fmt.Fprintln(w, "    $values = @{")
+	values := valueFlags()
+	for _, name := range sortedKeys(values) {
+		fmt.Fprintf(w, "        '%s' = %s\n", name, psList(values[name]))
+	}
+	fmt.Fprintln(w, "    }")
+	fmt.Fprintln(w, "    $words = @($commandAst.CommandElements | ForEach-Object { $_.ToString() })")
+	fmt.Fprintln(w, "    $prev = if ($wordToComplete) { $words[-2] } else { $words[-1] }")
+	fmt.Fprintln(w, "    $command = $words | Select-Object -Skip 1 | Where-Object { $commands.ContainsKey($_) } | Select-Object -First 1")
+	fmt.Fprintf(w, "    $candidates = if ($values.ContainsKey($prev)) { $values[$prev] } elseif ($command) { $commands[$command] } else { %s }\n", psList(globalWords()))
+	fmt.Fprintln(w, "    $candidates | Where-Object { $_ -like \"$wordToComplete*\" } | ForEach-Object {")
+	fmt.Fprintln(w, "        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)")
+	fmt.Fprintln(w, "    }")
+	fmt.Fprintln(w, "}")
+}
+
+// roffEscape escapes text for a man page
+func roffEscape(s string) string {
+	s = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+// writeManFlags writes flags as a tagged paragraph list
+func writeManFlags(w io.Writer, flags []cliFlag) {
+	for _, f := range flags {
+		fmt.Fprintln(w, ".TP")
+		if f.takesValue {
+			fmt.Fprintf(w, ".BI \\-\\-%s \" %s\"\n", f.name, strings.ToUpper(f.name))
+		} else {
+			fmt.Fprintf(w, ".B \\-\\-%s\n", f.name)
+		}
+		fmt.Fprintln(w, roffEscape(f.usage))
+	}
+}
+
+// defineMan prints the gylte(1) man page in roff format
+func defineMan(fs *flag.FlagSet) cliRunner {
+	return func(a *App, args []string, out, errOut io.Writer) error {
+		fmt.Fprintf(out, ".TH GYLTE 1 %q \"gylte %s\" \"User Commands\"\n", time.Now().Format("2006-01-02"), version)
+		fmt.Fprintln(out, ".SH NAME")
+		fmt.Fprintln(out, `gylte \- Nerd Font glyph picker`)
+		fmt.Fprintln(out, ".SH SYNOPSIS")
+		fmt.Fprintln(out, ".B gylte")
+		fmt.Fprintln(out, `[\fIflags\fR] [\fIcommand\fR] [\fIargs\fR]`)
+		fmt.Fprintln(out, ".SH DESCRIPTION")
+		fmt.Fprintln(out, "Without a command, gylte opens the glyph picker window.")
+		fmt.Fprintln(out, "The commands below and the \\-\\-rpc and \\-\\-filter modes run without a window")
+		fmt.Fprintln(out, "and share the database, favorites, and copy history of the GUI.")
+		fmt.Fprintln(out, ".SH OPTIONS")
+		writeManFlags(out, flagsOf(flag.CommandLine))
+		fmt.Fprintln(out, ".SH COMMANDS")
+		for _, name := range commandNames() {
+			fmt.Fprintln(out, ".SS "+roffEscape(commandUsage(name)))
+			fmt.Fprintln(out, roffEscape(cliCommands[name].summary)+".")
+			if choices := cliCommands[name].choices; len(choices) > 0 {
+				fmt.Fprintln(out, ".PP")
+				fmt.Fprintln(out, roffEscape(cliCommands[name].args+" is one of: "+strings.Join(choices, ", ")+"."))
+			}
+			writeManFlags(out, flagsOf(commandFlags(name)))
+		}
+		fmt.Fprintln(out, ".SH FILES")
+		fmt.Fprintln(out, ".TP")
+		fmt.Fprintln(out, ".I gylte.db")
+		fmt.Fprintln(out, "Glyph database, favorites, settings, and copy history.")
+		fmt.Fprintln(out, ".TP")
+		fmt.Fprintln(out, ".I gylte.log")
+		fmt.Fprintln(out, "Application log.")
+		fmt.Fprintln(out, ".SH EXAMPLES")
+		fmt.Fprintln(out, ".EX")
+		fmt.Fprintln(out, `echo nf\-fa\-rocket | gylte \-\-filter \-\-format char`)
+		fmt.Fprintln(out, `gylte resolve "$(gylte list \-\-fzf | fzf)"`)
+		fmt.Fprintln(out, ".EE")
+		return nil
+	}
+}
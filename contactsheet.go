@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Contact sheet layout in pixels
+const (
+	contactSheetCell     = 112
+	contactSheetGlyph    = 64
+	contactSheetMargin   = 16
+	contactSheetCaption  = 11.0
+	defaultSheetColumns  = 8
+	maxSheetColumns      = 32
+	maxContactSheetItems = 1000
+)
+
+var (
+	contactSheetBackground = color.NRGBA{R: 0xfa, G: 0xfa, B: 0xfa, A: 0xff}
+	contactSheetForeground = color.NRGBA{R: 0x22, G: 0x22, B: 0x22, A: 0xff}
+	contactSheetCaptionFg  = color.NRGBA{R: 0x66, G: 0x66, B: 0x66, A: 0xff}
+	contactSheetGridLine   = color.NRGBA{R: 0xe4, G: 0xe4, B: 0xe4, A: 0xff}
+)
+
+// captionFont draws glyph names; Nerd Fonts such as Symbols Nerd Font have
+// no Latin letters, so captions use the Go text font instead
+var captionFont = sync.OnceValues(func() (*opentype.Font, error) {
+	return opentype.Parse(goregular.TTF)
+})
+
+// truncateCaption shortens s with an ellipsis until it fits width in face
+func truncateCaption(face font.Face, s string, width fixed.Int26_6) string {
+	if font.MeasureString(face, s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 1 && font.MeasureString(face, string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// renderContactSheet lays glyphs out in a grid of cells, each with the glyph
+// above its name. Glyphs the font can't draw leave their cell empty.
+func (a *App) renderContactSheet(glyphs []Glyph, columns int) (*image.RGBA, error) {
+	// Fail early when no Nerd Font is configured rather than per glyph
+	if _, err := a.renderFont(); err != nil {
+		return nil, err
+	}
+	text, err := captionFont()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load caption font: %w", err)
+	}
+	caption, err := opentype.NewFace(text, &opentype.FaceOptions{Size: contactSheetCaption, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create font face: %w", err)
+	}
+	defer caption.Close()
+
+	columns = min(columns, len(glyphs))
+	rows := (len(glyphs) + columns - 1) / columns
+	width := 2*contactSheetMargin + columns*contactSheetCell
+	height := 2*contactSheetMargin + rows*contactSheetCell
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(contactSheetBackground), image.Point{}, draw.Src)
+
+	// Hairlines between cells
+	line := image.NewUniform(contactSheetGridLine)
+	for c := 1; c < columns; c++ {
+		x := contactSheetMargin + c*contactSheetCell
+		draw.Draw(img, image.Rect(x, contactSheetMargin, x+1, height-contactSheetMargin), line, image.Point{}, draw.Src)
+	}
+	for r := 1; r < rows; r++ {
+		y := contactSheetMargin + r*contactSheetCell
+		draw.Draw(img, image.Rect(contactSheetMargin, y, width-contactSheetMargin, y+1), line, image.Point{}, draw.Src)
+	}
+
+	metrics := caption.Metrics()
+	captionWidth := fixed.I(contactSheetCell - 8)
+	for i, g := range glyphs {
+		x := contactSheetMargin + (i%columns)*contactSheetCell
+		y := contactSheetMargin + (i/columns)*contactSheetCell
+
+		tile, err := a.renderGlyph(g, RenderOptions{Size: contactSheetGlyph, Foreground: contactSheetForeground, Padding: 0.05})
+		if err != nil {
+			if errorCode(err) != ErrCodeNotFound {
+				return nil, err
+			}
+			log.Printf("Contact sheet: %v", err)
+		} else {
+			at := image.Pt(x+(contactSheetCell-contactSheetGlyph)/2, y+12)
+			draw.Draw(img, image.Rectangle{Min: at, Max: at.Add(tile.Bounds().Size())}, tile, image.Point{}, draw.Over)
+		}
+
+		name := truncateCaption(caption, g.Name, captionWidth)
+		d := font.Drawer{Dst: img, Src: image.NewUniform(contactSheetCaptionFg), Face: caption}
+		d.Dot = fixed.Point26_6{
+			X: fixed.I(x) + (fixed.I(contactSheetCell)-d.MeasureString(name))/2,
+			Y: fixed.I(y+contactSheetCell-12) - metrics.Descent,
+		}
+		d.DrawString(name)
+	}
+	return img, nil
+}
+
+// RenderContactSheet draws glyphs in a grid with their names as a single PNG
+// for sharing icon proposals, and returns its path. Columns defaults to 8; an
+// empty path exports to the default exports directory.
+func (a *App) RenderContactSheet(ids []int, columns int, path string) (string, error) {
+	if len(ids) == 0 {
+		return "", invalidArgument("ids", "no glyphs given")
+	}
+	if len(ids) > maxContactSheetItems {
+		return "", invalidArgument("ids", "a contact sheet holds at most %d glyphs", maxContactSheetItems)
+	}
+	if columns == 0 {
+		columns = defaultSheetColumns
+	}
+	if columns < 1 || columns > maxSheetColumns {
+		return "", invalidArgument("columns", "columns must be between 1 and %d", maxSheetColumns)
+	}
+	glyphs := make([]Glyph, 0, len(ids))
+	for _, id := range ids {
+		g, ok := a.findGlyph(id)
+		if !ok {
+			return "", newAppError(ErrCodeNotFound, "glyph %d not found", id)
+		}
+		glyphs = append(glyphs, g)
+	}
+
+	op := a.startOperation("export", "Rendering contact sheet…")
+
+	img, err := a.renderContactSheet(glyphs, columns)
+	if err != nil {
+		return "", op.Fail("Could not render the contact sheet", err)
+	}
+
+	if path == "" {
+		path = a.defaultExportPath("contact-sheet", ".png")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", op.Fail("Could not render the contact sheet", fmt.Errorf("failed to create directory: %w", err))
+	}
+	err = writeExportFile(path, func(w io.Writer) error {
+		return png.Encode(w, img)
+	})
+	if err != nil {
+		return "", op.Fail("Could not render the contact sheet", fmt.Errorf("failed to write PNG: %w", err))
+	}
+
+	log.Printf("Rendered a contact sheet of %d glyphs to %s", len(glyphs), path)
+	op.Succeed(fmt.Sprintf("Rendered %d glyphs", len(glyphs)))
+	return path, nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// EventDatabaseReloaded is emitted after a different glyph database has been opened
+const EventDatabaseReloaded = "database:reloaded"
+
+// validateGlyphDatabase checks that path is a SQLite database with a glyphs table
+func validateGlyphDatabase(db *sql.DB) error {
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM glyphs").Scan(&count); err != nil {
+		return fmt.Errorf("not a glyph database: %w", err)
+	}
+	return nil
+}
+
+// openGlyphDatabase opens and validates a glyph database, migrating older schemas.
+// Unlike sql.Open it fails when the file doesn't exist instead of creating an empty one.
+func openGlyphDatabase(path string) (*sql.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("database not found: %w", err)
+	}
+
+	db, err := openSQLite(path, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := validateGlyphDatabase(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	// Older databases lack the description, block, codepoint and width columns
+	if err := ensureColumn(db, "glyphs", "description", "TEXT"); err != nil {
+		log.Printf("Failed to migrate glyphs table: %v", err)
+	}
+	if err := ensureColumn(db, "glyphs", "block", "TEXT"); err != nil {
+		log.Printf("Failed to migrate glyphs table: %v", err)
+	} else if err := backfillBlocks(db); err != nil {
+		log.Printf("Failed to compute Unicode blocks: %v", err)
+	}
+	if err := ensureColumn(db, "glyphs", "codepoint", "INTEGER"); err != nil {
+		log.Printf("Failed to migrate glyphs table: %v", err)
+	} else if err := backfillCodepoints(db); err != nil {
+		log.Printf("Failed to index code points: %v", err)
+	}
+	// Widths need the font, so they're classified once the profile is open
+	if err := ensureColumn(db, "glyphs", "width", "TEXT"); err != nil {
+		log.Printf("Failed to migrate glyphs table: %v", err)
+	}
+	return db, nil
+}
+
+// openReadPool opens a read-only pool for searches and cache loads, falling back
+// to the writer when the read-only connection can't be established
+func openReadPool(path string, writer *sql.DB) *sql.DB {
+	reader, err := openSQLite(path, true)
+	if err == nil {
+		err = reader.Ping()
+	}
+	if err != nil {
+		log.Printf("Failed to open read-only pool, sharing the writer: %v", err)
+		if reader != nil {
+			reader.Close()
+		}
+		return writer
+	}
+	return reader
+}
+
+// OpenDatabase replaces the glyph database at runtime and rebuilds the cache.
+// The default profile's user data lives in the glyph database, so it follows the switch;
+// other profiles keep their own user data.
+func (a *App) OpenDatabase(path string) error {
+	if a.isShared() {
+		return newAppError(ErrCodeInvalid, "cannot open another database while using the shared database %s", a.sharedDBPath)
+	}
+	op := a.startOperation("database", "Opening glyph database…")
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return op.Fail("Could not open the database", err)
+	}
+	db, err := openGlyphDatabase(abs)
+	if err != nil {
+		return op.Fail("Could not open the database", err)
+	}
+
+	old, oldReader := a.db, a.readDB
+	a.fallbackReason = ""
+	if a.userDB == old {
+		// openProfile must not close the old database while it is still being replaced
+		a.userDB = nil
+	}
+	a.db = db
+	a.readDB = openReadPool(abs, db)
+	a.dbPath = abs
+
+	if a.profile == "" {
+		a.profile = a.activeProfile()
+	}
+	if err := a.openProfile(a.profile); err != nil {
+		log.Printf("Failed to reload profile: %v", err)
+	}
+	if old != nil {
+		old.Close()
+	}
+	if oldReader != nil && oldReader != old {
+		oldReader.Close()
+	}
+
+	a.preloadCache()
+
+	count := a.cache.Len()
+
+	log.Printf("Opened database %s (%d glyphs)", abs, count)
+	a.emit(EventDatabaseReloaded, abs)
+	op.Succeed(fmt.Sprintf("Opened %s: %d glyphs", filepath.Base(abs), count))
+	return nil
+}
+
+// GetCurrentDatabase returns the path of the open glyph database
+func (a *App) GetCurrentDatabase() string {
+	abs, err := filepath.Abs(a.dbPath)
+	if err != nil {
+		return a.dbPath
+	}
+	return abs
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+)
+
+// columnExists reports whether a table has the given column
+func columnExists(db *sql.DB, table, column string) (bool, error) {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name, typ  string
+			notNull    int
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &defaultVal, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
+// tableExists reports whether the database has a table with the given name
+func tableExists(db *sql.DB, table string) (bool, error) {
+	var n int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&n)
+	return n > 0, err
+}
+
+// ensureColumn adds a column to an existing table when databases generated by
+// older versions of db_generator lack it
+func ensureColumn(db *sql.DB, table, column, decl string) error {
+	exists, err := columnExists(db, table, column)
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", table, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, decl)); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
+// dataDir returns the directory holding the default database and other user data.
+// It stays fixed when another glyph database is opened at runtime.
+func (a *App) dataDir() string {
+	dir, err := filepath.Abs(a.dataPath)
+	if err != nil {
+		return a.dataPath
+	}
+	return dir
+}
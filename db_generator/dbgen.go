@@ -3,12 +3,21 @@ package main
 import (
 	"database/sql"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"golang.org/x/text/unicode/runenames"
 	_ "modernc.org/sqlite"
+
+	"Gylte/internal/glyphdata"
 )
 
 type Glyph struct {
@@ -16,8 +25,38 @@ type Glyph struct {
 	Glyph string `json:"glyph"`
 }
 
+// DeprecatedGlyph maps a removed code point (hex, e.g. "F501") to its replacement name
+type DeprecatedGlyph struct {
+	Codepoint   string `json:"codepoint"`
+	Name        string `json:"name"`
+	Replacement string `json:"replacement"`
+	Note        string `json:"note"`
+}
+
+// releaseTag is the Nerd Fonts release the glyphs.json was taken from (e.g. "v3.2.1")
+var releaseTag = flag.String("release", "unknown", "Nerd Fonts release tag the glyph data comes from")
+
+// fixtureSize switches to writing a small synthetic database instead of the real one
+var fixtureSize = flag.Int("fixture", 0, "write a deterministic database of N synthetic glyphs to "+defaultFixturePath+" instead")
+
+// outPath overrides where the database is written
+var outPath = flag.String("out", "", "database file to write (default ../gylte.db, or "+defaultFixturePath+" with --fixture)")
+
 func main() {
-	if err := run(); err != nil {
+	flag.Parse()
+
+	if flag.Arg(0) == "diff" {
+		if err := runDiff(flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	generate := run
+	if *fixtureSize > 0 {
+		generate = runFixture
+	}
+	if err := generate(); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -33,7 +72,11 @@ func run() error {
 	log.Printf("Loaded %d glyphs from JSON", len(glyphs))
 
 	// Initialize database
-	db, err := initDB("../gylte.db")
+	path := "../gylte.db"
+	if *outPath != "" {
+		path = *outPath
+	}
+	db, err := initDB(path)
 	if err != nil {
 		return fmt.Errorf("initializing database: %w", err)
 	}
@@ -44,6 +87,24 @@ func run() error {
 		return fmt.Errorf("populating database: %w", err)
 	}
 
+	if err := populateSmartFilters(db); err != nil {
+		return fmt.Errorf("populating smart filters: %w", err)
+	}
+
+	if err := populateIconSets(db); err != nil {
+		return fmt.Errorf("populating icon sets: %w", err)
+	}
+
+	// Deprecation list is optional
+	deprecated, err := loadDeprecated("deprecated.json")
+	if err != nil {
+		return fmt.Errorf("loading deprecated glyphs: %w", err)
+	}
+	if err := populateDeprecated(db, deprecated); err != nil {
+		return fmt.Errorf("populating deprecated glyphs: %w", err)
+	}
+	log.Printf("Loaded %d deprecated glyphs", len(deprecated))
+
 	// Generate statistics
 	stats, err := generateStats(db)
 	if err != nil {
@@ -53,7 +114,8 @@ func run() error {
 	log.Println("\n=== Database Generation Complete ===")
 	log.Printf("Total glyphs: %d", stats["total"])
 	log.Printf("Unique categories: %d", stats["categories"])
-	log.Printf("Database file: ../gylte.db")
+	log.Printf("Nerd Fonts release: %s", *releaseTag)
+	log.Printf("Database file: %s", path)
 
 	// Print top categories
 	log.Println("\nTop 10 categories:")
@@ -79,6 +141,71 @@ func loadGlyphs(filename string) ([]Glyph, error) {
 	return glyphs, nil
 }
 
+func loadDeprecated(filename string) ([]DeprecatedGlyph, error) {
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	var deprecated []DeprecatedGlyph
+	if err := json.Unmarshal(data, &deprecated); err != nil {
+		return nil, fmt.Errorf("parsing JSON: %w", err)
+	}
+
+	return deprecated, nil
+}
+
+func populateDeprecated(db *sql.DB, deprecated []DeprecatedGlyph) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, d := range deprecated {
+		codepoint, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(d.Codepoint), "U+"), 16, 32)
+		if err != nil {
+			return fmt.Errorf("invalid codepoint %q for %s: %w", d.Codepoint, d.Name, err)
+		}
+
+		_, err = tx.Exec(`
+			INSERT OR REPLACE INTO deprecated_glyphs(codepoint, name, replacement, note)
+			VALUES(?, ?, ?, ?)
+		`, codepoint, d.Name, d.Replacement, d.Note)
+		if err != nil {
+			return fmt.Errorf("inserting deprecated glyph %s: %w", d.Name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func populateSmartFilters(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i, f := range glyphdata.SmartFilters {
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern for %s: %w", f.Name, err)
+		}
+		_, err = tx.Exec(`
+			INSERT OR REPLACE INTO smart_filters(name, title, query, pattern, tags, position)
+			VALUES(?, ?, ?, ?, ?, ?)
+		`, f.Name, f.Title, f.Query, f.Pattern, strings.Join(f.Tags, ","), i)
+		if err != nil {
+			return fmt.Errorf("inserting smart filter %s: %w", f.Name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
 func initDB(filename string) (*sql.DB, error) {
 	// Remove old database if exists
 	os.Remove(filename)
@@ -102,6 +229,9 @@ func initDB(filename string) (*sql.DB, error) {
 		category TEXT,
 		prefix TEXT,
 		normalized_name TEXT,
+		description TEXT,
+		block TEXT,
+		codepoint INTEGER,
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 	);
 
@@ -110,6 +240,7 @@ func initDB(filename string) (*sql.DB, error) {
 	CREATE INDEX IF NOT EXISTS idx_category ON glyphs(category);
 	CREATE INDEX IF NOT EXISTS idx_prefix ON glyphs(prefix);
 	CREATE INDEX IF NOT EXISTS idx_normalized ON glyphs(normalized_name);
+	CREATE INDEX IF NOT EXISTS idx_codepoint ON glyphs(codepoint);
 
 	-- Full-text search support
 	CREATE VIRTUAL TABLE IF NOT EXISTS glyphs_fts USING fts5(
@@ -134,6 +265,34 @@ func initDB(filename string) (*sql.DB, error) {
 		WHERE rowid = new.id;
 	END;
 
+	-- Glyphs removed or moved between Nerd Fonts releases, for migration audits
+	CREATE TABLE IF NOT EXISTS deprecated_glyphs (
+		codepoint INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		replacement TEXT,
+		note TEXT
+	);
+
+	-- Curated themes that cut across icon sets, e.g. arrows or brands
+	CREATE TABLE IF NOT EXISTS smart_filters (
+		name TEXT PRIMARY KEY,
+		title TEXT NOT NULL,
+		query TEXT,
+		pattern TEXT,
+		tags TEXT,
+		position INTEGER NOT NULL DEFAULT 0
+	);
+
+	-- License and attribution requirements of each icon set
+	CREATE TABLE IF NOT EXISTS icon_sets (
+		category TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		license TEXT NOT NULL,
+		url TEXT,
+		attribution TEXT,
+		requires_attribution INTEGER NOT NULL DEFAULT 0
+	);
+
 	-- Metadata table for app info
 	CREATE TABLE IF NOT EXISTS metadata (
 		key TEXT PRIMARY KEY,
@@ -172,6 +331,65 @@ func extractMetadata(name string) (category, prefix, normalized string) {
 	return
 }
 
+func populateIconSets(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Insert in a fixed order so the same input always gives the same file
+	categories := make([]string, 0, len(glyphdata.Licenses))
+	for category := range glyphdata.Licenses {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	for _, category := range categories {
+		l := glyphdata.Licenses[category]
+		name := glyphdata.IconSets[category]
+		if name == "" {
+			name = category
+		}
+		_, err = tx.Exec(`
+			INSERT OR REPLACE INTO icon_sets(category, name, license, url, attribution, requires_attribution)
+			VALUES(?, ?, ?, ?, ?, ?)
+		`, category, name, l.License, l.URL, l.Attribution, l.RequiresAttribution)
+		if err != nil {
+			return fmt.Errorf("inserting icon set %s: %w", category, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// describeGlyph builds an accessible description for screen readers.
+// Characters outside the private use area use their Unicode name; icon font
+// glyphs are described by their icon set and humanized name.
+func describeGlyph(name, glyph, category string) string {
+	words := strings.NewReplacer("_", " ", "-", " ").Replace(strings.TrimPrefix(name, "nf-"+category+"-"))
+
+	set := glyphdata.IconSets[category]
+	if set == "" {
+		set = category
+	}
+
+	runes := []rune(glyph)
+	if len(runes) == 1 && !unicode.In(runes[0], unicode.Co) {
+		if uname := runenames.Name(runes[0]); uname != "" {
+			return fmt.Sprintf("%s (%s icon: %s)", strings.ToLower(uname), set, words)
+		}
+	}
+
+	return fmt.Sprintf("%s icon: %s", set, words)
+}
+
+// firstRune returns the first code point of a glyph
+func firstRune(glyph string) rune {
+	r, _ := utf8.DecodeRuneInString(glyph)
+	return r
+}
+
 func populateDB(db *sql.DB, glyphs []Glyph) error {
 	tx, err := db.Begin()
 	if err != nil {
@@ -180,8 +398,8 @@ func populateDB(db *sql.DB, glyphs []Glyph) error {
 	defer tx.Rollback()
 
 	stmt, err := tx.Prepare(`
-		INSERT INTO glyphs(name, glyph, category, prefix, normalized_name) 
-		VALUES(?, ?, ?, ?, ?)
+		INSERT INTO glyphs(name, glyph, category, prefix, normalized_name, description, block, codepoint) 
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
@@ -198,6 +416,9 @@ func populateDB(db *sql.DB, glyphs []Glyph) error {
 			category,
 			prefix,
 			normalized,
+			describeGlyph(glyph.Name, glyph.Glyph, category),
+			glyphdata.BlockOf(firstRune(glyph.Glyph)),
+			firstRune(glyph.Glyph),
 		)
 
 		if err != nil {
@@ -235,6 +456,22 @@ func populateDB(db *sql.DB, glyphs []Glyph) error {
 		return err
 	}
 
+	_, err = tx.Exec(`
+		INSERT OR REPLACE INTO metadata(key, value) 
+		VALUES('release_tag', ?)
+	`, *releaseTag)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT OR REPLACE INTO metadata(key, value) 
+		VALUES('glyph_count', (SELECT COUNT(*) FROM glyphs))
+	`)
+	if err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
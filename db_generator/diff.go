@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"Gylte/internal/glyphdata"
+)
+
+// The diff subcommand compares two glyph databases or glyphs.json files:
+//
+//	go run . diff [--format json|markdown] old.db new.json
+
+// runDiff prints the diff of two datasets to stdout
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := fs.String("format", "markdown", "report format: json or markdown")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		return fmt.Errorf("usage: diff [--format json|markdown] <old.db|glyphs.json> <new.db|glyphs.json>")
+	}
+
+	pathA, pathB := fs.Arg(0), fs.Arg(1)
+	setA, err := glyphdata.LoadGlyphSet(pathA)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", pathA, err)
+	}
+	setB, err := glyphdata.LoadGlyphSet(pathB)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", pathB, err)
+	}
+	diff := glyphdata.DiffGlyphSets(setA, setB)
+	diff.PathA, diff.PathB = pathA, pathB
+
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diff)
+	case "markdown":
+		return glyphdata.WriteDiffMarkdown(os.Stdout, &diff)
+	}
+	return fmt.Errorf("unknown format %q", *format)
+}
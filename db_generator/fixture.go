@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"log"
+)
+
+// defaultFixturePath is where --fixture writes unless --out is given
+const defaultFixturePath = "../testdata/fixture.db"
+
+// fixtureCategories are the icon sets synthetic glyphs are spread across, with
+// the first code point each one counts up from
+var fixtureCategories = []struct {
+	Name string
+	Base rune
+}{
+	{"cod", 0xea60},
+	{"dev", 0xe700},
+	{"fa", 0xf000},
+	{"linux", 0xf300},
+	{"md", 0xf0001},
+	{"oct", 0xf400},
+	{"seti", 0xe5fa},
+	{"weather", 0xe300},
+}
+
+// fixtureWords name the synthetic glyphs; each category gets every word once
+// before any word repeats with a numeric suffix
+var fixtureWords = []string{
+	"account", "arrow_up", "arrow_down", "bell", "check", "close", "folder", "file",
+	"git_branch", "heart", "home", "lock", "rocket", "search", "star", "terminal",
+}
+
+// fixtureGlyphs builds n glyphs round-robin across the fixture categories. The
+// same n always yields the same names, characters and ids.
+func fixtureGlyphs(n int) []Glyph {
+	glyphs := make([]Glyph, n)
+	for i := range glyphs {
+		cat := fixtureCategories[i%len(fixtureCategories)]
+		slot := i / len(fixtureCategories)
+
+		name := fmt.Sprintf("nf-%s-%s", cat.Name, fixtureWords[slot%len(fixtureWords)])
+		if round := slot / len(fixtureWords); round > 0 {
+			name += fmt.Sprintf("_%d", round+1)
+		}
+		glyphs[i] = Glyph{Name: name, Glyph: string(cat.Base + rune(slot))}
+	}
+	return glyphs
+}
+
+// runFixture writes a small synthetic database for tests and demos, so they
+// don't depend on the full Nerd Fonts dataset
+func runFixture() error {
+	path := defaultFixturePath
+	if *outPath != "" {
+		path = *outPath
+	}
+	if *releaseTag == "unknown" {
+		*releaseTag = "fixture"
+	}
+
+	db, err := initDB(path)
+	if err != nil {
+		return fmt.Errorf("initializing database: %w", err)
+	}
+	defer db.Close()
+
+	glyphs := fixtureGlyphs(*fixtureSize)
+	if err := populateDB(db, glyphs); err != nil {
+		return fmt.Errorf("populating database: %w", err)
+	}
+	if err := populateSmartFilters(db); err != nil {
+		return fmt.Errorf("populating smart filters: %w", err)
+	}
+	if err := populateIconSets(db); err != nil {
+		return fmt.Errorf("populating icon sets: %w", err)
+	}
+
+	// Pin the timestamps so the contents don't differ between runs
+	if _, err := db.Exec(`
+		UPDATE metadata SET updated_at = '2000-01-01 00:00:00';
+		UPDATE metadata SET value = '2000-01-01 00:00:00' WHERE key = 'last_updated';
+		UPDATE glyphs SET created_at = '2000-01-01 00:00:00';
+	`); err != nil {
+		return fmt.Errorf("pinning timestamps: %w", err)
+	}
+
+	// Leave a single self-contained file for testdata
+	if _, err := db.Exec("PRAGMA journal_mode = DELETE"); err != nil {
+		return fmt.Errorf("leaving WAL mode: %w", err)
+	}
+	// Rewrite the file so the rows replaced above leave no old timestamps behind
+	if _, err := db.Exec("VACUUM"); err != nil {
+		return fmt.Errorf("compacting database: %w", err)
+	}
+
+	log.Printf("Wrote %d synthetic glyphs across %d categories to %s", len(glyphs), min(len(glyphs), len(fixtureCategories)), path)
+	return nil
+}
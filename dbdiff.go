@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+
+	"Gylte/internal/glyphdata"
+)
+
+// loadGlyphSet reads name -> code point from a glyph database or a glyphs.json file
+func loadGlyphSet(path string) (map[string]rune, error) {
+	set, err := glyphdata.LoadGlyphSet(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, newAppError(ErrCodeNotFound, "%s not found", path)
+	case errors.Is(err, glyphdata.ErrNotGlyphDatabase):
+		return nil, newAppError(ErrCodeInvalid, "%v", err)
+	}
+	return set, err
+}
+
+// DiffDatabases compares two glyph databases or glyphs.json files and reports
+// the glyphs B added, removed, renamed, or moved to another code point, e.g.
+// to audit an upstream release before importing it
+func (a *App) DiffDatabases(pathA, pathB string) (*glyphdata.DatabaseDiff, error) {
+	if pathA == "" || pathB == "" {
+		return nil, invalidArgument("path", "two paths are required")
+	}
+	setA, err := loadGlyphSet(pathA)
+	if err != nil {
+		return nil, err
+	}
+	setB, err := loadGlyphSet(pathB)
+	if err != nil {
+		return nil, err
+	}
+	diff := glyphdata.DiffGlyphSets(setA, setB)
+	diff.PathA, diff.PathB = pathA, pathB
+	return &diff, nil
+}
+
+// ExportDatabaseDiff writes DiffDatabases' report as "json" or "markdown" and
+// returns its path. An empty path exports to the default exports directory.
+func (a *App) ExportDatabaseDiff(pathA, pathB, format, path string) (string, error) {
+	if err := validateChoice("format", format, ExportFormatJSON, ExportFormatMarkdown); err != nil {
+		return "", err
+	}
+	op := a.startOperation("export", "Comparing glyph databases…")
+
+	diff, err := a.DiffDatabases(pathA, pathB)
+	if err != nil {
+		return "", op.Fail("Could not compare the databases", err)
+	}
+
+	ext := ".json"
+	if format == ExportFormatMarkdown {
+		ext = ".md"
+	}
+	if path == "" {
+		path = a.defaultExportPath("glyph-diff", ext)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", op.Fail("Could not export the diff", fmt.Errorf("failed to create directory: %w", err))
+	}
+
+	if format == ExportFormatJSON {
+		err = writeJSONFile(path, diff)
+	} else {
+		err = writeExportFile(path, func(w io.Writer) error {
+			return glyphdata.WriteDiffMarkdown(w, diff)
+		})
+	}
+	if err != nil {
+		return "", op.Fail("Could not export the diff", fmt.Errorf("failed to write diff: %w", err))
+	}
+
+	log.Printf("Exported glyph diff of %s and %s to %s", pathA, pathB, path)
+	op.Succeed(fmt.Sprintf("%d added, %d removed, %d renamed", len(diff.Added), len(diff.Removed), len(diff.Renamed)))
+	return path, nil
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiffDatabasesAgainstJSON(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	// The harness database was seeded from the same file, so nothing differs
+	diff, err := h.app.DiffDatabases(h.app.dbPath, filepath.Join("testdata", "fixture.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(diff.Added) + len(diff.Removed) + len(diff.Renamed) + len(diff.CodepointChanged); n != 0 {
+		t.Errorf("diff of a database and its source reports %d changes: %+v", n, diff)
+	}
+
+	if _, err := h.app.DiffDatabases(h.app.dbPath, filepath.Join(h.dir, "missing.db")); errorCode(err) != ErrCodeNotFound {
+		t.Errorf("missing database: got %v, want not found", err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// DatabaseInfo describes the glyph dataset currently loaded
+type DatabaseInfo struct {
+	Path        string            `json:"path"`
+	Version     string            `json:"version"`
+	LastUpdated string            `json:"lastUpdated"`
+	ReleaseTag  string            `json:"releaseTag"`
+	GlyphCount  int               `json:"glyphCount"`
+	SizeBytes   int64             `json:"sizeBytes"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+// readMetadata returns every key/value pair in the metadata table
+func (a *App) readMetadata() (map[string]string, error) {
+	rows, err := a.readDB.Query("SELECT key, COALESCE(value, '') FROM metadata")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	metadata := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		metadata[key] = value
+	}
+	return metadata, rows.Err()
+}
+
+// GetDatabaseInfo returns the dataset version, source release, and last update time
+func (a *App) GetDatabaseInfo() (*DatabaseInfo, error) {
+	if a.db == nil {
+		return nil, newAppError(ErrCodeDBMissing, "database not open")
+	}
+
+	metadata, err := a.readMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	info := &DatabaseInfo{
+		Path:        a.dbPath,
+		Version:     metadata["version"],
+		LastUpdated: metadata["last_updated"],
+		ReleaseTag:  metadata["release_tag"],
+		Metadata:    metadata,
+	}
+
+	if err := a.readDB.QueryRow("SELECT COUNT(*) FROM glyphs").Scan(&info.GlyphCount); err != nil {
+		return nil, fmt.Errorf("failed to count glyphs: %w", err)
+	}
+
+	if stat, err := os.Stat(a.dbPath); err == nil {
+		info.SizeBytes = stat.Size()
+	}
+
+	return info, nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+	"time"
+)
+
+// debugAddr is where --debug serves the pprof endpoints; a busy port falls
+// back to any free one
+const debugAddr = "127.0.0.1:6060"
+
+// Startup phases recorded in StartupTimings
+const (
+	StartupPhaseDatabase  = "database"
+	StartupPhaseProfile   = "profile"
+	StartupPhaseFavorites = "favorites"
+	StartupPhaseCache     = "cache"
+	StartupPhaseWindow    = "window"
+)
+
+// StartupPhase is one step of the launch, relative to process start
+type StartupPhase struct {
+	Name       string  `json:"name"`
+	StartMs    float64 `json:"startMs"`
+	DurationMs float64 `json:"durationMs"`
+}
+
+// StartupTimings lists how long each launch phase took, for diagnosing slow
+// starts (e.g. a data directory on a network home)
+type StartupTimings struct {
+	Phases []StartupPhase `json:"phases"`
+	// ReadyMs is when the window finished loading, 0 until it has
+	ReadyMs float64 `json:"readyMs"`
+	Debug   bool    `json:"debug"`
+	// PprofURL is set while --debug serves the pprof endpoints
+	PprofURL string `json:"pprofUrl,omitempty"`
+}
+
+// StartupTimer records the first run of each startup phase; later runs (a
+// cache rebuild, a profile switch) aren't part of the launch and are ignored
+type StartupTimer struct {
+	mu     sync.Mutex
+	phases []StartupPhase
+	seen   map[string]bool
+	ready  float64
+	server *http.Server
+	addr   string
+}
+
+// recordStartup notes that phase ran from start until now
+func (a *App) recordStartup(phase string, start time.Time) {
+	t := a.startupTimer
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.seen[phase] {
+		return
+	}
+	if t.seen == nil {
+		t.seen = make(map[string]bool)
+	}
+	t.seen[phase] = true
+	p := StartupPhase{
+		Name:       phase,
+		StartMs:    float64(start.Sub(a.startedAt).Microseconds()) / 1000,
+		DurationMs: millisSince(start),
+	}
+	t.phases = append(t.phases, p)
+	if phase == StartupPhaseWindow {
+		t.ready = p.StartMs + p.DurationMs
+	}
+	if a.debug {
+		log.Printf("Startup: %s took %.1fms (at %.1fms)", phase, p.DurationMs, p.StartMs)
+	}
+}
+
+// debugHandler serves the net/http/pprof endpoints
+func debugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// startDebugServer serves pprof on the loopback interface for --debug
+func (a *App) startDebugServer() {
+	listener, err := net.Listen("tcp", debugAddr)
+	if err != nil {
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	if err != nil {
+		log.Printf("Failed to start debug server: %v", err)
+		return
+	}
+
+	// Profiles take up to 30s by default, so only the header read is bounded
+	server := &http.Server{
+		Handler:           debugHandler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	t := a.startupTimer
+	t.mu.Lock()
+	t.server = server
+	t.addr = listener.Addr().String()
+	t.mu.Unlock()
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Debug server stopped: %v", err)
+		}
+	}()
+	log.Printf("pprof listening on http://%s/debug/pprof/", listener.Addr())
+}
+
+// stopDebugServer closes the pprof server, if running
+func (a *App) stopDebugServer() {
+	t := a.startupTimer
+	t.mu.Lock()
+	server := t.server
+	t.server, t.addr = nil, ""
+	t.mu.Unlock()
+
+	if server != nil {
+		server.Close()
+	}
+}
+
+// GetStartupTimings reports how long each launch phase took: opening the
+// database, loading the profile and favorites, loading the cache, and the
+// window becoming ready
+func (a *App) GetStartupTimings() StartupTimings {
+	t := a.startupTimer
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timings := StartupTimings{
+		Phases:  append([]StartupPhase{}, t.phases...),
+		ReadyMs: t.ready,
+		Debug:   a.debug,
+	}
+	if t.addr != "" {
+		timings.PprofURL = "http://" + t.addr + "/debug/pprof/"
+	}
+	return timings
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// deprecationsAsset is the release asset listing deprecated glyphs and their replacements
+const deprecationsAsset = "deprecations.json"
+
+// GlyphDeprecation marks a glyph that a newer icon set release removed or renamed
+type GlyphDeprecation struct {
+	// Replacement names the glyph to use instead; ReplacementID is set when it is loaded
+	Replacement   string `json:"replacement,omitempty"`
+	ReplacementID int    `json:"replacementId,omitempty"`
+	Note          string `json:"note"`
+	RemovedIn     string `json:"removedIn,omitempty"`
+}
+
+// deprecationEntry is one row of deprecations.json
+type deprecationEntry struct {
+	Name        string `json:"name"`
+	Replacement string `json:"replacement"`
+	Note        string `json:"note"`
+	RemovedIn   string `json:"removedIn"`
+}
+
+// Deprecations maps glyph names to their deprecation, with replacement ids
+// resolved against the loaded glyphs
+type Deprecations struct {
+	mu     sync.RWMutex
+	byName map[string]GlyphDeprecation
+}
+
+// Lookup returns the deprecation of a glyph, if any
+func (d *Deprecations) Lookup(name string) (GlyphDeprecation, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	dep, ok := d.byName[name]
+	return dep, ok
+}
+
+// replace swaps in a new mapping
+func (d *Deprecations) replace(byName map[string]GlyphDeprecation) {
+	d.mu.Lock()
+	d.byName = byName
+	d.mu.Unlock()
+}
+
+// loadDeprecations rebuilds the mapping from the glyph database's
+// deprecated_glyphs table and the glyph_deprecations table the updater
+// maintains, which takes precedence
+func (a *App) loadDeprecations() {
+	byName := make(map[string]GlyphDeprecation)
+
+	deprecated, err := a.loadDeprecatedGlyphs()
+	if err != nil {
+		log.Printf("%v", err)
+	}
+	for _, d := range deprecated {
+		byName[d.Name] = GlyphDeprecation{Replacement: d.Replacement, Note: d.Note}
+	}
+
+	if db := a.homeDB(); db != nil {
+		if exists, err := tableExists(db, "glyph_deprecations"); err == nil && exists {
+			rows, err := db.Query("SELECT name, COALESCE(replacement, ''), COALESCE(note, ''), COALESCE(removed_in, '') FROM glyph_deprecations")
+			if err != nil {
+				log.Printf("Failed to load glyph deprecations: %v", err)
+			} else {
+				for rows.Next() {
+					var name string
+					var d GlyphDeprecation
+					if err := rows.Scan(&name, &d.Replacement, &d.Note, &d.RemovedIn); err == nil {
+						byName[name] = d
+					}
+				}
+				rows.Close()
+			}
+		}
+	}
+
+	if len(byName) > 0 {
+		ids := make(map[string]int)
+		for _, d := range byName {
+			if d.Replacement != "" {
+				ids[d.Replacement] = 0
+			}
+		}
+		for _, g := range a.allGlyphs() {
+			if _, wanted := ids[g.Name]; wanted {
+				ids[g.Name] = g.ID
+			}
+		}
+		for name, d := range byName {
+			if d.Replacement != "" && d.Replacement != name {
+				d.ReplacementID = ids[d.Replacement]
+			}
+			if d.Note == "" {
+				d.Note = "Deprecated"
+				if d.Replacement != "" {
+					d.Note = "Deprecated; use " + d.Replacement + " instead"
+				}
+			}
+			byName[name] = d
+		}
+	}
+	a.deprecations.replace(byName)
+}
+
+// annotateDeprecations marks the deprecated glyphs among matches
+func (a *App) annotateDeprecations(matches []GlyphMatch) {
+	for i := range matches {
+		if d, ok := a.deprecations.Lookup(matches[i].Name); ok {
+			matches[i].Deprecated = &d
+		}
+	}
+}
+
+// fetchDeprecations downloads and parses the deprecation list of a release
+func (u *UpdateChecker) fetchDeprecations(ctx context.Context, url string) ([]deprecationEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Gylte/"+version)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download deprecations: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download deprecations: %s", resp.Status)
+	}
+
+	var entries []deprecationEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to parse deprecations: %w", err)
+	}
+	return entries, nil
+}
+
+// storeDeprecations replaces the glyph_deprecations table with entries
+func (a *App) storeDeprecations(entries []deprecationEntry) error {
+	tx, err := a.homeDB().Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS glyph_deprecations (
+			name TEXT PRIMARY KEY,
+			replacement TEXT,
+			note TEXT,
+			removed_in TEXT,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		DELETE FROM glyph_deprecations;
+	`); err != nil {
+		return fmt.Errorf("failed to reset deprecations: %w", err)
+	}
+	for _, e := range entries {
+		name := strings.TrimSpace(e.Name)
+		if name == "" {
+			continue
+		}
+		_, err := tx.Exec(`
+			INSERT OR REPLACE INTO glyph_deprecations (name, replacement, note, removed_in)
+			VALUES (?, ?, ?, ?)
+		`, name, strings.TrimSpace(e.Replacement), e.Note, e.RemovedIn)
+		if err != nil {
+			return fmt.Errorf("failed to save deprecation of %s: %w", name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// refreshDeprecations updates the deprecation mapping from the latest release,
+// if it ships one, and returns how many glyphs are deprecated
+func (a *App) refreshDeprecations(info *UpdateInfo) (int, error) {
+	var url string
+	for _, asset := range info.Assets {
+		if asset.Name == deprecationsAsset {
+			url = asset.URL
+		}
+	}
+	if url == "" {
+		return 0, newAppError(ErrCodeNotFound, "release %s has no %s", info.LatestVersion, deprecationsAsset)
+	}
+
+	entries, err := a.updater.fetchDeprecations(context.Background(), url)
+	if err != nil {
+		return 0, err
+	}
+	if err := a.storeDeprecations(entries); err != nil {
+		return 0, err
+	}
+	a.loadDeprecations()
+
+	log.Printf("Loaded %d glyph deprecations from release %s", len(entries), info.LatestVersion)
+	a.emit("deprecations:changed", len(entries))
+	return len(entries), nil
+}
+
+// RefreshDeprecations downloads the deprecated glyph mapping of the latest
+// release, which search results use to point at replacements
+func (a *App) RefreshDeprecations() (int, error) {
+	if err := a.checkWritable("update deprecations"); err != nil {
+		return 0, err
+	}
+	if a.homeDB() == nil {
+		return 0, newAppError(ErrCodeDBMissing, "database not open")
+	}
+
+	info := a.updater.Info()
+	if info == nil {
+		var err error
+		if info, err = a.updater.Check(context.Background()); err != nil {
+			return 0, err
+		}
+	}
+	return a.refreshDeprecations(info)
+}
+
+// GetGlyphDeprecation returns why a glyph is deprecated and what replaces it
+func (a *App) GetGlyphDeprecation(id int) (*GlyphDeprecation, error) {
+	g, ok := a.findGlyph(id)
+	if !ok {
+		return nil, newAppError(ErrCodeNotFound, "glyph %d not found", id)
+	}
+	d, ok := a.deprecations.Lookup(g.Name)
+	if !ok {
+		return nil, nil
+	}
+	return &d, nil
+}
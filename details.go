@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GlyphDetails extends a glyph with encoding and accessibility information
+type GlyphDetails struct {
+	Glyph
+	Codepoint  string `json:"codepoint"`
+	UTF8       string `json:"utf8"`
+	HTMLEntity string `json:"htmlEntity"`
+	IconSet    string `json:"iconSet"`
+	IsFavorite bool   `json:"isFavorite"`
+
+	// Metrics is nil when no Nerd Font has the glyph
+	Metrics *GlyphMetrics `json:"metrics,omitempty"`
+}
+
+// codepointOf returns the first rune of a glyph string
+func codepointOf(glyph string) rune {
+	for _, r := range glyph {
+		return r
+	}
+	return 0
+}
+
+// formatCodepoint formats a rune as "U+F0001"
+func formatCodepoint(r rune) string {
+	return fmt.Sprintf("U+%04X", r)
+}
+
+// utf8Bytes formats the UTF-8 encoding of a string as space separated hex bytes
+func utf8Bytes(s string) string {
+	parts := make([]string, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		parts = append(parts, fmt.Sprintf("%02X", s[i]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// findGlyph looks up a cached glyph by id
+func (a *App) findGlyph(id int) (Glyph, bool) {
+	if g, ok := a.cache.Snapshot().Glyph(id); ok || a.searchMode() != SearchModeDirect {
+		return g, ok
+	}
+	return a.directGlyph("id = ?", id)
+}
+
+// GetGlyphDetails returns a glyph with its description, encodings and font metrics
+func (a *App) GetGlyphDetails(glyphID int) (*GlyphDetails, error) {
+	g, ok := a.findGlyph(glyphID)
+	if !ok {
+		return nil, newAppError(ErrCodeNotFound, "glyph %d not found", glyphID)
+	}
+
+	r := codepointOf(g.Glyph)
+	g.Category = categoryOf(g)
+
+	isFavorite := a.favorites.Contains(g.ID)
+
+	return &GlyphDetails{
+		Glyph:      g,
+		Codepoint:  formatCodepoint(r),
+		UTF8:       utf8Bytes(g.Glyph),
+		HTMLEntity: fmt.Sprintf("&#x%X;", r),
+		IconSet:    iconSetName(g.Category),
+		IsFavorite: isFavorite,
+		Metrics:    a.glyphMetrics(g),
+	}, nil
+}
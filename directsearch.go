@@ -0,0 +1,443 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+)
+
+// Search modes. The cache mode keeps every glyph in memory and supports fuzzy
+// matching; the direct mode queries SQLite for each search (FTS, then LIKE)
+// for machines where memory matters more than search quality.
+const (
+	SearchModeCache  = "cache"
+	SearchModeDirect = "direct"
+)
+
+// searchMode returns the mode searches are currently served in
+func (a *App) searchMode() string {
+	if a.cache.direct.Load() {
+		return SearchModeDirect
+	}
+	return SearchModeCache
+}
+
+// cacheMemoryLimit returns the configured cap on the glyph cache in bytes, or 0 for none
+func (a *App) cacheMemoryLimit() int64 {
+	return int64(a.settings.GetInt("search.maxCacheMB", 0)) << 20
+}
+
+// useDirectSearch drops the in-memory glyphs and serves searches from SQLite
+func (a *App) useDirectSearch(reason string) {
+	a.cache.direct.Store(true)
+	a.cache.Swap(emptySnapshot)
+	a.cache.timings.Store(nil)
+	log.Printf("Direct search mode: %s", reason)
+}
+
+// ftsQuery turns a search term into an FTS5 prefix query ("arrow up" ->
+// `"arrow"* "up"*`), or "" when the term has no searchable words
+func ftsQuery(term string) string {
+	words := strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = `"` + w + `"*`
+	}
+	return strings.Join(words, " ")
+}
+
+// likePattern escapes s for a LIKE pattern with \ as the escape character
+func likePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// directFilter builds the WHERE clause shared by the count and page queries
+func (a *App) directFilter(category, scope string) (string, []any, error) {
+	var where []string
+	var args []any
+
+	if c, ok := strings.CutPrefix(scope, ScopeCategoryPrefix); ok {
+		category, scope = c, ""
+	}
+	if strings.HasPrefix(scope, ScopeSmartPrefix) {
+		return "", nil, newAppError(ErrCodeUnsupported, "smart filters aren't available in direct search mode")
+	}
+
+	if category != "" {
+		var members []string
+		for _, member := range a.categoryMembers(category) {
+			members = append(members, `name LIKE ? ESCAPE '\'`)
+			args = append(args, "%-"+likePattern(member)+"-%")
+		}
+		where = append(where, "("+strings.Join(members, " OR ")+")")
+	} else {
+		for disabled := range a.disabledCategories() {
+			where = append(where, `name NOT LIKE ? ESCAPE '\'`)
+			args = append(args, "%-"+likePattern(disabled)+"-%")
+		}
+	}
+
+	scopeIDs, err := a.scopeIDs(scope)
+	if err != nil {
+		return "", nil, err
+	}
+	if scopeIDs != nil {
+		ids := make([]int, 0, len(scopeIDs))
+		for id := range scopeIDs {
+			ids = append(ids, id)
+		}
+		where = append(where, "id IN (SELECT value FROM json_each(?))")
+		args = append(args, idsJSON(ids))
+	}
+
+	if len(where) == 0 {
+		return "", nil, nil
+	}
+	return " WHERE " + strings.Join(where, " AND "), args, nil
+}
+
+// directSearch serves one page of a search straight from the glyph database.
+// Only the primary database is searched; attached sources and plugin glyphs
+// need the cache.
+func (a *App) directSearch(term, category, scope string, limit, offset int) ([]GlyphMatch, int, error) {
+	if err := validateSearch(term, category); err != nil {
+		return nil, 0, err
+	}
+	db := a.readDB
+	if db == nil {
+		return nil, 0, newAppError(ErrCodeDBMissing, "database not open")
+	}
+	query, err := parseSearchQuery(term)
+	if err != nil {
+		return nil, 0, err
+	}
+	where, args, err := a.directFilter(category, scope)
+	if err != nil {
+		return nil, 0, err
+	}
+	columns := glyphColumns(db)
+
+	// Code point ranges use the indexed codepoint column when the database has it
+	codepoint := "unicode(glyph)"
+	if ok, _ := columnExists(db, "glyphs", "codepoint"); ok {
+		codepoint = "codepoint"
+	}
+	if query.Range != nil {
+		clause := codepoint + " BETWEEN ? AND ?"
+		if where == "" {
+			where = " WHERE " + clause
+		} else {
+			where += " AND " + clause
+		}
+		args = append(args, query.Range.Lo, query.Range.Hi)
+	}
+	// Glyphs without a stored width are classified when the profile opens;
+	// until then they count as single width like most icons
+	if query.Width != "" {
+		clause := "'" + WidthSingle + "' = ?"
+		if ok, _ := columnExists(db, "glyphs", "width"); ok {
+			clause = "COALESCE(width, '" + WidthSingle + "') = ?"
+		}
+		if where == "" {
+			where = " WHERE " + clause
+		} else {
+			where += " AND " + clause
+		}
+		args = append(args, query.Width)
+	}
+
+	// Rank full-text hits first; fall back to substring matches when the
+	// database has no FTS index or the index finds nothing
+	term = query.Text
+	var from, order string
+	var termArgs []any
+	if term == "" {
+		from, order = "glyphs"+where, "name"
+		if query.Range != nil {
+			order = codepoint + ", name"
+		}
+	} else {
+		if q := ftsQuery(term); q != "" {
+			if exists, _ := tableExists(db, "glyphs_fts"); exists {
+				from = "glyphs JOIN (SELECT rowid AS hit, rank FROM glyphs_fts WHERE glyphs_fts MATCH ?) ON hit = id" + where
+				order = "rank, name"
+				termArgs = []any{q}
+			}
+		}
+		if from != "" {
+			var n int
+			if err := db.QueryRow("SELECT COUNT(*) FROM "+from, append(termArgs, args...)...).Scan(&n); err != nil || n == 0 {
+				from, termArgs = "", nil
+			}
+		}
+		if from == "" {
+			match := `name LIKE ? ESCAPE '\'`
+			if ok, _ := columnExists(db, "glyphs", "description"); ok {
+				match = `(name LIKE ? ESCAPE '\' OR description LIKE ? ESCAPE '\')`
+			}
+			if where == "" {
+				from = "glyphs WHERE " + match
+			} else {
+				from = "glyphs" + where + " AND " + match
+			}
+			pattern := "%" + likePattern(term) + "%"
+			args = append(args, pattern)
+			if strings.Contains(match, "description") {
+				args = append(args, pattern)
+			}
+			order = "instr(name, ?) = 0, length(name), name"
+			args = append(args, term)
+		}
+	}
+	args = append(termArgs, args...)
+
+	// The ORDER BY placeholder (if any) is the last argument; COUNT doesn't use it
+	countArgs := args
+	if strings.Contains(order, "?") {
+		countArgs = args[:len(args)-1]
+	}
+	var total int
+	if err := db.QueryRow("SELECT COUNT(*) FROM "+from, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to search glyphs: %w", err)
+	}
+
+	rows, err := db.Query("SELECT "+columns+" FROM "+from+" ORDER BY "+order+" LIMIT ? OFFSET ?", append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search glyphs: %w", err)
+	}
+	defer rows.Close()
+
+	source := sourceName(a.dbPath)
+	var glyphs []Glyph
+	for rows.Next() {
+		g, err := scanGlyph(rows, 0, source)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read glyph: %w", err)
+		}
+		glyphs = append(glyphs, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to search glyphs: %w", err)
+	}
+	a.applyGlyphMetadata(glyphs)
+
+	favorites := a.favorites.Snapshot()
+	matches := make([]GlyphMatch, len(glyphs))
+	for i, g := range glyphs {
+		matches[i] = GlyphMatch{Glyph: g, IsFavorite: favorites[g.ID]}
+	}
+	return matches, total, nil
+}
+
+// directGlyph looks up one glyph in the database, for direct search mode
+func (a *App) directGlyph(where string, arg any) (Glyph, bool) {
+	if a.readDB == nil {
+		return Glyph{}, false
+	}
+	row := a.readDB.QueryRow("SELECT "+glyphColumns(a.readDB)+" FROM glyphs WHERE "+where, arg)
+	g, err := scanGlyph(row, 0, sourceName(a.dbPath))
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("Failed to look up glyph: %v", err)
+		}
+		return Glyph{}, false
+	}
+	glyphs := []Glyph{g}
+	a.applyGlyphMetadata(glyphs)
+	return glyphs[0], true
+}
+
+// idsJSON encodes glyph ids for `id IN (SELECT value FROM json_each(?))`
+func idsJSON(ids []int) string {
+	data, _ := json.Marshal(ids)
+	return string(data)
+}
+
+// directGlyphs reads the glyphs matching a WHERE clause (empty for all) from
+// the database in name order, like the cache, for direct search mode
+func (a *App) directGlyphs(where string, args ...any) ([]Glyph, error) {
+	if a.readDB == nil {
+		return nil, newAppError(ErrCodeDBMissing, "database not open")
+	}
+	rows, err := a.readDB.Query("SELECT "+glyphColumns(a.readDB)+" FROM glyphs"+where+" ORDER BY name", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read glyphs: %w", err)
+	}
+	defer rows.Close()
+
+	source := sourceName(a.dbPath)
+	var glyphs []Glyph
+	for rows.Next() {
+		g, err := scanGlyph(rows, 0, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read glyph: %w", err)
+		}
+		glyphs = append(glyphs, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read glyphs: %w", err)
+	}
+	a.applyGlyphMetadata(glyphs)
+	return glyphs, nil
+}
+
+// allGlyphs returns every glyph in name order. Direct search mode keeps no
+// glyphs in memory, so there they are read from the database on each call.
+func (a *App) allGlyphs() []Glyph {
+	if a.searchMode() != SearchModeDirect {
+		return a.cache.Snapshot().glyphs
+	}
+	glyphs, err := a.directGlyphs("")
+	if err != nil {
+		log.Printf("Failed to read glyphs: %v", err)
+	}
+	return glyphs
+}
+
+// lookupGlyphs returns the glyphs with the given ids, keyed by id, from the
+// cache or, in direct search mode, the database. Unknown ids are left out.
+func (a *App) lookupGlyphs(ids []int) map[int]Glyph {
+	found := make(map[int]Glyph, len(ids))
+	if a.searchMode() != SearchModeDirect {
+		snap := a.cache.Snapshot()
+		for _, id := range ids {
+			if g, ok := snap.Glyph(id); ok {
+				found[id] = g
+			}
+		}
+		return found
+	}
+
+	glyphs, err := a.directGlyphs(" WHERE id IN (SELECT value FROM json_each(?))", idsJSON(ids))
+	if err != nil {
+		log.Printf("Failed to look up glyphs: %v", err)
+	}
+	for _, g := range glyphs {
+		found[g.ID] = g
+	}
+	return found
+}
+
+// glyphsIn returns the glyphs whose ids are in ids, in name order like the cache
+func (a *App) glyphsIn(ids map[int]bool) []Glyph {
+	if a.searchMode() != SearchModeDirect {
+		var glyphs []Glyph
+		for _, g := range a.cache.Snapshot().glyphs {
+			if ids[g.ID] {
+				glyphs = append(glyphs, g)
+			}
+		}
+		return glyphs
+	}
+
+	list := make([]int, 0, len(ids))
+	for id := range ids {
+		list = append(list, id)
+	}
+	glyphs, err := a.directGlyphs(" WHERE id IN (SELECT value FROM json_each(?))", idsJSON(list))
+	if err != nil {
+		log.Printf("Failed to look up glyphs: %v", err)
+	}
+	return glyphs
+}
+
+// directCategoryIDs returns the ids of the glyphs in a category in name order,
+// for direct search mode
+func (a *App) directCategoryIDs(category string) ([]int, error) {
+	if a.readDB == nil {
+		return nil, newAppError(ErrCodeDBMissing, "database not open")
+	}
+	where, args, err := a.directFilter(category, "")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := a.readDB.Query("SELECT id FROM glyphs"+where+" ORDER BY name", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read category: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to read category: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// directCategories counts the glyphs per category in the database and picks
+// each category's first glyph as its sample
+func (a *App) directCategories() (map[string]int, map[string]Glyph) {
+	counts := make(map[string]int)
+	samples := make(map[string]Glyph)
+	if a.readDB == nil {
+		return counts, samples
+	}
+
+	// The category is the second dash-separated part of the name, as in glyphCategory
+	rows, err := a.readDB.Query(`
+		SELECT category, COUNT(*), MIN(id) FROM (
+			SELECT id, substr(rest, 1, instr(rest || '-', '-') - 1) AS category
+			FROM (SELECT id, substr(name, instr(name, '-') + 1) AS rest FROM glyphs WHERE instr(name, '-') > 0)
+		)
+		WHERE category != ''
+		GROUP BY category
+	`)
+	if err != nil {
+		log.Printf("Failed to count categories: %v", err)
+		return counts, samples
+	}
+	defer rows.Close()
+
+	firsts := make(map[string]int)
+	for rows.Next() {
+		var category string
+		var count, first int
+		if err := rows.Scan(&category, &count, &first); err == nil {
+			counts[category] = count
+			firsts[category] = first
+		}
+	}
+	rows.Close()
+	for category, id := range firsts {
+		if g, ok := a.directGlyph("id = ?", id); ok {
+			samples[category] = g
+		}
+	}
+	return counts, samples
+}
+
+// directGlyphCount counts the glyphs in the database
+func (a *App) directGlyphCount() int {
+	var n int
+	if a.readDB != nil {
+		a.readDB.QueryRow("SELECT COUNT(*) FROM glyphs").Scan(&n)
+	}
+	return n
+}
+
+// SetSearchMode switches between the in-memory cache ("cache") and querying
+// SQLite on every search ("direct") for low-memory machines
+func (a *App) SetSearchMode(mode string) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if mode != SearchModeCache && mode != SearchModeDirect {
+		return newAppError(ErrCodeInvalid, "unknown search mode: %s", mode)
+	}
+	if err := a.settings.Set("search.mode", mode); err != nil {
+		return err
+	}
+	if a.db != nil {
+		a.preloadCache()
+		a.emit("cache:rebuilt", a.cache.Len())
+	}
+	return nil
+}
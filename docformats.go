@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Documentation copy formats
+const (
+	DocFormatMarkdownRow = "markdown-row"
+	DocFormatObsidian    = "obsidian"
+)
+
+// docFormat is a template-driven format for cataloging glyphs in notes and docs
+type docFormat struct {
+	setting  string // settings key holding the user's template
+	template string // default template, using the glyph card placeholders
+	header   string // written once before the first glyph
+	joiner   string // placed between glyphs
+	escape   func(string) string
+}
+
+// docFormats lists the documentation formats by name
+var docFormats = map[string]docFormat{
+	DocFormatMarkdownRow: {
+		setting:  "copy.markdownTemplate",
+		template: "| {glyph} | `{name}` | {codepoint} |",
+		header:   "| Icon | Name | Codepoint |\n| --- | --- | --- |\n",
+		joiner:   "\n",
+		escape:   markdownCell,
+	},
+	DocFormatObsidian: {
+		setting:  "copy.calloutTemplate",
+		template: "> [!note] {glyph} {name}\n> Codepoint: {codepoint} · Icon set: {iconSet}",
+		joiner:   "\n\n",
+	},
+}
+
+// escapedDetails returns a copy of details with every placeholder value escaped
+func escapedDetails(d *GlyphDetails, escape func(string) string) *GlyphDetails {
+	if escape == nil {
+		return d
+	}
+	e := *d
+	e.Glyph.Glyph = escape(d.Glyph.Glyph)
+	e.Name = escape(d.Name)
+	e.Description = escape(d.Description)
+	e.IconSet = escape(d.IconSet)
+	return &e
+}
+
+// docTemplate returns the user's template for a format, or its default
+func (a *App) docTemplate(name string) (docFormat, string, error) {
+	format, ok := docFormats[name]
+	if !ok {
+		return docFormat{}, "", newAppError(ErrCodeInvalid, "unknown copy format: %s", name)
+	}
+	return format, a.settings.Get(format.setting, format.template), nil
+}
+
+// FormatGlyphs renders glyphs as Markdown table rows (with a header row when
+// there is more than one), Obsidian callouts, or a plugin's copy format
+func (a *App) FormatGlyphs(ids []int, format string) (string, error) {
+	if len(ids) == 0 {
+		return "", newAppError(ErrCodeInvalid, "no glyphs selected")
+	}
+	if len(ids) > maxBatchSize {
+		return "", invalidArgument("ids", "at most %d glyphs can be formatted at once", maxBatchSize)
+	}
+	if plugin, ok := a.pluginCopyFormat(format); ok {
+		glyphs := make([]Glyph, 0, len(ids))
+		for _, id := range ids {
+			g, ok := a.findGlyph(id)
+			if !ok {
+				return "", newAppError(ErrCodeNotFound, "glyph %d not found", id)
+			}
+			glyphs = append(glyphs, g)
+		}
+		return a.formatWithPlugin(plugin, format, glyphs)
+	}
+	f, tmpl, err := a.docTemplate(format)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if len(ids) > 1 {
+		b.WriteString(f.header)
+	}
+	for i, id := range ids {
+		details, err := a.GetGlyphDetails(id)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			b.WriteString(f.joiner)
+		}
+		b.WriteString(renderGlyphCard(tmpl, escapedDetails(details, f.escape)))
+	}
+	return b.String(), nil
+}
+
+// CopyGlyphsAs copies glyphs in a documentation format and returns the text
+func (a *App) CopyGlyphsAs(ids []int, format string) (string, error) {
+	text, err := a.FormatGlyphs(ids, format)
+	if err != nil {
+		return "", err
+	}
+	if _, err := a.setClipboard(text); err != nil {
+		return "", fmt.Errorf("failed to copy glyphs: %w", err)
+	}
+	return text, nil
+}
+
+// ExportGlyphsAs writes glyphs in a documentation format to a Markdown file
+// and returns its path. An empty path exports to the default exports directory.
+func (a *App) ExportGlyphsAs(ids []int, format, path string) (string, error) {
+	text, err := a.FormatGlyphs(ids, format)
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		path = a.defaultExportPath("glyphs-"+format, ".md")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := writeExportBytes(path, []byte(text+"\n")); err != nil {
+		return "", fmt.Errorf("failed to export glyphs: %w", err)
+	}
+	log.Printf("Exported %d glyphs as %s to %s", len(ids), format, path)
+	return path, nil
+}
+
+// GetDocTemplate returns the template used for a documentation format
+func (a *App) GetDocTemplate(format string) (string, error) {
+	_, tmpl, err := a.docTemplate(format)
+	return tmpl, err
+}
+
+// SetDocTemplate changes a documentation format's template; an empty template restores the default
+func (a *App) SetDocTemplate(format, tmpl string) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	f, ok := docFormats[format]
+	if !ok {
+		return newAppError(ErrCodeInvalid, "unknown copy format: %s", format)
+	}
+	if strings.TrimSpace(tmpl) == "" {
+		tmpl = f.template
+	}
+	return a.settings.Set(f.setting, tmpl)
+}
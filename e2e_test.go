@@ -0,0 +1,1323 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+)
+
+func TestE2ESearchRanksExactNamesFirst(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	names := h.search(SearchRequest{Query: "rocket"})
+	if len(names) != 2 {
+		t.Fatalf("search rocket = %v, want the fa and md rockets", names)
+	}
+	for _, name := range names {
+		if !strings.HasSuffix(name, "-rocket") {
+			t.Errorf("unexpected match %s", name)
+		}
+	}
+
+	names = h.search(SearchRequest{Query: "arrow_up"})
+	if len(names) < 3 || !slices.Contains(names[:2], "nf-cod-arrow_up") || !slices.Contains(names[:2], "nf-fa-arrow_up") {
+		t.Errorf("search arrow_up = %v, want the exact arrow_up glyphs ranked first", names)
+	}
+}
+
+func TestE2ESearchFiltersByCategory(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	names := h.search(SearchRequest{Query: "folder", Category: "md"})
+	if !slices.Equal(names, []string{"nf-md-folder_open"}) {
+		t.Errorf("search folder in md = %v", names)
+	}
+
+	resp, err := h.app.GetGlyphs(SearchRequest{Category: "weather"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Total != 2 || resp.HasMore {
+		t.Errorf("weather category: total %d, hasMore %v; want 2 and false", resp.Total, resp.HasMore)
+	}
+}
+
+func TestE2ETypoFallbackOnlyWithoutDirectMatches(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	if _, err := h.app.BulkTag([]int{h.glyphID("nf-fa-star")}, []string{"rocker"}); err != nil {
+		t.Fatal(err)
+	}
+	// "rocker" is one edit from "rocket", but the tag matches it exactly
+	resp, err := h.app.GetGlyphs(SearchRequest{Query: "rocker"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Glyphs) != 1 || resp.Glyphs[0].Name != "nf-fa-star" || len(resp.DidYouMean) > 0 {
+		t.Errorf("rocker = %v, did you mean %v; want only the tagged glyph", h.search(SearchRequest{Query: "rocker"}), resp.DidYouMean)
+	}
+
+	// Without a direct match the near misses still show
+	resp, err = h.app.GetGlyphs(SearchRequest{Query: "rockte"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.DidYouMean) == 0 {
+		t.Errorf("rockte: no did you mean")
+	}
+}
+
+func TestE2EUnknownScoringProfileSetting(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	if err := h.app.SetSetting("search.profile", "balancd"); errorCode(err) != ErrCodeInvalid {
+		t.Errorf("saving an unknown profile: %v", err)
+	}
+	// A bad value already stored still searches, with the default profile
+	if err := h.app.settings.Set("search.profile", "balancd"); err != nil {
+		t.Fatal(err)
+	}
+	if names := h.search(SearchRequest{Query: "rocket"}); len(names) == 0 {
+		t.Error("no results with an unknown profile setting")
+	}
+	if _, err := h.app.GetGlyphs(SearchRequest{Query: "rocket", Profile: "balancd"}); errorCode(err) != ErrCodeInvalid {
+		t.Errorf("unknown profile named in the query: %v", err)
+	}
+}
+
+func TestE2ESearchPaginates(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	fixture := loadFixture(t, "fixture.json")
+
+	var seen []string
+	for offset := 0; ; offset += 10 {
+		resp, err := h.app.GetGlyphs(SearchRequest{Limit: 10, Offset: offset})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Total != len(fixture) {
+			t.Fatalf("total = %d, want %d", resp.Total, len(fixture))
+		}
+		for _, m := range resp.Glyphs {
+			seen = append(seen, m.Name)
+		}
+		if !resp.HasMore {
+			break
+		}
+	}
+	slices.Sort(seen)
+	if len(slices.Compact(seen)) != len(fixture) {
+		t.Errorf("pages returned %d distinct glyphs, want %d", len(seen), len(fixture))
+	}
+}
+
+func TestE2EFavoritesSurviveRestart(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	h.favorite("nf-fa-star")
+	h.favorite("nf-dev-go")
+	h.favorite("nf-dev-go") // toggled back off
+
+	names := h.search(SearchRequest{Scope: ScopeFavorites})
+	if !slices.Equal(names, []string{"nf-fa-star"}) {
+		t.Fatalf("favorites scope = %v, want [nf-fa-star]", names)
+	}
+
+	// Restart the core on the same data directory
+	h.app.shutdown(context.Background())
+	a := NewApp()
+	a.dbPath, a.dataPath = h.app.dbPath, h.dir
+	a.startHeadless()
+	t.Cleanup(func() { a.shutdown(context.Background()) })
+	h.app = a
+
+	favorites, err := a.GetFavorites(0, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(favorites) != 1 || favorites[0].Name != "nf-fa-star" || !favorites[0].IsFavorite {
+		t.Errorf("favorites after restart = %+v", favorites)
+	}
+}
+
+func TestE2ESessionRestoredAfterRestart(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	h.search(SearchRequest{Query: "rocket", Limit: 10})
+	h.app.SaveSessionScroll(240)
+
+	h.app.shutdown(context.Background())
+	a := NewApp()
+	a.dbPath, a.dataPath = h.app.dbPath, h.dir
+	a.startHeadless()
+	t.Cleanup(func() { a.shutdown(context.Background()) })
+
+	// The first search of the new run must not replace the session to restore
+	if _, err := a.GetGlyphs(SearchRequest{Limit: 10}); err != nil {
+		t.Fatal(err)
+	}
+	last := a.GetLastSession()
+	if last == nil || last.SearchTerm != "rocket" || last.ScrollTop != 240 {
+		t.Errorf("last session after restart = %+v", last)
+	}
+}
+
+func TestE2EIncognitoSearchLeavesNoTrace(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	h.search(SearchRequest{Query: "rocket"})
+	if err := h.app.SetIncognito(true); err != nil {
+		t.Fatal(err)
+	}
+	h.search(SearchRequest{Query: "secret"})
+
+	h.app.session.mu.Lock()
+	state := h.app.session.state
+	h.app.session.mu.Unlock()
+	if state == nil || state.SearchTerm != "rocket" {
+		t.Errorf("session after an incognito search = %+v, want the rocket search", state)
+	}
+
+	var n int
+	if err := h.app.userDB.QueryRow("SELECT COUNT(*) FROM search_history WHERE term = 'secret'").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("incognito search saved to history")
+	}
+}
+
+func TestE2EProfileSwitchRestartsClipboardWatch(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	// Stand in for a watch started by the default profile
+	ctx, cancel := context.WithCancel(context.Background())
+	h.app.clipWatch.mu.Lock()
+	h.app.clipWatch.cancel = cancel
+	h.app.clipWatch.mu.Unlock()
+
+	if err := h.app.CreateProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.app.SwitchProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Err() == nil {
+		t.Error("clipboard watch of the previous profile still running")
+	}
+}
+
+func TestE2EExportFavorites(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	h.favorite("nf-md-rocket")
+	h.favorite("nf-weather-rain")
+
+	path, err := h.app.ExportFavorites("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exported []GlyphMatch
+	h.readJSON(path, &exported)
+
+	var names []string
+	for _, g := range exported {
+		names = append(names, g.Name)
+		if g.Glyph.Glyph == "" {
+			t.Errorf("%s exported without its character", g.Name)
+		}
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"nf-md-rocket", "nf-weather-rain"}) {
+		t.Errorf("exported favorites = %v", names)
+	}
+}
+
+// iconTestFont returns Go Regular with its cmap replaced so each codepoint in
+// icons maps to the glyph of a letter, standing in for a Nerd Font
+func iconTestFont(t *testing.T, icons map[rune]rune) []byte {
+	t.Helper()
+
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf sfnt.Buffer
+	mapping := make(map[rune]uint16, len(icons))
+	for icon, letter := range icons {
+		idx, err := f.GlyphIndex(&buf, letter)
+		if err != nil || idx == 0 {
+			t.Fatalf("Go Regular has no %q", letter)
+		}
+		mapping[icon] = uint16(idx)
+	}
+	tables, err := parseSFNT(goregular.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables["cmap"] = buildCmap(mapping)
+	return tables.TTF()
+}
+
+func TestE2ECheatSheetDrawsSupplementaryGlyphs(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	fontPath := filepath.Join(h.dir, "TestNerdFont-Regular.ttf")
+	if err := os.WriteFile(fontPath, iconTestFont(t, map[rune]rune{0xF0463: 'A', 0xF135: 'B'}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.app.settings.Set("font.path", fontPath); err != nil {
+		t.Fatal(err)
+	}
+	h.favorite("nf-md-rocket") // U+F0463, outside the BMP
+	h.favorite("nf-fa-rocket")
+
+	path, err := h.app.ExportCheatSheet(ScopeFavorites, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each glyph image carries its transparency as a soft mask
+	if n := strings.Count(string(data), "/SMask "); n != 2 {
+		t.Errorf("cheat sheet has %d glyph images, want 2", n)
+	}
+}
+
+func TestE2EContactSheetCaptionsUseATextFont(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	// Like Symbols Nerd Font, the test font maps no Latin letters
+	fontPath := filepath.Join(h.dir, "TestNerdFont-Regular.ttf")
+	if err := os.WriteFile(fontPath, iconTestFont(t, map[rune]rune{0xF0463: 'A', 0xF135: 'B'}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.app.settings.Set("font.path", fontPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// Names of equal length: .notdef boxes would ink both captions the same
+	glyphs := []Glyph{}
+	for _, name := range []string{"nf-fa-rocket", "nf-md-rocket"} {
+		g, _ := h.app.findGlyph(h.glyphID(name))
+		glyphs = append(glyphs, g)
+	}
+	img, err := h.app.renderContactSheet(glyphs, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ink := make([]int, len(glyphs))
+	top := contactSheetMargin + contactSheetCell - 24
+	for i := range glyphs {
+		left := contactSheetMargin + i*contactSheetCell
+		for y := top; y < top+20; y++ {
+			for x := left + 1; x < left+contactSheetCell; x++ {
+				if img.RGBAAt(x, y) != color.RGBAModel.Convert(contactSheetBackground) {
+					ink[i]++
+				}
+			}
+		}
+	}
+	if ink[0] == 0 || ink[0] == ink[1] {
+		t.Errorf("caption ink = %v, want two different non-empty captions", ink)
+	}
+}
+
+func TestE2ESubsetFontFormats(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	fontPath := filepath.Join(h.dir, "TestNerdFont-Regular.ttf")
+	if err := os.WriteFile(fontPath, iconTestFont(t, map[rune]rune{0xF135: 'A'}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ids := []int{h.glyphID("nf-fa-rocket")}
+
+	if _, err := h.app.SubsetFont("", ids, fontPath, "", "wof2"); errorCode(err) != ErrCodeInvalid {
+		t.Errorf("misspelled format: %v", err)
+	}
+	if _, err := h.app.SubsetFont("", ids, fontPath, filepath.Join(h.dir, "icons.otf"), ""); errorCode(err) != ErrCodeInvalid {
+		t.Errorf("unsupported extension: %v", err)
+	}
+	// The manifest would replace the font
+	if _, err := h.app.SubsetFont("", ids, fontPath, filepath.Join(h.dir, "icons.json"), SubsetFormatTTF); errorCode(err) != ErrCodeInvalid {
+		t.Errorf("font saved as .json: %v", err)
+	}
+
+	out := filepath.Join(h.dir, "icons.ttf")
+	manifest, err := h.app.SubsetFont("", ids, fontPath, out, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Format != SubsetFormatTTF || len(manifest.Glyphs) != 1 {
+		t.Errorf("manifest = %+v", manifest)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := opentype.Parse(data); err != nil {
+		t.Errorf("subset font doesn't parse: %v", err)
+	}
+}
+
+func TestE2EExportSearchResults(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	path, err := h.app.ExportSearchResults("check", "", ExportFormatCSV, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"nf-cod-check", "nf-fa-check"} {
+		if !strings.Contains(string(data), name+",") {
+			t.Errorf("CSV export lacks %s:\n%s", name, data)
+		}
+	}
+}
+
+func TestE2EOpenExportedFileStaysInExports(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	outside := filepath.Join(h.dir, "payload.sh")
+	if err := os.WriteFile(outside, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.app.OpenExportedFile(outside); errorCode(err) != ErrCodeInvalid {
+		t.Errorf("opening a file outside the exports folder: %v", err)
+	}
+
+	// A link inside the exports folder can't reach out of it either
+	if err := os.MkdirAll(h.app.exportsDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(h.app.exportsDir(), "payload.sh")
+	if err := os.Symlink(outside, link); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	if err := h.app.OpenExportedFile(link); errorCode(err) != ErrCodeInvalid {
+		t.Errorf("opening a link out of the exports folder: %v", err)
+	}
+}
+
+func TestE2EScanOrdersUnknownCodepointsNumerically(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "icons.conf"), []byte("a = \"\U00100000\"\nb = \"\uE0FF\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report, err := h.app.ScanDirectoryForGlyphs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var codepoints []string
+	for _, u := range report.Unknown {
+		codepoints = append(codepoints, u.Codepoint)
+	}
+	if want := []string{formatCodepoint(0xE0FF), formatCodepoint(0x100000)}; !slices.Equal(codepoints, want) {
+		t.Errorf("unknown codepoints = %v, want %v", codepoints, want)
+	}
+}
+
+func TestE2EStarshipConfigHasNoDuplicateKeys(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	config, err := h.app.GeneratePromptConfig([]int{h.glyphID("nf-md-rocket"), h.glyphID("nf-fa-star")}, PromptToolStarship)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every key must be unique within its table
+	seen := make(map[string]bool)
+	table := ""
+	for _, line := range strings.Split(config, "\n") {
+		switch {
+		case strings.HasPrefix(line, "["):
+			table = line
+		case strings.Contains(line, " = "):
+			key := table + "." + strings.SplitN(line, " = ", 2)[0]
+			if seen[key] {
+				t.Errorf("duplicate key %s in:\n%s", key, config)
+			}
+			seen[key] = true
+		}
+	}
+	if len(seen) != 2 {
+		t.Errorf("want 2 symbols, got %d in:\n%s", len(seen), config)
+	}
+}
+
+func TestE2EOpacityRejectsNaN(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if err := h.app.SetOpacity(v); errorCode(err) != ErrCodeInvalid {
+			t.Errorf("SetOpacity(%v) = %v, want invalid", v, err)
+		}
+	}
+	// A stored NaN, e.g. from an edited settings table, reads as opaque
+	if err := h.app.settings.Set("window.opacity", "NaN"); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.app.GetWindowPrefs().Opacity; got != 1 {
+		t.Errorf("opacity from a stored NaN = %v, want 1", got)
+	}
+}
+
+func TestE2ECollectionRoundTrip(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	if err := h.app.CreateCollection("devops"); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"nf-dev-docker", "nf-dev-git", "nf-cod-git_merge"} {
+		if err := h.app.AddToCollection("devops", h.glyphID(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := h.app.RemoveFromCollection("devops", h.glyphID("nf-dev-git")); err != nil {
+		t.Fatal(err)
+	}
+
+	names := h.search(SearchRequest{Query: "git", Scope: ScopeCollectionPrefix + "devops"})
+	if !slices.Equal(names, []string{"nf-cod-git_merge"}) {
+		t.Errorf("search git in collection = %v, want [nf-cod-git_merge]", names)
+	}
+}
+
+func TestE2EAttachedSourcesWithSameFileName(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	var paths []string
+	for i, name := range []string{"nf-custom-alpha", "nf-custom-beta"} {
+		dir := filepath.Join(t.TempDir(), strconv.Itoa(i))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, "icons.db")
+		seedGlyphDatabase(t, path, []fixtureGlyph{{Name: name, Glyph: string(rune(0xE900 + i))}})
+		if err := h.app.AttachDatabase(path); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	sources := h.app.ListSources()
+	if len(sources) != 3 || sources[1].Name == sources[2].Name {
+		t.Fatalf("sources = %+v, want distinct names", sources)
+	}
+	for _, src := range sources[1:] {
+		if src.GlyphCount != 1 {
+			t.Errorf("%s (%s) has %d glyphs, want 1", src.Name, src.Path, src.GlyphCount)
+		}
+	}
+	alpha, _ := h.app.findGlyphByName("nf-custom-alpha")
+	beta, _ := h.app.findGlyphByName("nf-custom-beta")
+	if alpha.Source == beta.Source {
+		t.Errorf("both attached glyphs are tagged %q", alpha.Source)
+	}
+}
+
+func TestE2EDirectSearchMatchesCache(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	cached := h.search(SearchRequest{Query: "account"})
+
+	if err := h.app.SetSearchMode(SearchModeDirect); err != nil {
+		t.Fatal(err)
+	}
+	direct := h.search(SearchRequest{Query: "account"})
+
+	slices.Sort(cached)
+	slices.Sort(direct)
+	if !slices.Equal(cached, direct) {
+		t.Errorf("direct search = %v, cache search = %v", direct, cached)
+	}
+}
+
+func TestE2EGeneratedFixtureCategories(t *testing.T) {
+	h := newHarness(t, "fixture.db")
+
+	// db_generator --fixture 120 spreads glyphs evenly over 8 icon sets
+	categories := h.app.GetCategories()
+	if len(categories) != 8 {
+		t.Fatalf("got %d categories, want 8", len(categories))
+	}
+	for _, c := range categories {
+		if c.Count != 15 {
+			t.Errorf("category %s has %d glyphs, want 15", c.Name, c.Count)
+		}
+		if c.License == nil {
+			t.Errorf("category %s has no license", c.Name)
+		}
+	}
+
+	names := h.search(SearchRequest{Query: "rocket", Category: "md"})
+	if len(names) == 0 || names[0] != "nf-md-rocket" {
+		t.Errorf("search rocket in md = %v, want nf-md-rocket first", names)
+	}
+}
+
+func TestE2ECodepointRange(t *testing.T) {
+	h := newHarness(t, "fixture.db")
+
+	for _, mode := range []string{SearchModeCache, SearchModeDirect} {
+		if err := h.app.SetSearchMode(mode); err != nil {
+			t.Fatal(err)
+		}
+
+		// The fixture's fa glyphs count up from U+F000
+		names := h.search(SearchRequest{Query: "cp:f000..f002"})
+		if !slices.Equal(names, []string{"nf-fa-account", "nf-fa-arrow_up", "nf-fa-arrow_down"}) {
+			t.Errorf("%s: cp:f000..f002 = %v", mode, names)
+		}
+		names = h.search(SearchRequest{Query: "arrow cp:U+F000..U+F0FF"})
+		if len(names) != 2 {
+			t.Errorf("%s: arrow in U+F000..U+F0FF = %v, want the two fa arrows", mode, names)
+		}
+		if _, err := h.app.GetGlyphs(SearchRequest{Query: "cp:f2ff..f000"}); errorCode(err) != ErrCodeInvalid {
+			t.Errorf("%s: reversed range: got %v, want an invalid argument error", mode, err)
+		}
+	}
+}
+
+func TestE2EOverlongQueryRejectedInEverySearchMode(t *testing.T) {
+	h := newHarness(t, "fixture.db")
+	long := strings.Repeat("a", maxQueryLength+1)
+
+	for _, mode := range []string{SearchModeCache, SearchModeDirect} {
+		if err := h.app.SetSearchMode(mode); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := h.app.GetGlyphs(SearchRequest{Query: long}); errorCode(err) != ErrCodeInvalid {
+			t.Errorf("%s: overlong query: got %v, want an invalid argument error", mode, err)
+		}
+		if _, err := h.app.GetGlyphs(SearchRequest{Category: long}); errorCode(err) != ErrCodeInvalid {
+			t.Errorf("%s: overlong category: got %v, want an invalid argument error", mode, err)
+		}
+	}
+}
+
+func TestE2EImportRecordsNewGlyphs(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	entries := loadFixture(t, "fixture.json")
+	entries = append(entries,
+		fixtureGlyph{Name: "nf-md-rocket_launch", Glyph: "\U000F14DE"},
+		fixtureGlyph{Name: "nf-cod-copilot", Glyph: "\uEC1E"},
+	)
+	path := filepath.Join(h.dir, "glyphs.json")
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := h.app.ImportGlyphsRelease(path, "v9.0.0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Added != 2 || result.Imported != len(entries) {
+		t.Errorf("import added %d of %d glyphs, want 2 of %d", result.Added, result.Imported, len(entries))
+	}
+
+	recent, err := h.app.GetRecentlyAddedGlyphs("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, g := range recent.Glyphs {
+		names = append(names, g.Name)
+	}
+	if recent.ReleaseTag != "v9.0.0" || !slices.Equal(names, []string{"nf-cod-copilot", "nf-md-rocket_launch"}) {
+		t.Errorf("recently added = %s %v", recent.ReleaseTag, names)
+	}
+
+	// Re-importing the same release adds nothing new
+	if result, err = h.app.ImportGlyphsRelease(path, "v9.0.1", false); err != nil {
+		t.Fatal(err)
+	}
+	if result.Added != 0 {
+		t.Errorf("re-import added %d glyphs, want 0", result.Added)
+	}
+	if recent, _ = h.app.GetRecentlyAddedGlyphs(""); recent.ReleaseTag != "v9.0.0" {
+		t.Errorf("latest release = %s, want v9.0.0", recent.ReleaseTag)
+	}
+}
+
+func TestE2ECategoryGroups(t *testing.T) {
+	h := newHarness(t, "fixture.db")
+
+	if err := h.app.SaveCategoryGroup("code", "Code", []string{"dev", "cod"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.app.SaveCategoryGroup("other", "Other", []string{"cod", "oct"}); errorCode(err) != ErrCodeConflict {
+		t.Errorf("overlapping group: got %v, want a conflict", err)
+	}
+
+	var code *CategoryInfo
+	for _, c := range h.app.GetCategories() {
+		if c.Name == "cod" || c.Name == "dev" {
+			t.Errorf("grouped category %s is still listed on its own", c.Name)
+		}
+		if c.Name == "code" {
+			code = &c
+		}
+	}
+	if code == nil || code.Count != 30 || code.DisplayName != "Code" || !slices.Equal(code.Members, []string{"cod", "dev"}) {
+		t.Fatalf("code group = %+v", code)
+	}
+
+	for _, mode := range []string{SearchModeCache, SearchModeDirect} {
+		if err := h.app.SetSearchMode(mode); err != nil {
+			t.Fatal(err)
+		}
+		names := h.search(SearchRequest{Query: "rocket", Category: "code"})
+		slices.Sort(names)
+		if !slices.Equal(names, []string{"nf-cod-rocket", "nf-dev-rocket"}) {
+			t.Errorf("%s: rocket in code group = %v", mode, names)
+		}
+	}
+
+	if err := h.app.DeleteCategoryGroup("code"); err != nil {
+		t.Fatal(err)
+	}
+	if names := h.search(SearchRequest{Category: "cod"}); len(names) != 15 {
+		t.Errorf("cod after deleting the group has %d glyphs, want 15", len(names))
+	}
+}
+
+func TestE2EWidthFilter(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	// Imports classify new glyphs; an emoji takes two terminal cells
+	entries := append(loadFixture(t, "fixture.json"), fixtureGlyph{Name: "nf-md-party_popper", Glyph: "\U0001F389"})
+	path := filepath.Join(h.dir, "glyphs.json")
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.app.ImportGlyphs(path, false); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []string{SearchModeCache, SearchModeDirect} {
+		if err := h.app.SetSearchMode(mode); err != nil {
+			t.Fatal(err)
+		}
+
+		if names := h.search(SearchRequest{Query: "party width:double"}); !slices.Equal(names, []string{"nf-md-party_popper"}) {
+			t.Errorf("%s: party width:double = %v", mode, names)
+		}
+		if names := h.search(SearchRequest{Query: "width:single"}); slices.Contains(names, "nf-md-party_popper") || len(names) == 0 {
+			t.Errorf("%s: width:single = %v, want the fixture glyphs without the emoji", mode, names)
+		}
+		if _, err := h.app.GetGlyphs(SearchRequest{Query: "width:triple"}); errorCode(err) != ErrCodeInvalid {
+			t.Errorf("%s: width:triple: got %v, want an invalid argument error", mode, err)
+		}
+	}
+}
+
+func TestE2EImportRecordsMetrics(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	// Go Mono stands in for a Nerd Font; it has Latin letters but no icons
+	fontPath := filepath.Join(h.dir, "GoMonoNerdFont-Regular.ttf")
+	if err := os.WriteFile(fontPath, gomono.TTF, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.app.settings.Set("font.path", fontPath); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := append(loadFixture(t, "fixture.json"), fixtureGlyph{Name: "nf-cod-letter_g", Glyph: "g"})
+	path := filepath.Join(h.dir, "glyphs.json")
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.app.ImportGlyphs(path, false); err != nil {
+		t.Fatal(err)
+	}
+
+	details, err := h.app.GetGlyphDetails(h.glyphID("nf-cod-letter_g"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := details.Metrics
+	if m == nil {
+		t.Fatal("no metrics recorded for nf-cod-letter_g")
+	}
+	// A "g" descends below the baseline and fits its advance
+	if m.Font != filepath.Base(fontPath) || m.UnitsPerEm == 0 || m.YMin >= 0 || m.YMax <= 0 || m.XMax > m.Advance {
+		t.Errorf("metrics of g = %+v", m)
+	}
+	if details.Width != WidthSingle {
+		t.Errorf("width of g = %s, want %s", details.Width, WidthSingle)
+	}
+
+	// Icons the font lacks have no metrics
+	if details, err = h.app.GetGlyphDetails(h.glyphID("nf-fa-rocket")); err != nil || details.Metrics != nil {
+		t.Errorf("nf-fa-rocket: metrics %+v, err %v; want none", details.Metrics, err)
+	}
+}
+
+func TestE2ECompareTray(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	names := []string{"nf-fa-rocket", "nf-md-rocket", "nf-fa-star", "nf-md-star_outline", "nf-fa-bell", "nf-fa-check"}
+	for _, name := range names {
+		if _, err := h.app.AddToCompare(h.glyphID(name)); err != nil {
+			t.Fatalf("add %s: %v", name, err)
+		}
+	}
+	// Pinning a glyph twice keeps one copy, and the tray holds six
+	if view, err := h.app.AddToCompare(h.glyphID("nf-fa-rocket")); err != nil || len(view.Glyphs) != len(names) {
+		t.Errorf("re-adding nf-fa-rocket: %v", err)
+	}
+	if _, err := h.app.AddToCompare(h.glyphID("nf-fa-folder")); errorCode(err) != ErrCodeInvalid {
+		t.Errorf("adding to a full tray: got %v, want an invalid argument error", err)
+	}
+
+	view, err := h.app.RemoveFromCompare(h.glyphID("nf-fa-star"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pinned []string
+	for _, g := range view.Glyphs {
+		pinned = append(pinned, g.Name)
+	}
+	if !slices.Equal(pinned, []string{"nf-fa-rocket", "nf-md-rocket", "nf-md-star_outline", "nf-fa-bell", "nf-fa-check"}) {
+		t.Errorf("compare tray = %v", pinned)
+	}
+
+	h.app.ClearCompare()
+	if view := h.app.GetCompare(); len(view.Glyphs) != 0 || len(view.Sizes) == 0 {
+		t.Errorf("after clearing: %d glyphs, sizes %v", len(view.Glyphs), view.Sizes)
+	}
+}
+
+func TestE2ESmartCopyRules(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	rules, err := h.app.ListCopyRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != len(defaultCopyRules) {
+		t.Fatalf("got %d copy rules, want the %d defaults", len(rules), len(defaultCopyRules))
+	}
+	if _, err := h.app.CreateCopyRule("firefox|chrom", CopyFormatHTML); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.app.CreateCopyRule("obsidian", "no-such-format"); errorCode(err) != ErrCodeInvalid {
+		t.Errorf("unknown format: got %v, want an invalid argument error", err)
+	}
+	if rules, err = h.app.ListCopyRules(); err != nil {
+		t.Fatal(err)
+	}
+
+	rocket, _ := h.app.findGlyph(h.glyphID("nf-fa-rocket"))
+	tests := []struct {
+		app  ForegroundApp
+		want string
+	}{
+		{ForegroundApp{Name: "Code", ID: "code"}, `\uF135`},
+		{ForegroundApp{Name: "Visual Studio Code", ID: `C:\Program Files\Microsoft VS Code\Code.exe`}, `\uF135`},
+		{ForegroundApp{Name: "Alacritty"}, rocket.Glyph},
+		{ForegroundApp{Name: "foot", ID: "/usr/bin/foot"}, rocket.Glyph},
+		{ForegroundApp{Name: "Firefox", ID: "org.mozilla.firefox"}, "&#xF135;"},
+		{ForegroundApp{Name: "Slack"}, ""},
+		{ForegroundApp{Name: "Barcode Scanner", ID: "barcode"}, ""},
+		{ForegroundApp{Name: "Bigfoot Tracker", ID: "/opt/footprints/bin/tracker"}, ""},
+	}
+	for _, tt := range tests {
+		rule, ok := matchCopyRule(rules, tt.app)
+		if !ok {
+			if tt.want != "" {
+				t.Errorf("%s: no rule matched", tt.app.Name)
+			}
+			continue
+		}
+		if tt.want == "" {
+			t.Errorf("%s: matched rule %q", tt.app.Name, rule.AppPattern)
+			continue
+		}
+		text, err := h.app.formatGlyphAs(rocket, rule.Format)
+		if err != nil || text != tt.want {
+			t.Errorf("%s: copied %q (%v), want %q", tt.app.Name, text, err, tt.want)
+		}
+	}
+}
+
+func TestE2EEscapeFormatOutsideTheBMP(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	rocket, _ := h.app.findGlyph(h.glyphID("nf-md-rocket"))
+
+	text, err := h.app.formatGlyphAs(rocket, CopyFormatEscape)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != `\uDB81\uDC63` {
+		t.Errorf("escape of U+F0463 = %s, want a UTF-16 surrogate pair", text)
+	}
+	var decoded string
+	if err := json.Unmarshal([]byte(`"`+text+`"`), &decoded); err != nil || decoded != rocket.Glyph {
+		t.Errorf("JSON reads %s as %q (%v), want %q", text, decoded, err, rocket.Glyph)
+	}
+	if runes := escapedRunes("icon: '" + text + "'"); !slices.Equal(runes, []rune{0xF0463}) {
+		t.Errorf("scanner reads %s as %U", text, runes)
+	}
+}
+
+func TestE2EReadOnlyProfileGetsNoSeedCopyRules(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	h.app.forceReadOnly = true
+	if err := h.app.openProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	if rules, err := h.app.ListCopyRules(); err != nil || len(rules) != 0 {
+		t.Errorf("read-only profile has copy rules %+v (%v), want none", rules, err)
+	}
+
+	h.app.forceReadOnly = false
+	if err := h.app.openProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := h.app.ListCopyRules()
+	if err != nil || len(rules) != len(defaultCopyRules) {
+		t.Fatalf("writable profile has %d copy rules (%v), want the defaults", len(rules), err)
+	}
+
+	// Rules the user deleted stay deleted
+	for _, r := range rules {
+		if err := h.app.DeleteCopyRule(r.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := h.app.openProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	if rules, _ := h.app.ListCopyRules(); len(rules) != 0 {
+		t.Errorf("deleted copy rules came back: %+v", rules)
+	}
+}
+
+func TestE2EJumpList(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	h.search(SearchRequest{Query: "rocket"})
+	h.search(SearchRequest{Query: "git merge"})
+	h.search(SearchRequest{Category: "fa"})
+
+	entries, err := h.app.GetJumpList(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	titles := make(map[string]bool)
+	for _, e := range entries {
+		titles[e.Title] = true
+
+		// Every entry's link opens it again
+		action, err := parseProtocolURL(e.URL)
+		if err != nil || action.Action != e.Kind || action.Value != e.Value {
+			t.Errorf("%s: link %s opens %+v (%v)", e.Title, e.URL, action, err)
+		}
+	}
+	for _, want := range []string{"Search: rocket", "Search: git merge", "Category: fa"} {
+		if !titles[want] {
+			t.Errorf("jump list %v lacks %q", entries, want)
+		}
+	}
+
+	if entries, _ = h.app.GetJumpList(1); len(entries) != 1 {
+		t.Errorf("GetJumpList(1) returned %d entries", len(entries))
+	}
+	if _, err := h.app.GetJumpList(-1); errorCode(err) != ErrCodeInvalid {
+		t.Errorf("negative limit: got %v, want an invalid argument error", err)
+	}
+}
+
+func TestE2ETaskbarProgress(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	if p := h.app.operationsProgress(); p.State != TaskbarProgressNone || p.badgeLabel() != "" {
+		t.Errorf("idle: %+v", p)
+	}
+
+	export := h.app.startOperation("export", "Exporting…")
+	if p := h.app.operationsProgress(); p.State != TaskbarProgressIndeterminate || p.Count != 1 {
+		t.Errorf("one indeterminate operation: %+v", p)
+	}
+	export.Progress(40, "")
+	if p := h.app.operationsProgress(); p.State != TaskbarProgressNormal || p.badgeLabel() != "40%" {
+		t.Errorf("one operation at 40%%: %+v, badge %q", p, p.badgeLabel())
+	}
+
+	// Indeterminate operations don't drag the mean down, and the badge counts operations
+	update := h.app.startOperation("update", "Updating…")
+	if p := h.app.operationsProgress(); p.Percent != 40 || p.badgeLabel() != "2" {
+		t.Errorf("two operations: %+v, badge %q", p, p.badgeLabel())
+	}
+
+	export.Succeed("Exported")
+	update.Fail("Update failed", nil)
+	if p := h.app.operationsProgress(); p.State != TaskbarProgressNone || p.Count != 0 {
+		t.Errorf("after both finished: %+v", p)
+	}
+}
+
+func TestE2EUninstallRemovesUserData(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "share"))
+
+	dir := t.TempDir()
+	for _, name := range []string{"gylte.db", "gylte.log", "notes.txt", "profiles/work.db", "exports/icons.json", "plugins/mine/plugin.json"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+		return err == nil
+	}
+	a := NewApp()
+
+	// Without --yes it only lists what would go
+	var out strings.Builder
+	if err := runCommand(a, "uninstall", []string{"--data-dir", dir}, &out, io.Discard); err == nil {
+		t.Error("uninstall without --yes succeeded")
+	}
+	if !strings.Contains(out.String(), "Would remove") || !exists("gylte.db") {
+		t.Errorf("uninstall without --yes: %q", out.String())
+	}
+
+	if err := runCommand(a, "uninstall", []string{"--yes", "--keep-data", "--data-dir", dir}, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if !exists("gylte.db") {
+		t.Error("--keep-data removed the database")
+	}
+
+	if err := runCommand(a, "uninstall", []string{"--yes", "--data-dir", dir}, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"gylte.db", "gylte.log", "profiles"} {
+		if exists(name) {
+			t.Errorf("%s survived uninstall", name)
+		}
+	}
+	// Files Gylte didn't create, and the user's exports and plugins, are left alone
+	for _, name := range []string{"notes.txt", "exports/icons.json", "plugins/mine/plugin.json"} {
+		if !exists(name) {
+			t.Errorf("uninstall removed %s", name)
+		}
+	}
+}
+
+func TestE2EUninstallRefusesOtherDirectories(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "exports", "report.pdf")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := runCommand(NewApp(), "uninstall", []string{"--yes", "--data-dir", dir}, io.Discard, io.Discard)
+	if errorCode(err) != ErrCodeNotFound {
+		t.Errorf("uninstall in a folder without gylte.db: got %v, want not found", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("uninstall touched an unrelated folder: %v", err)
+	}
+}
+
+func TestE2EFailedExportKeepsThePreviousFile(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	h.app.exporters.Register(exporterFunc{
+		name:       "broken",
+		extensions: []string{".txt"},
+		export: func(w io.Writer, glyphs []Glyph, opts ExportOptions) error {
+			io.WriteString(w, "partial")
+			return errors.New("disk full")
+		},
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "glyphs.txt")
+	if err := os.WriteFile(path, []byte("previous"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.app.ExportScope("all", "broken", path); err == nil {
+		t.Fatal("export succeeded")
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "previous" {
+		t.Errorf("failed export left %q", data)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("failed export left temporary files: %v", entries)
+	}
+}
+
+// writeTestPlugin creates a source plugin that answers after delay
+func writeTestPlugin(t *testing.T, pluginsDir, dir, name, glyphName string, delay time.Duration) {
+	t.Helper()
+	path := filepath.Join(pluginsDir, dir)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+	manifest := fmt.Sprintf(`{"name": %q, "command": ["sh", "run.sh"], "provides": ["source"]}`, name)
+	script := fmt.Sprintf("cat >/dev/null\nsleep %g\necho '{\"result\": [{\"name\": \"%s\", \"glyph\": \"A\"}]}'\n", delay.Seconds(), glyphName)
+	if err := os.WriteFile(filepath.Join(path, pluginManifestName), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "run.sh"), []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestE2EPluginsWithTheSameNameAreRejected(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	writeTestPlugin(t, h.app.pluginsDir(), "a", "icons", "x-a", 0)
+	writeTestPlugin(t, h.app.pluginsDir(), "b", "icons", "x-b", 0)
+	writeTestPlugin(t, h.app.pluginsDir(), "c", "other", "x-c", 0)
+	h.app.loadPlugins()
+
+	plugins := h.app.ListPlugins()
+	if len(plugins) != 3 {
+		t.Fatalf("plugins = %+v", plugins)
+	}
+	for _, p := range plugins[:2] {
+		if p.Name != "icons" || !p.Failed || !strings.Contains(p.LastError, "used by 2 plugins") {
+			t.Errorf("duplicate plugin listed as %+v", p)
+		}
+	}
+	if err := h.app.EnablePlugin("icons", true); err == nil {
+		t.Error("enabled a plugin whose name is shared")
+	}
+	if err := h.app.EnablePlugin("other", true); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestE2ESourcePluginsLoadConcurrently(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test plugins are shell scripts")
+	}
+	h := newHarness(t, "fixture.json")
+	writeTestPlugin(t, h.app.pluginsDir(), "slow-a", "slow-a", "x-slow-a", time.Second)
+	writeTestPlugin(t, h.app.pluginsDir(), "slow-b", "slow-b", "x-slow-b", time.Second)
+	h.app.loadPlugins()
+	if err := h.app.EnablePlugin("slow-a", true); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if err := h.app.EnablePlugin("slow-b", true); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 1800*time.Millisecond {
+		t.Errorf("rebuilding with two 1s plugins took %v", elapsed)
+	}
+	for _, name := range []string{"x-slow-a", "x-slow-b"} {
+		if _, ok := h.app.findGlyphByName(name); !ok {
+			t.Errorf("plugin glyph %s missing from the cache", name)
+		}
+	}
+}
+
+func TestE2EClearSearchHistoryReportsFailure(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	h.search(SearchRequest{Query: "rocket"})
+	if err := h.app.ClearSearchHistory(); err != nil {
+		t.Fatal(err)
+	}
+	if history := h.app.GetSearchHistory(); len(history) != 0 {
+		t.Errorf("history after clearing = %v", history)
+	}
+
+	h.app.userDB.Exec("DROP TABLE search_history")
+	if err := h.app.ClearSearchHistory(); err == nil {
+		t.Error("clearing succeeded without a history table")
+	}
+}
+
+func TestE2EDirectModeFavoritesExportsAndBulkEdits(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	if err := h.app.SetSearchMode(SearchModeDirect); err != nil {
+		t.Fatal(err)
+	}
+
+	h.favorite("nf-md-rocket")
+	favorites, err := h.app.GetFavorites(0, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(favorites) != 1 || favorites[0].Name != "nf-md-rocket" {
+		t.Errorf("favorites = %+v, want nf-md-rocket", favorites)
+	}
+
+	for _, scope := range []string{ScopeFavorites, ScopeCategoryPrefix + "fa"} {
+		path, err := h.app.ExportScope(scope, ExportFormatJSON, filepath.Join(h.dir, sanitizeFileName(scope)+".json"))
+		if err != nil {
+			t.Fatalf("export %s: %v", scope, err)
+		}
+		var exported []Glyph
+		h.readJSON(path, &exported)
+		if len(exported) == 0 {
+			t.Errorf("export of %s is empty", scope)
+		}
+	}
+
+	ids := []int{h.glyphID("nf-md-rocket"), h.glyphID("nf-fa-rocket")}
+	if n, err := h.app.BulkTag(ids, []string{"launch"}); err != nil || n != 2 {
+		t.Errorf("BulkTag = %d, %v; want 2 tagged", n, err)
+	}
+}
+
+func TestE2EBuiltinImportRecordsTheAppRelease(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+
+	result, err := h.app.ImportGlyphs("", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recent, err := h.app.GetRecentlyAddedGlyphs("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recent.ReleaseTag != "v"+version || len(recent.Glyphs) == 0 || result.Added == 0 {
+		t.Errorf("built-in import added %d glyphs; recently added = %s with %d glyphs, want v%s", result.Added, recent.ReleaseTag, len(recent.Glyphs), version)
+	}
+}
+
+func TestE2EReadOnlyStartupLeavesWidthsAlone(t *testing.T) {
+	h := newHarness(t, "fixture.json")
+	if _, err := h.app.db.Exec("UPDATE glyphs SET width = NULL"); err != nil {
+		t.Fatal(err)
+	}
+	unclassified := func() int {
+		var n int
+		if err := h.app.db.QueryRow("SELECT COUNT(*) FROM glyphs WHERE width IS NULL").Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	before := unclassified()
+
+	h.app.forceReadOnly = true
+	h.app.classifyGlyphWidths()
+	if n := unclassified(); n != before {
+		t.Errorf("read-only startup classified %d glyphs", before-n)
+	}
+
+	h.app.forceReadOnly = false
+	h.app.classifyGlyphWidths()
+	if n := unclassified(); n != 0 {
+		t.Errorf("%d glyphs left unclassified", n)
+	}
+}
+
+func TestE2ELocalAPITokenIsStableWhenSettingsCantBeSaved(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		setup func(h *harness)
+	}{
+		{"read-only", func(h *harness) {
+			h.app.forceReadOnly = true
+			h.app.settings.setReadOnly(true)
+		}},
+		{"unsaved", func(h *harness) {
+			if _, err := h.app.userDB.Exec("DROP TABLE settings"); err != nil {
+				t.Fatal(err)
+			}
+		}},
+	} {
+		h := newHarness(t, "fixture.json")
+		tc.setup(h)
+
+		token := h.app.GetLocalAPIStatus().Token
+		if again := h.app.GetLocalAPIStatus().Token; token == "" || again != token {
+			t.Fatalf("%s: status tokens %q and %q, want one stable token", tc.name, token, again)
+		}
+
+		handler := h.app.localAPIHandler()
+		for i := 0; i < 2; i++ {
+			req := httptest.NewRequest(http.MethodGet, "/api/status", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Errorf("%s: request %d with the status token: %d %s", tc.name, i, rec.Code, rec.Body)
+			}
+		}
+	}
+}
+
+func TestE2EMigrationPatchRunsWithPortableSed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the patch is a POSIX shell script")
+	}
+	root := t.TempDir()
+	path := filepath.Join(root, "config.lua")
+	if err := os.WriteFile(path, []byte("icon = '\uF501'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	patch := migrationPatch(root, []MigrationIssue{{Path: "config.lua", Match: "\uF501", ReplacementText: "\U000F0239"}})
+	script := filepath.Join(t.TempDir(), "patch.sh")
+	if err := os.WriteFile(script, []byte(patch), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("sh", script).CombinedOutput(); err != nil {
+		t.Fatalf("patch failed: %v\n%s\n%s", err, out, patch)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "icon = '\U000F0239'\n" {
+		t.Errorf("patched file = %q", data)
+	}
+	if _, err := os.Stat(path + ".bak"); !os.IsNotExist(err) {
+		t.Errorf("patch left a backup behind: %v", err)
+	}
+}
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// An encrypted profile is stored as <name>.db.enc next to the plaintext
+// profiles, so it can live in a synced folder. The file is
+//
+//	"GYLTEV01" | salt (16 bytes) | nonce (12 bytes) | AES-256-GCM ciphertext
+//
+// with the header as additional data and the key derived from the passphrase
+// with argon2id. While a profile is unlocked its database is decrypted to a
+// private directory under the user cache dir, never to the synced folder, and
+// sealed back on lock, on shutdown, and by the maintenance scheduler.
+const (
+	encryptedProfileExt = ".db.enc"
+	vaultMagic          = "GYLTEV01"
+	vaultSaltSize       = 16
+	vaultKeySize        = 32
+
+	// argon2id parameters of the GYLTEV01 format
+	vaultArgonTime    = 3
+	vaultArgonMemory  = 64 * 1024
+	vaultArgonThreads = 4
+
+	minPassphraseLength = 8
+)
+
+// Vault holds the key of the unlocked encrypted profile; at most one is unlocked at a time
+type Vault struct {
+	mu      sync.Mutex
+	profile string // "" while no profile is unlocked
+	key     []byte
+	salt    []byte
+}
+
+// EncryptionStatus reports whether the active profile is encrypted
+type EncryptionStatus struct {
+	Profile   string `json:"profile"`
+	Encrypted bool   `json:"encrypted"`
+	Unlocked  bool   `json:"unlocked"`
+	// Supported is false for the default profile, which shares gylte.db with the glyphs
+	Supported bool `json:"supported"`
+}
+
+// encryptedProfilePath returns the sealed database of a profile
+func (a *App) encryptedProfilePath(name string) string {
+	return filepath.Join(a.profilesDir(), name+encryptedProfileExt)
+}
+
+// profileEncrypted reports whether a profile is stored encrypted
+func (a *App) profileEncrypted(name string) bool {
+	if name == defaultProfile {
+		return false
+	}
+	_, err := os.Stat(a.encryptedProfilePath(name))
+	return err == nil
+}
+
+// unlockedDir holds the decrypted working copies of unlocked profiles
+func unlockedDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	return filepath.Join(cacheDir, "Gylte", "unlocked")
+}
+
+// unlockedProfilePath returns the decrypted working copy of a profile
+func unlockedProfilePath(name string) string {
+	return filepath.Join(unlockedDir(), name+".db")
+}
+
+// profileDBPath returns the database file openProfile should open for a
+// non-default profile, or a locked error if it is encrypted and locked
+func (a *App) profileDBPath(name string) (string, error) {
+	if !a.profileEncrypted(name) {
+		return a.profilePath(name), nil
+	}
+	a.vault.mu.Lock()
+	defer a.vault.mu.Unlock()
+	if a.vault.profile != name {
+		return "", newAppError(ErrCodeLocked, "profile %q is encrypted; unlock it first", name)
+	}
+	return unlockedProfilePath(name), nil
+}
+
+// deriveVaultKey stretches a passphrase into an AES-256 key
+func deriveVaultKey(passphrase string, salt []byte) []byte {
+	return argon2.IDKey([]byte(passphrase), salt, vaultArgonTime, vaultArgonMemory, vaultArgonThreads, vaultKeySize)
+}
+
+// sealVault encrypts data with a fresh nonce
+func sealVault(key, salt, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, len(vaultMagic)+len(salt)+gcm.NonceSize())
+	header = append(header, vaultMagic...)
+	header = append(header, salt...)
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	header = append(header, nonce...)
+	return gcm.Seal(header, nonce, data, header), nil
+}
+
+// openVault decrypts a sealed file, returning its contents and the derived key and salt
+func openVault(passphrase string, sealed []byte) (data, key, salt []byte, err error) {
+	headerSize := len(vaultMagic) + vaultSaltSize + 12
+	if len(sealed) < headerSize || !bytes.HasPrefix(sealed, []byte(vaultMagic)) {
+		return nil, nil, nil, fmt.Errorf("not an encrypted Gylte profile")
+	}
+	salt = sealed[len(vaultMagic) : len(vaultMagic)+vaultSaltSize]
+	key = deriveVaultKey(passphrase, salt)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	header := sealed[:headerSize]
+	data, err = gcm.Open(nil, header[len(vaultMagic)+vaultSaltSize:], sealed[headerSize:], header)
+	if err != nil {
+		return nil, nil, nil, newAppError(ErrCodeInvalid, "wrong passphrase")
+	}
+	return data, key, bytes.Clone(salt), nil
+}
+
+// writeFileAtomic replaces a file so a synced folder never sees a partial write
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// removeDatabaseFiles deletes a database and its WAL and shared-memory files
+func removeDatabaseFiles(path string) {
+	for _, p := range []string{path, path + "-wal", path + "-shm"} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			log.Printf("Failed to remove %s: %v", p, err)
+		}
+	}
+}
+
+// snapshotUserDB copies the open profile database to path as one consistent file
+func (a *App) snapshotUserDB(path string) ([]byte, error) {
+	removeDatabaseFiles(path)
+	if _, err := a.userDB.Exec("VACUUM INTO ?", path); err != nil {
+		return nil, fmt.Errorf("failed to snapshot profile: %w", err)
+	}
+	return os.ReadFile(path)
+}
+
+// sealProfile writes the unlocked profile back to its encrypted file
+func (a *App) sealProfile() error {
+	a.vault.mu.Lock()
+	name, key, salt := a.vault.profile, a.vault.key, a.vault.salt
+	a.vault.mu.Unlock()
+	if name == "" || name != a.profile || a.userDB == nil {
+		return nil
+	}
+
+	snapshot := filepath.Join(unlockedDir(), name+".snapshot.db")
+	data, err := a.snapshotUserDB(snapshot)
+	defer removeDatabaseFiles(snapshot)
+	if err != nil {
+		return err
+	}
+	sealed, err := sealVault(key, salt, data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt profile: %w", err)
+	}
+	if err := writeFileAtomic(a.encryptedProfilePath(name), sealed); err != nil {
+		return fmt.Errorf("failed to save encrypted profile: %w", err)
+	}
+	return nil
+}
+
+// forgetVault drops the key and deletes the decrypted working copy; the
+// profile must already be closed
+func (a *App) forgetVault() {
+	a.vault.mu.Lock()
+	name := a.vault.profile
+	a.vault.profile, a.vault.key, a.vault.salt = "", nil, nil
+	a.vault.mu.Unlock()
+	if name != "" {
+		removeDatabaseFiles(unlockedProfilePath(name))
+	}
+}
+
+// removeStaleUnlocked deletes working copies left behind by a crash
+func removeStaleUnlocked() {
+	if err := os.RemoveAll(unlockedDir()); err != nil {
+		log.Printf("Failed to remove decrypted profiles: %v", err)
+	}
+}
+
+// vaultUnlocked reports whether name is the unlocked encrypted profile
+func (a *App) vaultUnlocked(name string) bool {
+	a.vault.mu.Lock()
+	defer a.vault.mu.Unlock()
+	return name != "" && a.vault.profile == name
+}
+
+// GetEncryptionStatus reports whether the active profile is encrypted and unlocked
+func (a *App) GetEncryptionStatus() EncryptionStatus {
+	return EncryptionStatus{
+		Profile:   a.profile,
+		Encrypted: a.profileEncrypted(a.profile),
+		Unlocked:  a.vaultUnlocked(a.profile),
+		Supported: a.profile != defaultProfile,
+	}
+}
+
+// EncryptProfile moves the active profile from plaintext to an encrypted file
+// protected by passphrase. The plaintext database is deleted once the
+// encrypted copy is written; the profile stays unlocked.
+func (a *App) EncryptProfile(passphrase string) error {
+	if err := a.checkWritable("encrypt profiles"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+	name := a.profile
+	if name == defaultProfile {
+		return newAppError(ErrCodeInvalid, "the default profile shares gylte.db and can't be encrypted; create a profile for private data")
+	}
+	if a.profileEncrypted(name) {
+		return newAppError(ErrCodeConflict, "profile %q is already encrypted", name)
+	}
+	if len(passphrase) < minPassphraseLength {
+		return newAppError(ErrCodeInvalid, "passphrase must be at least %d characters", minPassphraseLength)
+	}
+	if err := os.MkdirAll(unlockedDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	op := a.startOperation("encrypt", "Encrypting profile…")
+	salt := make([]byte, vaultSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return op.Fail("Could not encrypt the profile", fmt.Errorf("failed to generate salt: %w", err))
+	}
+	key := deriveVaultKey(passphrase, salt)
+
+	// The working copy doubles as the data to seal
+	working := unlockedProfilePath(name)
+	data, err := a.snapshotUserDB(working)
+	if err != nil {
+		return op.Fail("Could not encrypt the profile", err)
+	}
+	sealed, err := sealVault(key, salt, data)
+	if err != nil {
+		return op.Fail("Could not encrypt the profile", err)
+	}
+	if err := writeFileAtomic(a.encryptedProfilePath(name), sealed); err != nil {
+		removeDatabaseFiles(working)
+		return op.Fail("Could not encrypt the profile", fmt.Errorf("failed to save encrypted profile: %w", err))
+	}
+
+	a.vault.mu.Lock()
+	a.vault.profile, a.vault.key, a.vault.salt = name, key, salt
+	a.vault.mu.Unlock()
+	if err := a.openProfile(name); err != nil {
+		return op.Fail("Could not encrypt the profile", err)
+	}
+	removeDatabaseFiles(a.profilePath(name))
+
+	log.Printf("Encrypted profile %s", name)
+	a.emit("profiles:changed", name)
+	op.Succeed("Profile encrypted")
+	return nil
+}
+
+// DecryptProfile moves the active, unlocked profile back to a plaintext database
+func (a *App) DecryptProfile(passphrase string) error {
+	if err := a.checkWritable("decrypt profiles"); err != nil {
+		return err
+	}
+	name := a.profile
+	if !a.profileEncrypted(name) {
+		return newAppError(ErrCodeInvalid, "profile %q isn't encrypted", name)
+	}
+	if !a.vaultUnlocked(name) {
+		return newAppError(ErrCodeLocked, "profile %q is locked", name)
+	}
+	a.vault.mu.Lock()
+	key, salt := a.vault.key, a.vault.salt
+	a.vault.mu.Unlock()
+	if subtle.ConstantTimeCompare(deriveVaultKey(passphrase, salt), key) != 1 {
+		return newAppError(ErrCodeInvalid, "wrong passphrase")
+	}
+
+	if _, err := a.snapshotUserDB(a.profilePath(name)); err != nil {
+		return err
+	}
+	if err := os.Remove(a.encryptedProfilePath(name)); err != nil {
+		removeDatabaseFiles(a.profilePath(name))
+		return fmt.Errorf("failed to remove encrypted profile: %w", err)
+	}
+	// Reopening switches from the working copy to the plaintext database
+	if err := a.openProfile(name); err != nil {
+		return err
+	}
+	a.forgetVault()
+
+	log.Printf("Decrypted profile %s", name)
+	a.emit("profiles:changed", name)
+	return nil
+}
+
+// UnlockProfile decrypts an encrypted profile and switches to it
+func (a *App) UnlockProfile(name, passphrase string) error {
+	if !a.profileEncrypted(name) {
+		return newAppError(ErrCodeNotFound, "encrypted profile %q not found", name)
+	}
+	if a.vaultUnlocked(name) {
+		return a.SwitchProfile(name)
+	}
+
+	sealed, err := os.ReadFile(a.encryptedProfilePath(name))
+	if err != nil {
+		return fmt.Errorf("failed to read encrypted profile: %w", err)
+	}
+	data, key, salt, err := openVault(passphrase, sealed)
+	if err != nil {
+		return err
+	}
+
+	// Only one profile is unlocked at a time
+	if err := a.LockProfile(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(unlockedDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	working := unlockedProfilePath(name)
+	removeDatabaseFiles(working)
+	if err := os.WriteFile(working, data, 0600); err != nil {
+		return fmt.Errorf("failed to decrypt profile: %w", err)
+	}
+
+	a.vault.mu.Lock()
+	a.vault.profile, a.vault.key, a.vault.salt = name, key, salt
+	a.vault.mu.Unlock()
+	if err := a.SwitchProfile(name); err != nil {
+		a.forgetVault()
+		return err
+	}
+	log.Printf("Unlocked profile %s", name)
+	return nil
+}
+
+// LockProfile seals the unlocked profile, forgets its key, and switches to the
+// default profile if it was active
+func (a *App) LockProfile() error {
+	a.vault.mu.Lock()
+	name := a.vault.profile
+	a.vault.mu.Unlock()
+	if name == "" {
+		return nil
+	}
+
+	if a.profile == name {
+		// Leaving the profile seals it
+		if err := a.openProfile(defaultProfile); err != nil {
+			return err
+		}
+		a.emit("profile:changed", defaultProfile)
+	}
+	a.forgetVault()
+	log.Printf("Locked profile %s", name)
+	return nil
+}
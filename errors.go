@@ -0,0 +1,127 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+// Error codes sent to the frontend with every failed binding call. The
+// frontend branches on the code; the message is for display.
+const (
+	// ErrCodeDBMissing: gylte.db or the profile database isn't open
+	ErrCodeDBMissing = "db_missing"
+	// ErrCodeNotFound: a glyph, collection, profile, file, or other named thing doesn't exist
+	ErrCodeNotFound = "not_found"
+	// ErrCodeReadOnly: the change is blocked by read-only mode
+	ErrCodeReadOnly = "readonly"
+	// ErrCodeIO: reading or writing a file failed
+	ErrCodeIO = "io_error"
+	// ErrCodeInvalid: an argument was out of range, malformed, or unsupported
+	ErrCodeInvalid = "invalid_argument"
+	// ErrCodeConflict: the thing being created or attached already exists
+	ErrCodeConflict = "conflict"
+	// ErrCodeLocked: the profile is encrypted and must be unlocked first
+	ErrCodeLocked = "locked"
+	// ErrCodeRuntime: a window, dialog, or clipboard operation failed
+	ErrCodeRuntime = "runtime"
+	// ErrCodeUnsupported: the feature isn't available on this OS or build (see GetCapabilities)
+	ErrCodeUnsupported = "unsupported"
+	// ErrCodeInternal: anything else; the message explains what went wrong
+	ErrCodeInternal = "internal"
+)
+
+// errorCodeDescriptions documents the error codes for GetErrorCodes
+var errorCodeDescriptions = map[string]string{
+	ErrCodeDBMissing:   "The glyph or profile database isn't open",
+	ErrCodeNotFound:    "The requested glyph, collection, file, or setting doesn't exist",
+	ErrCodeReadOnly:    "Read-only mode blocks this change",
+	ErrCodeIO:          "Reading or writing a file failed",
+	ErrCodeInvalid:     "An argument was out of range, malformed, or unsupported",
+	ErrCodeConflict:    "It already exists",
+	ErrCodeLocked:      "The profile is encrypted and locked",
+	ErrCodeRuntime:     "A window, dialog, or clipboard operation failed",
+	ErrCodeUnsupported: "The feature isn't available on this OS or build",
+	ErrCodeInternal:    "Something else went wrong",
+}
+
+// AppError is the error every bound method reports to the frontend
+type AppError struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+	Err     error             `json:"-"`
+}
+
+func (e *AppError) Error() string {
+	return e.Message
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// newAppError formats an error with a code; like fmt.Errorf, %w wraps a cause
+func newAppError(code, format string, args ...any) *AppError {
+	err := fmt.Errorf(format, args...)
+	return &AppError{Code: code, Message: err.Error(), Err: errors.Unwrap(err)}
+}
+
+// errorCode returns the code of an AppError in err's chain, or "" if there is none
+func errorCode(err error) string {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return ""
+}
+
+// toAppError classifies any error returned by a binding. Errors built with
+// newAppError keep their code; the others are recognized by their cause.
+func toAppError(err error) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		if appErr.Error() == err.Error() {
+			return appErr
+		}
+		// Keep the outer context, e.g. "failed to export: glyph 3 not found"
+		return &AppError{Code: appErr.Code, Message: err.Error(), Details: appErr.Details, Err: err}
+	}
+
+	var readOnly *ReadOnlyError
+	if errors.As(err, &readOnly) {
+		return &AppError{Code: ErrCodeReadOnly, Message: err.Error(), Details: map[string]string{"operation": readOnly.Operation}, Err: err}
+	}
+	var runtimeErr *RuntimeError
+	if errors.As(err, &runtimeErr) {
+		return &AppError{Code: ErrCodeRuntime, Message: runtimeErr.Message, Details: map[string]string{"operation": runtimeErr.Operation}, Err: err}
+	}
+
+	code := ErrCodeInternal
+	var pathErr *fs.PathError
+	switch {
+	case errors.Is(err, fs.ErrNotExist), errors.Is(err, sql.ErrNoRows):
+		code = ErrCodeNotFound
+	case errors.Is(err, fs.ErrPermission), errors.As(err, &pathErr):
+		code = ErrCodeIO
+	}
+	return &AppError{Code: code, Message: err.Error(), Err: err}
+}
+
+// ErrorCodeInfo describes an error code
+type ErrorCodeInfo struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// GetErrorCodes lists the error codes bound methods may return
+func (a *App) GetErrorCodes() []ErrorCodeInfo {
+	codes := make([]ErrorCodeInfo, 0, len(errorCodeDescriptions))
+	for code, description := range errorCodeDescriptions {
+		codes = append(codes, ErrorCodeInfo{Code: code, Description: description})
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+	return codes
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Events broadcast to WebSocket clients of the local API
+const (
+	BridgeEventGlyphCopied     = "glyph.copied"
+	BridgeEventFavoriteChanged = "favorite.changed"
+	BridgeEventSearch          = "search.performed"
+)
+
+const (
+	// bridgeQueueSize is how many events a client may fall behind before it is dropped
+	bridgeQueueSize = 64
+
+	bridgeWriteTimeout = 5 * time.Second
+	bridgePingInterval = 30 * time.Second
+)
+
+// BridgeEvent is the JSON message sent to WebSocket clients
+type BridgeEvent struct {
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+	Data  any       `json:"data,omitempty"`
+}
+
+// bridgeClient is one connected WebSocket
+type bridgeClient struct {
+	conn *websocket.Conn
+	send chan []byte
+}
+
+// EventBridge fans app events out to WebSocket clients such as stream decks,
+// status bars, and OBS overlays
+type EventBridge struct {
+	mu      sync.Mutex
+	clients map[*bridgeClient]bool
+}
+
+// bridgeUpgrader accepts connections from any origin; the API token is what
+// authorizes a client, and browser sources often have a file:// or null origin
+var bridgeUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// count returns the number of connected clients
+func (b *EventBridge) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.clients)
+}
+
+// add registers a client
+func (b *EventBridge) add(c *bridgeClient) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.clients == nil {
+		b.clients = make(map[*bridgeClient]bool)
+	}
+	b.clients[c] = true
+}
+
+// remove unregisters a client and closes its queue
+func (b *EventBridge) remove(c *bridgeClient) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.clients[c] {
+		delete(b.clients, c)
+		close(c.send)
+	}
+}
+
+// closeAll disconnects every client
+func (b *EventBridge) closeAll() {
+	b.mu.Lock()
+	clients := b.clients
+	b.clients = nil
+	b.mu.Unlock()
+
+	for c := range clients {
+		close(c.send)
+	}
+}
+
+// publish queues a message for every client, dropping clients that fell behind
+func (b *EventBridge) publish(msg []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for c := range b.clients {
+		select {
+		case c.send <- msg:
+		default:
+			log.Printf("Event bridge: dropping slow client %s", c.conn.RemoteAddr())
+			delete(b.clients, c)
+			close(c.send)
+		}
+	}
+}
+
+// broadcast sends an event to WebSocket clients of the local API
+func (a *App) broadcast(event string, data any) {
+	if a.bridge.count() == 0 {
+		return
+	}
+	msg, err := json.Marshal(BridgeEvent{Event: event, Time: time.Now(), Data: data})
+	if err != nil {
+		log.Printf("Failed to encode %s event: %v", event, err)
+		return
+	}
+	a.bridge.publish(msg)
+}
+
+// broadcastGlyph sends an event about a glyph, with extra fields merged into its data
+func (a *App) broadcastGlyph(event string, glyphID int, extra map[string]any) {
+	if a.bridge.count() == 0 {
+		return
+	}
+	g, ok := a.findGlyph(glyphID)
+	if !ok {
+		return
+	}
+	data := map[string]any{
+		"id":        g.ID,
+		"name":      g.Name,
+		"glyph":     g.Glyph,
+		"codepoint": formatCodepoint(codepointOf(g.Glyph)),
+		"source":    g.Source,
+	}
+	for k, v := range extra {
+		data[k] = v
+	}
+	a.broadcast(event, data)
+}
+
+// serveEvents upgrades a request to a WebSocket and streams events until the client leaves
+func (a *App) serveEvents(w http.ResponseWriter, r *http.Request) {
+	conn, err := bridgeUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Event bridge: upgrade failed: %v", err)
+		return
+	}
+
+	c := &bridgeClient{conn: conn, send: make(chan []byte, bridgeQueueSize)}
+	a.bridge.add(c)
+	log.Printf("Event bridge: %s connected", conn.RemoteAddr())
+
+	// Clients only listen; reading detects disconnects and handles pongs
+	go func() {
+		defer a.bridge.remove(c)
+		conn.SetReadLimit(1024)
+		conn.SetReadDeadline(time.Now().Add(2 * bridgePingInterval))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(2 * bridgePingInterval))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(bridgePingInterval)
+	defer func() {
+		ping.Stop()
+		conn.Close()
+		log.Printf("Event bridge: %s disconnected", conn.RemoteAddr())
+	}()
+
+	for {
+		select {
+		case msg, ok := <-c.send:
+			conn.SetWriteDeadline(time.Now().Add(bridgeWriteTimeout))
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""))
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				a.bridge.remove(c)
+				return
+			}
+		case <-ping.C:
+			conn.SetWriteDeadline(time.Now().Add(bridgeWriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				a.bridge.remove(c)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// hasRuntime reports whether the app is running inside the Wails runtime.
+// Wails stores its event bus on the startup context; without it runtime calls abort.
+func (a *App) hasRuntime() bool {
+	return a.ctx != nil && a.ctx.Value("events") != nil
+}
+
+// emit sends an event to the frontend, doing nothing when running headless
+func (a *App) emit(name string, data ...interface{}) {
+	if !a.hasRuntime() {
+		return
+	}
+	runtime.EventsEmit(a.ctx, name, data...)
+}
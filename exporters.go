@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ExportOptions carries what an exporter may need beyond the glyphs
+type ExportOptions struct {
+	// Title names the exported set in headings and comments, e.g. "Favorites"
+	Title string
+
+	// FontPath is the Nerd Font to render or embed; empty when none was found
+	FontPath string
+
+	// RenderGlyph draws a glyph as a square PNG of size pixels, black on
+	// transparent; nil when no Nerd Font was found
+	RenderGlyph func(g Glyph, size int) ([]byte, error)
+
+	// Attribution lists the icon sets in the export whose licenses require
+	// crediting their authors; exporters that can carry comments include it
+	Attribution []IconSetLicense
+
+	// Progress reports a step of a long export; it may be nil
+	Progress func(message string)
+}
+
+// progress reports a step if the caller asked for progress
+func (o ExportOptions) progress(message string) {
+	if o.Progress != nil {
+		o.Progress(message)
+	}
+}
+
+// Exporter writes glyphs in one file format
+type Exporter interface {
+	// Name identifies the format, e.g. "csv"
+	Name() string
+
+	// Extensions lists the file extensions for the format, preferred first
+	Extensions() []string
+
+	// Export writes glyphs to w
+	Export(w io.Writer, glyphs []Glyph, opts ExportOptions) error
+}
+
+// exporterFunc adapts a function to the Exporter interface
+type exporterFunc struct {
+	name       string
+	extensions []string
+	export     func(w io.Writer, glyphs []Glyph, opts ExportOptions) error
+}
+
+func (e exporterFunc) Name() string         { return e.name }
+func (e exporterFunc) Extensions() []string { return e.extensions }
+func (e exporterFunc) Export(w io.Writer, glyphs []Glyph, opts ExportOptions) error {
+	return e.export(w, glyphs, opts)
+}
+
+// ExporterInfo describes an export format for save dialogs and menus
+type ExporterInfo struct {
+	Name       string   `json:"name"`
+	Extensions []string `json:"extensions"`
+}
+
+// ExporterRegistry holds every export format features have registered
+type ExporterRegistry struct {
+	mu        sync.RWMutex
+	exporters map[string]Exporter
+}
+
+// NewExporterRegistry creates an empty registry
+func NewExporterRegistry() *ExporterRegistry {
+	return &ExporterRegistry{exporters: make(map[string]Exporter)}
+}
+
+// Register adds an exporter, replacing any existing exporter with the same name
+func (r *ExporterRegistry) Register(e Exporter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exporters[e.Name()] = e
+}
+
+// Unregister removes an export format, e.g. when its plugin is disabled
+func (r *ExporterRegistry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.exporters, name)
+}
+
+// Get returns the exporter for a format
+func (r *ExporterRegistry) Get(name string) (Exporter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, ok := r.exporters[name]
+	if !ok {
+		return nil, newAppError(ErrCodeInvalid, "unknown export format: %s", name)
+	}
+	return e, nil
+}
+
+// List returns all export formats sorted by name
+func (r *ExporterRegistry) List() []ExporterInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]ExporterInfo, 0, len(r.exporters))
+	for _, e := range r.exporters {
+		result = append(result, ExporterInfo{Name: e.Name(), Extensions: e.Extensions()})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// sidecarAttributionFormats can't carry comments, so their credits go to a
+// text file next to the export
+var sidecarAttributionFormats = map[string]bool{
+	ExportFormatJSON: true,
+	ExportFormatCSV:  true,
+}
+
+// registerExporters registers the built-in export formats
+func (a *App) registerExporters() {
+	for _, e := range []Exporter{
+		searchExporter(ExportFormatJSON),
+		searchExporter(ExportFormatCSV),
+		searchExporter(ExportFormatMarkdown),
+		pdfCheatSheetExporter,
+		htmlCheatSheetExporter,
+		snippetExporter(SnippetToolEspanso),
+		snippetExporter(SnippetToolAutoHotkey),
+		cssExporter,
+		scssExporter,
+		goConstExporter,
+		typeScriptConstExporter,
+	} {
+		a.exporters.Register(e)
+	}
+}
+
+// exportGlyphs is the shared export pipeline: it writes glyphs with the named
+// exporter to path (the exports directory when empty, named after baseName)
+// and reports progress and the outcome through op
+func (a *App) exportGlyphs(op *Operation, format, baseName, path, title string, glyphs []Glyph) (string, error) {
+	exporter, err := a.exporters.Get(format)
+	if err != nil {
+		return "", op.Fail("Could not export", err)
+	}
+	if len(glyphs) == 0 {
+		return "", op.Fail("Nothing to export", newAppError(ErrCodeNotFound, "%s has no glyphs", title))
+	}
+
+	if path == "" {
+		path = a.defaultExportPath(baseName, exporter.Extensions()[0])
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", op.Fail("Could not export", fmt.Errorf("failed to create directory: %w", err))
+	}
+
+	opts := ExportOptions{
+		Title:    title,
+		Progress: func(message string) { op.Progress(-1, message) },
+	}
+	if fontPath, err := a.nerdFontPath(); err == nil {
+		opts.FontPath = fontPath
+		opts.RenderGlyph = func(g Glyph, size int) ([]byte, error) {
+			return a.renderGlyphPNG(g, RenderOptions{Size: size, Foreground: color.Black, Padding: 0.05})
+		}
+	}
+	if opts.Attribution, err = a.exportAttribution(glyphs); err != nil {
+		log.Printf("Exporting without attribution: %v", err)
+	}
+
+	err = writeExportFile(path, func(w io.Writer) error {
+		return exporter.Export(w, glyphs, opts)
+	})
+	if err != nil {
+		return "", op.Fail("Could not export", fmt.Errorf("failed to write %s: %w", format, err))
+	}
+	if sidecarAttributionFormats[format] && len(opts.Attribution) > 0 {
+		sidecar := strings.TrimSuffix(path, filepath.Ext(path)) + ".attribution.txt"
+		if err := writeExportBytes(sidecar, []byte(attributionText(title, opts.Attribution))); err != nil {
+			return "", op.Fail("Could not export", fmt.Errorf("failed to write attribution: %w", err))
+		}
+	}
+
+	log.Printf("Exported %d glyphs from %s as %s to %s", len(glyphs), title, format, path)
+	op.Succeed(fmt.Sprintf("Exported %d glyphs", len(glyphs)))
+	return path, nil
+}
+
+// ListExporters returns the available export formats
+func (a *App) ListExporters() []ExporterInfo {
+	return a.exporters.List()
+}
+
+// ExportScope writes the glyphs of a scope ("all", "favorites", "collection:<name>",
+// "category:<name>", "smart:<name>") in any registered format and returns the file path.
+// An empty path exports to the default exports directory.
+func (a *App) ExportScope(scope, format, path string) (string, error) {
+	op := a.startOperation("export", "Exporting glyphs…")
+
+	glyphs, err := a.scopeGlyphs(scope)
+	if err != nil {
+		return "", op.Fail("Could not export", err)
+	}
+	title := scopeTitle(scope)
+	return a.exportGlyphs(op, format, sanitizeFileName(title), path, title, glyphs)
+}
+
+// ChooseExportPath asks where to save an export, offering the format's extensions
+// in the dialog; it returns "" if the user cancels
+func (a *App) ChooseExportPath(format, defaultName string) (string, error) {
+	exporter, err := a.exporters.Get(format)
+	if err != nil {
+		return "", err
+	}
+	if err := a.requireWindow("dialog"); err != nil {
+		return "", err
+	}
+
+	patterns := make([]string, len(exporter.Extensions()))
+	for i, ext := range exporter.Extensions() {
+		patterns[i] = "*" + ext
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:            "Export as " + format,
+		DefaultDirectory: a.exportsDir(),
+		DefaultFilename:  sanitizeFileName(defaultName) + exporter.Extensions()[0],
+		Filters: []runtime.FileFilter{{
+			DisplayName: fmt.Sprintf("%s (%s)", strings.ToUpper(format), strings.Join(patterns, ", ")),
+			Pattern:     strings.Join(patterns, ";"),
+		}},
+	})
+	if err != nil {
+		return "", a.runtimeFailure("dialog", fmt.Sprintf("Couldn't open the save dialog: %v", err), err)
+	}
+	return path, nil
+}
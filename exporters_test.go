@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+var exportTestGlyphs = []Glyph{
+	{ID: 1, Name: "nf-fa-rocket", Glyph: "\uf135", Category: "fa"},
+	{ID: 2, Name: "nf-md-rocket", Glyph: "\U000F0463", Category: "md"},
+	{ID: 3, Name: "nf-custom-a|b", Glyph: "\ue000`", Category: "custom"},
+}
+
+func TestExportersWriteEveryFormat(t *testing.T) {
+	// Each format must mention the glyphs in its own way
+	want := map[string]string{
+		ExportFormatJSON:      `"name":"nf-md-rocket"`,
+		ExportFormatCSV:       "nf-md-rocket,",
+		ExportFormatMarkdown:  "`nf-md-rocket`",
+		"pdf":                 "%PDF",
+		"html":                "nf-md-rocket",
+		SnippetToolEspanso:    `":md-rocket:"`,
+		SnippetToolAutoHotkey: ":*T:`:md-rocket`:::",
+		"css":                 `.nf-md-rocket::before { content: "\f0463"; }`,
+		"scss":                `"nf-fa-rocket": "\f135",`,
+		"go":                  `NfMdRocket = "\U000f0463" // nf-md-rocket`,
+		"typescript":          `"nf-md-rocket": "\u{f0463}",`,
+	}
+
+	registry := NewApp().exporters
+	formats := registry.List()
+	if len(formats) != len(want) {
+		t.Errorf("registered %d formats, want %d", len(formats), len(want))
+	}
+	for _, info := range formats {
+		exporter, err := registry.Get(info.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(info.Extensions) == 0 || !strings.HasPrefix(info.Extensions[0], ".") {
+			t.Errorf("%s: extensions = %v", info.Name, info.Extensions)
+		}
+
+		var buf bytes.Buffer
+		if err := exporter.Export(&buf, exportTestGlyphs, ExportOptions{Title: "test"}); err != nil {
+			t.Errorf("%s: %v", info.Name, err)
+			continue
+		}
+		if !strings.Contains(buf.String(), want[info.Name]) {
+			t.Errorf("%s output lacks %q:\n%s", info.Name, want[info.Name], buf.String())
+		}
+	}
+}
+
+func TestGoConstExportParses(t *testing.T) {
+	glyphs := append(exportTestGlyphs, Glyph{Name: "nf-fa-rocket", Glyph: "x"}, Glyph{Name: "9-lives", Glyph: "y"})
+
+	var buf bytes.Buffer
+	if err := goConstExporter.Export(&buf, glyphs, ExportOptions{Title: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "glyphs.go", buf.Bytes(), 0); err != nil {
+		t.Fatalf("generated Go doesn't parse: %v\n%s", err, buf.String())
+	}
+	for _, name := range []string{"NfFaRocket2 =", "G9Lives ="} {
+		if !strings.Contains(buf.String(), name) {
+			t.Errorf("generated Go lacks %q:\n%s", name, buf.String())
+		}
+	}
+}
+
+func TestExportersIncludeAttribution(t *testing.T) {
+	a := NewApp()
+	attribution, err := a.exportAttribution(exportTestGlyphs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attribution) != 3 {
+		t.Fatalf("attribution = %+v, want fa, md, and custom", attribution)
+	}
+
+	opts := ExportOptions{Title: "test", Attribution: attribution}
+	for _, format := range []string{ExportFormatMarkdown, "html", SnippetToolEspanso, SnippetToolAutoHotkey, "css", "scss", "go", "typescript"} {
+		exporter, err := a.exporters.Get(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := exporter.Export(&buf, exportTestGlyphs, opts); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if !strings.Contains(buf.String(), "Material Design: Material Design Icons by Pictogrammers (Apache-2.0") {
+			t.Errorf("%s output lacks the Material Design credit:\n%s", format, buf.String())
+		}
+		if format == "go" {
+			if _, err := parser.ParseFile(token.NewFileSet(), "glyphs.go", buf.Bytes(), 0); err != nil {
+				t.Errorf("generated Go with attribution doesn't parse: %v", err)
+			}
+		}
+	}
+}
+
+func TestExporterRegistryUnknownFormat(t *testing.T) {
+	if _, err := NewExporterRegistry().Get("docx"); err == nil {
+		t.Error("Get of an unregistered format succeeded")
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// exportsDir returns the default directory for exported files
+func (a *App) exportsDir() string {
+	return filepath.Join(a.dataDir(), "exports")
+}
+
+// defaultExportPath builds a timestamped file name in the exports directory
+func (a *App) defaultExportPath(name, ext string) string {
+	return filepath.Join(a.exportsDir(), fmt.Sprintf("%s-%s%s", name, time.Now().Format("20060102-150405"), ext))
+}
+
+// sanitizeFileName replaces characters that aren't safe in file names (e.g. "My Icons/2" -> "My-Icons-2")
+func sanitizeFileName(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, name)
+	return strings.Trim(safe, "-")
+}
+
+// writeJSONFile writes v as indented JSON, creating parent directories
+func writeJSONFile(path string, v interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeExportBytes(path, data)
+}
+
+// writeExportFile writes an export through a temporary file in the same
+// directory and renames it into place, so a failed export leaves neither a
+// truncated file nor the temporary file behind
+func writeExportFile(path string, write func(w io.Writer) error) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+	if err = write(w); err != nil {
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	// CreateTemp makes the file private; exports are ordinary documents
+	if err = f.Chmod(0644); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// writeExportBytes is writeExportFile for an export already in memory
+func writeExportBytes(path string, data []byte) error {
+	return writeExportFile(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// ExportFavorites writes all favorites to a JSON file and returns its path.
+// An empty path exports to the default exports directory.
+func (a *App) ExportFavorites(path string) (string, error) {
+	op := a.startOperation("export", "Exporting favorites…")
+
+	favorites := a.favoriteGlyphs()
+
+	if path == "" {
+		path = a.defaultExportPath("favorites", ".json")
+	}
+
+	if err := writeJSONFile(path, favorites); err != nil {
+		return "", op.Fail("Could not export favorites", fmt.Errorf("failed to export favorites: %w", err))
+	}
+
+	log.Printf("Exported %d favorites to %s", len(favorites), path)
+	op.Succeed(fmt.Sprintf("Exported %d favorites", len(favorites)))
+	return path, nil
+}
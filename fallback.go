@@ -0,0 +1,98 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"Gylte/internal/glyphdata"
+)
+
+// embeddedGlyphs is the generator's source data, used when gylte.db is unavailable
+//
+//go:embed db_generator/glyphs.json
+var embeddedGlyphs []byte
+
+// Data modes reported by GetDataStatus
+const (
+	DataModeDatabase = "database"
+	DataModeFallback = "fallback"
+)
+
+// DataStatus tells the frontend where glyphs come from and which features work
+type DataStatus struct {
+	Mode               string `json:"mode"`
+	Message            string `json:"message,omitempty"`
+	FavoritesAvailable bool   `json:"favoritesAvailable"`
+}
+
+// glyphEntry is one record of the generator's glyphs.json format
+type glyphEntry struct {
+	Name  string `json:"name"`
+	Glyph string `json:"glyph"`
+}
+
+// loadEmbeddedGlyphs parses the embedded glyph list, assigning ids in name order
+func loadEmbeddedGlyphs() ([]Glyph, error) {
+	var raw []glyphEntry
+	if err := json.Unmarshal(embeddedGlyphs, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded glyphs: %w", err)
+	}
+
+	sort.SliceStable(raw, func(i, j int) bool {
+		return raw[i].Name < raw[j].Name
+	})
+
+	glyphs := make([]Glyph, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, r := range raw {
+		if seen[r.Name] {
+			continue
+		}
+		seen[r.Name] = true
+
+		g := Glyph{ID: len(glyphs) + 1, Name: r.Name, Glyph: r.Glyph, Source: "embedded", Block: glyphdata.BlockOf(codepointOf(r.Glyph))}
+		g.Description = describeGlyph(g)
+		glyphs = append(glyphs, g)
+	}
+	return glyphs, nil
+}
+
+// startFallback serves search from the embedded glyph list because the database failed to open
+func (a *App) startFallback(reason error) {
+	glyphs, err := loadEmbeddedGlyphs()
+	if err != nil {
+		log.Printf("Failed to load fallback glyphs: %v", err)
+		return
+	}
+
+	a.fallbackReason = reason.Error()
+	a.cache.Swap(newCacheSnapshot(glyphs))
+
+	log.Printf("Database unavailable (%v); serving %d embedded glyphs", reason, len(glyphs))
+	a.emit("database:fallback", a.GetDataStatus())
+}
+
+// requireUserDB returns an error explaining why user data can't be changed in fallback mode
+func (a *App) requireUserDB() error {
+	if a.userDB != nil {
+		return nil
+	}
+	if a.fallbackReason != "" {
+		return newAppError(ErrCodeDBMissing, "favorites and collections are unavailable without gylte.db: %s", a.fallbackReason)
+	}
+	return newAppError(ErrCodeDBMissing, "database not open")
+}
+
+// GetDataStatus reports whether glyphs come from the database or the embedded fallback
+func (a *App) GetDataStatus() DataStatus {
+	if a.fallbackReason != "" {
+		return DataStatus{
+			Mode:    DataModeFallback,
+			Message: "gylte.db could not be opened, so built-in glyph data is shown. Favorites and collections are disabled. (" + a.fallbackReason + ")",
+		}
+	}
+	return DataStatus{Mode: DataModeDatabase, FavoritesAvailable: a.userDB != nil}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// FavoritesStore holds the active profile's favorite glyph ids. It is the only
+// way App reads or changes favorites; implementations are safe for concurrent
+// use and never call back into App, so callers may use them without holding
+// any other lock.
+type FavoritesStore interface {
+	// Open switches the store to a profile's database and loads its favorites
+	Open(db *sql.DB) error
+
+	// Contains reports whether a glyph is a favorite
+	Contains(id int) bool
+
+	// Count returns the number of favorites
+	Count() int
+
+	// Snapshot returns a copy of the favorite ids, safe to read after other changes
+	Snapshot() map[int]bool
+
+	// Toggle adds or removes a favorite and returns whether it is now a favorite
+	Toggle(id int) (bool, error)
+
+	// Add favorites glyphs in one transaction and returns the ids that weren't already favorites
+	Add(ids []int) ([]int, error)
+
+	// Remove unfavorites glyphs in one transaction
+	Remove(ids []int) error
+}
+
+// sqliteFavorites keeps favorites in memory for fast lookups during search and
+// writes every change through to the favorites table. The lock is held across
+// the write so the map and the table never disagree; writes made during a
+// glyph import are queued in writes and reach the table once it finishes.
+type sqliteFavorites struct {
+	mu     sync.RWMutex
+	ids    map[int]bool
+	db     *sql.DB
+	writes *WriteQueue
+}
+
+// newSQLiteFavorites returns an empty store writing through writes; Open
+// loads a profile into it
+func newSQLiteFavorites(writes *WriteQueue) *sqliteFavorites {
+	return &sqliteFavorites{ids: make(map[int]bool), writes: writes}
+}
+
+func (s *sqliteFavorites) Open(db *sql.DB) error {
+	ids := make(map[int]bool)
+	if db != nil {
+		rows, err := db.Query("SELECT glyph_id FROM favorites")
+		if err != nil {
+			return fmt.Errorf("failed to load favorites: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				continue
+			}
+			ids[id] = true
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to load favorites: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.db = db
+	s.ids = ids
+	log.Printf("Loaded %d favorites", len(ids))
+	return nil
+}
+
+func (s *sqliteFavorites) Contains(id int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ids[id]
+}
+
+func (s *sqliteFavorites) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.ids)
+}
+
+func (s *sqliteFavorites) Snapshot() map[int]bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make(map[int]bool, len(s.ids))
+	for id := range s.ids {
+		ids[id] = true
+	}
+	return ids
+}
+
+func (s *sqliteFavorites) Toggle(id int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return false, newAppError(ErrCodeDBMissing, "favorites are not available")
+	}
+	if s.ids[id] {
+		if err := s.writes.Exec(s.db, "DELETE FROM favorites WHERE glyph_id = ?", id); err != nil {
+			return true, fmt.Errorf("failed to remove favorite: %w", err)
+		}
+		delete(s.ids, id)
+		return false, nil
+	}
+	if err := s.writes.Exec(s.db, "INSERT INTO favorites (glyph_id) VALUES (?)", id); err != nil {
+		return false, fmt.Errorf("failed to add favorite: %w", err)
+	}
+	s.ids[id] = true
+	return true, nil
+}
+
+func (s *sqliteFavorites) Add(ids []int) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return nil, newAppError(ErrCodeDBMissing, "favorites are not available")
+	}
+	added := []int{}
+	var writes []queuedWrite
+	for _, id := range ids {
+		if s.ids[id] || containsInt(added, id) {
+			continue
+		}
+		writes = append(writes, queuedWrite{"INSERT INTO favorites (glyph_id) VALUES (?)", []any{id}})
+		added = append(added, id)
+	}
+	if len(writes) > 0 {
+		if err := s.writes.ExecBatch(s.db, writes); err != nil {
+			return nil, fmt.Errorf("failed to add favorites: %w", err)
+		}
+	}
+	for _, id := range added {
+		s.ids[id] = true
+	}
+	return added, nil
+}
+
+func (s *sqliteFavorites) Remove(ids []int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return newAppError(ErrCodeDBMissing, "favorites are not available")
+	}
+	writes := make([]queuedWrite, len(ids))
+	for i, id := range ids {
+		writes[i] = queuedWrite{"DELETE FROM favorites WHERE glyph_id = ?", []any{id}}
+	}
+	if len(writes) > 0 {
+		if err := s.writes.ExecBatch(s.db, writes); err != nil {
+			return fmt.Errorf("failed to remove favorites: %w", err)
+		}
+	}
+	for _, id := range ids {
+		delete(s.ids, id)
+	}
+	return nil
+}
+
+// containsInt reports whether ids contains id
+func containsInt(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newFavoritesTestApp returns an App on a scratch profile database with a
+// small in-memory glyph cache
+func newFavoritesTestApp(t *testing.T, glyphs int) *App {
+	t.Helper()
+
+	dir := t.TempDir()
+	db, err := openSQLite(filepath.Join(dir, "gylte.db"), false)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	a := NewApp()
+	a.db, a.readDB = db, db
+	a.dataPath = dir
+	if err := a.openProfile(defaultProfile); err != nil {
+		t.Fatalf("open profile: %v", err)
+	}
+
+	list := make([]Glyph, glyphs)
+	for i := range list {
+		list[i] = Glyph{ID: i + 1, Name: fmt.Sprintf("nf-test-glyph%03d", i), Glyph: string(rune(0xe000 + i))}
+	}
+	a.cache.Swap(newCacheSnapshot(list))
+	return a
+}
+
+// storedFavorites reads the favorites table directly
+func storedFavorites(t *testing.T, a *App) map[int]bool {
+	t.Helper()
+
+	rows, err := a.userDB.Query("SELECT glyph_id FROM favorites")
+	if err != nil {
+		t.Fatalf("query favorites: %v", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[int]bool)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		ids[id] = true
+	}
+	return ids
+}
+
+func TestFavoritesStoreMatchesTable(t *testing.T) {
+	a := newFavoritesTestApp(t, 10)
+
+	added, err := a.favorites.Add([]int{1, 2, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 3 {
+		t.Fatalf("Add returned %v, want 3 new ids", added)
+	}
+	if added, _ := a.favorites.Add([]int{2, 4}); len(added) != 1 || added[0] != 4 {
+		t.Fatalf("Add of existing favorites returned %v, want [4]", added)
+	}
+	if on, err := a.favorites.Toggle(1); err != nil || on {
+		t.Fatalf("Toggle(1) = %v, %v; want false", on, err)
+	}
+	if err := a.favorites.Remove([]int{3}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[int]bool{2: true, 4: true}
+	if got := a.favorites.Snapshot(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Snapshot() = %v, want %v", got, want)
+	}
+	if got := storedFavorites(t, a); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("table = %v, want %v", got, want)
+	}
+
+	// Reopening reads the same favorites back
+	if err := a.favorites.Open(a.userDB); err != nil {
+		t.Fatal(err)
+	}
+	if a.favorites.Count() != 2 || !a.favorites.Contains(4) {
+		t.Errorf("after Open: %v", a.favorites.Snapshot())
+	}
+}
+
+// TestConcurrentToggleAndSearch toggles favorites while searches, scopes, and
+// stats read them; run with -race to catch unsynchronized access
+func TestConcurrentToggleAndSearch(t *testing.T) {
+	const glyphs, workers, rounds = 50, 8, 40
+	a := newFavoritesTestApp(t, glyphs)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				if err := a.ToggleFavorite((w*rounds+i)%glyphs + 1); err != nil {
+					t.Errorf("ToggleFavorite: %v", err)
+					return
+				}
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				if _, err := a.GetGlyphs(SearchRequest{Query: "glyph", Limit: 20, Scope: ScopeAll}); err != nil {
+					t.Errorf("GetGlyphs: %v", err)
+					return
+				}
+				if _, err := a.GetGlyphs(SearchRequest{Limit: 20, Scope: ScopeFavorites}); err != nil {
+					t.Errorf("GetGlyphs favorites: %v", err)
+					return
+				}
+				a.GetStats()
+				a.GetGlyphDetails(i%glyphs + 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Every toggle reached both the map and the table
+	if got, want := a.favorites.Snapshot(), storedFavorites(t, a); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("store %v disagrees with table %v", got, want)
+	}
+	result, err := a.GetGlyphs(SearchRequest{Limit: glyphs, Scope: ScopeFavorites})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != a.favorites.Count() {
+		t.Errorf("favorites scope has %d glyphs, store has %d", result.Total, a.favorites.Count())
+	}
+}
+
+// TestFavoritesQueuedDuringImport changes favorites while an import's
+// transaction holds the writer connection; the changes must not wait for it
+func TestFavoritesQueuedDuringImport(t *testing.T) {
+	a := newFavoritesTestApp(t, 10)
+
+	a.writes.hold()
+	tx, err := a.db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		if _, err := a.favorites.Toggle(3); err != nil {
+			done <- err
+			return
+		}
+		_, err := a.favorites.Add([]int{5, 6})
+		a.recordCategoryUse("md")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("favorite writes waited for the import transaction")
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	a.writes.release(a.db)
+
+	if got := storedFavorites(t, a); fmt.Sprint(got) != fmt.Sprint(map[int]bool{3: true, 5: true, 6: true}) {
+		t.Errorf("table after import = %v", got)
+	}
+	if _, ok := a.recentCategories()["md"]; !ok {
+		t.Error("category use queued during the import was lost")
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+)
+
+// fontDirs returns the directories where fonts are installed on this OS
+func fontDirs() []string {
+	home, _ := os.UserHomeDir()
+
+	switch goruntime.GOOS {
+	case "windows":
+		dirs := []string{filepath.Join(os.Getenv("WINDIR"), "Fonts")}
+		if local := os.Getenv("LOCALAPPDATA"); local != "" {
+			dirs = append(dirs, filepath.Join(local, "Microsoft", "Windows", "Fonts"))
+		}
+		return dirs
+	case "darwin":
+		return []string{
+			filepath.Join(home, "Library", "Fonts"),
+			"/Library/Fonts",
+			"/System/Library/Fonts",
+		}
+	default:
+		return []string{
+			filepath.Join(home, ".local", "share", "fonts"),
+			filepath.Join(home, ".fonts"),
+			"/usr/local/share/fonts",
+			"/usr/share/fonts",
+		}
+	}
+}
+
+// isNerdFontFile reports whether a file name looks like a patched Nerd Font
+func isNerdFontFile(name string) bool {
+	lower := strings.ToLower(name)
+	ext := filepath.Ext(lower)
+	if ext != ".ttf" && ext != ".otf" {
+		return false
+	}
+	return strings.Contains(lower, "nerd") || strings.Contains(lower, "nfm") || strings.Contains(lower, "nf-")
+}
+
+// isColorFontFile reports whether a file name looks like a color emoji font
+func isColorFontFile(name string) bool {
+	lower := strings.ToLower(name)
+	switch filepath.Ext(lower) {
+	case ".ttf", ".otf", ".ttc":
+	default:
+		return false
+	}
+	return strings.Contains(lower, "emoji") || strings.Contains(lower, "seguiemj")
+}
+
+// findFonts returns the paths of installed font files whose names match
+func findFonts(match func(name string) bool) []string {
+	var found []string
+	for _, dir := range fontDirs() {
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if d != nil && d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if !d.IsDir() && match(d.Name()) {
+				found = append(found, path)
+			}
+			return nil
+		})
+	}
+	return found
+}
+
+// detectNerdFonts returns the paths of installed Nerd Font files
+func detectNerdFonts() []string {
+	return findFonts(isNerdFontFile)
+}
+
+// detectColorFonts returns the paths of installed color emoji fonts
+func detectColorFonts() []string {
+	return findFonts(isColorFontFile)
+}
+
+// nerdFontPath returns the configured Nerd Font file, or the first installed one
+func (a *App) nerdFontPath() (string, error) {
+	if path := a.settings.Get("font.path", ""); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	if fonts := detectNerdFonts(); len(fonts) > 0 {
+		return fonts[0], nil
+	}
+	return "", newAppError(ErrCodeNotFound, "no Nerd Font found; install one or set font.path")
+}
+
+// GetInstalledNerdFonts lists Nerd Font files found in the system font directories
+func (a *App) GetInstalledNerdFonts() []string {
+	return detectNerdFonts()
+}
+
+// colorFontPath returns the configured color emoji font, or the first
+// installed one, or "" when there is none
+func (a *App) colorFontPath() string {
+	if path := a.settings.Get("font.emoji", ""); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return a.renderer.detectedColorFont()
+}
+
+// GetInstalledColorFonts lists color emoji fonts found in the system font directories
+func (a *App) GetInstalledColorFonts() []string {
+	return detectColorFonts()
+}
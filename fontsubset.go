@@ -0,0 +1,701 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+// sfntTables maps table tags (e.g. "glyf") to their raw data
+type sfntTables map[string][]byte
+
+// parseSFNT reads the table directory of a TrueType font. For collections the
+// first font is used.
+func parseSFNT(data []byte) (sfntTables, error) {
+	if len(data) < 12 {
+		return nil, fmt.Errorf("font file too short")
+	}
+
+	offset := 0
+	switch string(data[:4]) {
+	case "ttcf":
+		if len(data) < 16 {
+			return nil, fmt.Errorf("font collection too short")
+		}
+		offset = int(binary.BigEndian.Uint32(data[12:]))
+	case "OTTO":
+		return nil, fmt.Errorf("CFF-based OpenType fonts are not supported; use the TrueType (.ttf) Nerd Font")
+	}
+	if offset+12 > len(data) {
+		return nil, fmt.Errorf("invalid font offset")
+	}
+	if v := binary.BigEndian.Uint32(data[offset:]); v != 0x00010000 && string(data[offset:offset+4]) != "true" {
+		return nil, fmt.Errorf("not a TrueType font")
+	}
+
+	numTables := int(binary.BigEndian.Uint16(data[offset+4:]))
+	tables := make(sfntTables, numTables)
+	for i := 0; i < numTables; i++ {
+		rec := offset + 12 + 16*i
+		if rec+16 > len(data) {
+			return nil, fmt.Errorf("truncated table directory")
+		}
+		tag := string(data[rec : rec+4])
+		start := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		if start < 0 || length < 0 || start+length > len(data) {
+			return nil, fmt.Errorf("table %q out of bounds", tag)
+		}
+		tables[tag] = data[start : start+length]
+	}
+
+	for _, required := range []string{"cmap", "head", "hhea", "hmtx", "maxp", "loca", "glyf"} {
+		if tables[required] == nil {
+			return nil, fmt.Errorf("font has no %s table", required)
+		}
+	}
+	return tables, nil
+}
+
+// cmapLookup maps code points to glyph ids using the font's best Unicode cmap subtable
+func cmapLookup(cmap []byte, runes []rune) (map[rune]uint16, error) {
+	if len(cmap) < 4 {
+		return nil, fmt.Errorf("invalid cmap table")
+	}
+
+	// Prefer full-repertoire format 12 subtables, then BMP format 4
+	var format4, format12 []byte
+	numSubtables := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < numSubtables; i++ {
+		rec := 4 + 8*i
+		if rec+8 > len(cmap) {
+			break
+		}
+		platform, encoding := binary.BigEndian.Uint16(cmap[rec:]), binary.BigEndian.Uint16(cmap[rec+2:])
+		off := int(binary.BigEndian.Uint32(cmap[rec+4:]))
+		if off+4 > len(cmap) || (platform != 0 && !(platform == 3 && (encoding == 1 || encoding == 10))) {
+			continue
+		}
+		switch binary.BigEndian.Uint16(cmap[off:]) {
+		case 4:
+			format4 = cmap[off:]
+		case 12:
+			format12 = cmap[off:]
+		}
+	}
+
+	result := make(map[rune]uint16, len(runes))
+	switch {
+	case format12 != nil:
+		if len(format12) < 16 {
+			return nil, fmt.Errorf("invalid cmap format 12")
+		}
+		nGroups := int(binary.BigEndian.Uint32(format12[12:]))
+		for _, r := range runes {
+			for g := 0; g < nGroups && 16+12*g+12 <= len(format12); g++ {
+				group := format12[16+12*g:]
+				start, end := rune(binary.BigEndian.Uint32(group)), rune(binary.BigEndian.Uint32(group[4:]))
+				if r >= start && r <= end {
+					result[r] = uint16(binary.BigEndian.Uint32(group[8:]) + uint32(r-start))
+					break
+				}
+			}
+		}
+
+	case format4 != nil:
+		if len(format4) < 14 {
+			return nil, fmt.Errorf("invalid cmap format 4")
+		}
+		segCount := int(binary.BigEndian.Uint16(format4[6:])) / 2
+		ends := 14
+		starts := ends + 2*segCount + 2
+		deltas := starts + 2*segCount
+		rangeOffsets := deltas + 2*segCount
+		if rangeOffsets+2*segCount > len(format4) {
+			return nil, fmt.Errorf("invalid cmap format 4")
+		}
+		for _, r := range runes {
+			if r > 0xFFFF {
+				continue
+			}
+			c := uint16(r)
+			for s := 0; s < segCount; s++ {
+				end, start := binary.BigEndian.Uint16(format4[ends+2*s:]), binary.BigEndian.Uint16(format4[starts+2*s:])
+				if c < start || c > end {
+					continue
+				}
+				delta := binary.BigEndian.Uint16(format4[deltas+2*s:])
+				rangeOffset := int(binary.BigEndian.Uint16(format4[rangeOffsets+2*s:]))
+				if rangeOffset == 0 {
+					result[r] = c + delta
+				} else if pos := rangeOffsets + 2*s + rangeOffset + 2*int(c-start); pos+2 <= len(format4) {
+					if gid := binary.BigEndian.Uint16(format4[pos:]); gid != 0 {
+						result[r] = gid + delta
+					}
+				}
+				break
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("font has no Unicode cmap")
+	}
+
+	for r, gid := range result {
+		if gid == 0 {
+			delete(result, r)
+		}
+	}
+	return result, nil
+}
+
+// Composite glyph flags
+const (
+	compositeArgsAreWords = 0x0001
+	compositeHaveScale    = 0x0008
+	compositeMore         = 0x0020
+	compositeXYScale      = 0x0040
+	compositeTwoByTwo     = 0x0080
+)
+
+// forEachComponent calls fn with the offset of every component glyph index in a composite glyph
+func forEachComponent(glyph []byte, fn func(indexOffset int)) {
+	if len(glyph) < 10 || int16(binary.BigEndian.Uint16(glyph)) >= 0 {
+		return
+	}
+	for pos := 10; pos+4 <= len(glyph); {
+		flags := binary.BigEndian.Uint16(glyph[pos:])
+		fn(pos + 2)
+
+		pos += 4
+		if flags&compositeArgsAreWords != 0 {
+			pos += 4
+		} else {
+			pos += 2
+		}
+		switch {
+		case flags&compositeHaveScale != 0:
+			pos += 2
+		case flags&compositeXYScale != 0:
+			pos += 4
+		case flags&compositeTwoByTwo != 0:
+			pos += 8
+		}
+		if flags&compositeMore == 0 {
+			return
+		}
+	}
+}
+
+// fontSubset is a font reduced to a chosen set of code points
+type fontSubset struct {
+	tables  sfntTables
+	mapping map[rune]uint16 // code point -> glyph id in the subset
+	missing []rune          // requested code points the font has no glyph for
+}
+
+// subsetFont keeps only the glyphs for runes (plus .notdef and composite
+// components), renumbering them and rebuilding cmap, glyf, loca and hmtx
+func subsetFont(fontData []byte, runes []rune) (*fontSubset, error) {
+	src, err := parseSFNT(fontData)
+	if err != nil {
+		return nil, err
+	}
+
+	head, maxp, hhea := src["head"], src["maxp"], src["hhea"]
+	if len(head) < 54 || len(maxp) < 6 || len(hhea) < 36 {
+		return nil, fmt.Errorf("invalid font header tables")
+	}
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:]))
+	longLoca := binary.BigEndian.Uint16(head[50:]) == 1
+
+	// Glyph boundaries from loca
+	loca := src["loca"]
+	glyphOffset := func(gid int) int {
+		if longLoca {
+			if 4*gid+4 > len(loca) {
+				return -1
+			}
+			return int(binary.BigEndian.Uint32(loca[4*gid:]))
+		}
+		if 2*gid+2 > len(loca) {
+			return -1
+		}
+		return 2 * int(binary.BigEndian.Uint16(loca[2*gid:]))
+	}
+	glyf := src["glyf"]
+	glyphData := func(gid int) []byte {
+		start, end := glyphOffset(gid), glyphOffset(gid+1)
+		if gid >= numGlyphs || start < 0 || end < start || end > len(glyf) {
+			return nil
+		}
+		return glyf[start:end]
+	}
+
+	lookup, err := cmapLookup(src["cmap"], runes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect glyphs, following composite references
+	keep := map[uint16]bool{0: true}
+	var queue []uint16
+	var missing []rune
+	for _, r := range runes {
+		if gid, ok := lookup[r]; ok {
+			queue = append(queue, gid)
+		} else {
+			missing = append(missing, r)
+		}
+	}
+	for len(queue) > 0 {
+		gid := queue[0]
+		queue = queue[1:]
+		if keep[gid] && gid != 0 {
+			continue
+		}
+		keep[gid] = true
+		data := glyphData(int(gid))
+		forEachComponent(data, func(off int) {
+			if c := binary.BigEndian.Uint16(data[off:]); !keep[c] {
+				queue = append(queue, c)
+			}
+		})
+	}
+
+	oldIDs := make([]uint16, 0, len(keep))
+	for gid := range keep {
+		oldIDs = append(oldIDs, gid)
+	}
+	sort.Slice(oldIDs, func(i, j int) bool { return oldIDs[i] < oldIDs[j] })
+	newID := make(map[uint16]uint16, len(oldIDs))
+	for i, gid := range oldIDs {
+		newID[gid] = uint16(i)
+	}
+
+	// glyf and long-format loca
+	var newGlyf bytes.Buffer
+	newLoca := make([]byte, 4*(len(oldIDs)+1))
+	for i, gid := range oldIDs {
+		binary.BigEndian.PutUint32(newLoca[4*i:], uint32(newGlyf.Len()))
+		data := append([]byte(nil), glyphData(int(gid))...)
+		forEachComponent(data, func(off int) {
+			binary.BigEndian.PutUint16(data[off:], newID[binary.BigEndian.Uint16(data[off:])])
+		})
+		newGlyf.Write(data)
+		for newGlyf.Len()%4 != 0 {
+			newGlyf.WriteByte(0)
+		}
+	}
+	binary.BigEndian.PutUint32(newLoca[4*len(oldIDs):], uint32(newGlyf.Len()))
+
+	// hmtx with a full metric per glyph
+	hmtx := src["hmtx"]
+	numHMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	newHmtx := make([]byte, 4*len(oldIDs))
+	for i, gid := range oldIDs {
+		var advance, lsb uint16
+		switch g := int(gid); {
+		case g < numHMetrics && 4*g+4 <= len(hmtx):
+			advance, lsb = binary.BigEndian.Uint16(hmtx[4*g:]), binary.BigEndian.Uint16(hmtx[4*g+2:])
+		case numHMetrics > 0 && 4*numHMetrics+2*(g-numHMetrics)+2 <= len(hmtx):
+			advance = binary.BigEndian.Uint16(hmtx[4*(numHMetrics-1):])
+			lsb = binary.BigEndian.Uint16(hmtx[4*numHMetrics+2*(g-numHMetrics):])
+		}
+		binary.BigEndian.PutUint16(newHmtx[4*i:], advance)
+		binary.BigEndian.PutUint16(newHmtx[4*i+2:], lsb)
+	}
+
+	mapping := make(map[rune]uint16, len(lookup))
+	for r, gid := range lookup {
+		mapping[r] = newID[gid]
+	}
+
+	newHead := append([]byte(nil), head...)
+	binary.BigEndian.PutUint16(newHead[50:], 1)
+	binary.BigEndian.PutUint32(newHead[8:], 0)
+	newMaxp := append([]byte(nil), maxp...)
+	binary.BigEndian.PutUint16(newMaxp[4:], uint16(len(oldIDs)))
+	newHhea := append([]byte(nil), hhea...)
+	binary.BigEndian.PutUint16(newHhea[34:], uint16(len(oldIDs)))
+
+	tables := sfntTables{
+		"cmap": buildCmap(mapping),
+		"head": newHead,
+		"hhea": newHhea,
+		"hmtx": newHmtx,
+		"maxp": newMaxp,
+		"loca": newLoca,
+		"glyf": newGlyf.Bytes(),
+	}
+	if post := src["post"]; len(post) >= 32 {
+		// Version 3 drops glyph names, which would refer to the old glyph ids
+		newPost := append([]byte(nil), post[:32]...)
+		binary.BigEndian.PutUint32(newPost, 0x00030000)
+		tables["post"] = newPost
+	}
+	// Global tables that don't reference glyph ids are kept as-is
+	for _, tag := range []string{"name", "OS/2", "cvt ", "fpgm", "prep", "gasp"} {
+		if data := src[tag]; data != nil {
+			tables[tag] = data
+		}
+	}
+
+	return &fontSubset{tables: tables, mapping: mapping, missing: missing}, nil
+}
+
+// buildCmap writes a cmap with a format 4 subtable for BMP code points and a
+// format 12 subtable covering all of them
+func buildCmap(mapping map[rune]uint16) []byte {
+	runes := make([]rune, 0, len(mapping))
+	for r := range mapping {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	// Format 4: one segment per BMP code point plus the required 0xFFFF segment
+	var bmp []rune
+	for _, r := range runes {
+		if r <= 0xFFFF {
+			bmp = append(bmp, r)
+		}
+	}
+	segCount := len(bmp) + 1
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= segCount {
+		entrySelector++
+	}
+	searchRange := 2 * (1 << entrySelector)
+
+	f4 := make([]byte, 16+8*segCount)
+	binary.BigEndian.PutUint16(f4[0:], 4)
+	binary.BigEndian.PutUint16(f4[2:], uint16(len(f4)))
+	binary.BigEndian.PutUint16(f4[6:], uint16(2*segCount))
+	binary.BigEndian.PutUint16(f4[8:], uint16(searchRange))
+	binary.BigEndian.PutUint16(f4[10:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(f4[12:], uint16(2*segCount-searchRange))
+	ends, starts, deltas := 14, 16+2*segCount, 16+4*segCount
+	for i, r := range bmp {
+		binary.BigEndian.PutUint16(f4[ends+2*i:], uint16(r))
+		binary.BigEndian.PutUint16(f4[starts+2*i:], uint16(r))
+		binary.BigEndian.PutUint16(f4[deltas+2*i:], mapping[r]-uint16(r))
+	}
+	last := len(bmp)
+	binary.BigEndian.PutUint16(f4[ends+2*last:], 0xFFFF)
+	binary.BigEndian.PutUint16(f4[starts+2*last:], 0xFFFF)
+	binary.BigEndian.PutUint16(f4[deltas+2*last:], 1)
+
+	// Format 12: one group per code point
+	f12 := make([]byte, 16+12*len(runes))
+	binary.BigEndian.PutUint16(f12[0:], 12)
+	binary.BigEndian.PutUint32(f12[4:], uint32(len(f12)))
+	binary.BigEndian.PutUint32(f12[12:], uint32(len(runes)))
+	for i, r := range runes {
+		group := f12[16+12*i:]
+		binary.BigEndian.PutUint32(group, uint32(r))
+		binary.BigEndian.PutUint32(group[4:], uint32(r))
+		binary.BigEndian.PutUint32(group[8:], uint32(mapping[r]))
+	}
+
+	header := make([]byte, 4+8*2)
+	binary.BigEndian.PutUint16(header[2:], 2)
+	binary.BigEndian.PutUint16(header[4:], 3)
+	binary.BigEndian.PutUint16(header[6:], 1)
+	binary.BigEndian.PutUint32(header[8:], uint32(len(header)))
+	binary.BigEndian.PutUint16(header[12:], 3)
+	binary.BigEndian.PutUint16(header[14:], 10)
+	binary.BigEndian.PutUint32(header[16:], uint32(len(header)+len(f4)))
+
+	return append(append(header, f4...), f12...)
+}
+
+// tableChecksum sums a table as big-endian uint32 words, zero padded
+func tableChecksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
+
+// padded4 rounds n up to a multiple of four
+func padded4(n int) int {
+	return (n + 3) &^ 3
+}
+
+// sortedTags returns the table tags in ascending order, as the sfnt directory requires
+func (t sfntTables) sortedTags() []string {
+	tags := make([]string, 0, len(t))
+	for tag := range t {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// TTF serializes the tables as a TrueType font, fixing up head.checkSumAdjustment
+func (t sfntTables) TTF() []byte {
+	tags := t.sortedTags()
+	numTables := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= numTables {
+		entrySelector++
+	}
+	searchRange := 16 * (1 << entrySelector)
+
+	header := make([]byte, 12+16*numTables)
+	binary.BigEndian.PutUint32(header[0:], 0x00010000)
+	binary.BigEndian.PutUint16(header[4:], uint16(numTables))
+	binary.BigEndian.PutUint16(header[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(header[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(header[10:], uint16(16*numTables-searchRange))
+
+	out := bytes.NewBuffer(header)
+	headOffset := -1
+	for i, tag := range tags {
+		data := t[tag]
+		rec := header[12+16*i:]
+		copy(rec, tag)
+		binary.BigEndian.PutUint32(rec[4:], tableChecksum(data))
+		binary.BigEndian.PutUint32(rec[8:], uint32(out.Len()))
+		binary.BigEndian.PutUint32(rec[12:], uint32(len(data)))
+		if tag == "head" {
+			headOffset = out.Len()
+		}
+		out.Write(data)
+		out.Write(make([]byte, padded4(len(data))-len(data)))
+	}
+
+	font := out.Bytes()
+	copy(font, header)
+	if headOffset >= 0 {
+		binary.BigEndian.PutUint32(font[headOffset+8:], 0xB1B0AFBA-tableChecksum(font))
+	}
+	return font
+}
+
+// appendUIntBase128 appends v in WOFF2's variable-length encoding
+func appendUIntBase128(b []byte, v uint32) []byte {
+	var tmp [5]byte
+	n := 0
+	for {
+		tmp[4-n] = byte(v & 0x7F)
+		v >>= 7
+		n++
+		if v == 0 {
+			break
+		}
+	}
+	for i := 5 - n; i < 4; i++ {
+		tmp[i] |= 0x80
+	}
+	return append(b, tmp[5-n:]...)
+}
+
+// WOFF2 stores the tables brotli-compressed without glyf/loca transforms, so
+// the decoded font matches TTF byte for byte
+func (t sfntTables) WOFF2() ([]byte, error) {
+	ttf := t.TTF()
+	tables, err := parseSFNT(ttf)
+	if err != nil {
+		return nil, err
+	}
+
+	// loca must directly follow glyf in the WOFF2 directory
+	var order []string
+	for _, tag := range tables.sortedTags() {
+		if tag == "loca" {
+			continue
+		}
+		order = append(order, tag)
+		if tag == "glyf" {
+			order = append(order, "loca")
+		}
+	}
+
+	var directory []byte
+	var stream bytes.Buffer
+	for _, tag := range order {
+		flags := byte(63) // explicit tag follows
+		if tag == "glyf" || tag == "loca" {
+			flags |= 3 << 6 // null transform
+		}
+		directory = append(directory, flags)
+		directory = append(directory, tag...)
+		directory = appendUIntBase128(directory, uint32(len(tables[tag])))
+		stream.Write(tables[tag])
+	}
+
+	var compressed bytes.Buffer
+	w := brotli.NewWriterLevel(&compressed, brotli.BestCompression)
+	if _, err := w.Write(stream.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 48)
+	copy(header, "wOF2")
+	binary.BigEndian.PutUint32(header[4:], 0x00010000)
+	binary.BigEndian.PutUint16(header[12:], uint16(len(order)))
+	binary.BigEndian.PutUint32(header[16:], uint32(len(ttf)))
+	binary.BigEndian.PutUint32(header[20:], uint32(compressed.Len()))
+	binary.BigEndian.PutUint16(header[24:], 1)
+
+	font := append(append(header, directory...), compressed.Bytes()...)
+	font = append(font, make([]byte, padded4(len(font))-len(font))...)
+	binary.BigEndian.PutUint32(font[8:], uint32(len(font)))
+	return font, nil
+}
+
+// Subset font formats
+const (
+	SubsetFormatTTF   = "ttf"
+	SubsetFormatWOFF2 = "woff2"
+)
+
+// SubsetGlyph is a manifest entry for a glyph included in a subset font
+type SubsetGlyph struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Glyph     string `json:"glyph"`
+	Codepoint string `json:"codepoint"`
+}
+
+// SubsetManifest describes a subset font; it is written next to the font as JSON
+type SubsetManifest struct {
+	Font       string        `json:"font"`
+	Format     string        `json:"format"`
+	SourceFont string        `json:"sourceFont"`
+	SizeBytes  int           `json:"sizeBytes"`
+	Glyphs     []SubsetGlyph `json:"glyphs"`
+	Missing    []SubsetGlyph `json:"missing,omitempty"`
+	CreatedAt  time.Time     `json:"createdAt" ts_type:"string"`
+}
+
+// SubsetFont writes a font with only the selected glyphs, for embedding in web projects.
+// Glyphs come from glyphIDs when given, otherwise from scope ("favorites",
+// "collection:<name>", "category:<name>"). Empty sourceFontPath uses the configured
+// Nerd Font, empty format is inferred from outPath (default woff2), and empty
+// outPath exports to the default exports directory. The manifest is saved beside
+// the font with a .json extension.
+func (a *App) SubsetFont(scope string, glyphIDs []int, sourceFontPath, outPath, format string) (*SubsetManifest, error) {
+	field := "format"
+	if format == "" {
+		field, format = "outPath", strings.TrimPrefix(filepath.Ext(outPath), ".")
+		if format == "" {
+			format = SubsetFormatWOFF2
+		}
+	}
+	format = strings.ToLower(format)
+	if format != SubsetFormatTTF && format != SubsetFormatWOFF2 {
+		return nil, invalidArgument(field, "unsupported font format %q; use %s or %s", format, SubsetFormatTTF, SubsetFormatWOFF2)
+	}
+	if outPath == "" {
+		outPath = a.defaultExportPath("subset", "."+format)
+	}
+	manifestPath := strings.TrimSuffix(outPath, filepath.Ext(outPath)) + ".json"
+	if manifestPath == outPath {
+		return nil, invalidArgument("outPath", "%s would be overwritten by the manifest; use a .%s extension", filepath.Base(outPath), format)
+	}
+
+	op := a.startOperation("export", "Subsetting font…")
+
+	var glyphs []Glyph
+	if len(glyphIDs) > 0 {
+		for _, id := range glyphIDs {
+			g, ok := a.findGlyph(id)
+			if !ok {
+				return nil, op.Fail("Could not subset the font", fmt.Errorf("glyph %d not found", id))
+			}
+			glyphs = append(glyphs, g)
+		}
+	} else {
+		var err error
+		if glyphs, err = a.scopeGlyphs(scope); err != nil {
+			return nil, op.Fail("Could not subset the font", err)
+		}
+	}
+	if len(glyphs) == 0 {
+		return nil, op.Fail("Could not subset the font", fmt.Errorf("no glyphs selected"))
+	}
+
+	if sourceFontPath == "" {
+		var err error
+		if sourceFontPath, err = a.nerdFontPath(); err != nil {
+			return nil, op.Fail("Could not subset the font", err)
+		}
+	}
+	op.Progress(10, "Reading "+filepath.Base(sourceFontPath)+"…")
+	data, err := os.ReadFile(sourceFontPath)
+	if err != nil {
+		return nil, op.Fail("Could not subset the font", fmt.Errorf("failed to read font: %w", err))
+	}
+
+	op.Progress(30, fmt.Sprintf("Extracting %d glyphs…", len(glyphs)))
+	runes := glyphRunes(glyphs)
+	subset, err := subsetFont(data, runes)
+	if err != nil {
+		return nil, op.Fail("Could not subset the font", err)
+	}
+	if len(subset.missing) == len(runes) {
+		return nil, op.Fail("Could not subset the font", fmt.Errorf("%s has none of the selected glyphs", filepath.Base(sourceFontPath)))
+	}
+
+	op.Progress(70, "Encoding "+strings.ToUpper(format)+"…")
+	var font []byte
+	if format == SubsetFormatTTF {
+		font = subset.tables.TTF()
+	} else if font, err = subset.tables.WOFF2(); err != nil {
+		return nil, op.Fail("Could not subset the font", fmt.Errorf("failed to encode WOFF2: %w", err))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		return nil, op.Fail("Could not subset the font", fmt.Errorf("failed to create directory: %w", err))
+	}
+	if err := writeExportBytes(outPath, font); err != nil {
+		return nil, op.Fail("Could not subset the font", fmt.Errorf("failed to write font: %w", err))
+	}
+
+	missing := make(map[rune]bool, len(subset.missing))
+	for _, r := range subset.missing {
+		missing[r] = true
+	}
+	manifest := &SubsetManifest{
+		Font:       outPath,
+		Format:     format,
+		SourceFont: sourceFontPath,
+		SizeBytes:  len(font),
+		Glyphs:     []SubsetGlyph{},
+		CreatedAt:  time.Now(),
+	}
+	for _, g := range glyphs {
+		cp := codepointOf(g.Glyph)
+		entry := SubsetGlyph{ID: g.ID, Name: g.Name, Glyph: g.Glyph, Codepoint: formatCodepoint(cp)}
+		if missing[cp] {
+			manifest.Missing = append(manifest.Missing, entry)
+		} else {
+			manifest.Glyphs = append(manifest.Glyphs, entry)
+		}
+	}
+
+	op.Progress(90, "Writing manifest…")
+	if err := writeJSONFile(manifestPath, manifest); err != nil {
+		return nil, op.Fail("Could not subset the font", fmt.Errorf("failed to write manifest: %w", err))
+	}
+
+	log.Printf("Subset %s to %d glyphs (%d bytes, %d missing) at %s", filepath.Base(sourceFontPath), len(manifest.Glyphs), len(font), len(manifest.Missing), outPath)
+	op.Succeed(fmt.Sprintf("Font with %d glyphs saved (%d KB)", len(manifest.Glyphs), (len(font)+1023)/1024))
+	return manifest, nil
+}
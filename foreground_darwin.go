@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// foregroundAppSupport reports why the focused app can't be detected, or nil if it can
+func foregroundAppSupport() error {
+	if !hasCommand("lsappinfo") {
+		return fmt.Errorf("detecting the focused app needs lsappinfo")
+	}
+	return nil
+}
+
+// lsappinfoValue runs "lsappinfo info -only key front" and returns the value
+// of its "key"=value output, unquoted
+func lsappinfoValue(key string) (string, error) {
+	out, err := exec.Command("lsappinfo", "info", "-only", key, "front").Output()
+	if err != nil {
+		return "", fmt.Errorf("lsappinfo failed: %w", err)
+	}
+	_, value, ok := strings.Cut(strings.TrimSpace(string(out)), "=")
+	if !ok {
+		return "", fmt.Errorf("no %s for the front app", key)
+	}
+	return strings.Trim(value, `"`), nil
+}
+
+// foregroundApp asks Launch Services for the frontmost application
+func foregroundApp() (*ForegroundApp, error) {
+	name, err := lsappinfoValue("name")
+	if err != nil {
+		return nil, err
+	}
+	app := &ForegroundApp{Name: name}
+	app.ID, _ = lsappinfoValue("bundleid")
+	if pid, err := lsappinfoValue("pid"); err == nil {
+		app.PID, _ = strconv.Atoi(pid)
+	}
+	return app, nil
+}
@@ -0,0 +1,80 @@
+//go:build !windows && !darwin
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// xpropWindowID, xpropClass and xpropPID pick values out of xprop's "NAME(TYPE) = value" lines
+var (
+	xpropWindowID = regexp.MustCompile(`window id # (0x[0-9a-fA-F]+)`)
+	xpropClass    = regexp.MustCompile(`WM_CLASS\(STRING\) = "([^"]*)", "([^"]*)"`)
+	xpropPID      = regexp.MustCompile(`_NET_WM_PID\(CARDINAL\) = (\d+)`)
+)
+
+// foregroundAppSupport reports why the focused app can't be detected, or nil if it can
+func foregroundAppSupport() error {
+	if os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "" && hasCommand("hyprctl") {
+		return nil
+	}
+	if os.Getenv("DISPLAY") != "" && hasCommand("xprop") {
+		return nil
+	}
+	return fmt.Errorf("detecting the focused app needs xprop on X11 (or XWayland) or hyprctl on Hyprland")
+}
+
+// foregroundApp reads the active window's class and process from the compositor or X server
+func foregroundApp() (*ForegroundApp, error) {
+	if os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "" && hasCommand("hyprctl") {
+		return hyprlandActiveWindow()
+	}
+	if os.Getenv("DISPLAY") == "" || !hasCommand("xprop") {
+		return nil, foregroundAppSupport()
+	}
+
+	out, err := exec.Command("xprop", "-root", "_NET_ACTIVE_WINDOW").Output()
+	if err != nil {
+		return nil, fmt.Errorf("xprop failed: %w", err)
+	}
+	m := xpropWindowID.FindSubmatch(out)
+	if m == nil || string(m[1]) == "0x0" {
+		return nil, fmt.Errorf("no window has focus")
+	}
+	out, err = exec.Command("xprop", "-id", string(m[1]), "WM_CLASS", "_NET_WM_PID").Output()
+	if err != nil {
+		return nil, fmt.Errorf("xprop failed: %w", err)
+	}
+	class := xpropClass.FindSubmatch(out)
+	if class == nil {
+		return nil, fmt.Errorf("focused window has no class")
+	}
+	app := &ForegroundApp{Name: string(class[2]), ID: string(class[1])}
+	if pid := xpropPID.FindSubmatch(out); pid != nil {
+		app.PID, _ = strconv.Atoi(string(pid[1]))
+	}
+	return app, nil
+}
+
+// hyprlandActiveWindow asks Hyprland for the focused window
+func hyprlandActiveWindow() (*ForegroundApp, error) {
+	out, err := exec.Command("hyprctl", "activewindow", "-j").Output()
+	if err != nil {
+		return nil, fmt.Errorf("hyprctl failed: %w", err)
+	}
+	var w struct {
+		Class        string `json:"class"`
+		InitialClass string `json:"initialClass"`
+		PID          int    `json:"pid"`
+	}
+	if err := json.Unmarshal(out, &w); err != nil || strings.TrimSpace(w.Class) == "" {
+		return nil, fmt.Errorf("no window has focus")
+	}
+	return &ForegroundApp{Name: w.Class, ID: w.InitialClass, PID: w.PID}, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	procGetForegroundWindow        = user32.NewProc("GetForegroundWindow")
+	procGetWindowThreadProcessId   = user32.NewProc("GetWindowThreadProcessId")
+	procQueryFullProcessImageNameW = syscall.NewLazyDLL("kernel32.dll").NewProc("QueryFullProcessImageNameW")
+)
+
+// processQueryLimitedInformation is enough access to read a process's image name
+const processQueryLimitedInformation = 0x1000
+
+// foregroundAppSupport reports why the focused app can't be detected, or nil if it can
+func foregroundAppSupport() error {
+	return nil
+}
+
+// foregroundApp identifies the focused window's process by its executable
+func foregroundApp() (*ForegroundApp, error) {
+	hwnd, _, _ := procGetForegroundWindow.Call()
+	if hwnd == 0 {
+		return nil, fmt.Errorf("no window has focus")
+	}
+	var pid uint32
+	procGetWindowThreadProcessId.Call(hwnd, uintptr(unsafe.Pointer(&pid)))
+	if pid == 0 {
+		return nil, fmt.Errorf("failed to find the focused window's process")
+	}
+
+	h, err := syscall.OpenProcess(processQueryLimitedInformation, false, pid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open process %d: %w", pid, err)
+	}
+	defer syscall.CloseHandle(h)
+
+	buf := make([]uint16, syscall.MAX_PATH)
+	size := uint32(len(buf))
+	if ret, _, err := procQueryFullProcessImageNameW.Call(uintptr(h), 0, uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size))); ret == 0 {
+		return nil, fmt.Errorf("failed to read the image name of process %d: %w", pid, err)
+	}
+	path := syscall.UTF16ToString(buf[:size])
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return &ForegroundApp{Name: name, ID: path, PID: int(pid)}, nil
+}
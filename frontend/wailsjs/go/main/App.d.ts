@@ -1,19 +1,418 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 import {main} from '../models';
+import {glyphdata} from '../models';
+
+export function AddToCollection(arg1:string,arg2:number):Promise<void>;
+
+export function AddToCompare(arg1:number):Promise<main.CompareView>;
+
+export function AddToScratchpad(arg1:number):Promise<Array<main.Glyph>>;
+
+export function ApplySmartFilter(arg1:string,arg2:number,arg3:number):Promise<main.SearchResponse>;
+
+export function AssignGlyphHotkey(arg1:number,arg2:string):Promise<void>;
+
+export function AttachDatabase(arg1:string):Promise<void>;
+
+export function AuditGlyphMigration(arg1:string,arg2:boolean):Promise<main.MigrationReport>;
+
+export function BulkAssignCategory(arg1:Array<number>,arg2:string):Promise<void>;
+
+export function BulkTag(arg1:Array<number>,arg2:Array<string>):Promise<number>;
+
+export function BulkUntag(arg1:Array<number>,arg2:Array<string>):Promise<number>;
+
+export function CancelImport():Promise<boolean>;
+
+export function CheckForUpdates():Promise<main.UpdateInfo>;
+
+export function ChooseExportPath(arg1:string,arg2:string):Promise<string>;
+
+export function ChooseOpenPath(arg1:string):Promise<string>;
+
+export function ChooseSavePath(arg1:string,arg2:string):Promise<string>;
+
+export function ClearCompare():Promise<void>;
+
+export function ClearLastSession():Promise<void>;
+
+export function ClearScratchpad():Promise<void>;
 
 export function ClearSearchHistory():Promise<void>;
 
-export function CopyToClipboard(arg1:string):Promise<void>;
+export function CompactDatabase():Promise<Array<main.DatabaseCompaction>>;
+
+export function CompareSearch(arg1:string,arg2:string,arg3:string):Promise<main.SearchComparison>;
+
+export function CompleteOnboardingStep(arg1:string):Promise<main.OnboardingState>;
+
+export function CopyGlyph(arg1:number):Promise<void>;
+
+export function CopyGlyphCard(arg1:number):Promise<string>;
+
+export function CopyGlyphImage(arg1:number,arg2:number,arg3:string):Promise<void>;
+
+export function CopyGlyphsAs(arg1:Array<number>,arg2:string):Promise<string>;
+
+export function CopyPreview(arg1:string,arg2:number):Promise<string>;
+
+export function CopyScratchpad(arg1:string):Promise<string>;
+
+export function CopyToClipboard(arg1:string):Promise<main.CopyResult>;
+
+export function CreateCollection(arg1:string):Promise<void>;
+
+export function CreateCopyRule(arg1:string,arg2:string):Promise<number>;
+
+export function CreateProfile(arg1:string):Promise<void>;
+
+export function CreateTagRule(arg1:string,arg2:Array<string>):Promise<number>;
+
+export function DecryptProfile(arg1:string):Promise<void>;
+
+export function DeleteCategoryGroup(arg1:string):Promise<void>;
+
+export function DeleteCollection(arg1:string):Promise<void>;
+
+export function DeleteCopyRule(arg1:number):Promise<void>;
+
+export function DeleteProfile(arg1:string):Promise<void>;
+
+export function DeleteTagRule(arg1:number):Promise<void>;
+
+export function DetachDatabase(arg1:string):Promise<void>;
+
+export function DiffDatabases(arg1:string,arg2:string):Promise<glyphdata.DatabaseDiff>;
+
+export function DisableIntegration():Promise<void>;
+
+export function DismissSampleData():Promise<void>;
+
+export function DownloadUpdate():Promise<string>;
+
+export function EnableIntegration(arg1:main.IntegrationOptions):Promise<main.IntegrationStatus>;
+
+export function EnablePlugin(arg1:string,arg2:boolean):Promise<void>;
+
+export function EncryptProfile(arg1:string):Promise<void>;
+
+export function ExecuteCommand(arg1:string):Promise<void>;
+
+export function ExportCheatSheet(arg1:string,arg2:string):Promise<string>;
+
+export function ExportCollectionImages(arg1:string,arg2:Array<number>,arg3:string,arg4:string):Promise<string>;
+
+export function ExportDatabaseDiff(arg1:string,arg2:string,arg3:string,arg4:string):Promise<string>;
+
+export function ExportFavorites(arg1:string):Promise<string>;
+
+export function ExportGlyphsAs(arg1:Array<number>,arg2:string,arg3:string):Promise<string>;
+
+export function ExportHTMLCheatSheet(arg1:string,arg2:string):Promise<string>;
+
+export function ExportScope(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function ExportSearchResults(arg1:string,arg2:string,arg3:string,arg4:string):Promise<string>;
+
+export function ExportSnippets(arg1:string,arg2:string):Promise<string>;
+
+export function FormatGlyphs(arg1:Array<number>,arg2:string):Promise<string>;
+
+export function GeneratePromptConfig(arg1:Array<number>,arg2:string):Promise<string>;
+
+export function GetActiveOperations():Promise<Array<main.OperationEvent>>;
+
+export function GetActiveProfile():Promise<string>;
+
+export function GetAvailableLocales():Promise<Array<main.LocaleInfo>>;
+
+export function GetCapabilities():Promise<main.Capabilities>;
+
+export function GetCategories():Promise<Array<main.CategoryInfo>>;
+
+export function GetCategoryGroups():Promise<Array<main.CategoryGroup>>;
+
+export function GetCategoryInfo(arg1:string):Promise<main.CategoryInfo>;
+
+export function GetCategorySamples(arg1:number):Promise<Record<string, Array<main.Glyph>>>;
+
+export function GetClickThrough():Promise<boolean>;
+
+export function GetClipboardBackends():Promise<Array<main.ClipboardBackendInfo>>;
+
+export function GetClipboardWatch():Promise<boolean>;
+
+export function GetCollection(arg1:string):Promise<Array<main.GlyphMatch>>;
+
+export function GetCompare():Promise<main.CompareView>;
+
+export function GetCopyTarget():Promise<main.CopyTarget>;
+
+export function GetCurrentDatabase():Promise<string>;
+
+export function GetDataStatus():Promise<main.DataStatus>;
+
+export function GetDatabaseDiagnostics():Promise<main.DatabaseDiagnostics>;
+
+export function GetDatabaseInfo():Promise<main.DatabaseInfo>;
+
+export function GetDatabaseSizes():Promise<Array<main.DatabaseSize>>;
+
+export function GetDocTemplate(arg1:string):Promise<string>;
+
+export function GetEncryptionStatus():Promise<main.EncryptionStatus>;
+
+export function GetErrorCodes():Promise<Array<main.ErrorCodeInfo>>;
+
+export function GetFavorites(arg1:number,arg2:number,arg3:string):Promise<Array<main.GlyphMatch>>;
+
+export function GetGlyphCard(arg1:number):Promise<string>;
+
+export function GetGlyphCardPlaceholders():Promise<Array<string>>;
+
+export function GetGlyphCardTemplate():Promise<string>;
+
+export function GetGlyphDeprecation(arg1:number):Promise<main.GlyphDeprecation>;
+
+export function GetGlyphDetails(arg1:number):Promise<main.GlyphDetails>;
+
+export function GetGlyphs(arg1:main.SearchRequest):Promise<main.SearchResponse>;
+
+export function GetGlyphsByBlock(arg1:string,arg2:number):Promise<main.SearchResponse>;
+
+export function GetGridPrefs():Promise<main.GridPrefs>;
+
+export function GetHistorySettings():Promise<main.HistorySettings>;
+
+export function GetHooks():Promise<Array<main.HookConfig>>;
+
+export function GetInstalledColorFonts():Promise<Array<string>>;
+
+export function GetInstalledNerdFonts():Promise<Array<string>>;
+
+export function GetIntegrationStatus():Promise<main.IntegrationStatus>;
+
+export function GetJumpList(arg1:number):Promise<Array<main.JumpListEntry>>;
+
+export function GetLastSession():Promise<main.SessionState>;
+
+export function GetLocalAPIStatus():Promise<main.LocalAPIStatus>;
+
+export function GetLocale():Promise<string>;
+
+export function GetMaintenanceStatus():Promise<main.MaintenanceStatus>;
+
+export function GetOnboardingState():Promise<main.OnboardingState>;
+
+export function GetPageSize():Promise<main.PageSize>;
 
-export function GetCategories():Promise<Record<string, number>>;
+export function GetPreviewTemplates():Promise<Array<string>>;
 
-export function GetFavorites():Promise<Array<main.GlyphMatch>>;
+export function GetQuickPicks(arg1:number):Promise<Array<main.GlyphMatch>>;
 
-export function GetGlyphs(arg1:string,arg2:string,arg3:number,arg4:number):Promise<main.SearchResult>;
+export function GetRecentlyAddedGlyphs(arg1:string):Promise<main.RecentlyAdded>;
+
+export function GetScoringFeedback():Promise<Array<main.ScoringFeedback>>;
+
+export function GetScratchpad():Promise<Array<main.Glyph>>;
 
 export function GetSearchHistory():Promise<Array<string>>;
 
-export function GetStats():Promise<Record<string, any>>;
+export function GetSearchLatencyStats():Promise<main.SearchLatencyStats>;
+
+export function GetSettings():Promise<Record<string, string>>;
+
+export function GetSharedDatabase():Promise<main.SharedDatabaseInfo>;
+
+export function GetSmartFilters():Promise<Array<main.SmartFilter>>;
+
+export function GetStartupTimings():Promise<main.StartupTimings>;
+
+export function GetStats():Promise<main.Stats>;
+
+export function GetTheme():Promise<string>;
+
+export function GetTranslations(arg1:string):Promise<Record<string, string>>;
+
+export function GetUnicodeBlocks():Promise<Array<main.UnicodeBlockInfo>>;
+
+export function GetUpdateInfo():Promise<main.UpdateInfo>;
+
+export function GetWindowPrefs():Promise<main.WindowPrefs>;
+
+export function GetZoomLevel():Promise<number>;
+
+export function HasSampleData():Promise<boolean>;
+
+export function HideWindow():Promise<void>;
+
+export function ImportGlyphs(arg1:string,arg2:boolean):Promise<main.ImportResult>;
+
+export function ImportGlyphsRelease(arg1:string,arg2:string,arg3:boolean):Promise<main.ImportResult>;
+
+export function IsReadOnly():Promise<boolean>;
+
+export function ListCollections():Promise<Array<main.Collection>>;
+
+export function ListCommands():Promise<Array<main.Command>>;
+
+export function ListCopyRules():Promise<Array<main.CopyRule>>;
+
+export function ListExporters():Promise<Array<main.ExporterInfo>>;
+
+export function ListGlyphHotkeys():Promise<Array<main.GlyphHotkey>>;
+
+export function ListPlugins():Promise<Array<main.PluginInfo>>;
+
+export function ListProfiles():Promise<Array<main.Profile>>;
+
+export function ListScoringProfiles():Promise<Array<main.ScoringProfileInfo>>;
+
+export function ListSources():Promise<Array<main.SourceInfo>>;
+
+export function ListTagRules():Promise<Array<main.TagRule>>;
+
+export function ListTags():Promise<Array<main.TagCount>>;
+
+export function LockProfile():Promise<void>;
+
+export function MinimiseWindow():Promise<void>;
+
+export function NotifyUser(arg1:string,arg2:string):Promise<void>;
+
+export function OpenDataFolder():Promise<void>;
+
+export function OpenDatabase(arg1:string):Promise<void>;
+
+export function OpenExportedFile(arg1:string):Promise<void>;
+
+export function OpenLogFile():Promise<void>;
+
+export function OpenPluginsFolder():Promise<void>;
+
+export function PreviewTagRule(arg1:string,arg2:Array<string>):Promise<main.TagRulePreview>;
+
+export function PreviewTagRules():Promise<Array<main.TagRulePreview>>;
+
+export function RebuildCache():Promise<void>;
+
+export function RecordSearchFeedback(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
+
+export function RefreshDeprecations():Promise<number>;
+
+export function RegenerateLocalAPIToken():Promise<string>;
+
+export function ReloadPlugins():Promise<Array<main.PluginInfo>>;
+
+export function RemoveFromCollection(arg1:string,arg2:number):Promise<void>;
+
+export function RemoveFromCompare(arg1:number):Promise<main.CompareView>;
+
+export function RemoveFromScratchpad(arg1:number):Promise<Array<main.Glyph>>;
+
+export function RemoveGlyphHotkey(arg1:string):Promise<void>;
+
+export function RemoveSampleData():Promise<void>;
+
+export function RenderContactSheet(arg1:Array<number>,arg2:number,arg3:string):Promise<string>;
+
+export function RenderPreview(arg1:string,arg2:number):Promise<string>;
+
+export function ResetOnboarding():Promise<main.OnboardingState>;
+
+export function RunMaintenanceNow(arg1:string):Promise<main.MaintenanceStatus>;
+
+export function SaveCategoryGroup(arg1:string,arg2:string,arg3:Array<string>):Promise<void>;
+
+export function SaveSessionScroll(arg1:number):Promise<void>;
+
+export function ScanDirectoryForGlyphs(arg1:string):Promise<main.GlyphScanReport>;
+
+export function SeedSampleData():Promise<void>;
+
+export function SetAlwaysOnTop(arg1:boolean):Promise<void>;
+
+export function SetAutoUpdateCheck(arg1:boolean):Promise<void>;
+
+export function SetCategoryEnabled(arg1:string,arg2:boolean):Promise<void>;
+
+export function SetCategorySort(arg1:string):Promise<void>;
+
+export function SetClickThrough(arg1:boolean):Promise<void>;
+
+export function SetClickThroughRegions(arg1:Array<main.ClickRegion>):Promise<void>;
+
+export function SetClipboardBackend(arg1:string):Promise<void>;
+
+export function SetClipboardWatch(arg1:boolean):Promise<void>;
+
+export function SetDocTemplate(arg1:string,arg2:string):Promise<void>;
+
+export function SetFrameless(arg1:boolean):Promise<void>;
+
+export function SetGlyphCardTemplate(arg1:string):Promise<void>;
+
+export function SetGridPrefs(arg1:main.GridPrefs):Promise<void>;
+
+export function SetHistorySettings(arg1:main.HistorySettings):Promise<void>;
+
+export function SetHook(arg1:main.HookConfig):Promise<void>;
+
+export function SetIncognito(arg1:boolean):Promise<void>;
+
+export function SetLocalAPIEnabled(arg1:boolean):Promise<main.LocalAPIStatus>;
+
+export function SetLocalAPIPort(arg1:number):Promise<main.LocalAPIStatus>;
+
+export function SetLocale(arg1:string):Promise<void>;
+
+export function SetMaintenanceInterval(arg1:string,arg2:number):Promise<void>;
+
+export function SetOpacity(arg1:number):Promise<void>;
+
+export function SetPageSize(arg1:number):Promise<void>;
+
+export function SetReadOnlyMode(arg1:boolean):Promise<void>;
+
+export function SetScoringProfile(arg1:string):Promise<void>;
+
+export function SetScratchpadPersist(arg1:boolean):Promise<void>;
+
+export function SetSearchMode(arg1:string):Promise<void>;
+
+export function SetSetting(arg1:string,arg2:string):Promise<void>;
+
+export function SetSmartCopy(arg1:boolean):Promise<void>;
+
+export function SetTaskbarProgress(arg1:boolean):Promise<void>;
+
+export function SetTheme(arg1:string):Promise<void>;
+
+export function SetWindowEffect(arg1:string):Promise<void>;
+
+export function SetZoomLevel(arg1:number):Promise<number>;
+
+export function ShouldOfferSampleData():Promise<boolean>;
+
+export function ShowWindow():Promise<void>;
+
+export function SubsetFont(arg1:string,arg2:Array<number>,arg3:string,arg4:string,arg5:string):Promise<main.SubsetManifest>;
+
+export function SwitchProfile(arg1:string):Promise<void>;
+
+export function TagSearchResults(arg1:string,arg2:string,arg3:string,arg4:Array<string>):Promise<number>;
+
+export function TestHook(arg1:string,arg2:number):Promise<main.HookRun>;
 
 export function ToggleFavorite(arg1:number):Promise<void>;
+
+export function ToggleTheme():Promise<string>;
+
+export function UndoBulkEdit():Promise<string>;
+
+export function UnlockProfile(arg1:string,arg2:string):Promise<void>;
+
+export function UpdateCopyRule(arg1:number,arg2:string,arg3:string,arg4:boolean):Promise<void>;
+
+export function UpdateTagRule(arg1:number,arg2:string,arg3:Array<string>,arg4:boolean):Promise<void>;
@@ -2,34 +2,830 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddToCollection(arg1, arg2) {
+  return window['go']['main']['App']['AddToCollection'](arg1, arg2);
+}
+
+export function AddToCompare(arg1) {
+  return window['go']['main']['App']['AddToCompare'](arg1);
+}
+
+export function AddToScratchpad(arg1) {
+  return window['go']['main']['App']['AddToScratchpad'](arg1);
+}
+
+export function ApplySmartFilter(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ApplySmartFilter'](arg1, arg2, arg3);
+}
+
+export function AssignGlyphHotkey(arg1, arg2) {
+  return window['go']['main']['App']['AssignGlyphHotkey'](arg1, arg2);
+}
+
+export function AttachDatabase(arg1) {
+  return window['go']['main']['App']['AttachDatabase'](arg1);
+}
+
+export function AuditGlyphMigration(arg1, arg2) {
+  return window['go']['main']['App']['AuditGlyphMigration'](arg1, arg2);
+}
+
+export function BulkAssignCategory(arg1, arg2) {
+  return window['go']['main']['App']['BulkAssignCategory'](arg1, arg2);
+}
+
+export function BulkTag(arg1, arg2) {
+  return window['go']['main']['App']['BulkTag'](arg1, arg2);
+}
+
+export function BulkUntag(arg1, arg2) {
+  return window['go']['main']['App']['BulkUntag'](arg1, arg2);
+}
+
+export function CancelImport() {
+  return window['go']['main']['App']['CancelImport']();
+}
+
+export function CheckForUpdates() {
+  return window['go']['main']['App']['CheckForUpdates']();
+}
+
+export function ChooseExportPath(arg1, arg2) {
+  return window['go']['main']['App']['ChooseExportPath'](arg1, arg2);
+}
+
+export function ChooseOpenPath(arg1) {
+  return window['go']['main']['App']['ChooseOpenPath'](arg1);
+}
+
+export function ChooseSavePath(arg1, arg2) {
+  return window['go']['main']['App']['ChooseSavePath'](arg1, arg2);
+}
+
+export function ClearCompare() {
+  return window['go']['main']['App']['ClearCompare']();
+}
+
+export function ClearLastSession() {
+  return window['go']['main']['App']['ClearLastSession']();
+}
+
+export function ClearScratchpad() {
+  return window['go']['main']['App']['ClearScratchpad']();
+}
+
 export function ClearSearchHistory() {
   return window['go']['main']['App']['ClearSearchHistory']();
 }
 
+export function CompactDatabase() {
+  return window['go']['main']['App']['CompactDatabase']();
+}
+
+export function CompareSearch(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CompareSearch'](arg1, arg2, arg3);
+}
+
+export function CompleteOnboardingStep(arg1) {
+  return window['go']['main']['App']['CompleteOnboardingStep'](arg1);
+}
+
+export function CopyGlyph(arg1) {
+  return window['go']['main']['App']['CopyGlyph'](arg1);
+}
+
+export function CopyGlyphCard(arg1) {
+  return window['go']['main']['App']['CopyGlyphCard'](arg1);
+}
+
+export function CopyGlyphImage(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CopyGlyphImage'](arg1, arg2, arg3);
+}
+
+export function CopyGlyphsAs(arg1, arg2) {
+  return window['go']['main']['App']['CopyGlyphsAs'](arg1, arg2);
+}
+
+export function CopyPreview(arg1, arg2) {
+  return window['go']['main']['App']['CopyPreview'](arg1, arg2);
+}
+
+export function CopyScratchpad(arg1) {
+  return window['go']['main']['App']['CopyScratchpad'](arg1);
+}
+
 export function CopyToClipboard(arg1) {
   return window['go']['main']['App']['CopyToClipboard'](arg1);
 }
 
+export function CreateCollection(arg1) {
+  return window['go']['main']['App']['CreateCollection'](arg1);
+}
+
+export function CreateCopyRule(arg1, arg2) {
+  return window['go']['main']['App']['CreateCopyRule'](arg1, arg2);
+}
+
+export function CreateProfile(arg1) {
+  return window['go']['main']['App']['CreateProfile'](arg1);
+}
+
+export function CreateTagRule(arg1, arg2) {
+  return window['go']['main']['App']['CreateTagRule'](arg1, arg2);
+}
+
+export function DecryptProfile(arg1) {
+  return window['go']['main']['App']['DecryptProfile'](arg1);
+}
+
+export function DeleteCategoryGroup(arg1) {
+  return window['go']['main']['App']['DeleteCategoryGroup'](arg1);
+}
+
+export function DeleteCollection(arg1) {
+  return window['go']['main']['App']['DeleteCollection'](arg1);
+}
+
+export function DeleteCopyRule(arg1) {
+  return window['go']['main']['App']['DeleteCopyRule'](arg1);
+}
+
+export function DeleteProfile(arg1) {
+  return window['go']['main']['App']['DeleteProfile'](arg1);
+}
+
+export function DeleteTagRule(arg1) {
+  return window['go']['main']['App']['DeleteTagRule'](arg1);
+}
+
+export function DetachDatabase(arg1) {
+  return window['go']['main']['App']['DetachDatabase'](arg1);
+}
+
+export function DiffDatabases(arg1, arg2) {
+  return window['go']['main']['App']['DiffDatabases'](arg1, arg2);
+}
+
+export function DisableIntegration() {
+  return window['go']['main']['App']['DisableIntegration']();
+}
+
+export function DismissSampleData() {
+  return window['go']['main']['App']['DismissSampleData']();
+}
+
+export function DownloadUpdate() {
+  return window['go']['main']['App']['DownloadUpdate']();
+}
+
+export function EnableIntegration(arg1) {
+  return window['go']['main']['App']['EnableIntegration'](arg1);
+}
+
+export function EnablePlugin(arg1, arg2) {
+  return window['go']['main']['App']['EnablePlugin'](arg1, arg2);
+}
+
+export function EncryptProfile(arg1) {
+  return window['go']['main']['App']['EncryptProfile'](arg1);
+}
+
+export function ExecuteCommand(arg1) {
+  return window['go']['main']['App']['ExecuteCommand'](arg1);
+}
+
+export function ExportCheatSheet(arg1, arg2) {
+  return window['go']['main']['App']['ExportCheatSheet'](arg1, arg2);
+}
+
+export function ExportCollectionImages(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ExportCollectionImages'](arg1, arg2, arg3, arg4);
+}
+
+export function ExportDatabaseDiff(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ExportDatabaseDiff'](arg1, arg2, arg3, arg4);
+}
+
+export function ExportFavorites(arg1) {
+  return window['go']['main']['App']['ExportFavorites'](arg1);
+}
+
+export function ExportGlyphsAs(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportGlyphsAs'](arg1, arg2, arg3);
+}
+
+export function ExportHTMLCheatSheet(arg1, arg2) {
+  return window['go']['main']['App']['ExportHTMLCheatSheet'](arg1, arg2);
+}
+
+export function ExportScope(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportScope'](arg1, arg2, arg3);
+}
+
+export function ExportSearchResults(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ExportSearchResults'](arg1, arg2, arg3, arg4);
+}
+
+export function ExportSnippets(arg1, arg2) {
+  return window['go']['main']['App']['ExportSnippets'](arg1, arg2);
+}
+
+export function FormatGlyphs(arg1, arg2) {
+  return window['go']['main']['App']['FormatGlyphs'](arg1, arg2);
+}
+
+export function GeneratePromptConfig(arg1, arg2) {
+  return window['go']['main']['App']['GeneratePromptConfig'](arg1, arg2);
+}
+
+export function GetActiveOperations() {
+  return window['go']['main']['App']['GetActiveOperations']();
+}
+
+export function GetActiveProfile() {
+  return window['go']['main']['App']['GetActiveProfile']();
+}
+
+export function GetAvailableLocales() {
+  return window['go']['main']['App']['GetAvailableLocales']();
+}
+
+export function GetCapabilities() {
+  return window['go']['main']['App']['GetCapabilities']();
+}
+
 export function GetCategories() {
   return window['go']['main']['App']['GetCategories']();
 }
 
-export function GetFavorites() {
-  return window['go']['main']['App']['GetFavorites']();
+export function GetCategoryGroups() {
+  return window['go']['main']['App']['GetCategoryGroups']();
+}
+
+export function GetCategoryInfo(arg1) {
+  return window['go']['main']['App']['GetCategoryInfo'](arg1);
+}
+
+export function GetCategorySamples(arg1) {
+  return window['go']['main']['App']['GetCategorySamples'](arg1);
+}
+
+export function GetClickThrough() {
+  return window['go']['main']['App']['GetClickThrough']();
+}
+
+export function GetClipboardBackends() {
+  return window['go']['main']['App']['GetClipboardBackends']();
+}
+
+export function GetClipboardWatch() {
+  return window['go']['main']['App']['GetClipboardWatch']();
+}
+
+export function GetCollection(arg1) {
+  return window['go']['main']['App']['GetCollection'](arg1);
+}
+
+export function GetCompare() {
+  return window['go']['main']['App']['GetCompare']();
+}
+
+export function GetCopyTarget() {
+  return window['go']['main']['App']['GetCopyTarget']();
+}
+
+export function GetCurrentDatabase() {
+  return window['go']['main']['App']['GetCurrentDatabase']();
+}
+
+export function GetDataStatus() {
+  return window['go']['main']['App']['GetDataStatus']();
+}
+
+export function GetDatabaseDiagnostics() {
+  return window['go']['main']['App']['GetDatabaseDiagnostics']();
+}
+
+export function GetDatabaseInfo() {
+  return window['go']['main']['App']['GetDatabaseInfo']();
+}
+
+export function GetDatabaseSizes() {
+  return window['go']['main']['App']['GetDatabaseSizes']();
+}
+
+export function GetDocTemplate(arg1) {
+  return window['go']['main']['App']['GetDocTemplate'](arg1);
+}
+
+export function GetEncryptionStatus() {
+  return window['go']['main']['App']['GetEncryptionStatus']();
+}
+
+export function GetErrorCodes() {
+  return window['go']['main']['App']['GetErrorCodes']();
+}
+
+export function GetFavorites(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetFavorites'](arg1, arg2, arg3);
+}
+
+export function GetGlyphCard(arg1) {
+  return window['go']['main']['App']['GetGlyphCard'](arg1);
+}
+
+export function GetGlyphCardPlaceholders() {
+  return window['go']['main']['App']['GetGlyphCardPlaceholders']();
+}
+
+export function GetGlyphCardTemplate() {
+  return window['go']['main']['App']['GetGlyphCardTemplate']();
+}
+
+export function GetGlyphDeprecation(arg1) {
+  return window['go']['main']['App']['GetGlyphDeprecation'](arg1);
+}
+
+export function GetGlyphDetails(arg1) {
+  return window['go']['main']['App']['GetGlyphDetails'](arg1);
+}
+
+export function GetGlyphs(arg1) {
+  return window['go']['main']['App']['GetGlyphs'](arg1);
+}
+
+export function GetGlyphsByBlock(arg1, arg2) {
+  return window['go']['main']['App']['GetGlyphsByBlock'](arg1, arg2);
+}
+
+export function GetGridPrefs() {
+  return window['go']['main']['App']['GetGridPrefs']();
+}
+
+export function GetHistorySettings() {
+  return window['go']['main']['App']['GetHistorySettings']();
+}
+
+export function GetHooks() {
+  return window['go']['main']['App']['GetHooks']();
+}
+
+export function GetInstalledColorFonts() {
+  return window['go']['main']['App']['GetInstalledColorFonts']();
+}
+
+export function GetInstalledNerdFonts() {
+  return window['go']['main']['App']['GetInstalledNerdFonts']();
+}
+
+export function GetIntegrationStatus() {
+  return window['go']['main']['App']['GetIntegrationStatus']();
+}
+
+export function GetJumpList(arg1) {
+  return window['go']['main']['App']['GetJumpList'](arg1);
+}
+
+export function GetLastSession() {
+  return window['go']['main']['App']['GetLastSession']();
+}
+
+export function GetLocalAPIStatus() {
+  return window['go']['main']['App']['GetLocalAPIStatus']();
+}
+
+export function GetLocale() {
+  return window['go']['main']['App']['GetLocale']();
+}
+
+export function GetMaintenanceStatus() {
+  return window['go']['main']['App']['GetMaintenanceStatus']();
+}
+
+export function GetOnboardingState() {
+  return window['go']['main']['App']['GetOnboardingState']();
+}
+
+export function GetPageSize() {
+  return window['go']['main']['App']['GetPageSize']();
+}
+
+export function GetPreviewTemplates() {
+  return window['go']['main']['App']['GetPreviewTemplates']();
+}
+
+export function GetQuickPicks(arg1) {
+  return window['go']['main']['App']['GetQuickPicks'](arg1);
+}
+
+export function GetRecentlyAddedGlyphs(arg1) {
+  return window['go']['main']['App']['GetRecentlyAddedGlyphs'](arg1);
 }
 
-export function GetGlyphs(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['GetGlyphs'](arg1, arg2, arg3, arg4);
+export function GetScoringFeedback() {
+  return window['go']['main']['App']['GetScoringFeedback']();
+}
+
+export function GetScratchpad() {
+  return window['go']['main']['App']['GetScratchpad']();
 }
 
 export function GetSearchHistory() {
   return window['go']['main']['App']['GetSearchHistory']();
 }
 
+export function GetSearchLatencyStats() {
+  return window['go']['main']['App']['GetSearchLatencyStats']();
+}
+
+export function GetSettings() {
+  return window['go']['main']['App']['GetSettings']();
+}
+
+export function GetSharedDatabase() {
+  return window['go']['main']['App']['GetSharedDatabase']();
+}
+
+export function GetSmartFilters() {
+  return window['go']['main']['App']['GetSmartFilters']();
+}
+
+export function GetStartupTimings() {
+  return window['go']['main']['App']['GetStartupTimings']();
+}
+
 export function GetStats() {
   return window['go']['main']['App']['GetStats']();
 }
 
+export function GetTheme() {
+  return window['go']['main']['App']['GetTheme']();
+}
+
+export function GetTranslations(arg1) {
+  return window['go']['main']['App']['GetTranslations'](arg1);
+}
+
+export function GetUnicodeBlocks() {
+  return window['go']['main']['App']['GetUnicodeBlocks']();
+}
+
+export function GetUpdateInfo() {
+  return window['go']['main']['App']['GetUpdateInfo']();
+}
+
+export function GetWindowPrefs() {
+  return window['go']['main']['App']['GetWindowPrefs']();
+}
+
+export function GetZoomLevel() {
+  return window['go']['main']['App']['GetZoomLevel']();
+}
+
+export function HasSampleData() {
+  return window['go']['main']['App']['HasSampleData']();
+}
+
+export function HideWindow() {
+  return window['go']['main']['App']['HideWindow']();
+}
+
+export function ImportGlyphs(arg1, arg2) {
+  return window['go']['main']['App']['ImportGlyphs'](arg1, arg2);
+}
+
+export function ImportGlyphsRelease(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ImportGlyphsRelease'](arg1, arg2, arg3);
+}
+
+export function IsReadOnly() {
+  return window['go']['main']['App']['IsReadOnly']();
+}
+
+export function ListCollections() {
+  return window['go']['main']['App']['ListCollections']();
+}
+
+export function ListCommands() {
+  return window['go']['main']['App']['ListCommands']();
+}
+
+export function ListCopyRules() {
+  return window['go']['main']['App']['ListCopyRules']();
+}
+
+export function ListExporters() {
+  return window['go']['main']['App']['ListExporters']();
+}
+
+export function ListGlyphHotkeys() {
+  return window['go']['main']['App']['ListGlyphHotkeys']();
+}
+
+export function ListPlugins() {
+  return window['go']['main']['App']['ListPlugins']();
+}
+
+export function ListProfiles() {
+  return window['go']['main']['App']['ListProfiles']();
+}
+
+export function ListScoringProfiles() {
+  return window['go']['main']['App']['ListScoringProfiles']();
+}
+
+export function ListSources() {
+  return window['go']['main']['App']['ListSources']();
+}
+
+export function ListTagRules() {
+  return window['go']['main']['App']['ListTagRules']();
+}
+
+export function ListTags() {
+  return window['go']['main']['App']['ListTags']();
+}
+
+export function LockProfile() {
+  return window['go']['main']['App']['LockProfile']();
+}
+
+export function MinimiseWindow() {
+  return window['go']['main']['App']['MinimiseWindow']();
+}
+
+export function NotifyUser(arg1, arg2) {
+  return window['go']['main']['App']['NotifyUser'](arg1, arg2);
+}
+
+export function OpenDataFolder() {
+  return window['go']['main']['App']['OpenDataFolder']();
+}
+
+export function OpenDatabase(arg1) {
+  return window['go']['main']['App']['OpenDatabase'](arg1);
+}
+
+export function OpenExportedFile(arg1) {
+  return window['go']['main']['App']['OpenExportedFile'](arg1);
+}
+
+export function OpenLogFile() {
+  return window['go']['main']['App']['OpenLogFile']();
+}
+
+export function OpenPluginsFolder() {
+  return window['go']['main']['App']['OpenPluginsFolder']();
+}
+
+export function PreviewTagRule(arg1, arg2) {
+  return window['go']['main']['App']['PreviewTagRule'](arg1, arg2);
+}
+
+export function PreviewTagRules() {
+  return window['go']['main']['App']['PreviewTagRules']();
+}
+
+export function RebuildCache() {
+  return window['go']['main']['App']['RebuildCache']();
+}
+
+export function RecordSearchFeedback(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['RecordSearchFeedback'](arg1, arg2, arg3, arg4);
+}
+
+export function RefreshDeprecations() {
+  return window['go']['main']['App']['RefreshDeprecations']();
+}
+
+export function RegenerateLocalAPIToken() {
+  return window['go']['main']['App']['RegenerateLocalAPIToken']();
+}
+
+export function ReloadPlugins() {
+  return window['go']['main']['App']['ReloadPlugins']();
+}
+
+export function RemoveFromCollection(arg1, arg2) {
+  return window['go']['main']['App']['RemoveFromCollection'](arg1, arg2);
+}
+
+export function RemoveFromCompare(arg1) {
+  return window['go']['main']['App']['RemoveFromCompare'](arg1);
+}
+
+export function RemoveFromScratchpad(arg1) {
+  return window['go']['main']['App']['RemoveFromScratchpad'](arg1);
+}
+
+export function RemoveGlyphHotkey(arg1) {
+  return window['go']['main']['App']['RemoveGlyphHotkey'](arg1);
+}
+
+export function RemoveSampleData() {
+  return window['go']['main']['App']['RemoveSampleData']();
+}
+
+export function RenderContactSheet(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RenderContactSheet'](arg1, arg2, arg3);
+}
+
+export function RenderPreview(arg1, arg2) {
+  return window['go']['main']['App']['RenderPreview'](arg1, arg2);
+}
+
+export function ResetOnboarding() {
+  return window['go']['main']['App']['ResetOnboarding']();
+}
+
+export function RunMaintenanceNow(arg1) {
+  return window['go']['main']['App']['RunMaintenanceNow'](arg1);
+}
+
+export function SaveCategoryGroup(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SaveCategoryGroup'](arg1, arg2, arg3);
+}
+
+export function SaveSessionScroll(arg1) {
+  return window['go']['main']['App']['SaveSessionScroll'](arg1);
+}
+
+export function ScanDirectoryForGlyphs(arg1) {
+  return window['go']['main']['App']['ScanDirectoryForGlyphs'](arg1);
+}
+
+export function SeedSampleData() {
+  return window['go']['main']['App']['SeedSampleData']();
+}
+
+export function SetAlwaysOnTop(arg1) {
+  return window['go']['main']['App']['SetAlwaysOnTop'](arg1);
+}
+
+export function SetAutoUpdateCheck(arg1) {
+  return window['go']['main']['App']['SetAutoUpdateCheck'](arg1);
+}
+
+export function SetCategoryEnabled(arg1, arg2) {
+  return window['go']['main']['App']['SetCategoryEnabled'](arg1, arg2);
+}
+
+export function SetCategorySort(arg1) {
+  return window['go']['main']['App']['SetCategorySort'](arg1);
+}
+
+export function SetClickThrough(arg1) {
+  return window['go']['main']['App']['SetClickThrough'](arg1);
+}
+
+export function SetClickThroughRegions(arg1) {
+  return window['go']['main']['App']['SetClickThroughRegions'](arg1);
+}
+
+export function SetClipboardBackend(arg1) {
+  return window['go']['main']['App']['SetClipboardBackend'](arg1);
+}
+
+export function SetClipboardWatch(arg1) {
+  return window['go']['main']['App']['SetClipboardWatch'](arg1);
+}
+
+export function SetDocTemplate(arg1, arg2) {
+  return window['go']['main']['App']['SetDocTemplate'](arg1, arg2);
+}
+
+export function SetFrameless(arg1) {
+  return window['go']['main']['App']['SetFrameless'](arg1);
+}
+
+export function SetGlyphCardTemplate(arg1) {
+  return window['go']['main']['App']['SetGlyphCardTemplate'](arg1);
+}
+
+export function SetGridPrefs(arg1) {
+  return window['go']['main']['App']['SetGridPrefs'](arg1);
+}
+
+export function SetHistorySettings(arg1) {
+  return window['go']['main']['App']['SetHistorySettings'](arg1);
+}
+
+export function SetHook(arg1) {
+  return window['go']['main']['App']['SetHook'](arg1);
+}
+
+export function SetIncognito(arg1) {
+  return window['go']['main']['App']['SetIncognito'](arg1);
+}
+
+export function SetLocalAPIEnabled(arg1) {
+  return window['go']['main']['App']['SetLocalAPIEnabled'](arg1);
+}
+
+export function SetLocalAPIPort(arg1) {
+  return window['go']['main']['App']['SetLocalAPIPort'](arg1);
+}
+
+export function SetLocale(arg1) {
+  return window['go']['main']['App']['SetLocale'](arg1);
+}
+
+export function SetMaintenanceInterval(arg1, arg2) {
+  return window['go']['main']['App']['SetMaintenanceInterval'](arg1, arg2);
+}
+
+export function SetOpacity(arg1) {
+  return window['go']['main']['App']['SetOpacity'](arg1);
+}
+
+export function SetPageSize(arg1) {
+  return window['go']['main']['App']['SetPageSize'](arg1);
+}
+
+export function SetReadOnlyMode(arg1) {
+  return window['go']['main']['App']['SetReadOnlyMode'](arg1);
+}
+
+export function SetScoringProfile(arg1) {
+  return window['go']['main']['App']['SetScoringProfile'](arg1);
+}
+
+export function SetScratchpadPersist(arg1) {
+  return window['go']['main']['App']['SetScratchpadPersist'](arg1);
+}
+
+export function SetSearchMode(arg1) {
+  return window['go']['main']['App']['SetSearchMode'](arg1);
+}
+
+export function SetSetting(arg1, arg2) {
+  return window['go']['main']['App']['SetSetting'](arg1, arg2);
+}
+
+export function SetSmartCopy(arg1) {
+  return window['go']['main']['App']['SetSmartCopy'](arg1);
+}
+
+export function SetTaskbarProgress(arg1) {
+  return window['go']['main']['App']['SetTaskbarProgress'](arg1);
+}
+
+export function SetTheme(arg1) {
+  return window['go']['main']['App']['SetTheme'](arg1);
+}
+
+export function SetWindowEffect(arg1) {
+  return window['go']['main']['App']['SetWindowEffect'](arg1);
+}
+
+export function SetZoomLevel(arg1) {
+  return window['go']['main']['App']['SetZoomLevel'](arg1);
+}
+
+export function ShouldOfferSampleData() {
+  return window['go']['main']['App']['ShouldOfferSampleData']();
+}
+
+export function ShowWindow() {
+  return window['go']['main']['App']['ShowWindow']();
+}
+
+export function SubsetFont(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SubsetFont'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SwitchProfile(arg1) {
+  return window['go']['main']['App']['SwitchProfile'](arg1);
+}
+
+export function TagSearchResults(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['TagSearchResults'](arg1, arg2, arg3, arg4);
+}
+
+export function TestHook(arg1, arg2) {
+  return window['go']['main']['App']['TestHook'](arg1, arg2);
+}
+
 export function ToggleFavorite(arg1) {
   return window['go']['main']['App']['ToggleFavorite'](arg1);
 }
+
+export function ToggleTheme() {
+  return window['go']['main']['App']['ToggleTheme']();
+}
+
+export function UndoBulkEdit() {
+  return window['go']['main']['App']['UndoBulkEdit']();
+}
+
+export function UnlockProfile(arg1, arg2) {
+  return window['go']['main']['App']['UnlockProfile'](arg1, arg2);
+}
+
+export function UpdateCopyRule(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['UpdateCopyRule'](arg1, arg2, arg3, arg4);
+}
+
+export function UpdateTagRule(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['UpdateTagRule'](arg1, arg2, arg3, arg4);
+}
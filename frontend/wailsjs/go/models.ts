@@ -1,13 +1,384 @@
+export namespace glyphdata {
+	
+	export class CodepointChange {
+	    name: string;
+	    from: string;
+	    to: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CodepointChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class GlyphRename {
+	    from: string;
+	    to: string;
+	    codepoint: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GlyphRename(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.codepoint = source["codepoint"];
+	    }
+	}
+	export class DiffGlyph {
+	    name: string;
+	    codepoint: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffGlyph(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.codepoint = source["codepoint"];
+	    }
+	}
+	export class DatabaseDiff {
+	    pathA: string;
+	    pathB: string;
+	    added: DiffGlyph[];
+	    removed: DiffGlyph[];
+	    renamed: GlyphRename[];
+	    codepointChanged: CodepointChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pathA = source["pathA"];
+	        this.pathB = source["pathB"];
+	        this.added = this.convertValues(source["added"], DiffGlyph);
+	        this.removed = this.convertValues(source["removed"], DiffGlyph);
+	        this.renamed = this.convertValues(source["renamed"], GlyphRename);
+	        this.codepointChanged = this.convertValues(source["codepointChanged"], CodepointChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+
+}
+
 export namespace main {
 	
+	export class CacheLoadTimings {
+	    queryMs: number;
+	    mergeMs: number;
+	    indexMs: number;
+	    totalMs: number;
+	    glyphs: number;
+	    loadedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CacheLoadTimings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.queryMs = source["queryMs"];
+	        this.mergeMs = source["mergeMs"];
+	        this.indexMs = source["indexMs"];
+	        this.totalMs = source["totalMs"];
+	        this.glyphs = source["glyphs"];
+	        this.loadedAt = source["loadedAt"];
+	    }
+	}
+	export class Capability {
+	    available: boolean;
+	    reason?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Capability(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.available = source["available"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class Capabilities {
+	    os: string;
+	    arch: string;
+	    features: Record<string, Capability>;
+	    windowEffects: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Capabilities(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.os = source["os"];
+	        this.arch = source["arch"];
+	        this.features = this.convertValues(source["features"], Capability, true);
+	        this.windowEffects = source["windowEffects"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CategoryGroup {
+	    name: string;
+	    title: string;
+	    members: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CategoryGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.title = source["title"];
+	        this.members = source["members"];
+	    }
+	}
+	export class IconSetLicense {
+	    category: string;
+	    iconSet: string;
+	    license: string;
+	    url?: string;
+	    attribution?: string;
+	    requiresAttribution: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new IconSetLicense(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.category = source["category"];
+	        this.iconSet = source["iconSet"];
+	        this.license = source["license"];
+	        this.url = source["url"];
+	        this.attribution = source["attribution"];
+	        this.requiresAttribution = source["requiresAttribution"];
+	    }
+	}
+	export class CategoryInfo {
+	    name: string;
+	    displayName: string;
+	    count: number;
+	    enabled: boolean;
+	    sample: string;
+	    lastUsed?: string;
+	    license?: IconSetLicense;
+	    members?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CategoryInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.displayName = source["displayName"];
+	        this.count = source["count"];
+	        this.enabled = source["enabled"];
+	        this.sample = source["sample"];
+	        this.lastUsed = source["lastUsed"];
+	        this.license = this.convertValues(source["license"], IconSetLicense);
+	        this.members = source["members"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ClickRegion {
+	    x: number;
+	    y: number;
+	    width: number;
+	    height: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClickRegion(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	    }
+	}
+	export class ClipboardBackendInfo {
+	    name: string;
+	    available: boolean;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClipboardBackendInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.available = source["available"];
+	        this.active = source["active"];
+	    }
+	}
+	export class Collection {
+	    id: number;
+	    name: string;
+	    count: number;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Collection(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.count = source["count"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class Command {
+	    id: string;
+	    title: string;
+	    keywords?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Command(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.keywords = source["keywords"];
+	    }
+	}
+	export class ScoreBreakdown {
+	    matchType: string;
+	    matchedOn: string;
+	    base: number;
+	    boundary: number;
+	    consecutive: number;
+	    length: number;
+	    typo: number;
+	    frequency: number;
+	    favorite: number;
+	    score: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoreBreakdown(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.matchType = source["matchType"];
+	        this.matchedOn = source["matchedOn"];
+	        this.base = source["base"];
+	        this.boundary = source["boundary"];
+	        this.consecutive = source["consecutive"];
+	        this.length = source["length"];
+	        this.typo = source["typo"];
+	        this.frequency = source["frequency"];
+	        this.favorite = source["favorite"];
+	        this.score = source["score"];
+	    }
+	}
+	export class GlyphDeprecation {
+	    replacement?: string;
+	    replacementId?: number;
+	    note: string;
+	    removedIn?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GlyphDeprecation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.replacement = source["replacement"];
+	        this.replacementId = source["replacementId"];
+	        this.note = source["note"];
+	        this.removedIn = source["removedIn"];
+	    }
+	}
 	export class GlyphMatch {
 	    id: number;
 	    name: string;
 	    glyph: string;
 	    category?: string;
 	    tags?: string;
+	    block?: string;
+	    width?: string;
+	    description?: string;
+	    source?: string;
 	    score: number;
 	    isFavorite: boolean;
+	    favoritedAt?: string;
+	    timesUsed?: number;
+	    deprecated?: GlyphDeprecation;
+	    explain?: ScoreBreakdown;
 	
 	    static createFrom(source: any = {}) {
 	        return new GlyphMatch(source);
@@ -20,28 +391,50 @@ export namespace main {
 	        this.glyph = source["glyph"];
 	        this.category = source["category"];
 	        this.tags = source["tags"];
+	        this.block = source["block"];
+	        this.width = source["width"];
+	        this.description = source["description"];
+	        this.source = source["source"];
 	        this.score = source["score"];
 	        this.isFavorite = source["isFavorite"];
+	        this.favoritedAt = source["favoritedAt"];
+	        this.timesUsed = source["timesUsed"];
+	        this.deprecated = this.convertValues(source["deprecated"], GlyphDeprecation);
+	        this.explain = this.convertValues(source["explain"], ScoreBreakdown);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class SearchResult {
+	export class CompareView {
 	    glyphs: GlyphMatch[];
-	    total: number;
-	    searchTime: number;
-	    hasMore: boolean;
-	    categories?: string[];
+	    sizes: number[];
+	    max: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new SearchResult(source);
+	        return new CompareView(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.glyphs = this.convertValues(source["glyphs"], GlyphMatch);
-	        this.total = source["total"];
-	        this.searchTime = source["searchTime"];
-	        this.hasMore = source["hasMore"];
-	        this.categories = source["categories"];
+	        this.sizes = source["sizes"];
+	        this.max = source["max"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -62,6 +455,1756 @@ export namespace main {
 		    return a;
 		}
 	}
+	export class CopyResult {
+	    success: boolean;
+	    backend?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CopyResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.backend = source["backend"];
+	        this.error = source["error"];
+	    }
+	}
+	export class CopyRule {
+	    id: number;
+	    appPattern: string;
+	    format: string;
+	    enabled: boolean;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CopyRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.appPattern = source["appPattern"];
+	        this.format = source["format"];
+	        this.enabled = source["enabled"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class ForegroundApp {
+	    name: string;
+	    id: string;
+	    pid: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ForegroundApp(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.id = source["id"];
+	        this.pid = source["pid"];
+	    }
+	}
+	export class CopyTarget {
+	    app?: ForegroundApp;
+	    format: string;
+	    ruleId?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CopyTarget(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.app = this.convertValues(source["app"], ForegroundApp);
+	        this.format = source["format"];
+	        this.ruleId = source["ruleId"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DataStatus {
+	    mode: string;
+	    message?: string;
+	    favoritesAvailable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DataStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.message = source["message"];
+	        this.favoritesAvailable = source["favoritesAvailable"];
+	    }
+	}
+	export class DatabaseSize {
+	    name: string;
+	    path: string;
+	    sizeBytes: number;
+	    walBytes: number;
+	    freeBytes: number;
+	    freePercent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseSize(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.sizeBytes = source["sizeBytes"];
+	        this.walBytes = source["walBytes"];
+	        this.freeBytes = source["freeBytes"];
+	        this.freePercent = source["freePercent"];
+	    }
+	}
+	export class DatabaseCompaction {
+	    before: DatabaseSize;
+	    after: DatabaseSize;
+	    reclaimedBytes: number;
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseCompaction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.before = this.convertValues(source["before"], DatabaseSize);
+	        this.after = this.convertValues(source["after"], DatabaseSize);
+	        this.reclaimedBytes = source["reclaimedBytes"];
+	        this.durationMs = source["durationMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PragmaState {
+	    journalMode: string;
+	    busyTimeout: number;
+	    foreignKeys: boolean;
+	    synchronous: number;
+	    queryOnly: boolean;
+	    pageSize: number;
+	    pageCount: number;
+	    freelistCount: number;
+	    openConns: number;
+	    inUse: number;
+	    maxOpenConns: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PragmaState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.journalMode = source["journalMode"];
+	        this.busyTimeout = source["busyTimeout"];
+	        this.foreignKeys = source["foreignKeys"];
+	        this.synchronous = source["synchronous"];
+	        this.queryOnly = source["queryOnly"];
+	        this.pageSize = source["pageSize"];
+	        this.pageCount = source["pageCount"];
+	        this.freelistCount = source["freelistCount"];
+	        this.openConns = source["openConns"];
+	        this.inUse = source["inUse"];
+	        this.maxOpenConns = source["maxOpenConns"];
+	    }
+	}
+	export class DatabaseDiagnostics {
+	    path: string;
+	    writer?: PragmaState;
+	    reader?: PragmaState;
+	    user?: PragmaState;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseDiagnostics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.writer = this.convertValues(source["writer"], PragmaState);
+	        this.reader = this.convertValues(source["reader"], PragmaState);
+	        this.user = this.convertValues(source["user"], PragmaState);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DatabaseInfo {
+	    path: string;
+	    version: string;
+	    lastUpdated: string;
+	    releaseTag: string;
+	    glyphCount: number;
+	    sizeBytes: number;
+	    metadata: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.version = source["version"];
+	        this.lastUpdated = source["lastUpdated"];
+	        this.releaseTag = source["releaseTag"];
+	        this.glyphCount = source["glyphCount"];
+	        this.sizeBytes = source["sizeBytes"];
+	        this.metadata = source["metadata"];
+	    }
+	}
+	
+	export class EncryptionStatus {
+	    profile: string;
+	    encrypted: boolean;
+	    unlocked: boolean;
+	    supported: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new EncryptionStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profile = source["profile"];
+	        this.encrypted = source["encrypted"];
+	        this.unlocked = source["unlocked"];
+	        this.supported = source["supported"];
+	    }
+	}
+	export class ErrorCodeInfo {
+	    code: string;
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ErrorCodeInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.description = source["description"];
+	    }
+	}
+	export class ExporterInfo {
+	    name: string;
+	    extensions: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ExporterInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.extensions = source["extensions"];
+	    }
+	}
+	
+	export class Glyph {
+	    id: number;
+	    name: string;
+	    glyph: string;
+	    category?: string;
+	    tags?: string;
+	    block?: string;
+	    width?: string;
+	    description?: string;
+	    source?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Glyph(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.glyph = source["glyph"];
+	        this.category = source["category"];
+	        this.tags = source["tags"];
+	        this.block = source["block"];
+	        this.width = source["width"];
+	        this.description = source["description"];
+	        this.source = source["source"];
+	    }
+	}
+	
+	export class GlyphMetrics {
+	    font: string;
+	    unitsPerEm: number;
+	    advance: number;
+	    xMin: number;
+	    yMin: number;
+	    xMax: number;
+	    yMax: number;
+	    ascent: number;
+	    descent: number;
+	    capHeight: number;
+	    xHeight: number;
+	    verticalOffset: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GlyphMetrics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.font = source["font"];
+	        this.unitsPerEm = source["unitsPerEm"];
+	        this.advance = source["advance"];
+	        this.xMin = source["xMin"];
+	        this.yMin = source["yMin"];
+	        this.xMax = source["xMax"];
+	        this.yMax = source["yMax"];
+	        this.ascent = source["ascent"];
+	        this.descent = source["descent"];
+	        this.capHeight = source["capHeight"];
+	        this.xHeight = source["xHeight"];
+	        this.verticalOffset = source["verticalOffset"];
+	    }
+	}
+	export class GlyphDetails {
+	    id: number;
+	    name: string;
+	    glyph: string;
+	    category?: string;
+	    tags?: string;
+	    block?: string;
+	    width?: string;
+	    description?: string;
+	    source?: string;
+	    codepoint: string;
+	    utf8: string;
+	    htmlEntity: string;
+	    iconSet: string;
+	    isFavorite: boolean;
+	    metrics?: GlyphMetrics;
+	
+	    static createFrom(source: any = {}) {
+	        return new GlyphDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.glyph = source["glyph"];
+	        this.category = source["category"];
+	        this.tags = source["tags"];
+	        this.block = source["block"];
+	        this.width = source["width"];
+	        this.description = source["description"];
+	        this.source = source["source"];
+	        this.codepoint = source["codepoint"];
+	        this.utf8 = source["utf8"];
+	        this.htmlEntity = source["htmlEntity"];
+	        this.iconSet = source["iconSet"];
+	        this.isFavorite = source["isFavorite"];
+	        this.metrics = this.convertValues(source["metrics"], GlyphMetrics);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GlyphHotkey {
+	    accelerator: string;
+	    glyphId: number;
+	    name: string;
+	    glyph: string;
+	    registered: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GlyphHotkey(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.accelerator = source["accelerator"];
+	        this.glyphId = source["glyphId"];
+	        this.name = source["name"];
+	        this.glyph = source["glyph"];
+	        this.registered = source["registered"];
+	        this.error = source["error"];
+	    }
+	}
+	export class GlyphLocation {
+	    path: string;
+	    line: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GlyphLocation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.line = source["line"];
+	    }
+	}
+	
+	
+	export class UnknownGlyphUsage {
+	    codepoint: string;
+	    count: number;
+	    locations: GlyphLocation[];
+	
+	    static createFrom(source: any = {}) {
+	        return new UnknownGlyphUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.codepoint = source["codepoint"];
+	        this.count = source["count"];
+	        this.locations = this.convertValues(source["locations"], GlyphLocation);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GlyphUsage {
+	    id: number;
+	    name: string;
+	    glyph: string;
+	    category?: string;
+	    tags?: string;
+	    block?: string;
+	    width?: string;
+	    description?: string;
+	    source?: string;
+	    count: number;
+	    locations: GlyphLocation[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GlyphUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.glyph = source["glyph"];
+	        this.category = source["category"];
+	        this.tags = source["tags"];
+	        this.block = source["block"];
+	        this.width = source["width"];
+	        this.description = source["description"];
+	        this.source = source["source"];
+	        this.count = source["count"];
+	        this.locations = this.convertValues(source["locations"], GlyphLocation);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GlyphScanReport {
+	    root: string;
+	    filesScanned: number;
+	    filesSkipped: number;
+	    glyphs: GlyphUsage[];
+	    unknown: UnknownGlyphUsage[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GlyphScanReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.root = source["root"];
+	        this.filesScanned = source["filesScanned"];
+	        this.filesSkipped = source["filesSkipped"];
+	        this.glyphs = this.convertValues(source["glyphs"], GlyphUsage);
+	        this.unknown = this.convertValues(source["unknown"], UnknownGlyphUsage);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class GridPrefs {
+	    tileSize: string;
+	    columns: number;
+	    showNames: boolean;
+	    showCodepoints: boolean;
+	    density: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GridPrefs(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tileSize = source["tileSize"];
+	        this.columns = source["columns"];
+	        this.showNames = source["showNames"];
+	        this.showCodepoints = source["showCodepoints"];
+	        this.density = source["density"];
+	    }
+	}
+	export class HistorySettings {
+	    maxSize: number;
+	    retentionDays: number;
+	    incognito: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistorySettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxSize = source["maxSize"];
+	        this.retentionDays = source["retentionDays"];
+	        this.incognito = source["incognito"];
+	    }
+	}
+	export class HookConfig {
+	    event: string;
+	    command: string;
+	    enabled: boolean;
+	    timeoutSeconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HookConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.event = source["event"];
+	        this.command = source["command"];
+	        this.enabled = source["enabled"];
+	        this.timeoutSeconds = source["timeoutSeconds"];
+	    }
+	}
+	export class HookRun {
+	    output: string;
+	    exitCode: number;
+	    duration: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HookRun(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.output = source["output"];
+	        this.exitCode = source["exitCode"];
+	        this.duration = source["duration"];
+	    }
+	}
+	
+	export class ImportResult {
+	    imported: number;
+	    removed: number;
+	    skipped: number;
+	    total: number;
+	    added: number;
+	    releaseTag?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.imported = source["imported"];
+	        this.removed = source["removed"];
+	        this.skipped = source["skipped"];
+	        this.total = source["total"];
+	        this.added = source["added"];
+	        this.releaseTag = source["releaseTag"];
+	    }
+	}
+	export class IntegrationOptions {
+	    protocol: boolean;
+	    startMenu: boolean;
+	    desktop: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new IntegrationOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.protocol = source["protocol"];
+	        this.startMenu = source["startMenu"];
+	        this.desktop = source["desktop"];
+	    }
+	}
+	export class IntegrationStatus {
+	    enabled: boolean;
+	    options: IntegrationOptions;
+	    items: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new IntegrationStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.options = this.convertValues(source["options"], IntegrationOptions);
+	        this.items = source["items"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JumpListEntry {
+	    kind: string;
+	    value: string;
+	    title: string;
+	    url: string;
+	    usedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JumpListEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.value = source["value"];
+	        this.title = source["title"];
+	        this.url = source["url"];
+	        this.usedAt = source["usedAt"];
+	    }
+	}
+	export class LocalAPIStatus {
+	    enabled: boolean;
+	    running: boolean;
+	    port: number;
+	    url: string;
+	    token: string;
+	    clients: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LocalAPIStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.running = source["running"];
+	        this.port = source["port"];
+	        this.url = source["url"];
+	        this.token = source["token"];
+	        this.clients = source["clients"];
+	        this.error = source["error"];
+	    }
+	}
+	export class LocaleInfo {
+	    code: string;
+	    name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LocaleInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.name = source["name"];
+	    }
+	}
+	export class MaintenanceTaskStatus {
+	    name: string;
+	    title: string;
+	    intervalMinutes: number;
+	    lastRun: string;
+	    lastDurationMs: number;
+	    lastError?: string;
+	    nextRun: string;
+	    running: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MaintenanceTaskStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.title = source["title"];
+	        this.intervalMinutes = source["intervalMinutes"];
+	        this.lastRun = source["lastRun"];
+	        this.lastDurationMs = source["lastDurationMs"];
+	        this.lastError = source["lastError"];
+	        this.nextRun = source["nextRun"];
+	        this.running = source["running"];
+	    }
+	}
+	export class MaintenanceStatus {
+	    running: boolean;
+	    tasks: MaintenanceTaskStatus[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MaintenanceStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.running = source["running"];
+	        this.tasks = this.convertValues(source["tasks"], MaintenanceTaskStatus);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class MigrationIssue {
+	    path: string;
+	    line: number;
+	    match: string;
+	    codepoint?: string;
+	    oldName?: string;
+	    replacement?: Glyph;
+	    replacementText?: string;
+	    note: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MigrationIssue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.line = source["line"];
+	        this.match = source["match"];
+	        this.codepoint = source["codepoint"];
+	        this.oldName = source["oldName"];
+	        this.replacement = this.convertValues(source["replacement"], Glyph);
+	        this.replacementText = source["replacementText"];
+	        this.note = source["note"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MigrationReport {
+	    root: string;
+	    filesScanned: number;
+	    filesSkipped: number;
+	    issues: MigrationIssue[];
+	    fixable: number;
+	    patchPath?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MigrationReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.root = source["root"];
+	        this.filesScanned = source["filesScanned"];
+	        this.filesSkipped = source["filesSkipped"];
+	        this.issues = this.convertValues(source["issues"], MigrationIssue);
+	        this.fixable = source["fixable"];
+	        this.patchPath = source["patchPath"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OnboardingStep {
+	    id: string;
+	    completed: boolean;
+	    completedAt?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OnboardingStep(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.completed = source["completed"];
+	        this.completedAt = source["completedAt"];
+	    }
+	}
+	export class OnboardingState {
+	    steps: OnboardingStep[];
+	    currentStep: string;
+	    completed: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new OnboardingState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steps = this.convertValues(source["steps"], OnboardingStep);
+	        this.currentStep = source["currentStep"];
+	        this.completed = source["completed"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class OperationEvent {
+	    id: string;
+	    kind: string;
+	    status: string;
+	    message: string;
+	    progress: number;
+	    error?: string;
+	    timestamp: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OperationEvent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.status = source["status"];
+	        this.message = source["message"];
+	        this.progress = source["progress"];
+	        this.error = source["error"];
+	        this.timestamp = source["timestamp"];
+	    }
+	}
+	export class PageSize {
+	    size: number;
+	    min: number;
+	    max: number;
+	    default: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PageSize(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.size = source["size"];
+	        this.min = source["min"];
+	        this.max = source["max"];
+	        this.default = source["default"];
+	    }
+	}
+	export class PluginExporter {
+	    name: string;
+	    extensions: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PluginExporter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.extensions = source["extensions"];
+	    }
+	}
+	export class PluginInfo {
+	    name: string;
+	    version: string;
+	    description: string;
+	    command: string[];
+	    provides: string[];
+	    exporters?: PluginExporter[];
+	    copyFormats?: string[];
+	    dir: string;
+	    enabled: boolean;
+	    failed: boolean;
+	    lastError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PluginInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.version = source["version"];
+	        this.description = source["description"];
+	        this.command = source["command"];
+	        this.provides = source["provides"];
+	        this.exporters = this.convertValues(source["exporters"], PluginExporter);
+	        this.copyFormats = source["copyFormats"];
+	        this.dir = source["dir"];
+	        this.enabled = source["enabled"];
+	        this.failed = source["failed"];
+	        this.lastError = source["lastError"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Profile {
+	    name: string;
+	    active: boolean;
+	    encrypted: boolean;
+	    unlocked: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Profile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.active = source["active"];
+	        this.encrypted = source["encrypted"];
+	        this.unlocked = source["unlocked"];
+	    }
+	}
+	export class Ranking {
+	    profile: string;
+	    glyphs: GlyphMatch[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Ranking(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profile = source["profile"];
+	        this.glyphs = this.convertValues(source["glyphs"], GlyphMatch);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RecentlyAdded {
+	    releaseTag: string;
+	    total: number;
+	    glyphs: GlyphMatch[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentlyAdded(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.releaseTag = source["releaseTag"];
+	        this.total = source["total"];
+	        this.glyphs = this.convertValues(source["glyphs"], GlyphMatch);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ReleaseAsset {
+	    name: string;
+	    url: string;
+	    size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReleaseAsset(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.url = source["url"];
+	        this.size = source["size"];
+	    }
+	}
+	
+	export class ScoringFeedback {
+	    profile: string;
+	    wins: number;
+	    losses: number;
+	    ties: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoringFeedback(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profile = source["profile"];
+	        this.wins = source["wins"];
+	        this.losses = source["losses"];
+	        this.ties = source["ties"];
+	    }
+	}
+	export class ScoringProfileInfo {
+	    name: string;
+	    description: string;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoringProfileInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.active = source["active"];
+	    }
+	}
+	export class SearchComparison {
+	    query: string;
+	    a: Ranking;
+	    b: Ranking;
+	    overlap: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.query = source["query"];
+	        this.a = this.convertValues(source["a"], Ranking);
+	        this.b = this.convertValues(source["b"], Ranking);
+	        this.overlap = source["overlap"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SearchLatencyStats {
+	    count: number;
+	    window: number;
+	    avgMs: number;
+	    p50Ms: number;
+	    p95Ms: number;
+	    maxMs: number;
+	    slowThresholdMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchLatencyStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.count = source["count"];
+	        this.window = source["window"];
+	        this.avgMs = source["avgMs"];
+	        this.p50Ms = source["p50Ms"];
+	        this.p95Ms = source["p95Ms"];
+	        this.maxMs = source["maxMs"];
+	        this.slowThresholdMs = source["slowThresholdMs"];
+	    }
+	}
+	export class SearchRequest {
+	    query: string;
+	    category?: string;
+	    limit?: number;
+	    offset?: number;
+	    scope?: string;
+	    profile?: string;
+	    debug?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.query = source["query"];
+	        this.category = source["category"];
+	        this.limit = source["limit"];
+	        this.offset = source["offset"];
+	        this.scope = source["scope"];
+	        this.profile = source["profile"];
+	        this.debug = source["debug"];
+	    }
+	}
+	export class SearchResponse {
+	    glyphs: GlyphMatch[];
+	    total: number;
+	    searchTime: number;
+	    hasMore: boolean;
+	    categories?: string[];
+	    didYouMean?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.glyphs = this.convertValues(source["glyphs"], GlyphMatch);
+	        this.total = source["total"];
+	        this.searchTime = source["searchTime"];
+	        this.hasMore = source["hasMore"];
+	        this.categories = source["categories"];
+	        this.didYouMean = source["didYouMean"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SessionState {
+	    searchTerm: string;
+	    category: string;
+	    scope: string;
+	    page: number;
+	    pageSize: number;
+	    scrollTop: number;
+	    updatedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.searchTerm = source["searchTerm"];
+	        this.category = source["category"];
+	        this.scope = source["scope"];
+	        this.page = source["page"];
+	        this.pageSize = source["pageSize"];
+	        this.scrollTop = source["scrollTop"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class SharedDatabaseInfo {
+	    enabled: boolean;
+	    path?: string;
+	    localPath?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SharedDatabaseInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.path = source["path"];
+	        this.localPath = source["localPath"];
+	    }
+	}
+	export class SmartFilter {
+	    name: string;
+	    title: string;
+	    query?: string;
+	    pattern?: string;
+	    tags?: string[];
+	    count: number;
+	    sample: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SmartFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.title = source["title"];
+	        this.query = source["query"];
+	        this.pattern = source["pattern"];
+	        this.tags = source["tags"];
+	        this.count = source["count"];
+	        this.sample = source["sample"];
+	    }
+	}
+	export class SourceInfo {
+	    name: string;
+	    path: string;
+	    primary: boolean;
+	    glyphCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SourceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.primary = source["primary"];
+	        this.glyphCount = source["glyphCount"];
+	    }
+	}
+	export class StartupPhase {
+	    name: string;
+	    startMs: number;
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StartupPhase(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.startMs = source["startMs"];
+	        this.durationMs = source["durationMs"];
+	    }
+	}
+	export class StartupTimings {
+	    phases: StartupPhase[];
+	    readyMs: number;
+	    debug: boolean;
+	    pprofUrl?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StartupTimings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.phases = this.convertValues(source["phases"], StartupPhase);
+	        this.readyMs = source["readyMs"];
+	        this.debug = source["debug"];
+	        this.pprofUrl = source["pprofUrl"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Stats {
+	    totalGlyphs: number;
+	    totalFavorites: number;
+	    totalCategories: number;
+	    categories: Record<string, number>;
+	    sources: Record<string, number>;
+	    cacheLoaded: boolean;
+	    cacheMemoryBytes: number;
+	    cacheTimings?: CacheLoadTimings;
+	    searchMode: string;
+	    dataMode: string;
+	    dbSizeBytes: number;
+	    avgSearchMs: number;
+	    searchCount: number;
+	    startedAt: string;
+	    uptimeSeconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Stats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.totalGlyphs = source["totalGlyphs"];
+	        this.totalFavorites = source["totalFavorites"];
+	        this.totalCategories = source["totalCategories"];
+	        this.categories = source["categories"];
+	        this.sources = source["sources"];
+	        this.cacheLoaded = source["cacheLoaded"];
+	        this.cacheMemoryBytes = source["cacheMemoryBytes"];
+	        this.cacheTimings = this.convertValues(source["cacheTimings"], CacheLoadTimings);
+	        this.searchMode = source["searchMode"];
+	        this.dataMode = source["dataMode"];
+	        this.dbSizeBytes = source["dbSizeBytes"];
+	        this.avgSearchMs = source["avgSearchMs"];
+	        this.searchCount = source["searchCount"];
+	        this.startedAt = source["startedAt"];
+	        this.uptimeSeconds = source["uptimeSeconds"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SubsetGlyph {
+	    id: number;
+	    name: string;
+	    glyph: string;
+	    codepoint: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SubsetGlyph(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.glyph = source["glyph"];
+	        this.codepoint = source["codepoint"];
+	    }
+	}
+	export class SubsetManifest {
+	    font: string;
+	    format: string;
+	    sourceFont: string;
+	    sizeBytes: number;
+	    glyphs: SubsetGlyph[];
+	    missing?: SubsetGlyph[];
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SubsetManifest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.font = source["font"];
+	        this.format = source["format"];
+	        this.sourceFont = source["sourceFont"];
+	        this.sizeBytes = source["sizeBytes"];
+	        this.glyphs = this.convertValues(source["glyphs"], SubsetGlyph);
+	        this.missing = this.convertValues(source["missing"], SubsetGlyph);
+	        this.createdAt = source["createdAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TagCount {
+	    tag: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TagCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tag = source["tag"];
+	        this.count = source["count"];
+	    }
+	}
+	export class TagRule {
+	    id: number;
+	    pattern: string;
+	    tags: string[];
+	    enabled: boolean;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TagRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.pattern = source["pattern"];
+	        this.tags = source["tags"];
+	        this.enabled = source["enabled"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class TagRulePreview {
+	    rule: TagRule;
+	    matches: number;
+	    samples: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TagRulePreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rule = this.convertValues(source["rule"], TagRule);
+	        this.matches = source["matches"];
+	        this.samples = source["samples"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UnicodeBlockInfo {
+	    name: string;
+	    start: string;
+	    end: string;
+	    count: number;
+	    sample: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UnicodeBlockInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.count = source["count"];
+	        this.sample = source["sample"];
+	    }
+	}
+	
+	export class UpdateInfo {
+	    currentVersion: string;
+	    latestVersion: string;
+	    available: boolean;
+	    releaseName: string;
+	    releaseNotes: string;
+	    releaseUrl: string;
+	    publishedAt: string;
+	    assets: ReleaseAsset[];
+	    checkedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currentVersion = source["currentVersion"];
+	        this.latestVersion = source["latestVersion"];
+	        this.available = source["available"];
+	        this.releaseName = source["releaseName"];
+	        this.releaseNotes = source["releaseNotes"];
+	        this.releaseUrl = source["releaseUrl"];
+	        this.publishedAt = source["publishedAt"];
+	        this.assets = this.convertValues(source["assets"], ReleaseAsset);
+	        this.checkedAt = source["checkedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WindowPrefs {
+	    effect: string;
+	    opacity: number;
+	    frameless: boolean;
+	    alwaysOnTop: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WindowPrefs(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.effect = source["effect"];
+	        this.opacity = source["opacity"];
+	        this.frameless = source["frameless"];
+	        this.alwaysOnTop = source["alwaysOnTop"];
+	    }
+	}
 
 }
 
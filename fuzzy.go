@@ -0,0 +1,186 @@
+package main
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// foldText prepares s for matching: Unicode case folding and, when stripDiacritics
+// is set, removal of combining marks (e.g. "Café" -> "cafe", "STRASSE" == "straße")
+func foldText(s string, stripDiacritics bool) []rune {
+	// Glyph names are ASCII, so skip the Unicode transforms for them
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		runes := make([]rune, len(s))
+		for i := 0; i < len(s); i++ {
+			c := s[i]
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			runes[i] = rune(c)
+		}
+		return runes
+	}
+
+	// Casers keep state, so each call needs its own
+	s = cases.Fold().String(s)
+	if !stripDiacritics {
+		return []rune(norm.NFC.String(s))
+	}
+
+	runes := make([]rune, 0, len(s))
+	for _, r := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			runes = append(runes, r)
+		}
+	}
+	return []rune(norm.NFC.String(string(runes)))
+}
+
+// runeIndex returns the index of the first occurrence of sub in s, or -1
+func runeIndex(s, sub []rune) int {
+outer:
+	for i := 0; i+len(sub) <= len(s); i++ {
+		for j := range sub {
+			if s[i+j] != sub[j] {
+				continue outer
+			}
+		}
+		return i
+	}
+	return -1
+}
+
+// isWordBoundary reports whether position i of text starts a word
+func isWordBoundary(text []rune, i int) bool {
+	return i == 0 || text[i-1] == '-' || text[i-1] == '_' || unicode.IsSpace(text[i-1])
+}
+
+// Match types reported in score explanations
+const (
+	MatchExact     = "exact"
+	MatchSubstring = "substring"
+	MatchFuzzy     = "fuzzy"
+	MatchTypo      = "typo"
+)
+
+// ScoreBreakdown explains how a match score was computed; Score is the sum of the parts
+type ScoreBreakdown struct {
+	MatchType   string `json:"matchType"`
+	MatchedOn   string `json:"matchedOn"` // "name", "tags" or "alias"
+	Base        int    `json:"base"`
+	Boundary    int    `json:"boundary"`
+	Consecutive int    `json:"consecutive"`
+	Length      int    `json:"length"`
+	Typo        int    `json:"typo"`
+	Frequency   int    `json:"frequency"`
+	Favorite    int    `json:"favorite"`
+	Score       int    `json:"score"`
+}
+
+// total sums the parts into Score
+func (b *ScoreBreakdown) total() int {
+	b.Score = b.Base + b.Boundary + b.Consecutive + b.Length + b.Typo + b.Frequency + b.Favorite
+	return b.Score
+}
+
+// fuzzyScore implements fzf-style fuzzy matching of already folded runes,
+// itemizing the score
+func fuzzyScore(pattern, text []rune) (ScoreBreakdown, bool) {
+	var b ScoreBreakdown
+	if len(pattern) == 0 {
+		return b, true
+	}
+
+	// Exact match gets highest score
+	if string(pattern) == string(text) {
+		b.MatchType, b.Base = MatchExact, 10000
+		b.total()
+		return b, true
+	}
+
+	// Exact substring match
+	if idx := runeIndex(text, pattern); idx != -1 {
+		b.MatchType, b.Base = MatchSubstring, 5000
+		if idx == 0 {
+			b.Boundary = 2000 // Bonus for prefix match
+		}
+		b.Length = -len(text) * 2 // Penalty for length
+		b.total()
+		return b, true
+	}
+
+	// Fuzzy matching
+	b.MatchType = MatchFuzzy
+	textIdx := 0
+	consecutiveMatches := 0
+	lastMatchIdx := -1
+
+	for _, p := range pattern {
+		found := false
+		for textIdx < len(text) {
+			if p == text[textIdx] {
+				found = true
+				b.Base += 100
+
+				// Bonus for consecutive matches
+				if textIdx == lastMatchIdx+1 {
+					consecutiveMatches++
+					b.Consecutive += consecutiveMatches * 50
+				} else {
+					consecutiveMatches = 0
+				}
+
+				// Bonus for word boundary matches
+				if isWordBoundary(text, textIdx) {
+					b.Boundary += 200
+				}
+
+				lastMatchIdx = textIdx
+				textIdx++
+				break
+			}
+			textIdx++
+		}
+
+		if !found {
+			return ScoreBreakdown{}, false
+		}
+	}
+
+	// Penalty for length difference
+	b.Length = -(len(text) - len(pattern)) * 3
+
+	b.total()
+	return b, true
+}
+
+// fuzzyMatch returns the fuzzy match score of already folded runes
+func fuzzyMatch(pattern, text []rune) (int, bool) {
+	b, ok := fuzzyScore(pattern, text)
+	return b.Score, ok
+}
+
+// matchAny returns the best match of text against any of the patterns
+func matchAny(profile ScoringProfile, patterns [][]rune, text []rune) (ScoreBreakdown, bool) {
+	var best ScoreBreakdown
+	matched := false
+	for i, p := range patterns {
+		if b, ok := profile.Match(p, text); ok && (!matched || b.Score > best.Score) {
+			best, matched = b, true
+			if i > 0 {
+				best.MatchedOn = "alias"
+			}
+		}
+	}
+	return best, matched
+}
@@ -0,0 +1,79 @@
+package main
+
+import "testing"
+
+func TestFoldText(t *testing.T) {
+	tests := []struct {
+		in              string
+		stripDiacritics bool
+		want            string
+	}{
+		{"NF-COD-Account", true, "nf-cod-account"},
+		{"Café", true, "cafe"},
+		{"Café", false, "café"},
+		{"CAFE\u0301", false, "café"}, // decomposed input is recomposed
+		{"Straße", true, "strasse"},
+		{"ÅNGSTRÖM", true, "angstrom"},
+		{"Ωμέγα", true, "ωμεγα"},
+		{"矢印", true, "矢印"},
+	}
+	for _, tt := range tests {
+		if got := string(foldText(tt.in, tt.stripDiacritics)); got != tt.want {
+			t.Errorf("foldText(%q, %v) = %q, want %q", tt.in, tt.stripDiacritics, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzyMatchNonASCII(t *testing.T) {
+	tests := []struct {
+		pattern, text   string
+		stripDiacritics bool
+		want            bool
+	}{
+		{"café", "nf-x-cafe", true, true},
+		{"CAFÉ", "nf-x-café", false, true},
+		{"cafe", "nf-x-café", false, false},
+		{"strasse", "Straße", true, true},
+		{"pfeil", "Pfeil nach oben", true, true},
+		{"pno", "Pfeil nach oben", true, true},
+		{"矢印", "上矢印", true, true},
+		{"上印", "上矢印", true, true},
+		{"印上", "上矢印", true, false},
+		{"é", "e", false, false},
+	}
+	for _, tt := range tests {
+		_, ok := fuzzyMatch(foldText(tt.pattern, tt.stripDiacritics), foldText(tt.text, tt.stripDiacritics))
+		if ok != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q, strip=%v) matched = %v, want %v", tt.pattern, tt.text, tt.stripDiacritics, ok, tt.want)
+		}
+	}
+}
+
+func TestFuzzyMatchCountsRunes(t *testing.T) {
+	// A multi-byte rune must score like its single-byte counterpart
+	ascii, _ := fuzzyMatch(foldText("ae", false), foldText("a-b-e", false))
+	accented, _ := fuzzyMatch(foldText("aé", false), foldText("a-b-é", false))
+	if ascii != accented {
+		t.Errorf("score for accented text = %d, want %d", accented, ascii)
+	}
+
+	substr, _ := fuzzyMatch(foldText("é", false), foldText("xé", false))
+	plain, _ := fuzzyMatch(foldText("e", false), foldText("xe", false))
+	if substr != plain {
+		t.Errorf("substring score for accented text = %d, want %d", substr, plain)
+	}
+}
+
+func TestFuzzyMatchRanking(t *testing.T) {
+	pattern := foldText("account", true)
+	exact, _ := fuzzyMatch(pattern, foldText("account", true))
+	prefix, _ := fuzzyMatch(pattern, foldText("account_plus", true))
+	substr, _ := fuzzyMatch(pattern, foldText("nf-cod-account", true))
+	fuzzy, ok := fuzzyMatch(foldText("acnt", true), foldText("nf-cod-account", true))
+	if !ok {
+		t.Fatal("expected acnt to fuzzy match nf-cod-account")
+	}
+	if !(exact > prefix && prefix > substr && substr > fuzzy) {
+		t.Errorf("scores not ordered: exact=%d prefix=%d substring=%d fuzzy=%d", exact, prefix, substr, fuzzy)
+	}
+}
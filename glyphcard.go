@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultGlyphCardTemplate is used when the "copy.cardTemplate" setting is unset
+const defaultGlyphCardTemplate = `{glyph}  {name}
+Codepoint: {codepoint}
+UTF-8: {utf8}
+HTML: {html}
+Icon set: {iconSet}`
+
+// glyphCardPlaceholders lists the placeholders a card template may use
+var glyphCardPlaceholders = []string{"{glyph}", "{name}", "{codepoint}", "{utf8}", "{html}", "{iconSet}", "{description}"}
+
+// renderGlyphCard fills a card template with a glyph's details
+func renderGlyphCard(tmpl string, d *GlyphDetails) string {
+	return strings.NewReplacer(
+		"{glyph}", d.Glyph.Glyph,
+		"{name}", d.Name,
+		"{codepoint}", d.Codepoint,
+		"{utf8}", d.UTF8,
+		"{html}", d.HTMLEntity,
+		"{iconSet}", d.IconSet,
+		"{description}", d.Description,
+	).Replace(tmpl)
+}
+
+// GetGlyphCard returns the formatted info card for a glyph without copying it
+func (a *App) GetGlyphCard(id int) (string, error) {
+	details, err := a.GetGlyphDetails(id)
+	if err != nil {
+		return "", err
+	}
+	return renderGlyphCard(a.GetGlyphCardTemplate(), details), nil
+}
+
+// CopyGlyphCard copies a multi-line description of a glyph (character, name,
+// codepoint, UTF-8 bytes, HTML entity) for pasting into issues and docs
+func (a *App) CopyGlyphCard(id int) (string, error) {
+	card, err := a.GetGlyphCard(id)
+	if err != nil {
+		return "", err
+	}
+	if _, err := a.setClipboard(card); err != nil {
+		return "", fmt.Errorf("failed to copy glyph card: %w", err)
+	}
+	a.recordCopy(id)
+	return card, nil
+}
+
+// GetGlyphCardTemplate returns the card template with its {placeholders}
+func (a *App) GetGlyphCardTemplate() string {
+	return a.settings.Get("copy.cardTemplate", defaultGlyphCardTemplate)
+}
+
+// GetGlyphCardPlaceholders lists the placeholders available in card templates
+func (a *App) GetGlyphCardPlaceholders() []string {
+	return glyphCardPlaceholders
+}
+
+// SetGlyphCardTemplate changes the card template; an empty template restores the default
+func (a *App) SetGlyphCardTemplate(tmpl string) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if strings.TrimSpace(tmpl) == "" {
+		tmpl = defaultGlyphCardTemplate
+	}
+	return a.settings.Set("copy.cardTemplate", tmpl)
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	minRenderSize = 16
+	maxRenderSize = 1024
+)
+
+// RenderOptions controls how a glyph is rasterized
+type RenderOptions struct {
+	// Size is the width and height of the square image in pixels
+	Size int
+	// Foreground and Background default to white on transparent
+	Foreground color.Color
+	Background color.Color
+	// Padding is the fraction of Size left empty on each side
+	Padding float64
+	// Monochrome draws emoji as silhouettes in Foreground even when a color font has them
+	Monochrome bool
+}
+
+// renderFace is a parsed font with its color tables, if it has any
+type renderFace struct {
+	font  *opentype.Font
+	color *colorTables
+}
+
+// GlyphRenderer rasterizes glyphs with the configured Nerd Font, and emoji
+// with a color font when one is installed. Parsed fonts are cached by path.
+type GlyphRenderer struct {
+	mu    sync.Mutex
+	faces map[string]*renderFace
+
+	detectOnce sync.Once
+	detected   string
+}
+
+// detectedColorFont returns the first installed color emoji font, searching
+// the font directories only once
+func (r *GlyphRenderer) detectedColorFont() string {
+	r.detectOnce.Do(func() {
+		if fonts := detectColorFonts(); len(fonts) > 0 {
+			r.detected = fonts[0]
+		}
+	})
+	return r.detected
+}
+
+// loadFace returns the parsed font at path, loading it on first use
+func (r *GlyphRenderer) loadFace(path string) (*renderFace, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if face, ok := r.faces[path]; ok {
+		return face, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font: %w", err)
+	}
+	f, err := parseFontData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font %s: %w", path, err)
+	}
+	face := &renderFace{font: f}
+	if face.color, err = parseColorTables(data, f.NumGlyphs()); err != nil {
+		log.Printf("Ignoring color tables of %s: %v", path, err)
+	}
+	if r.faces == nil {
+		r.faces = make(map[string]*renderFace)
+	}
+	r.faces[path] = face
+	return face, nil
+}
+
+// parseFontData parses a font file, or the first font of a collection
+func parseFontData(data []byte) (*opentype.Font, error) {
+	if len(data) >= 4 && string(data[:4]) == "ttcf" {
+		c, err := opentype.ParseCollection(data)
+		if err != nil {
+			return nil, err
+		}
+		return c.Font(0)
+	}
+	return opentype.Parse(data)
+}
+
+// renderFont returns the parsed Nerd Font, loading it on first use
+func (a *App) renderFont() (*renderFace, error) {
+	path, err := a.nerdFontPath()
+	if err != nil {
+		return nil, err
+	}
+	return a.renderer.loadFace(path)
+}
+
+// colorFace returns the parsed color emoji font, or nil when none is installed
+func (a *App) colorFace() *renderFace {
+	path := a.colorFontPath()
+	if path == "" {
+		return nil
+	}
+	face, err := a.renderer.loadFace(path)
+	if err != nil {
+		log.Printf("%v", err)
+		return nil
+	}
+	return face
+}
+
+// hasGlyphs reports whether f maps every rune of s to a glyph
+func hasGlyphs(f *opentype.Font, s string) bool {
+	var buf sfnt.Buffer
+	for _, r := range s {
+		if idx, err := f.GlyphIndex(&buf, r); err != nil || idx == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// colorGlyphIndex returns the glyph of a single-codepoint emoji, ignoring
+// variation selectors. Sequences need shaping and render monochrome.
+func colorGlyphIndex(f *opentype.Font, s string) (sfnt.GlyphIndex, bool) {
+	var r rune
+	n := 0
+	for _, c := range s {
+		if c == 0xFE0E || c == 0xFE0F {
+			continue
+		}
+		r = c
+		n++
+	}
+	if n != 1 {
+		return 0, false
+	}
+	var buf sfnt.Buffer
+	idx, err := f.GlyphIndex(&buf, r)
+	return idx, err == nil && idx != 0
+}
+
+// drawColor draws a glyph from the first font with a color version of it:
+// the Nerd Font itself, then the color emoji font
+func (a *App) drawColor(img *image.RGBA, g Glyph, avail float64, fg color.Color) (bool, error) {
+	var faces []*renderFace
+	if face, err := a.renderFont(); err == nil {
+		faces = append(faces, face)
+	}
+	if face := a.colorFace(); face != nil {
+		faces = append(faces, face)
+	}
+
+	for _, face := range faces {
+		idx, ok := colorGlyphIndex(face.font, g.Glyph)
+		if !ok || !face.color.hasColor(idx) {
+			continue
+		}
+		drawn, err := drawColorGlyph(img, face.font, face.color, idx, avail, fg)
+		if err != nil {
+			return false, err
+		}
+		if drawn {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// renderGlyph draws a glyph centered in a square image, scaled to fit inside
+// the padding. Emoji are drawn in color when a color font has them.
+func (a *App) renderGlyph(g Glyph, opts RenderOptions) (*image.RGBA, error) {
+	if opts.Size < minRenderSize || opts.Size > maxRenderSize {
+		return nil, newAppError(ErrCodeInvalid, "image size must be between %d and %d pixels", minRenderSize, maxRenderSize)
+	}
+	if opts.Foreground == nil {
+		opts.Foreground = color.White
+	}
+	if opts.Background == nil {
+		opts.Background = color.Transparent
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, opts.Size, opts.Size))
+	draw.Draw(img, img.Bounds(), image.NewUniform(opts.Background), image.Point{}, draw.Src)
+	avail := float64(opts.Size) * (1 - 2*opts.Padding)
+
+	if !opts.Monochrome {
+		drawn, err := a.drawColor(img, g, avail, opts.Foreground)
+		if err != nil {
+			return nil, err
+		}
+		if drawn {
+			return img, nil
+		}
+	}
+
+	// Emoji missing from the Nerd Font fall back to the color font's outlines
+	face, err := a.renderFont()
+	if err != nil {
+		return nil, err
+	}
+	if !hasGlyphs(face.font, g.Glyph) {
+		if cf := a.colorFace(); cf != nil && hasGlyphs(cf.font, g.Glyph) {
+			face = cf
+		} else {
+			for _, r := range g.Glyph {
+				if !hasGlyphs(face.font, string(r)) {
+					return nil, newAppError(ErrCodeNotFound, "font has no glyph for %s", formatCodepoint(r))
+				}
+			}
+		}
+	}
+	f := face.font
+
+	// Measure at the nominal size, then rescale so the ink box fills the available space
+	ff, err := opentype.NewFace(f, &opentype.FaceOptions{Size: avail, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create font face: %w", err)
+	}
+	bounds, _ := font.BoundString(ff, g.Glyph)
+	w := (bounds.Max.X - bounds.Min.X).Round()
+	h := (bounds.Max.Y - bounds.Min.Y).Round()
+	ff.Close()
+	if w <= 0 || h <= 0 {
+		return img, nil
+	}
+
+	scale := min(avail/float64(w), avail/float64(h))
+	ff, err = opentype.NewFace(f, &opentype.FaceOptions{Size: avail * scale, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create font face: %w", err)
+	}
+	defer ff.Close()
+
+	bounds, _ = font.BoundString(ff, g.Glyph)
+	w = (bounds.Max.X - bounds.Min.X).Ceil()
+	h = (bounds.Max.Y - bounds.Min.Y).Ceil()
+	d := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(opts.Foreground),
+		Face: ff,
+		Dot: fixed.Point26_6{
+			X: fixed.I((opts.Size-w)/2) - bounds.Min.X,
+			Y: fixed.I((opts.Size-h)/2) - bounds.Min.Y,
+		},
+	}
+	d.DrawString(g.Glyph)
+	return img, nil
+}
+
+// renderGlyphPNG renders a glyph and encodes it as PNG
+func (a *App) renderGlyphPNG(g Glyph, opts RenderOptions) ([]byte, error) {
+	img, err := a.renderGlyph(g, opts)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// parseHexColor reads "#rgb", "#rrggbb", or "#rrggbbaa" (the # is optional)
+func parseHexColor(s string) (color.Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return nil, newAppError(ErrCodeInvalid, "invalid color: %s", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, newAppError(ErrCodeInvalid, "invalid color: %s", s)
+	}
+	c := color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}
+	return c, nil
+}
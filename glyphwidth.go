@@ -0,0 +1,200 @@
+package main
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/text/width"
+)
+
+// Width classes of a glyph in a terminal cell grid, for the "width:" search filter
+const (
+	WidthSingle = "single"
+	WidthDouble = "double"
+)
+
+// doubleWidthRatio is how many cells a glyph may span before it is double width;
+// most icons overhang their cell a little without covering the next one
+const doubleWidthRatio = 1.2
+
+// fontWidthClass classifies s by the font's metrics: a glyph whose advance or
+// ink reaches past doubleWidthRatio cells overlaps the next terminal cell.
+// ok is false when the font lacks one of its code points.
+func fontWidthClass(f *opentype.Font, s string) (class string, ok bool) {
+	var buf sfnt.Buffer
+	ppem := fixed.I(int(f.UnitsPerEm()))
+
+	idx, err := f.GlyphIndex(&buf, '0')
+	if err != nil || idx == 0 {
+		return "", false
+	}
+	cell, err := f.GlyphAdvance(&buf, idx, ppem, 0)
+	if err != nil || cell <= 0 {
+		return "", false
+	}
+
+	var span fixed.Int26_6
+	for _, r := range s {
+		if r == 0xFE0E || r == 0xFE0F {
+			continue
+		}
+		idx, err := f.GlyphIndex(&buf, r)
+		if err != nil || idx == 0 {
+			return "", false
+		}
+		advance, err := f.GlyphAdvance(&buf, idx, ppem, 0)
+		if err != nil {
+			return "", false
+		}
+		if bounds, _, err := f.GlyphBounds(&buf, idx, ppem, 0); err == nil {
+			advance = max(advance, bounds.Max.X)
+		}
+		span += advance
+	}
+
+	if float64(span) > doubleWidthRatio*float64(cell) {
+		return WidthDouble, true
+	}
+	return WidthSingle, true
+}
+
+// unicodeWidthClass classifies s the way terminals do without font metrics:
+// East Asian wide and emoji presentation characters take two cells
+func unicodeWidthClass(s string) string {
+	if strings.ContainsRune(s, 0xFE0F) {
+		return WidthDouble
+	}
+	for _, r := range s {
+		switch width.LookupRune(r).Kind() {
+		case width.EastAsianWide, width.EastAsianFullwidth:
+			return WidthDouble
+		}
+	}
+	return WidthSingle
+}
+
+// widthClassifier returns the function classifying glyphs and the font it
+// measures, or "" when no Nerd Font is installed and only Unicode data is used.
+// Code points the font lacks are drawn by a fallback font, so they are
+// classified by Unicode data too.
+func (a *App) widthClassifier() (func(string) string, string) {
+	path, err := a.nerdFontPath()
+	if err != nil {
+		return unicodeWidthClass, ""
+	}
+	face, err := a.renderer.loadFace(path)
+	if err != nil {
+		log.Printf("Classifying glyph widths without font metrics: %v", err)
+		return unicodeWidthClass, ""
+	}
+	return func(s string) string {
+		if class, ok := fontWidthClass(face.font, s); ok {
+			return class
+		}
+		return unicodeWidthClass(s)
+	}, path
+}
+
+// glyphWidth returns a glyph's width class, working it out for glyphs from
+// sources that don't store one
+func glyphWidth(g Glyph) string {
+	if g.Width != "" {
+		return g.Width
+	}
+	return unicodeWidthClass(g.Glyph)
+}
+
+// filterWidth keeps the glyphs of the given width class
+func filterWidth(glyphs []Glyph, class string) []Glyph {
+	var kept []Glyph
+	for _, g := range glyphs {
+		if glyphWidth(g) == class {
+			kept = append(kept, g)
+		}
+	}
+	return kept
+}
+
+// backfillWidths classifies glyphs that have no width yet, or all of them when
+// fontPath isn't the font they were measured with
+func backfillWidths(db *sql.DB, classify func(string) string, fontPath string) error {
+	var measured string
+	hasMetadata, _ := tableExists(db, "metadata")
+	if hasMetadata {
+		err := db.QueryRow("SELECT COALESCE(value, '') FROM metadata WHERE key = 'width_font'").Scan(&measured)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+	}
+
+	query := "SELECT id, glyph FROM glyphs WHERE width IS NULL"
+	if measured != fontPath {
+		query = "SELECT id, glyph FROM glyphs"
+	}
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	widths := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var glyph string
+		if err := rows.Scan(&id, &glyph); err == nil {
+			widths[id] = classify(glyph)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(widths) == 0 && measured == fontPath {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("UPDATE glyphs SET width = ? WHERE id = ?")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for id, class := range widths {
+		if _, err := stmt.Exec(class, id); err != nil {
+			return err
+		}
+	}
+	if hasMetadata {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO metadata (key, value) VALUES ('width_font', ?)", fontPath); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_width ON glyphs(width)"); err != nil {
+		return err
+	}
+	log.Printf("Classified the width of %d glyphs", len(widths))
+	return tx.Commit()
+}
+
+// classifyGlyphWidths brings the stored width classes up to date with the
+// configured Nerd Font. Shared databases are left to whoever maintains them,
+// and nothing is written in read-only mode.
+func (a *App) classifyGlyphWidths() {
+	if a.db == nil || a.isShared() || a.isReadOnly() {
+		return
+	}
+	if ok, _ := columnExists(a.db, "glyphs", "width"); !ok {
+		return
+	}
+	classify, fontPath := a.widthClassifier()
+	if err := backfillWidths(a.db, classify, fontPath); err != nil {
+		log.Printf("Failed to classify glyph widths: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/opentype"
+)
+
+func TestFontWidthClass(t *testing.T) {
+	f, err := opentype.Parse(gomono.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		glyph string
+		class string
+		ok    bool
+	}{
+		{"a", WidthSingle, true},
+		{"W", WidthSingle, true},
+		{"ab", WidthDouble, true},
+		{"\uF135", "", false},
+	}
+	for _, tt := range tests {
+		class, ok := fontWidthClass(f, tt.glyph)
+		if class != tt.class || ok != tt.ok {
+			t.Errorf("fontWidthClass(%q) = %q, %v; want %q, %v", tt.glyph, class, ok, tt.class, tt.ok)
+		}
+	}
+}
+
+func TestUnicodeWidthClass(t *testing.T) {
+	for glyph, class := range map[string]string{
+		"\uF135":     WidthSingle,
+		"漢":          WidthDouble,
+		"\U0001F389": WidthDouble,
+		"❤️":         WidthDouble,
+	} {
+		if got := unicodeWidthClass(glyph); got != class {
+			t.Errorf("unicodeWidthClass(%q) = %s, want %s", glyph, got, class)
+		}
+	}
+}
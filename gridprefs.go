@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strconv"
+)
+
+// Tile sizes and densities accepted by SetGridPrefs
+var (
+	gridTileSizes = []string{"small", "medium", "large"}
+	gridDensities = []string{"compact", "comfortable", "spacious"}
+)
+
+// maxGridColumns bounds the fixed column count; 0 means fit to the window
+const maxGridColumns = 12
+
+// EventGridChanged is emitted with the new GridPrefs after they change
+const EventGridChanged = "grid:changed"
+
+// GridPrefs are the glyph grid layout settings
+type GridPrefs struct {
+	TileSize       string `json:"tileSize"`
+	Columns        int    `json:"columns"`
+	ShowNames      bool   `json:"showNames"`
+	ShowCodepoints bool   `json:"showCodepoints"`
+	Density        string `json:"density"`
+}
+
+// oneOf returns v if it is in allowed, otherwise def
+func oneOf(v string, allowed []string, def string) string {
+	for _, a := range allowed {
+		if v == a {
+			return v
+		}
+	}
+	return def
+}
+
+// GetGridPrefs returns the glyph grid layout settings
+func (a *App) GetGridPrefs() GridPrefs {
+	columns := a.settings.GetInt("grid.columns", 0)
+	if columns < 0 || columns > maxGridColumns {
+		columns = 0
+	}
+
+	return GridPrefs{
+		TileSize:       oneOf(a.settings.Get("grid.tileSize", ""), gridTileSizes, "medium"),
+		Columns:        columns,
+		ShowNames:      a.settings.GetBool("grid.showNames", true),
+		ShowCodepoints: a.settings.GetBool("grid.showCodepoints", false),
+		Density:        oneOf(a.settings.Get("grid.density", ""), gridDensities, "comfortable"),
+	}
+}
+
+// SetGridPrefs validates and persists the glyph grid layout settings
+func (a *App) SetGridPrefs(prefs GridPrefs) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+
+	if oneOf(prefs.TileSize, gridTileSizes, "") == "" {
+		return newAppError(ErrCodeInvalid, "unknown tile size: %s", prefs.TileSize)
+	}
+	if oneOf(prefs.Density, gridDensities, "") == "" {
+		return newAppError(ErrCodeInvalid, "unknown density: %s", prefs.Density)
+	}
+	if prefs.Columns < 0 || prefs.Columns > maxGridColumns {
+		return newAppError(ErrCodeInvalid, "columns must be between 0 (auto) and %d", maxGridColumns)
+	}
+
+	values := map[string]string{
+		"grid.tileSize":       prefs.TileSize,
+		"grid.columns":        strconv.Itoa(prefs.Columns),
+		"grid.showNames":      strconv.FormatBool(prefs.ShowNames),
+		"grid.showCodepoints": strconv.FormatBool(prefs.ShowCodepoints),
+		"grid.density":        prefs.Density,
+	}
+	for key, value := range values {
+		if err := a.settings.Set(key, value); err != nil {
+			return err
+		}
+	}
+
+	a.emit(EventGridChanged, prefs)
+	return nil
+}
+
+// updateGridPrefs applies change to the current grid settings and saves them
+func (a *App) updateGridPrefs(change func(*GridPrefs)) error {
+	prefs := a.GetGridPrefs()
+	change(&prefs)
+	return a.SetGridPrefs(prefs)
+}
+
+// nextOf returns the value after v in values, wrapping around
+func nextOf(v string, values []string) string {
+	for i, value := range values {
+		if value == v {
+			return values[(i+1)%len(values)]
+		}
+	}
+	return values[0]
+}
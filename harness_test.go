@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// harness runs the core without Wails against a scratch glyph database seeded
+// from a fixture, the same way the command line modes start it
+type harness struct {
+	t   *testing.T
+	app *App
+	dir string
+}
+
+// fixtureGlyph is one entry of a testdata fixture
+type fixtureGlyph struct {
+	Name        string `json:"name"`
+	Glyph       string `json:"glyph"`
+	Description string `json:"description,omitempty"`
+}
+
+// fixtureSchema is the part of the generated database schema the core reads
+const fixtureSchema = `
+	CREATE TABLE glyphs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		glyph TEXT NOT NULL,
+		category TEXT,
+		prefix TEXT,
+		normalized_name TEXT,
+		description TEXT
+	);
+	CREATE TABLE metadata (key TEXT PRIMARY KEY, value TEXT, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP);
+	CREATE VIRTUAL TABLE glyphs_fts USING fts5(name, category, content='glyphs', content_rowid='id');
+	CREATE TRIGGER glyphs_ai AFTER INSERT ON glyphs BEGIN
+		INSERT INTO glyphs_fts(rowid, name, category) VALUES (new.id, new.name, new.category);
+	END;
+`
+
+// loadFixture reads testdata/<name>
+func loadFixture(t *testing.T, name string) []fixtureGlyph {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	var glyphs []fixtureGlyph
+	if err := json.Unmarshal(data, &glyphs); err != nil {
+		t.Fatalf("parse fixture %s: %v", name, err)
+	}
+	return glyphs
+}
+
+// seedGlyphDatabase writes glyphs to a new glyph database at path; ids follow
+// the fixture order starting at 1
+func seedGlyphDatabase(t *testing.T, path string, glyphs []fixtureGlyph) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("create database: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(fixtureSchema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	for _, g := range glyphs {
+		if _, err := db.Exec("INSERT INTO glyphs (name, glyph, category, description) VALUES (?, ?, ?, ?)",
+			g.Name, g.Glyph, glyphCategory(g.Name), g.Description); err != nil {
+			t.Fatalf("insert %s: %v", g.Name, err)
+		}
+	}
+}
+
+// copyFixtureDatabase copies testdata/<name>, a database written by
+// db_generator --fixture, to path
+func copyFixtureDatabase(t *testing.T, name, path string) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("copy fixture: %v", err)
+	}
+}
+
+// newHarness starts the core on a temp copy of testdata/<fixture> and shuts it
+// down when the test ends. JSON fixtures list glyphs to seed; .db fixtures are
+// generated databases (regenerate fixture.db by running
+// "go run . --fixture 120" in db_generator).
+func newHarness(t *testing.T, fixture string) *harness {
+	t.Helper()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "gylte.db")
+	if strings.HasSuffix(fixture, ".db") {
+		copyFixtureDatabase(t, fixture, dbPath)
+	} else {
+		seedGlyphDatabase(t, dbPath, loadFixture(t, fixture))
+	}
+
+	a := NewApp()
+	a.dbPath = dbPath
+	a.dataPath = dir
+	a.startHeadless()
+	t.Cleanup(func() { a.shutdown(context.Background()) })
+
+	if a.fallbackReason != "" {
+		t.Fatalf("core started in fallback mode: %s", a.fallbackReason)
+	}
+	return &harness{t: t, app: a, dir: dir}
+}
+
+// search runs a query through GetGlyphs and returns the matching names in rank order
+func (h *harness) search(req SearchRequest) []string {
+	h.t.Helper()
+
+	resp, err := h.app.GetGlyphs(req)
+	if err != nil {
+		h.t.Fatalf("search %q: %v", req.Query, err)
+	}
+	names := make([]string, len(resp.Glyphs))
+	for i, m := range resp.Glyphs {
+		names[i] = m.Name
+	}
+	return names
+}
+
+// glyphID looks up a fixture glyph's id by name
+func (h *harness) glyphID(name string) int {
+	h.t.Helper()
+
+	g, ok := h.app.findGlyphByName(name)
+	if !ok {
+		h.t.Fatalf("glyph %s not in fixture", name)
+	}
+	return g.ID
+}
+
+// favorite toggles the named glyph's favorite state
+func (h *harness) favorite(name string) {
+	h.t.Helper()
+
+	if err := h.app.ToggleFavorite(h.glyphID(name)); err != nil {
+		h.t.Fatalf("toggle favorite %s: %v", name, err)
+	}
+}
+
+// readJSON decodes the JSON file at path into v
+func (h *harness) readJSON(path string, v any) {
+	h.t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		h.t.Fatalf("read %s: %v", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		h.t.Fatalf("parse %s: %v", path, err)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"log"
+)
+
+// startHeadless opens the databases and loads the glyph cache for command line
+// modes that run without a window. Background services (update checks,
+// clipboard watching, global hotkeys, OS integration) stay off.
+func (a *App) startHeadless() {
+	a.ctx = context.Background()
+	a.headless = true
+	a.setupLogging()
+
+	// stdout and stderr belong to the caller's pipeline; log to the file only
+	if a.logFile != nil {
+		log.SetOutput(a.logFile)
+	}
+
+	if err := a.openDatabases(); err != nil {
+		log.Printf("Failed to open database: %v", err)
+		a.startFallback(err)
+		a.loadLocale()
+		return
+	}
+
+	if err := a.openProfile(a.activeProfile()); err != nil {
+		log.Printf("Failed to open profile: %v", err)
+	}
+	a.classifyGlyphWidths()
+	a.preloadCache()
+}
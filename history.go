@@ -0,0 +1,216 @@
+package main
+
+import (
+	"log"
+	"strconv"
+	"time"
+)
+
+// Bounds and defaults for the search history settings
+const (
+	defaultHistorySize          = 20
+	maxHistorySize              = 200
+	defaultHistoryRetentionDays = 30
+	maxHistoryRetentionDays     = 3650
+)
+
+// historyPruneInterval is the least time between pruning saved searches while searching
+const historyPruneInterval = time.Minute
+
+// EventIncognitoChanged is emitted with the new incognito state after it changes
+const EventIncognitoChanged = "history:incognito"
+
+// HistorySettings controls how searches are remembered
+type HistorySettings struct {
+	// MaxSize is the number of searches kept; 0 disables history
+	MaxSize int `json:"maxSize"`
+	// RetentionDays expires searches older than this; 0 keeps them forever
+	RetentionDays int `json:"retentionDays"`
+	// Incognito suspends recording without clearing existing history
+	Incognito bool `json:"incognito"`
+}
+
+// initSearchHistoryTable creates the search_history table if it doesn't exist
+func (a *App) initSearchHistoryTable() error {
+	_, err := a.userDB.Exec(`
+		CREATE TABLE IF NOT EXISTS search_history (
+			term TEXT PRIMARY KEY,
+			searched_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_search_history_searched ON search_history(searched_at);
+	`)
+	return err
+}
+
+// historySettings returns the history settings, replacing out-of-bounds values with defaults
+func (a *App) historySettings() HistorySettings {
+	size := a.settings.GetInt("history.maxSize", defaultHistorySize)
+	if size < 0 || size > maxHistorySize {
+		size = defaultHistorySize
+	}
+	days := a.settings.GetInt("history.retentionDays", defaultHistoryRetentionDays)
+	if days < 0 || days > maxHistoryRetentionDays {
+		days = defaultHistoryRetentionDays
+	}
+	return HistorySettings{
+		MaxSize:       size,
+		RetentionDays: days,
+		Incognito:     a.settings.GetBool("history.incognito", false),
+	}
+}
+
+// loadSearchHistory replaces the in-memory history with the profile's saved searches,
+// dropping any that have expired
+func (a *App) loadSearchHistory() {
+	a.history.mu.Lock()
+	a.history.history = nil
+	a.history.searchedAt = make(map[string]time.Time)
+	a.history.mu.Unlock()
+
+	if a.userDB == nil {
+		return
+	}
+	settings := a.historySettings()
+	a.pruneSearchHistory(settings)
+
+	rows, err := a.userDB.Query(`
+		SELECT term, CAST(strftime('%s', searched_at) AS INTEGER)
+		FROM search_history
+		ORDER BY searched_at DESC
+		LIMIT ?
+	`, settings.MaxSize)
+	if err != nil {
+		log.Printf("Failed to load search history: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	a.history.mu.Lock()
+	defer a.history.mu.Unlock()
+	for rows.Next() {
+		var term string
+		var searchedAt int64
+		if err := rows.Scan(&term, &searchedAt); err != nil {
+			continue
+		}
+		a.history.history = append(a.history.history, term)
+		a.history.searchedAt[term] = time.Unix(searchedAt, 0)
+	}
+}
+
+// pruneSearchHistory deletes saved searches that are expired or beyond the size limit
+func (a *App) pruneSearchHistory(settings HistorySettings) {
+	if a.userDB == nil || a.isReadOnly() {
+		return
+	}
+
+	a.history.mu.Lock()
+	a.history.prunedAt = time.Now()
+	a.history.mu.Unlock()
+
+	err := a.writes.Exec(a.userDB, `
+		DELETE FROM search_history
+		WHERE (?1 > 0 AND searched_at < datetime('now', '-' || ?1 || ' days'))
+			OR term NOT IN (SELECT term FROM search_history ORDER BY searched_at DESC LIMIT ?2)
+	`, settings.RetentionDays, settings.MaxSize)
+	if err != nil {
+		log.Printf("Failed to prune search history: %v", err)
+	}
+}
+
+// pruneDue reports whether saved searches were last pruned over historyPruneInterval ago
+func (sh *SearchHistory) pruneDue(now time.Time) bool {
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	return now.Sub(sh.prunedAt) >= historyPruneInterval
+}
+
+// recordSearch adds a search to the history and saves it, unless incognito mode is on
+func (a *App) recordSearch(term string) {
+	settings := a.historySettings()
+	if settings.Incognito || settings.MaxSize == 0 {
+		return
+	}
+
+	now := time.Now()
+	a.history.Add(term, now, settings.MaxSize)
+
+	if a.userDB == nil || a.isReadOnly() {
+		return
+	}
+	err := a.writes.Exec(a.userDB, `
+		INSERT INTO search_history (term, searched_at) VALUES (?, ?)
+		ON CONFLICT(term) DO UPDATE SET searched_at = excluded.searched_at
+	`, term, now.UTC().Format("2006-01-02 15:04:05.000"))
+	if err != nil {
+		log.Printf("Failed to save search history: %v", err)
+		return
+	}
+	// Pruning on every keystroke would double the writes; the in-memory
+	// history is already capped, and loading prunes too
+	if a.history.pruneDue(now) {
+		a.pruneSearchHistory(settings)
+	}
+	a.scheduleJumpListUpdate()
+}
+
+// expire drops searches made before cutoff
+func (sh *SearchHistory) expire(cutoff time.Time) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	kept := sh.history[:0]
+	for _, term := range sh.history {
+		if sh.searchedAt[term].Before(cutoff) {
+			delete(sh.searchedAt, term)
+			continue
+		}
+		kept = append(kept, term)
+	}
+	sh.history = kept
+}
+
+// GetHistorySettings returns the search history size, retention, and incognito state
+func (a *App) GetHistorySettings() HistorySettings {
+	return a.historySettings()
+}
+
+// SetHistorySettings validates and persists the search history settings, trimming
+// saved searches to the new limits
+func (a *App) SetHistorySettings(settings HistorySettings) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if settings.MaxSize < 0 || settings.MaxSize > maxHistorySize {
+		return newAppError(ErrCodeInvalid, "history size must be between 0 and %d", maxHistorySize)
+	}
+	if settings.RetentionDays < 0 || settings.RetentionDays > maxHistoryRetentionDays {
+		return newAppError(ErrCodeInvalid, "retention must be between 0 (forever) and %d days", maxHistoryRetentionDays)
+	}
+
+	wasIncognito := a.historySettings().Incognito
+	values := map[string]string{
+		"history.maxSize":       strconv.Itoa(settings.MaxSize),
+		"history.retentionDays": strconv.Itoa(settings.RetentionDays),
+		"history.incognito":     strconv.FormatBool(settings.Incognito),
+	}
+	for key, value := range values {
+		if err := a.settings.Set(key, value); err != nil {
+			return err
+		}
+	}
+
+	a.pruneSearchHistory(settings)
+	a.loadSearchHistory()
+	if settings.Incognito != wasIncognito {
+		a.emit(EventIncognitoChanged, settings.Incognito)
+	}
+	return nil
+}
+
+// SetIncognito suspends or resumes recording searches
+func (a *App) SetIncognito(enabled bool) error {
+	settings := a.historySettings()
+	settings.Incognito = enabled
+	return a.SetHistorySettings(settings)
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	goruntime "runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Hook events that can run a user command
+const (
+	HookOnCopy     = "on_copy"
+	HookOnFavorite = "on_favorite"
+)
+
+// hookEvents lists the supported hook events
+var hookEvents = []string{HookOnCopy, HookOnFavorite}
+
+const (
+	defaultHookTimeout = 10
+	maxHookTimeout     = 300
+
+	// maxRunningHooks caps concurrent runs of one hook so rapid copies can't
+	// pile up processes; extra runs are dropped
+	maxRunningHooks = 4
+)
+
+// HookConfig is a user command run after an event. The command gets the glyph
+// in GLYPH_NAME, GLYPH_CHAR, GLYPH_CODEPOINT, GLYPH_ID, and GLYPH_SOURCE, plus
+// GYLTE_EVENT; on_favorite also sets GLYPH_FAVORITE to 1 or 0.
+type HookConfig struct {
+	Event   string `json:"event"`
+	Command string `json:"command"`
+	Enabled bool   `json:"enabled"`
+	// TimeoutSeconds kills the command after this long
+	TimeoutSeconds int `json:"timeoutSeconds"`
+}
+
+// HookRun is the outcome of a hook invocation
+type HookRun struct {
+	Output   string  `json:"output"`
+	ExitCode int     `json:"exitCode"`
+	Duration float64 `json:"duration"` // milliseconds
+}
+
+// Hooks tracks running hook commands
+type Hooks struct {
+	mu      sync.Mutex
+	running map[string]int
+}
+
+// validHookEvent reports whether event is a supported hook event
+func validHookEvent(event string) bool {
+	for _, e := range hookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// hookConfig returns the saved configuration of a hook
+func (a *App) hookConfig(event string) HookConfig {
+	timeout := a.settings.GetInt("hooks."+event+".timeout", defaultHookTimeout)
+	if timeout <= 0 || timeout > maxHookTimeout {
+		timeout = defaultHookTimeout
+	}
+	return HookConfig{
+		Event:          event,
+		Command:        a.settings.Get("hooks."+event+".command", ""),
+		Enabled:        a.settings.GetBool("hooks."+event+".enabled", false),
+		TimeoutSeconds: timeout,
+	}
+}
+
+// hookEnv returns the environment passed to a hook command
+func hookEnv(event string, g Glyph, extra map[string]string) []string {
+	env := append(os.Environ(),
+		"GYLTE_EVENT="+event,
+		"GLYPH_ID="+strconv.Itoa(g.ID),
+		"GLYPH_NAME="+g.Name,
+		"GLYPH_CHAR="+g.Glyph,
+		"GLYPH_CODEPOINT="+formatCodepoint(codepointOf(g.Glyph)),
+		"GLYPH_SOURCE="+g.Source,
+	)
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		env = append(env, k+"="+extra[k])
+	}
+	return env
+}
+
+// shellCommand runs command through the platform shell
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if goruntime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// execHook runs a hook command to completion and returns its combined output
+func execHook(cfg HookConfig, g Glyph, extra map[string]string) (*HookRun, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.TimeoutSeconds)*time.Second)
+	defer cancel()
+
+	cmd := shellCommand(ctx, cfg.Command)
+	cmd.Env = hookEnv(cfg.Event, g, extra)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	// Don't wait on children of the shell that keep its output open after a timeout
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	err := cmd.Run()
+	run := &HookRun{
+		Output:   strings.TrimSpace(out.String()),
+		ExitCode: cmd.ProcessState.ExitCode(),
+		Duration: millisSince(start),
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return run, fmt.Errorf("hook %s timed out after %ds", cfg.Event, cfg.TimeoutSeconds)
+	}
+	if err != nil {
+		return run, fmt.Errorf("hook %s failed: %w", cfg.Event, err)
+	}
+	return run, nil
+}
+
+// runHook starts the command configured for event in the background, if enabled
+func (a *App) runHook(event string, glyphID int, extra map[string]string) {
+	cfg := a.hookConfig(event)
+	if !cfg.Enabled || strings.TrimSpace(cfg.Command) == "" {
+		return
+	}
+	g, ok := a.findGlyph(glyphID)
+	if !ok {
+		return
+	}
+
+	a.hooks.mu.Lock()
+	if a.hooks.running == nil {
+		a.hooks.running = make(map[string]int)
+	}
+	if a.hooks.running[event] >= maxRunningHooks {
+		a.hooks.mu.Unlock()
+		log.Printf("Hook %s skipped: %d runs still in progress", event, maxRunningHooks)
+		return
+	}
+	a.hooks.running[event]++
+	a.hooks.mu.Unlock()
+
+	go func() {
+		defer func() {
+			a.hooks.mu.Lock()
+			a.hooks.running[event]--
+			a.hooks.mu.Unlock()
+		}()
+		if run, err := execHook(cfg, g, extra); err != nil {
+			log.Printf("%v: %s", err, lastLine(run.Output))
+		}
+	}()
+}
+
+// GetHooks returns the configuration of every hook event
+func (a *App) GetHooks() []HookConfig {
+	hooks := make([]HookConfig, len(hookEvents))
+	for i, event := range hookEvents {
+		hooks[i] = a.hookConfig(event)
+	}
+	return hooks
+}
+
+// SetHook saves a hook's command, enabled flag, and timeout
+func (a *App) SetHook(cfg HookConfig) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if !validHookEvent(cfg.Event) {
+		return fmt.Errorf("unknown hook event: %s", cfg.Event)
+	}
+	if cfg.TimeoutSeconds <= 0 || cfg.TimeoutSeconds > maxHookTimeout {
+		return fmt.Errorf("hook timeout must be between 1 and %d seconds", maxHookTimeout)
+	}
+	cfg.Command = strings.TrimSpace(cfg.Command)
+	if cfg.Enabled && cfg.Command == "" {
+		return fmt.Errorf("hook %s needs a command", cfg.Event)
+	}
+
+	values := map[string]string{
+		"hooks." + cfg.Event + ".command": cfg.Command,
+		"hooks." + cfg.Event + ".enabled": strconv.FormatBool(cfg.Enabled),
+		"hooks." + cfg.Event + ".timeout": strconv.Itoa(cfg.TimeoutSeconds),
+	}
+	for key, value := range values {
+		if err := a.settings.Set(key, value); err != nil {
+			return err
+		}
+	}
+	log.Printf("Hook %s saved (enabled: %v)", cfg.Event, cfg.Enabled)
+	return nil
+}
+
+// TestHook runs a hook's saved command for a glyph right away, even when the
+// hook is disabled, and returns its output
+func (a *App) TestHook(event string, glyphID int) (*HookRun, error) {
+	if !validHookEvent(event) {
+		return nil, fmt.Errorf("unknown hook event: %s", event)
+	}
+	cfg := a.hookConfig(event)
+	if strings.TrimSpace(cfg.Command) == "" {
+		return nil, fmt.Errorf("hook %s has no command", event)
+	}
+	g, ok := a.findGlyph(glyphID)
+	if !ok {
+		return nil, fmt.Errorf("glyph %d not found", glyphID)
+	}
+
+	var extra map[string]string
+	if event == HookOnFavorite {
+		extra = map[string]string{"GLYPH_FAVORITE": "1"}
+	}
+	return execHook(cfg, g, extra)
+}
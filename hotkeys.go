@@ -0,0 +1,339 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EventHotkeyTriggered is emitted when a glyph hotkey copies its glyph
+const EventHotkeyTriggered = "hotkey:triggered"
+
+// errHotkeysUnsupported is returned by platforms without a global hotkey backend
+var errHotkeysUnsupported = errors.New("global hotkeys are not supported on this platform")
+
+// Accelerator is a parsed key combination such as "Ctrl+Alt+1"
+type Accelerator struct {
+	Ctrl  bool
+	Alt   bool
+	Shift bool
+	Super bool
+	Key   string // "A"-"Z", "0"-"9" or "F1"-"F24"
+}
+
+// parseAccelerator parses a "+"-separated key combination. At least one
+// modifier is required so plain typing is never captured.
+func parseAccelerator(s string) (Accelerator, error) {
+	var acc Accelerator
+	parts := strings.Split(strings.TrimSpace(s), "+")
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if i < len(parts)-1 {
+			switch strings.ToLower(part) {
+			case "ctrl", "control", "cmdorctrl":
+				acc.Ctrl = true
+			case "alt", "option":
+				acc.Alt = true
+			case "shift":
+				acc.Shift = true
+			case "super", "win", "cmd", "meta":
+				acc.Super = true
+			default:
+				return acc, newAppError(ErrCodeInvalid, "unknown modifier %q in %q", part, s)
+			}
+			continue
+		}
+
+		key := strings.ToUpper(part)
+		if !validHotkeyKey(key) {
+			return acc, newAppError(ErrCodeInvalid, "unsupported key %q in %q", part, s)
+		}
+		acc.Key = key
+	}
+
+	if !acc.Ctrl && !acc.Alt && !acc.Shift && !acc.Super {
+		return acc, newAppError(ErrCodeInvalid, "hotkey %q needs at least one modifier", s)
+	}
+	return acc, nil
+}
+
+// validHotkeyKey reports whether key is a letter, digit or function key
+func validHotkeyKey(key string) bool {
+	if len(key) == 1 {
+		c := key[0]
+		return (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(key, "F")); err == nil && key[0] == 'F' {
+		return n >= 1 && n <= 24
+	}
+	return false
+}
+
+// String formats the accelerator in canonical order, e.g. "Ctrl+Alt+1"
+func (acc Accelerator) String() string {
+	var parts []string
+	if acc.Ctrl {
+		parts = append(parts, "Ctrl")
+	}
+	if acc.Alt {
+		parts = append(parts, "Alt")
+	}
+	if acc.Shift {
+		parts = append(parts, "Shift")
+	}
+	if acc.Super {
+		parts = append(parts, "Super")
+	}
+	return strings.Join(append(parts, acc.Key), "+")
+}
+
+// hotkeyBackend registers global shortcuts with the operating system
+type hotkeyBackend interface {
+	Register(id int, acc Accelerator) error
+	Unregister(id int) error
+	Close()
+}
+
+// GlyphHotkey is a global shortcut that copies a glyph
+type GlyphHotkey struct {
+	Accelerator string `json:"accelerator"`
+	GlyphID     int    `json:"glyphId"`
+	Name        string `json:"name"`
+	Glyph       string `json:"glyph"`
+	Registered  bool   `json:"registered"`
+	Error       string `json:"error,omitempty"`
+}
+
+// GlyphHotkeys tracks which shortcuts are registered with the OS
+type GlyphHotkeys struct {
+	mu      sync.Mutex
+	backend hotkeyBackend
+	glyphs  map[int]int       // registration id -> glyph id
+	ids     map[string]int    // accelerator -> registration id
+	errors  map[string]string // accelerator -> registration error
+}
+
+// initHotkeysTable creates the glyph_hotkeys table if it doesn't exist
+func (a *App) initHotkeysTable() error {
+	_, err := a.userDB.Exec(`
+		CREATE TABLE IF NOT EXISTS glyph_hotkeys (
+			accelerator TEXT PRIMARY KEY,
+			glyph_id INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	return err
+}
+
+// storedHotkeys returns the persisted accelerator -> glyph id assignments
+func (a *App) storedHotkeys() (map[string]int, error) {
+	result := make(map[string]int)
+	if a.userDB == nil {
+		return result, nil
+	}
+
+	rows, err := a.userDB.Query("SELECT accelerator, glyph_id FROM glyph_hotkeys")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hotkeys: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var accelerator string
+		var glyphID int
+		if err := rows.Scan(&accelerator, &glyphID); err != nil {
+			continue
+		}
+		result[accelerator] = glyphID
+	}
+	return result, rows.Err()
+}
+
+// triggerHotkey copies the glyph bound to a registration id
+func (a *App) triggerHotkey(id int) {
+	a.hotkeys.mu.Lock()
+	glyphID, ok := a.hotkeys.glyphs[id]
+	a.hotkeys.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if err := a.CopyGlyph(glyphID); err != nil {
+		log.Printf("Hotkey copy failed: %v", err)
+		return
+	}
+	if g, ok := a.findGlyph(glyphID); ok {
+		a.emit(EventHotkeyTriggered, g)
+	}
+}
+
+// registerGlyphHotkeys replaces all OS registrations with the active profile's hotkeys
+func (a *App) registerGlyphHotkeys() {
+	stored, err := a.storedHotkeys()
+	if err != nil {
+		log.Printf("Failed to load hotkeys: %v", err)
+		return
+	}
+
+	h := a.hotkeys
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.backend != nil {
+		for id := range h.glyphs {
+			h.backend.Unregister(id)
+		}
+	}
+	h.glyphs = make(map[int]int)
+	h.ids = make(map[string]int)
+	h.errors = make(map[string]string)
+
+	if len(stored) == 0 || a.headless {
+		return
+	}
+	if h.backend == nil {
+		backend, err := newHotkeyBackend(a.triggerHotkey)
+		if err != nil {
+			for accelerator := range stored {
+				h.errors[accelerator] = err.Error()
+			}
+			log.Printf("Global hotkeys unavailable: %v", err)
+			return
+		}
+		h.backend = backend
+	}
+
+	accelerators := make([]string, 0, len(stored))
+	for accelerator := range stored {
+		accelerators = append(accelerators, accelerator)
+	}
+	sort.Strings(accelerators)
+
+	for i, accelerator := range accelerators {
+		id := i + 1
+		acc, err := parseAccelerator(accelerator)
+		if err == nil {
+			err = h.backend.Register(id, acc)
+		}
+		if err != nil {
+			h.errors[accelerator] = err.Error()
+			log.Printf("Failed to register hotkey %s: %v", accelerator, err)
+			continue
+		}
+		h.glyphs[id] = stored[accelerator]
+		h.ids[accelerator] = id
+	}
+	log.Printf("Registered %d of %d glyph hotkeys", len(h.ids), len(stored))
+}
+
+// closeHotkeys releases every OS registration
+func (a *App) closeHotkeys() {
+	h := a.hotkeys
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.backend != nil {
+		h.backend.Close()
+		h.backend = nil
+	}
+	h.glyphs = nil
+	h.ids = nil
+}
+
+// AssignGlyphHotkey binds a global shortcut (e.g. "Ctrl+Alt+1") to copy a glyph,
+// replacing any glyph previously bound to it
+func (a *App) AssignGlyphHotkey(glyphID int, accelerator string) error {
+	if err := a.checkWritable("assign hotkeys"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+
+	if err := requireCapability(CapabilityGlobalHotkeys); err != nil {
+		return err
+	}
+	acc, err := parseAccelerator(accelerator)
+	if err != nil {
+		return err
+	}
+	if _, ok := a.findGlyph(glyphID); !ok {
+		return newAppError(ErrCodeNotFound, "glyph %d not found", glyphID)
+	}
+
+	_, err = a.userDB.Exec(`
+		INSERT INTO glyph_hotkeys (accelerator, glyph_id) VALUES (?, ?)
+		ON CONFLICT(accelerator) DO UPDATE SET glyph_id = excluded.glyph_id
+	`, acc.String(), glyphID)
+	if err != nil {
+		return fmt.Errorf("failed to save hotkey: %w", err)
+	}
+
+	a.registerGlyphHotkeys()
+
+	a.hotkeys.mu.Lock()
+	regErr := a.hotkeys.errors[acc.String()]
+	a.hotkeys.mu.Unlock()
+	if regErr != "" {
+		return fmt.Errorf("hotkey saved but could not be registered: %s", regErr)
+	}
+
+	if err := a.completeOnboardingStep(StepHotkeyConfigured); err != nil {
+		log.Printf("Failed to record onboarding step: %v", err)
+	}
+	return nil
+}
+
+// RemoveGlyphHotkey deletes a glyph shortcut
+func (a *App) RemoveGlyphHotkey(accelerator string) error {
+	if err := a.checkWritable("remove hotkeys"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+
+	acc, err := parseAccelerator(accelerator)
+	if err != nil {
+		return err
+	}
+	if _, err := a.userDB.Exec("DELETE FROM glyph_hotkeys WHERE accelerator = ?", acc.String()); err != nil {
+		return fmt.Errorf("failed to remove hotkey: %w", err)
+	}
+
+	a.registerGlyphHotkeys()
+	return nil
+}
+
+// ListGlyphHotkeys returns every glyph shortcut with its registration state
+func (a *App) ListGlyphHotkeys() ([]GlyphHotkey, error) {
+	stored, err := a.storedHotkeys()
+	if err != nil {
+		return nil, err
+	}
+
+	a.hotkeys.mu.Lock()
+	defer a.hotkeys.mu.Unlock()
+
+	result := make([]GlyphHotkey, 0, len(stored))
+	for accelerator, glyphID := range stored {
+		hk := GlyphHotkey{
+			Accelerator: accelerator,
+			GlyphID:     glyphID,
+			Error:       a.hotkeys.errors[accelerator],
+		}
+		_, hk.Registered = a.hotkeys.ids[accelerator]
+		if g, ok := a.findGlyph(glyphID); ok {
+			hk.Name, hk.Glyph = g.Name, g.Glyph
+		}
+		result = append(result, hk)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Accelerator < result[j].Accelerator
+	})
+	return result, nil
+}
@@ -0,0 +1,193 @@
+//go:build linux && cgo
+
+package main
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+#include <stdlib.h>
+
+static int gylte_grab_failed;
+
+static int gylte_grab_error(Display *d, XErrorEvent *e) {
+	gylte_grab_failed = 1;
+	return 0;
+}
+
+// gylte_grab grabs a key on the root window for every lock-key state, returning
+// non-zero when another client already owns the combination
+static int gylte_grab(Display *d, int keycode, unsigned int mods) {
+	unsigned int locks[] = {0, LockMask, Mod2Mask, LockMask | Mod2Mask};
+	Window root = DefaultRootWindow(d);
+
+	gylte_grab_failed = 0;
+	XErrorHandler prev = XSetErrorHandler(gylte_grab_error);
+	for (int i = 0; i < 4; i++) {
+		XGrabKey(d, keycode, mods | locks[i], root, False, GrabModeAsync, GrabModeAsync);
+	}
+	XSync(d, False);
+	XSetErrorHandler(prev);
+	return gylte_grab_failed;
+}
+
+static void gylte_ungrab(Display *d, int keycode, unsigned int mods) {
+	unsigned int locks[] = {0, LockMask, Mod2Mask, LockMask | Mod2Mask};
+	Window root = DefaultRootWindow(d);
+	for (int i = 0; i < 4; i++) {
+		XUngrabKey(d, keycode, mods | locks[i], root);
+	}
+	XSync(d, False);
+}
+
+// gylte_next_key returns 1 and fills keycode/state when the next pending event is a key press
+static int gylte_next_key(Display *d, unsigned int *keycode, unsigned int *state) {
+	XEvent ev;
+	XNextEvent(d, &ev);
+	if (ev.type != KeyPress) {
+		return 0;
+	}
+	*keycode = ev.xkey.keycode;
+	*state = ev.xkey.state & (ShiftMask | ControlMask | Mod1Mask | Mod4Mask);
+	return 1;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	goruntime "runtime"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// hotkeysSupported reports that this build has a global hotkey backend
+const hotkeysSupported = true
+
+// x11PollInterval is how often the event loop checks for key presses and requests
+const x11PollInterval = 50 * time.Millisecond
+
+// x11Grab is a key combination grabbed on the root window
+type x11Grab struct {
+	keycode C.int
+	mods    C.uint
+}
+
+// x11Hotkeys grabs keys on the X11 root window. Xlib isn't safe to share across
+// threads, so a single locked goroutine owns the display and runs every request.
+type x11Hotkeys struct {
+	requests chan func()
+	quit     chan struct{}
+	display  *C.Display
+	grabs    map[int]x11Grab
+}
+
+// newHotkeyBackend connects to the X server and starts the event loop
+func newHotkeyBackend(trigger func(id int)) (hotkeyBackend, error) {
+	x := &x11Hotkeys{
+		requests: make(chan func()),
+		quit:     make(chan struct{}),
+		grabs:    make(map[int]x11Grab),
+	}
+	opened := make(chan error, 1)
+
+	go func() {
+		goruntime.LockOSThread()
+		defer goruntime.UnlockOSThread()
+
+		x.display = C.XOpenDisplay(nil)
+		if x.display == nil {
+			opened <- fmt.Errorf("no X11 display available (Wayland sessions need an X11 compatibility layer)")
+			return
+		}
+		defer C.XCloseDisplay(x.display)
+		opened <- nil
+
+		ticker := time.NewTicker(x11PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-x.quit:
+				return
+			case fn := <-x.requests:
+				fn()
+			case <-ticker.C:
+				for C.XPending(x.display) > 0 {
+					var keycode, state C.uint
+					if C.gylte_next_key(x.display, &keycode, &state) == 0 {
+						continue
+					}
+					for id, g := range x.grabs {
+						if C.uint(g.keycode) == keycode && g.mods == state {
+							go trigger(id)
+						}
+					}
+				}
+			}
+		}
+	}()
+
+	if err := <-opened; err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// call runs fn on the event loop goroutine and waits for its result
+func (x *x11Hotkeys) call(fn func() error) error {
+	done := make(chan error, 1)
+	x.requests <- func() { done <- fn() }
+	return <-done
+}
+
+func (x *x11Hotkeys) Register(id int, acc Accelerator) error {
+	var mods C.uint
+	if acc.Ctrl {
+		mods |= C.ControlMask
+	}
+	if acc.Alt {
+		mods |= C.Mod1Mask
+	}
+	if acc.Shift {
+		mods |= C.ShiftMask
+	}
+	if acc.Super {
+		mods |= C.Mod4Mask
+	}
+
+	// X keysym names are lowercase for letters and "F1" style for function keys
+	name := acc.Key
+	if len(name) == 1 {
+		name = strings.ToLower(name)
+	}
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	return x.call(func() error {
+		keycode := C.int(C.XKeysymToKeycode(x.display, C.XStringToKeysym(cname)))
+		if keycode == 0 {
+			return fmt.Errorf("no key code for %s", acc.Key)
+		}
+		if C.gylte_grab(x.display, keycode, mods) != 0 {
+			C.gylte_ungrab(x.display, keycode, mods)
+			return fmt.Errorf("%s is already in use", acc)
+		}
+		x.grabs[id] = x11Grab{keycode: keycode, mods: mods}
+		return nil
+	})
+}
+
+func (x *x11Hotkeys) Unregister(id int) error {
+	return x.call(func() error {
+		if g, ok := x.grabs[id]; ok {
+			C.gylte_ungrab(x.display, g.keycode, g.mods)
+			delete(x.grabs, id)
+		}
+		return nil
+	})
+}
+
+func (x *x11Hotkeys) Close() {
+	close(x.quit)
+}
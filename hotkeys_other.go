@@ -0,0 +1,11 @@
+//go:build !windows && !(linux && cgo)
+
+package main
+
+// hotkeysSupported reports that this build has a global hotkey backend
+const hotkeysSupported = false
+
+// newHotkeyBackend reports that global hotkeys aren't available on this platform
+func newHotkeyBackend(trigger func(id int)) (hotkeyBackend, error) {
+	return nil, errHotkeysUnsupported
+}
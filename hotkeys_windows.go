@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	goruntime "runtime"
+	"syscall"
+	"unsafe"
+)
+
+// hotkeysSupported reports that this build has a global hotkey backend
+const hotkeysSupported = true
+
+var (
+	user32                 = syscall.NewLazyDLL("user32.dll")
+	procRegisterHotKey     = user32.NewProc("RegisterHotKey")
+	procUnregisterHotKey   = user32.NewProc("UnregisterHotKey")
+	procGetMessageW        = user32.NewProc("GetMessageW")
+	procPeekMessageW       = user32.NewProc("PeekMessageW")
+	procPostThreadMessageW = user32.NewProc("PostThreadMessageW")
+	procGetCurrentThreadId = syscall.NewLazyDLL("kernel32.dll").NewProc("GetCurrentThreadId")
+)
+
+const (
+	modAlt      = 0x0001
+	modControl  = 0x0002
+	modShift    = 0x0004
+	modWin      = 0x0008
+	modNoRepeat = 0x4000
+
+	wmQuit   = 0x0012
+	wmHotkey = 0x0312
+	wmApp    = 0x8000
+)
+
+// winMsg mirrors the Win32 MSG structure
+type winMsg struct {
+	hwnd    uintptr
+	message uint32
+	wParam  uintptr
+	lParam  uintptr
+	time    uint32
+	pt      struct{ x, y int32 }
+}
+
+// winHotkeys owns a message loop thread; RegisterHotKey delivers WM_HOTKEY to
+// the registering thread, so every call is marshalled onto it
+type winHotkeys struct {
+	threadID uintptr
+	requests chan func()
+}
+
+// newHotkeyBackend starts the hotkey message loop
+func newHotkeyBackend(trigger func(id int)) (hotkeyBackend, error) {
+	w := &winHotkeys{requests: make(chan func(), 8)}
+	ready := make(chan struct{})
+
+	go func() {
+		goruntime.LockOSThread()
+		defer goruntime.UnlockOSThread()
+
+		// Peeking forces Windows to create the thread's message queue
+		var m winMsg
+		procPeekMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0, 0)
+		w.threadID, _, _ = procGetCurrentThreadId.Call()
+		close(ready)
+
+		for {
+			ret, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+			if int32(ret) <= 0 {
+				return
+			}
+			switch m.message {
+			case wmHotkey:
+				go trigger(int(m.wParam))
+			case wmApp:
+				for len(w.requests) > 0 {
+					(<-w.requests)()
+				}
+			}
+		}
+	}()
+
+	<-ready
+	return w, nil
+}
+
+// call runs fn on the message loop thread and waits for its result
+func (w *winHotkeys) call(fn func() error) error {
+	done := make(chan error, 1)
+	w.requests <- func() { done <- fn() }
+	if ret, _, err := procPostThreadMessageW.Call(w.threadID, wmApp, 0, 0); ret == 0 {
+		return fmt.Errorf("failed to reach hotkey thread: %w", err)
+	}
+	return <-done
+}
+
+// virtualKey maps an accelerator key to a Windows virtual-key code
+func virtualKey(key string) uintptr {
+	if len(key) == 1 {
+		return uintptr(key[0]) // 'A'-'Z' and '0'-'9' match their VK codes
+	}
+	var n int
+	fmt.Sscanf(key, "F%d", &n)
+	return uintptr(0x70 + n - 1)
+}
+
+func (w *winHotkeys) Register(id int, acc Accelerator) error {
+	mods := uintptr(modNoRepeat)
+	if acc.Ctrl {
+		mods |= modControl
+	}
+	if acc.Alt {
+		mods |= modAlt
+	}
+	if acc.Shift {
+		mods |= modShift
+	}
+	if acc.Super {
+		mods |= modWin
+	}
+
+	return w.call(func() error {
+		if ret, _, err := procRegisterHotKey.Call(0, uintptr(id), mods, virtualKey(acc.Key)); ret == 0 {
+			return fmt.Errorf("%s is already in use: %w", acc, err)
+		}
+		return nil
+	})
+}
+
+func (w *winHotkeys) Unregister(id int) error {
+	return w.call(func() error {
+		procUnregisterHotKey.Call(0, uintptr(id))
+		return nil
+	})
+}
+
+func (w *winHotkeys) Close() {
+	procPostThreadMessageW.Call(w.threadID, wmQuit, 0, 0)
+}
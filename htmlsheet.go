@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// htmlSheetTemplate is a standalone page; clicking a card copies its glyph
+var htmlSheetTemplate = template.Must(template.New("sheet").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+{{- if .FontURI}}
+@font-face { font-family: "GylteSubset"; src: url({{.FontURI}}) format("woff2"); }
+{{- end}}
+body { margin: 2rem; font-family: system-ui, sans-serif; background: #121212; color: #e0e0e0; }
+header { display: flex; justify-content: space-between; align-items: baseline; }
+.grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(120px, 1fr)); gap: 0.5rem; }
+.card { border: 1px solid #333; border-radius: 6px; padding: 0.75rem 0.25rem; text-align: center; cursor: pointer; }
+.card:hover { border-color: #888; }
+.glyph { font-family: "GylteSubset", "Symbols Nerd Font", monospace; font-size: 2rem; line-height: 1.2; }
+.name { font-size: 0.7rem; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+.cp { font-size: 0.65rem; color: #888; font-family: monospace; }
+footer { margin-top: 2rem; font-size: 0.75rem; color: #888; }
+</style>
+</head>
+<body>
+<header><h1>{{.Title}}</h1><span>{{len .Glyphs}} glyphs - {{.Date}}</span></header>
+<div class="grid">
+{{- range .Glyphs}}
+<div class="card" title="{{.Name}}" data-glyph="{{.Glyph}}"><div class="glyph">{{.Glyph}}</div><div class="name">{{.Name}}</div><div class="cp">{{.Codepoint}}</div></div>
+{{- end}}
+</div>
+{{- if .Attribution}}
+<footer><p>{{.Heading}}</p><ul>{{range .Attribution}}<li>{{.}}</li>{{end}}</ul></footer>
+{{- end}}
+<script>
+document.querySelectorAll(".card").forEach(function (card) {
+  card.addEventListener("click", function () { navigator.clipboard.writeText(card.dataset.glyph); });
+});
+</script>
+</body>
+</html>
+`))
+
+// htmlSheetGlyph is one card on the HTML cheat sheet
+type htmlSheetGlyph struct {
+	Name      string
+	Glyph     string
+	Codepoint string
+}
+
+// glyphRunes returns the distinct code points used by glyphs
+func glyphRunes(glyphs []Glyph) []rune {
+	seen := make(map[rune]bool)
+	var runes []rune
+	for _, g := range glyphs {
+		for _, r := range g.Glyph {
+			if !seen[r] {
+				seen[r] = true
+				runes = append(runes, r)
+			}
+		}
+	}
+	return runes
+}
+
+// renderHTMLCheatSheet writes the page, embedding font (a WOFF2 subset) as a data URI when present
+func renderHTMLCheatSheet(title string, glyphs []Glyph, font []byte, attribution []IconSetLicense) ([]byte, error) {
+	data := struct {
+		Title       string
+		Date        string
+		FontURI     template.URL
+		Glyphs      []htmlSheetGlyph
+		Heading     string
+		Attribution []string
+	}{
+		Title:       title,
+		Date:        time.Now().Format("2006-01-02"),
+		Heading:     attributionHeading,
+		Attribution: attributionLines(attribution),
+	}
+	if font != nil {
+		data.FontURI = template.URL("data:font/woff2;base64," + base64.StdEncoding.EncodeToString(font))
+	}
+	for _, g := range glyphs {
+		data.Glyphs = append(data.Glyphs, htmlSheetGlyph{
+			Name:      g.Name,
+			Glyph:     g.Glyph,
+			Codepoint: formatCodepoint(codepointOf(g.Glyph)),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := htmlSheetTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// htmlCheatSheetExporter writes a standalone page with a WOFF2 subset of the
+// Nerd Font embedded, so it renders without the font installed
+var htmlCheatSheetExporter = exporterFunc{
+	name:       "html",
+	extensions: []string{".html", ".htm"},
+	export: func(w io.Writer, glyphs []Glyph, opts ExportOptions) error {
+		opts.progress("Subsetting font…")
+		var font []byte
+		var err error
+		if opts.FontPath == "" {
+			err = newAppError(ErrCodeNotFound, "no Nerd Font found")
+		} else {
+			var data []byte
+			if data, err = os.ReadFile(opts.FontPath); err == nil {
+				var subset *fontSubset
+				if subset, err = subsetFont(data, glyphRunes(glyphs)); err == nil {
+					if len(subset.missing) > 0 {
+						log.Printf("%s has no glyph for %d code points", filepath.Base(opts.FontPath), len(subset.missing))
+					}
+					font, err = subset.tables.WOFF2()
+				}
+			}
+		}
+		if err != nil {
+			log.Printf("HTML cheat sheet without an embedded font: %v", err)
+		}
+
+		page, err := renderHTMLCheatSheet("Gylte - "+opts.Title, glyphs, font, opts.Attribution)
+		if err != nil {
+			return fmt.Errorf("failed to render HTML: %w", err)
+		}
+		_, err = w.Write(page)
+		return err
+	},
+}
+
+// ExportHTMLCheatSheet writes the glyphs of a scope to a standalone HTML page with a
+// WOFF2 subset of the Nerd Font embedded, so it renders without the font installed.
+// An empty path exports to the default exports directory.
+func (a *App) ExportHTMLCheatSheet(scope string, path string) (string, error) {
+	op := a.startOperation("export", "Creating HTML cheat sheet…")
+
+	glyphs, err := a.scopeGlyphs(scope)
+	if err != nil {
+		return "", op.Fail("Could not create the cheat sheet", err)
+	}
+	title := scopeTitle(scope)
+	return a.exportGlyphs(op, "html", "cheatsheet-"+sanitizeFileName(title), path, title, glyphs)
+}
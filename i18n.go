@@ -0,0 +1,250 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+const defaultLocale = "en"
+
+// LocaleCatalog holds the UI strings and search keywords for one locale
+type LocaleCatalog struct {
+	Name     string              `json:"name"`
+	Strings  map[string]string   `json:"strings"`
+	Keywords map[string][]string `json:"keywords"`
+}
+
+// LocaleInfo describes an available locale
+type LocaleInfo struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// Localizer manages translations and localized keyword aliases
+type Localizer struct {
+	mu       sync.RWMutex
+	locale   string
+	catalogs map[string]*LocaleCatalog
+}
+
+// NewLocalizer loads all embedded locale catalogs
+func NewLocalizer() *Localizer {
+	l := &Localizer{
+		locale:   defaultLocale,
+		catalogs: make(map[string]*LocaleCatalog),
+	}
+
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		log.Printf("Failed to read locales: %v", err)
+		return l
+	}
+
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			log.Printf("Failed to read locale %s: %v", entry.Name(), err)
+			continue
+		}
+
+		var catalog LocaleCatalog
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			log.Printf("Failed to parse locale %s: %v", entry.Name(), err)
+			continue
+		}
+
+		// Keywords are matched case-insensitively
+		keywords := make(map[string][]string, len(catalog.Keywords))
+		for k, v := range catalog.Keywords {
+			keywords[strings.ToLower(k)] = v
+		}
+		catalog.Keywords = keywords
+
+		code := strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))
+		l.catalogs[code] = &catalog
+	}
+
+	return l
+}
+
+// systemLocale guesses the user's locale from the environment (e.g. "de_DE.UTF-8" -> "de")
+func systemLocale() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			return baseLocale(strings.SplitN(v, ".", 2)[0])
+		}
+	}
+	return defaultLocale
+}
+
+// baseLocale reduces a locale tag to its language code (e.g. "de-AT" -> "de")
+func baseLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	return strings.SplitN(strings.ReplaceAll(locale, "_", "-"), "-", 2)[0]
+}
+
+// Supports reports whether a catalog exists for the locale
+func (l *Localizer) Supports(locale string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	_, ok := l.catalogs[baseLocale(locale)]
+	return ok
+}
+
+// normalizeLocale maps a locale code to a supported catalog, falling back to the default
+func (l *Localizer) normalizeLocale(locale string) string {
+	if l.Supports(locale) {
+		return baseLocale(locale)
+	}
+	return defaultLocale
+}
+
+// Locale returns the active locale
+func (l *Localizer) Locale() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.locale
+}
+
+// SetLocale switches the active locale
+func (l *Localizer) SetLocale(locale string) {
+	locale = l.normalizeLocale(locale)
+
+	l.mu.Lock()
+	l.locale = locale
+	l.mu.Unlock()
+}
+
+// Translations returns the UI strings for a locale, filling gaps from the default locale
+func (l *Localizer) Translations(locale string) map[string]string {
+	locale = l.normalizeLocale(locale)
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	result := make(map[string]string)
+	if base, ok := l.catalogs[defaultLocale]; ok {
+		for k, v := range base.Strings {
+			result[k] = v
+		}
+	}
+	if catalog, ok := l.catalogs[locale]; ok {
+		for k, v := range catalog.Strings {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Keywords returns the localized search keywords of the current locale with their English aliases
+func (l *Localizer) Keywords() map[string][]string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	catalog, ok := l.catalogs[l.locale]
+	if !ok {
+		return nil
+	}
+	result := make(map[string][]string, len(catalog.Keywords))
+	for k, v := range catalog.Keywords {
+		result[k] = v
+	}
+	return result
+}
+
+// Aliases returns the English search keywords a localized term maps to.
+// Partial terms (3+ characters) match keywords by prefix so results appear while typing.
+func (l *Localizer) Aliases(term string) []string {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" {
+		return nil
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	catalog, ok := l.catalogs[l.locale]
+	if !ok || len(catalog.Keywords) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var aliases []string
+	for keyword, targets := range catalog.Keywords {
+		if keyword != term && (len([]rune(term)) < 3 || !strings.HasPrefix(keyword, term)) {
+			continue
+		}
+		for _, t := range targets {
+			if !seen[t] {
+				seen[t] = true
+				aliases = append(aliases, t)
+			}
+		}
+	}
+
+	sort.Strings(aliases)
+	return aliases
+}
+
+// Available lists all loaded locales
+func (l *Localizer) Available() []LocaleInfo {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	result := make([]LocaleInfo, 0, len(l.catalogs))
+	for code, catalog := range l.catalogs {
+		result = append(result, LocaleInfo{Code: code, Name: catalog.Name})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
+
+// loadLocale applies the persisted locale setting, defaulting to the system locale
+func (a *App) loadLocale() {
+	a.i18n.SetLocale(a.settings.Get("locale", systemLocale()))
+	log.Printf("Locale: %s", a.i18n.Locale())
+}
+
+// GetLocale returns the active locale code
+func (a *App) GetLocale() string {
+	return a.i18n.Locale()
+}
+
+// SetLocale changes and persists the active locale
+func (a *App) SetLocale(locale string) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+
+	if !a.i18n.Supports(locale) {
+		return newAppError(ErrCodeInvalid, "unsupported locale: %s", locale)
+	}
+
+	a.i18n.SetLocale(locale)
+	return a.settings.Set("locale", a.i18n.Locale())
+}
+
+// GetAvailableLocales returns all supported locales
+func (a *App) GetAvailableLocales() []LocaleInfo {
+	return a.i18n.Available()
+}
+
+// GetTranslations returns translated UI strings for a locale (the active one if empty)
+func (a *App) GetTranslations(locale string) map[string]string {
+	if locale == "" {
+		locale = a.i18n.Locale()
+	}
+	return a.i18n.Translations(locale)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+
+	"Gylte/internal/glyphdata"
+)
+
+// glyphCategory extracts the category from a glyph name (e.g. "nf-cod-account" -> "cod")
+func glyphCategory(name string) string {
+	parts := strings.Split(name, "-")
+	if len(parts) >= 2 {
+		return parts[1]
+	}
+	return ""
+}
+
+// categoryOf returns a glyph's category, preferring one assigned by the user over the name prefix
+func categoryOf(g Glyph) string {
+	if g.Category != "" {
+		return g.Category
+	}
+	return glyphCategory(g.Name)
+}
+
+// iconSetName returns the display name of a category's icon set
+func iconSetName(category string) string {
+	if name, ok := glyphdata.IconSets[category]; ok {
+		return name
+	}
+	return category
+}
+
+// describeGlyph derives a readable description from the glyph name, used when
+// the database predates the description column
+func describeGlyph(g Glyph) string {
+	humanize := strings.NewReplacer("_", " ", "-", " ").Replace
+	if !strings.HasPrefix(g.Name, "nf-") {
+		return humanize(g.Name)
+	}
+
+	category := glyphCategory(g.Name)
+	return iconSetName(category) + " icon: " + humanize(strings.TrimPrefix(g.Name, "nf-"+category+"-"))
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Image export formats
+const (
+	ImageFormatPNG = "png"
+	ImageFormatSVG = "svg"
+)
+
+// defaultImageSizes are the icon sizes exported when none are given
+var defaultImageSizes = []int{16, 24, 32, 64}
+
+// imageManifestName is written next to the exported images
+const imageManifestName = "manifest.json"
+
+// ImageManifest describes a batch image export
+type ImageManifest struct {
+	Collection  string              `json:"collection"`
+	Format      string              `json:"format"`
+	Sizes       []int               `json:"sizes"`
+	Glyphs      []ImageManifestItem `json:"glyphs"`
+	Attribution []IconSetLicense    `json:"attribution,omitempty"`
+	// Skipped lists glyphs the font couldn't render
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// ImageManifestItem lists the files exported for one glyph, keyed by size
+type ImageManifestItem struct {
+	exportedGlyph
+	Files map[string]string `json:"files"`
+}
+
+// imageSizeDir names the directory holding the images of one size, e.g. "32x32"
+func imageSizeDir(size int) string {
+	return fmt.Sprintf("%dx%d", size, size)
+}
+
+// renderGlyphSVG draws a glyph's outline as a square SVG document. SVGs are
+// always monochrome and drawn with currentColor so they can be restyled.
+func (a *App) renderGlyphSVG(g Glyph, size int) ([]byte, error) {
+	face, err := a.renderFont()
+	if err != nil {
+		return nil, err
+	}
+	f := face.font
+
+	// Load outlines in font units so the path is independent of the output size
+	var buf sfnt.Buffer
+	ppem := fixed.I(int(f.UnitsPerEm()))
+	var d strings.Builder
+	var bounds fixed.Rectangle26_6
+	var dot fixed.Int26_6
+	first := true
+	for _, r := range g.Glyph {
+		idx, err := f.GlyphIndex(&buf, r)
+		if err != nil || idx == 0 {
+			return nil, newAppError(ErrCodeNotFound, "font has no glyph for %s", formatCodepoint(r))
+		}
+		segs, err := f.LoadGlyph(&buf, idx, ppem, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load glyph outline: %w", err)
+		}
+		if len(segs) > 0 {
+			b := segs.Bounds().Add(fixed.Point26_6{X: dot})
+			if first {
+				bounds, first = b, false
+			} else {
+				bounds = bounds.Union(b)
+			}
+		}
+		for _, seg := range segs {
+			cmd, n := "M", 1
+			switch seg.Op {
+			case sfnt.SegmentOpLineTo:
+				cmd = "L"
+			case sfnt.SegmentOpQuadTo:
+				cmd, n = "Q", 2
+			case sfnt.SegmentOpCubeTo:
+				cmd, n = "C", 3
+			}
+			d.WriteString(cmd)
+			for _, p := range seg.Args[:n] {
+				fmt.Fprintf(&d, "%d %d ", (p.X + dot).Floor(), p.Y.Floor())
+			}
+		}
+		advance, err := f.GlyphAdvance(&buf, idx, ppem, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure glyph: %w", err)
+		}
+		dot += advance
+	}
+
+	// Center the ink box in a square view box
+	minX, minY := bounds.Min.X.Floor(), bounds.Min.Y.Floor()
+	w, h := bounds.Max.X.Ceil()-minX, bounds.Max.Y.Ceil()-minY
+	side := max(w, h, 1)
+	minX -= (side - w) / 2
+	minY -= (side - h) / 2
+
+	svg := fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="%d %d %d %d"><title>%s</title><path fill="currentColor" d="%s"/></svg>`+"\n",
+		size, size, minX, minY, side, side, html.EscapeString(g.Name), strings.TrimSpace(d.String()))
+	return []byte(svg), nil
+}
+
+// ExportCollectionImages renders every glyph of a collection to
+// <dir>/<size>x<size>/<glyph name>.<format> at each size (16, 24, 32 and 64
+// pixels when sizes is empty), with a manifest.json listing the files, and
+// returns the directory. An empty dir exports to the default exports directory.
+func (a *App) ExportCollectionImages(name string, sizes []int, format, dir string) (string, error) {
+	format = strings.ToLower(format)
+	if format == "" {
+		format = ImageFormatPNG
+	}
+	if format != ImageFormatPNG && format != ImageFormatSVG {
+		return "", newAppError(ErrCodeInvalid, "unsupported image format: %s", format)
+	}
+	if len(sizes) == 0 {
+		sizes = defaultImageSizes
+	}
+	sizes = slices.Clone(sizes)
+	slices.Sort(sizes)
+	sizes = slices.Compact(sizes)
+	for _, size := range sizes {
+		if size < minRenderSize || size > maxRenderSize {
+			return "", newAppError(ErrCodeInvalid, "image size must be between %d and %d pixels", minRenderSize, maxRenderSize)
+		}
+	}
+
+	op := a.startOperation("export", "Exporting images…")
+
+	if _, err := a.renderFont(); err != nil {
+		return "", op.Fail("Could not export images", err)
+	}
+
+	ids, err := a.collectionGlyphIDs(name)
+	if err != nil {
+		return "", op.Fail("Could not export images", err)
+	}
+	found := a.lookupGlyphs(ids)
+	glyphs := make([]Glyph, 0, len(ids))
+	for _, id := range ids {
+		if g, ok := found[id]; ok {
+			glyphs = append(glyphs, g)
+		}
+	}
+	if len(glyphs) == 0 {
+		return "", op.Fail("Nothing to export", newAppError(ErrCodeNotFound, "collection %q has no glyphs", name))
+	}
+
+	if dir == "" {
+		dir = a.defaultExportPath("images-"+sanitizeFileName(name), "")
+	}
+	for _, size := range sizes {
+		if err := os.MkdirAll(filepath.Join(dir, imageSizeDir(size)), 0755); err != nil {
+			return "", op.Fail("Could not export images", fmt.Errorf("failed to create directory: %w", err))
+		}
+	}
+
+	manifest := ImageManifest{Collection: name, Format: format, Sizes: sizes, Glyphs: []ImageManifestItem{}}
+	exported := make([]Glyph, 0, len(glyphs))
+	for i, g := range glyphs {
+		op.Progress(float64(i)*100/float64(len(glyphs)), g.Name)
+
+		item := ImageManifestItem{exportedGlyph: exportRow(g), Files: make(map[string]string, len(sizes))}
+		fileName := sanitizeFileName(g.Name) + "." + format
+		for _, size := range sizes {
+			var data []byte
+			if format == ImageFormatSVG {
+				data, err = a.renderGlyphSVG(g, size)
+			} else {
+				data, err = a.renderGlyphPNG(g, RenderOptions{Size: size})
+			}
+			if errorCode(err) == ErrCodeNotFound {
+				// The font lacks this glyph; list it as skipped
+				break
+			}
+			if err != nil {
+				return "", op.Fail("Could not export images", fmt.Errorf("failed to render %s: %w", g.Name, err))
+			}
+
+			rel := filepath.ToSlash(filepath.Join(imageSizeDir(size), fileName))
+			if err := writeExportBytes(filepath.Join(dir, rel), data); err != nil {
+				return "", op.Fail("Could not export images", fmt.Errorf("failed to write image: %w", err))
+			}
+			item.Files[strconv.Itoa(size)] = rel
+		}
+		if len(item.Files) < len(sizes) {
+			manifest.Skipped = append(manifest.Skipped, g.Name)
+			continue
+		}
+		manifest.Glyphs = append(manifest.Glyphs, item)
+		exported = append(exported, g)
+	}
+
+	if manifest.Attribution, err = a.exportAttribution(exported); err != nil {
+		log.Printf("Exporting without attribution: %v", err)
+	}
+	if err := writeJSONFile(filepath.Join(dir, imageManifestName), manifest); err != nil {
+		return "", op.Fail("Could not export images", fmt.Errorf("failed to write manifest: %w", err))
+	}
+
+	log.Printf("Exported %d glyphs of collection %q as %s images to %s", len(manifest.Glyphs), name, format, dir)
+	op.Succeed(fmt.Sprintf("Exported %d glyphs at %d sizes", len(manifest.Glyphs), len(sizes)))
+	return dir, nil
+}
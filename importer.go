@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"Gylte/internal/glyphdata"
+)
+
+// importChunkSize is how many glyphs are written between progress reports and cancellation checks
+const importChunkSize = 500
+
+// Importer tracks the running glyph import so it can be cancelled
+type Importer struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// ImportResult summarizes a finished glyph import
+type ImportResult struct {
+	Imported int `json:"imported"`
+	Removed  int `json:"removed"`
+	Skipped  int `json:"skipped"`
+	Total    int `json:"total"`
+
+	// Added counts the imported glyphs that weren't in the database before
+	Added      int    `json:"added"`
+	ReleaseTag string `json:"releaseTag,omitempty"`
+}
+
+// begin registers a new import, failing if one is already running
+func (im *Importer) begin() (context.Context, error) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	if im.cancel != nil {
+		return nil, newAppError(ErrCodeConflict, "an import is already running")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	im.cancel = cancel
+	return ctx, nil
+}
+
+// end releases the running import
+func (im *Importer) end() {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	if im.cancel != nil {
+		im.cancel()
+		im.cancel = nil
+	}
+}
+
+// Cancel stops the running import, reporting whether one was running
+func (im *Importer) Cancel() bool {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	if im.cancel == nil {
+		return false
+	}
+	im.cancel()
+	return true
+}
+
+// readGlyphEntries loads a glyphs.json file, or the embedded copy when path is empty
+func readGlyphEntries(path string) ([]glyphEntry, error) {
+	data := embeddedGlyphs
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read glyph file: %w", err)
+		}
+	}
+
+	var entries []glyphEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse glyph file: %w", err)
+	}
+	return entries, nil
+}
+
+// builtinReleaseTag names the release the built-in glyphs.json came with: the
+// GitHub release tag of the running version, which the updater installed
+func builtinReleaseTag() string {
+	return "v" + strings.TrimPrefix(version, "v")
+}
+
+// splitGlyphName mirrors the generator's metadata extraction
+// (e.g. "nf-cod-account" -> prefix "nf", normalized "cod account")
+func splitGlyphName(name string) (prefix, normalized string) {
+	parts := strings.Split(name, "-")
+	if len(parts) > 1 {
+		return parts[0], strings.Join(parts[1:], " ")
+	}
+	return parts[0], name
+}
+
+// importGlyphs writes entries into the glyph database inside a single transaction.
+// Glyphs are upserted by name so ids, and therefore favorites, stay stable; with
+// replace set, glyphs missing from entries are removed. Glyphs new to the
+// database are recorded under releaseTag, if given. Any error or cancellation
+// rolls the whole import back.
+func (a *App) importGlyphs(ctx context.Context, entries []glyphEntry, releaseTag string, replace bool, progress func(done, total int)) (*ImportResult, error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin import: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Created inside the transaction, so a rollback discards it too
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TEMP TABLE import_names (name TEXT PRIMARY KEY);
+		CREATE TEMP TABLE import_existing AS SELECT id FROM glyphs;
+	`); err != nil {
+		return nil, fmt.Errorf("failed to prepare import: %w", err)
+	}
+
+	upsert, err := tx.PrepareContext(ctx, `
+		INSERT INTO glyphs (name, glyph, category, prefix, normalized_name, description, block, codepoint, width)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			glyph = excluded.glyph,
+			codepoint = excluded.codepoint,
+			category = excluded.category,
+			prefix = excluded.prefix,
+			normalized_name = excluded.normalized_name,
+			description = excluded.description,
+			block = excluded.block,
+			width = excluded.width
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare import: %w", err)
+	}
+	defer upsert.Close()
+
+	track, err := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO temp.import_names (name) VALUES (?)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare import: %w", err)
+	}
+	defer track.Close()
+
+	// Metrics come from the configured Nerd Font; without one the preview
+	// measures glyphs itself when it can
+	measure := a.metricsMeasurer()
+	var metrics *sql.Stmt
+	if measure != nil {
+		if err := createMetricsTable(ctx, tx); err != nil {
+			return nil, fmt.Errorf("failed to prepare import: %w", err)
+		}
+		if metrics, err = storeMetricsStmt(ctx, tx); err != nil {
+			return nil, fmt.Errorf("failed to prepare import: %w", err)
+		}
+		defer metrics.Close()
+	}
+
+	classify, _ := a.widthClassifier()
+	result := &ImportResult{Total: len(entries)}
+	for start := 0; start < len(entries); start += importChunkSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		end := min(start+importChunkSize, len(entries))
+		for _, e := range entries[start:end] {
+			if e.Name == "" || e.Glyph == "" {
+				result.Skipped++
+				continue
+			}
+
+			res, err := track.ExecContext(ctx, e.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to import glyph %s: %w", e.Name, err)
+			}
+			if n, _ := res.RowsAffected(); n == 0 {
+				// Duplicate name within the file; the generator keeps the first one too
+				result.Skipped++
+				continue
+			}
+
+			g := Glyph{Name: e.Name, Glyph: e.Glyph}
+			prefix, normalized := splitGlyphName(e.Name)
+			if _, err := upsert.ExecContext(ctx, e.Name, e.Glyph, glyphCategory(e.Name), prefix, normalized, describeGlyph(g), glyphdata.BlockOf(codepointOf(e.Glyph)), codepointOf(e.Glyph), classify(e.Glyph)); err != nil {
+				return nil, fmt.Errorf("failed to import glyph %s: %w", e.Name, err)
+			}
+			if measure != nil {
+				if m, ok := measure(e.Glyph); ok {
+					if err := storeMetrics(ctx, metrics, e.Name, m); err != nil {
+						return nil, fmt.Errorf("failed to import glyph %s: %w", e.Name, err)
+					}
+				}
+			}
+			result.Imported++
+		}
+		progress(end, len(entries))
+	}
+
+	if replace {
+		res, err := tx.ExecContext(ctx, `DELETE FROM glyphs WHERE name NOT IN (SELECT name FROM temp.import_names)`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove stale glyphs: %w", err)
+		}
+		removed, _ := res.RowsAffected()
+		result.Removed = int(removed)
+		if measure != nil {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM glyph_metrics WHERE glyph_id NOT IN (SELECT id FROM glyphs)`); err != nil {
+				return nil, fmt.Errorf("failed to remove stale glyphs: %w", err)
+			}
+		}
+	}
+
+	added, err := recordReleaseGlyphs(ctx, tx, releaseTag)
+	if err != nil {
+		return nil, err
+	}
+	result.Added = added
+	result.ReleaseTag = releaseTag
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT OR REPLACE INTO metadata (key, value) VALUES
+			('last_updated', datetime('now')),
+			('glyph_count', (SELECT COUNT(*) FROM glyphs))
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update metadata: %w", err)
+	}
+	if releaseTag != "" {
+		if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO metadata (key, value) VALUES ('release_tag', ?)`, releaseTag); err != nil {
+			return nil, fmt.Errorf("failed to update metadata: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DROP TABLE temp.import_names; DROP TABLE temp.import_existing`); err != nil {
+		return nil, fmt.Errorf("failed to finish import: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit import: %w", err)
+	}
+	return result, nil
+}
+
+// ImportGlyphs updates the open glyph database from a glyphs.json file (the
+// built-in copy when path is empty). With replace set, glyphs missing from the
+// file are removed. Progress is reported through operation events, and
+// CancelImport rolls the import back. The built-in copy ships with the app, so
+// glyphs it adds are recorded under the running version's release.
+func (a *App) ImportGlyphs(path string, replace bool) (*ImportResult, error) {
+	releaseTag := ""
+	if path == "" {
+		releaseTag = builtinReleaseTag()
+	}
+	return a.ImportGlyphsRelease(path, releaseTag, replace)
+}
+
+// ImportGlyphsRelease is ImportGlyphs for a file from a known Nerd Fonts
+// release (e.g. "v3.3.0"); glyphs it adds are listed by GetRecentlyAddedGlyphs
+func (a *App) ImportGlyphsRelease(path string, releaseTag string, replace bool) (*ImportResult, error) {
+	if err := a.checkWritable("import glyphs"); err != nil {
+		return nil, err
+	}
+	releaseTag = strings.TrimSpace(releaseTag)
+	if err := validateQuery("releaseTag", releaseTag); err != nil {
+		return nil, err
+	}
+	if err := a.checkGlyphsWritable("import glyphs"); err != nil {
+		return nil, err
+	}
+	if a.db == nil {
+		return nil, newAppError(ErrCodeDBMissing, "database not open")
+	}
+
+	ctx, err := a.importer.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer a.importer.end()
+
+	// The default profile shares the import's writer connection; queue its
+	// small writes instead of letting them wait for the whole import
+	if a.userDB == a.db {
+		a.writes.hold()
+		defer a.writes.release(a.db)
+	}
+
+	op := a.startOperation("import", "Importing glyphs…")
+
+	entries, err := readGlyphEntries(path)
+	if err != nil {
+		return nil, op.Fail("Could not import glyphs", err)
+	}
+
+	result, err := a.importGlyphs(ctx, entries, releaseTag, replace, func(done, total int) {
+		op.Progress(float64(done)/float64(total)*100, fmt.Sprintf("Importing glyphs… %d/%d", done, total))
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil, op.Fail("Import cancelled; the database was not changed", err)
+	}
+	if err != nil {
+		return nil, op.Fail("Could not import glyphs; the database was not changed", err)
+	}
+
+	a.preloadCache()
+
+	log.Printf("Imported %d glyphs (%d new, %d removed, %d skipped)", result.Imported, result.Added, result.Removed, result.Skipped)
+	if result.Added > 0 && releaseTag != "" {
+		a.emit(EventReleaseGlyphsAdded, map[string]any{"releaseTag": releaseTag, "count": result.Added})
+	}
+	a.emit(EventDatabaseReloaded, a.GetCurrentDatabase())
+	op.Succeed(fmt.Sprintf("Imported %d glyphs", result.Imported))
+	return result, nil
+}
+
+// CancelImport stops a running ImportGlyphs call, reporting whether one was running
+func (a *App) CancelImport() bool {
+	return a.importer.Cancel()
+}
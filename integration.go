@@ -0,0 +1,367 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// protocolScheme is the URL scheme Gylte registers, e.g. gylte://search?q=arrow
+const protocolScheme = "gylte"
+
+// EventProtocolOpen is emitted when a gylte:// link asks the UI to do something
+const EventProtocolOpen = "protocol:open"
+
+// IntegrationOptions selects which OS integrations are installed
+type IntegrationOptions struct {
+	Protocol  bool `json:"protocol"`
+	StartMenu bool `json:"startMenu"` // Start Menu on Windows, application menu on Linux
+	Desktop   bool `json:"desktop"`
+}
+
+// IntegrationStatus reports what is currently registered with the OS
+type IntegrationStatus struct {
+	Enabled bool               `json:"enabled"`
+	Options IntegrationOptions `json:"options"`
+	Items   []string           `json:"items"` // shortcut files and registry keys created
+}
+
+// ProtocolAction is a parsed gylte:// link
+type ProtocolAction struct {
+	Action string `json:"action"` // "search", "category", "copy", or "show"
+	Value  string `json:"value,omitempty"`
+}
+
+// defaultIntegrationOptions are installed on first run
+var defaultIntegrationOptions = IntegrationOptions{Protocol: true}
+
+// parseProtocolURL parses gylte://search?q=term, gylte://category/<name>,
+// gylte://copy/<name>, and gylte://show
+func parseProtocolURL(raw string) (ProtocolAction, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ProtocolAction{}, fmt.Errorf("invalid link: %w", err)
+	}
+	if u.Scheme != protocolScheme {
+		return ProtocolAction{}, newAppError(ErrCodeInvalid, "not a %s:// link: %s", protocolScheme, raw)
+	}
+
+	value := strings.Trim(u.Path, "/")
+	switch u.Host {
+	case "search":
+		if q := u.Query().Get("q"); q != "" {
+			value = q
+		}
+		return ProtocolAction{Action: "search", Value: value}, nil
+	case "category":
+		if value == "" {
+			return ProtocolAction{}, newAppError(ErrCodeInvalid, "category link needs a category name")
+		}
+		return ProtocolAction{Action: "category", Value: value}, nil
+	case "copy":
+		if value == "" {
+			return ProtocolAction{}, newAppError(ErrCodeInvalid, "copy link needs a glyph name")
+		}
+		return ProtocolAction{Action: "copy", Value: value}, nil
+	case "", "show":
+		return ProtocolAction{Action: "show"}, nil
+	}
+	return ProtocolAction{}, newAppError(ErrCodeInvalid, "unknown link action: %s", u.Host)
+}
+
+// protocolURLArg returns the gylte:// link the app was launched with, if any
+func protocolURLArg(args []string) string {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, protocolScheme+"://") {
+			return arg
+		}
+	}
+	return ""
+}
+
+// openURL handles a gylte:// link from the command line or the OS
+func (a *App) openURL(raw string) {
+	action, err := parseProtocolURL(raw)
+	if err != nil {
+		log.Printf("Ignoring link: %v", err)
+		return
+	}
+	log.Printf("Opening link: %s", raw)
+
+	if err := a.ShowWindow(); err != nil {
+		log.Printf("Failed to show window for link: %v", err)
+	}
+	if action.Action == "copy" {
+		g, ok := a.findGlyphByName(action.Value)
+		if !ok {
+			log.Printf("Link glyph not found: %s", action.Value)
+			return
+		}
+		if err := a.CopyGlyph(g.ID); err != nil {
+			log.Printf("Failed to copy linked glyph: %v", err)
+		}
+	}
+	a.emit(EventProtocolOpen, action)
+}
+
+// singleInstanceID identifies Gylte to the OS so a second launch is handed to
+// the running window instead of opening another one
+const singleInstanceID = "com.limpdev.gylte"
+
+// onSecondInstanceLaunch brings the running window forward when Gylte is
+// launched again, following the gylte:// link it was launched with, if any
+func (a *App) onSecondInstanceLaunch(data options.SecondInstanceData) {
+	if raw := protocolURLArg(data.Args); raw != "" {
+		a.openURL(raw)
+		return
+	}
+	if err := a.ShowWindow(); err != nil {
+		log.Printf("Failed to show window for second launch: %v", err)
+	}
+}
+
+// findGlyphByName returns the cached glyph with the given name
+func (a *App) findGlyphByName(name string) (Glyph, bool) {
+	for _, g := range a.cache.Snapshot().glyphs {
+		if g.Name == name {
+			return g, true
+		}
+	}
+	if a.searchMode() == SearchModeDirect {
+		return a.directGlyph("name = ?", name)
+	}
+	return Glyph{}, false
+}
+
+// integrationOptions returns the options last passed to EnableIntegration
+func (a *App) integrationOptions() IntegrationOptions {
+	opts := defaultIntegrationOptions
+	if raw := a.settings.Get("integration.options", ""); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &opts); err != nil {
+			log.Printf("Failed to parse integration options: %v", err)
+		}
+	}
+	return opts
+}
+
+// setupIntegration registers the protocol handler the first time the app runs.
+// Users who disabled integration, and --portable runs, are left alone.
+func (a *App) setupIntegration() {
+	if a.portable || a.settings.Get("integration.enabled", "") != "" || a.IsReadOnly() {
+		return
+	}
+	if _, err := a.EnableIntegration(defaultIntegrationOptions); err != nil {
+		log.Printf("Failed to set up OS integration: %v", err)
+	}
+}
+
+// GetIntegrationStatus reports which OS integrations are installed
+func (a *App) GetIntegrationStatus() IntegrationStatus {
+	status := IntegrationStatus{
+		Enabled: a.settings.GetBool("integration.enabled", false),
+		Options: a.integrationOptions(),
+	}
+	if raw := a.settings.Get("integration.items", ""); raw != "" {
+		json.Unmarshal([]byte(raw), &status.Items)
+	}
+	return status
+}
+
+// EnableIntegration registers the gylte:// protocol handler and creates the
+// selected shortcuts, replacing any previous registration
+func (a *App) EnableIntegration(opts IntegrationOptions) (*IntegrationStatus, error) {
+	if err := a.checkWritable("change OS integration"); err != nil {
+		return nil, err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate executable: %w", err)
+	}
+	if err := removeIntegration(); err != nil {
+		log.Printf("Failed to remove previous integration: %v", err)
+	}
+
+	var items []string
+	if opts.Protocol {
+		registered, err := registerProtocol(exe)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register %s:// links: %w", protocolScheme, err)
+		}
+		items = append(items, registered...)
+	}
+	if opts.StartMenu || opts.Desktop {
+		shortcuts, err := createShortcuts(exe, opts.StartMenu, opts.Desktop)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create shortcuts: %w", err)
+		}
+		items = append(items, shortcuts...)
+	}
+
+	if err := a.saveIntegration(true, opts, items); err != nil {
+		return nil, err
+	}
+	log.Printf("OS integration enabled: %v", items)
+	status := a.GetIntegrationStatus()
+	return &status, nil
+}
+
+// DisableIntegration removes the protocol handler and shortcuts so the app
+// can run portably; it is not re-registered on later starts
+func (a *App) DisableIntegration() error {
+	if err := a.checkWritable("change OS integration"); err != nil {
+		return err
+	}
+	if err := removeIntegration(); err != nil {
+		return fmt.Errorf("failed to remove OS integration: %w", err)
+	}
+	log.Println("OS integration disabled")
+	return a.saveIntegration(false, a.integrationOptions(), nil)
+}
+
+// saveIntegration records the integration state in settings
+func (a *App) saveIntegration(enabled bool, opts IntegrationOptions, items []string) error {
+	optsJSON, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	itemsJSON, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	if err := a.settings.Set("integration.options", string(optsJSON)); err != nil {
+		return err
+	}
+	if err := a.settings.Set("integration.items", string(itemsJSON)); err != nil {
+		return err
+	}
+	return a.settings.Set("integration.enabled", fmt.Sprint(enabled))
+}
+
+// removeIfExists deletes a file, ignoring files that are already gone
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// removeIntegration undoes registerProtocol and createShortcuts
+func removeIntegration() error {
+	if err := unregisterProtocol(); err != nil {
+		return err
+	}
+	return removeShortcuts()
+}
+
+// installDir returns the directory of the running executable, where an
+// installed Gylte keeps gylte.db and the rest of its data
+func installDir() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	return filepath.Dir(exe), nil
+}
+
+// userDataFiles lists the files Gylte created in dataDir: the databases with
+// their journals, the log, and the profile databases. Exports and plugins are
+// the user's own files and are never listed.
+func userDataFiles(dataDir string) []string {
+	var names []string
+	for _, db := range []string{"gylte.db", localUserDBName} {
+		names = append(names, db, db+"-wal", db+"-shm")
+	}
+	names = append(names, "gylte.log")
+
+	entries, _ := os.ReadDir(filepath.Join(dataDir, "profiles"))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+		for _, suffix := range []string{".db", ".db-wal", ".db-shm", encryptedProfileExt} {
+			if strings.HasSuffix(name, suffix) {
+				names = append(names, filepath.Join("profiles", name))
+				break
+			}
+		}
+	}
+
+	var files []string
+	for _, name := range names {
+		path := filepath.Join(dataDir, name)
+		if info, err := os.Lstat(path); err == nil && info.Mode().IsRegular() {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// uninstall removes the OS integration and, unless keepData is set, the files
+// Gylte created in dataDir. It refuses to touch a directory without gylte.db,
+// and only lists what it would remove unless confirmed is set. Installers run
+// it through `gylte uninstall --yes`.
+func (a *App) uninstall(dataDir string, keepData, confirmed bool) ([]string, error) {
+	var files []string
+	if !keepData {
+		if _, err := os.Stat(filepath.Join(dataDir, "gylte.db")); err != nil {
+			return nil, newAppError(ErrCodeNotFound, "%s is not a Gylte data directory: gylte.db not found", dataDir)
+		}
+		files = userDataFiles(dataDir)
+	}
+	if !confirmed {
+		return files, newAppError(ErrCodeInvalid, "uninstalling removes gylte:// links, shortcuts, and %d files; pass --yes to confirm", len(files))
+	}
+
+	if err := removeIntegration(); err != nil {
+		return nil, fmt.Errorf("failed to remove OS integration: %w", err)
+	}
+	var removed []string
+	for _, path := range files {
+		if err := removeIfExists(path); err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		removed = append(removed, path)
+	}
+	// Drop the profiles folder once it is empty; anything else in it is kept
+	os.Remove(filepath.Join(dataDir, "profiles"))
+	return removed, nil
+}
+
+// defineUninstall is the `gylte uninstall` command
+func defineUninstall(fs *flag.FlagSet) cliRunner {
+	keepData := fs.Bool("keep-data", false, "keep favorites, settings, and profiles")
+	dataDir := fs.String("data-dir", "", "data directory to clean up (default: the directory of the gylte executable)")
+	yes := fs.Bool("yes", false, "uninstall without asking; otherwise only list what would be removed")
+	return func(a *App, args []string, out, errOut io.Writer) error {
+		dir := *dataDir
+		if dir == "" {
+			var err error
+			if dir, err = installDir(); err != nil {
+				return err
+			}
+		}
+		files, err := a.uninstall(dir, *keepData, *yes)
+		if !*yes {
+			for _, path := range files {
+				fmt.Fprintf(out, "Would remove %s\n", path)
+			}
+			return err
+		}
+		for _, path := range files {
+			fmt.Fprintf(out, "Removed %s\n", path)
+		}
+		return err
+	}
+}
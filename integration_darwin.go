@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// lsregister is the Launch Services tool that (re)registers app bundles
+const lsregister = "/System/Library/Frameworks/CoreServices.framework/Frameworks/LaunchServices.framework/Support/lsregister"
+
+// appBundle returns the .app bundle containing exe
+func appBundle(exe string) (string, error) {
+	for dir := filepath.Dir(exe); dir != "/" && dir != "."; dir = filepath.Dir(dir) {
+		if strings.HasSuffix(dir, ".app") {
+			return dir, nil
+		}
+	}
+	return "", newAppError(ErrCodeNotFound, "%s is not inside an app bundle", exe)
+}
+
+// registerProtocol registers the bundle with Launch Services; the gylte://
+// scheme itself is declared in Info.plist
+func registerProtocol(exe string) ([]string, error) {
+	bundle, err := appBundle(exe)
+	if err != nil {
+		return nil, err
+	}
+	if err := exec.Command(lsregister, "-f", bundle).Run(); err != nil {
+		return nil, err
+	}
+	return []string{bundle}, nil
+}
+
+// unregisterProtocol removes the bundle from Launch Services
+func unregisterProtocol() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	bundle, err := appBundle(exe)
+	if err != nil {
+		return nil // nothing was registered
+	}
+	return exec.Command(lsregister, "-u", bundle).Run()
+}
+
+// shortcutPaths returns the ~/Applications and desktop alias locations
+func shortcutPaths() (applications, desktop string, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", err
+	}
+	return filepath.Join(home, "Applications", "Gylte.app"), filepath.Join(home, "Desktop", "Gylte.app"), nil
+}
+
+// createShortcuts links the bundle into ~/Applications (Launchpad and
+// Spotlight) and/or onto the desktop
+func createShortcuts(exe string, applications, desktop bool) ([]string, error) {
+	bundle, err := appBundle(exe)
+	if err != nil {
+		return nil, err
+	}
+	applicationsPath, desktopPath, err := shortcutPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	if applications && filepath.Dir(bundle) != filepath.Dir(applicationsPath) {
+		paths = append(paths, applicationsPath)
+	}
+	if desktop {
+		paths = append(paths, desktopPath)
+	}
+	for _, path := range paths {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.Symlink(bundle, path); err != nil && !os.IsExist(err) {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// removeSymlink deletes path only if it is a link created by createShortcuts
+func removeSymlink(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return nil
+	}
+	return os.Remove(path)
+}
+
+// removeShortcuts deletes the links created by createShortcuts
+func removeShortcuts() error {
+	applications, desktop, err := shortcutPaths()
+	if err != nil {
+		return err
+	}
+	return errors.Join(removeSymlink(applications), removeSymlink(desktop))
+}
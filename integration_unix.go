@@ -0,0 +1,139 @@
+//go:build !windows && !darwin
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	protocolDesktopFile = "gylte-url.desktop"
+	menuDesktopFile     = "gylte.desktop"
+)
+
+// xdgDataHome returns $XDG_DATA_HOME, defaulting to ~/.local/share
+func xdgDataHome() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share"), nil
+}
+
+// applicationsDir is where desktop entries for the application menu live
+func applicationsDir() (string, error) {
+	data, err := xdgDataHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(data, "applications"), nil
+}
+
+// desktopDir asks xdg-user-dir for the desktop folder, falling back to ~/Desktop
+func desktopDir() (string, error) {
+	if out, err := exec.Command("xdg-user-dir", "DESKTOP").Output(); err == nil {
+		if dir := strings.TrimSpace(string(out)); dir != "" {
+			return dir, nil
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Desktop"), nil
+}
+
+// desktopExecQuote quotes a path for the Exec key of a desktop entry
+func desktopExecQuote(path string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", "$", `\$`)
+	return `"` + r.Replace(path) + `"`
+}
+
+// writeDesktopEntry writes a desktop entry launching exe
+func writeDesktopEntry(path, exe string, extra string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	entry := fmt.Sprintf(`[Desktop Entry]
+Type=Application
+Name=Gylte
+Comment=Nerd Font glyph picker
+Exec=%s %%u
+Terminal=false
+Categories=Utility;
+%s`, desktopExecQuote(exe), extra)
+	return os.WriteFile(path, []byte(entry), 0755)
+}
+
+// registerProtocol installs a hidden desktop entry handling gylte:// links
+func registerProtocol(exe string) ([]string, error) {
+	dir, err := applicationsDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, protocolDesktopFile)
+	extra := "NoDisplay=true\nMimeType=x-scheme-handler/" + protocolScheme + ";\n"
+	if err := writeDesktopEntry(path, exe, extra); err != nil {
+		return nil, err
+	}
+
+	if err := exec.Command("xdg-mime", "default", protocolDesktopFile, "x-scheme-handler/"+protocolScheme).Run(); err != nil {
+		return nil, fmt.Errorf("xdg-mime failed: %w", err)
+	}
+	return []string{path}, nil
+}
+
+// unregisterProtocol removes the link handler desktop entry
+func unregisterProtocol() error {
+	dir, err := applicationsDir()
+	if err != nil {
+		return err
+	}
+	return removeIfExists(filepath.Join(dir, protocolDesktopFile))
+}
+
+// createShortcuts adds Gylte to the application menu and/or the desktop
+func createShortcuts(exe string, menu, desktop bool) ([]string, error) {
+	var paths []string
+	if menu {
+		dir, err := applicationsDir()
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, filepath.Join(dir, menuDesktopFile))
+	}
+	if desktop {
+		dir, err := desktopDir()
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, filepath.Join(dir, menuDesktopFile))
+	}
+
+	for _, path := range paths {
+		if err := writeDesktopEntry(path, exe, ""); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// removeShortcuts deletes menu and desktop entries created by createShortcuts
+func removeShortcuts() error {
+	var errs []error
+	if dir, err := applicationsDir(); err == nil {
+		errs = append(errs, removeIfExists(filepath.Join(dir, menuDesktopFile)))
+	}
+	if dir, err := desktopDir(); err == nil {
+		errs = append(errs, removeIfExists(filepath.Join(dir, menuDesktopFile)))
+	}
+	return errors.Join(errs...)
+}
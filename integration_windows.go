@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+)
+
+// protocolKey is the per-user registry key for gylte:// links
+const protocolKey = `HKCU\Software\Classes\` + protocolScheme
+
+// shortcutScript creates a .lnk through WScript.Shell; paths are passed via
+// environment variables so they never need PowerShell escaping
+const shortcutScript = `
+$shortcut = (New-Object -ComObject WScript.Shell).CreateShortcut($env:GYLTE_SHORTCUT)
+$shortcut.TargetPath = $env:GYLTE_EXE
+$shortcut.WorkingDirectory = Split-Path $env:GYLTE_EXE
+$shortcut.Save()
+`
+
+// hiddenCommand runs a console tool without flashing a window
+func hiddenCommand(name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	return cmd
+}
+
+// registerProtocol registers gylte:// links under HKEY_CURRENT_USER
+func registerProtocol(exe string) ([]string, error) {
+	commands := [][]string{
+		{"add", protocolKey, "/ve", "/d", "URL:Gylte Protocol", "/f"},
+		{"add", protocolKey, "/v", "URL Protocol", "/d", "", "/f"},
+		{"add", protocolKey + `\shell\open\command`, "/ve", "/d", `"` + exe + `" "%1"`, "/f"},
+	}
+	for _, args := range commands {
+		if err := hiddenCommand("reg", args...).Run(); err != nil {
+			return nil, err
+		}
+	}
+	return []string{protocolKey}, nil
+}
+
+// unregisterProtocol deletes the gylte:// registry key
+func unregisterProtocol() error {
+	if hiddenCommand("reg", "query", protocolKey).Run() != nil {
+		return nil
+	}
+	return hiddenCommand("reg", "delete", protocolKey, "/f").Run()
+}
+
+// shortcutPaths returns the Start Menu and desktop shortcut locations
+func shortcutPaths() (startMenu, desktop string, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", err
+	}
+	appData := os.Getenv("APPDATA")
+	if appData == "" {
+		appData = filepath.Join(home, "AppData", "Roaming")
+	}
+	startMenu = filepath.Join(appData, "Microsoft", "Windows", "Start Menu", "Programs", "Gylte.lnk")
+	desktop = filepath.Join(home, "Desktop", "Gylte.lnk")
+	return startMenu, desktop, nil
+}
+
+// createShortcuts adds Start Menu and/or desktop shortcuts
+func createShortcuts(exe string, startMenu, desktop bool) ([]string, error) {
+	startMenuPath, desktopPath, err := shortcutPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	if startMenu {
+		paths = append(paths, startMenuPath)
+	}
+	if desktop {
+		paths = append(paths, desktopPath)
+	}
+	for _, path := range paths {
+		cmd := hiddenCommand("powershell", "-NoProfile", "-NonInteractive", "-Command", shortcutScript)
+		cmd.Env = append(os.Environ(), "GYLTE_SHORTCUT="+path, "GYLTE_EXE="+exe)
+		if err := cmd.Run(); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// removeShortcuts deletes the shortcuts created by createShortcuts
+func removeShortcuts() error {
+	startMenu, desktop, err := shortcutPaths()
+	if err != nil {
+		return err
+	}
+	return errors.Join(removeIfExists(startMenu), removeIfExists(desktop))
+}
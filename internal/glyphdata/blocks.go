@@ -0,0 +1,62 @@
+package glyphdata
+
+import "sort"
+
+// Block is a named code point range from the Unicode Blocks.txt data
+type Block struct {
+	Name       string
+	Start, End rune
+}
+
+// Blocks lists the blocks glyph fonts draw from, in code point order
+var Blocks = []Block{
+	{"Basic Latin", 0x0000, 0x007F},
+	{"Latin-1 Supplement", 0x0080, 0x00FF},
+	{"Latin Extended-A", 0x0100, 0x017F},
+	{"Latin Extended-B", 0x0180, 0x024F},
+	{"IPA Extensions", 0x0250, 0x02AF},
+	{"Spacing Modifier Letters", 0x02B0, 0x02FF},
+	{"Combining Diacritical Marks", 0x0300, 0x036F},
+	{"Greek and Coptic", 0x0370, 0x03FF},
+	{"Cyrillic", 0x0400, 0x04FF},
+	{"General Punctuation", 0x2000, 0x206F},
+	{"Superscripts and Subscripts", 0x2070, 0x209F},
+	{"Currency Symbols", 0x20A0, 0x20CF},
+	{"Letterlike Symbols", 0x2100, 0x214F},
+	{"Number Forms", 0x2150, 0x218F},
+	{"Arrows", 0x2190, 0x21FF},
+	{"Mathematical Operators", 0x2200, 0x22FF},
+	{"Miscellaneous Technical", 0x2300, 0x23FF},
+	{"Control Pictures", 0x2400, 0x243F},
+	{"Enclosed Alphanumerics", 0x2460, 0x24FF},
+	{"Box Drawing", 0x2500, 0x257F},
+	{"Block Elements", 0x2580, 0x259F},
+	{"Geometric Shapes", 0x25A0, 0x25FF},
+	{"Miscellaneous Symbols", 0x2600, 0x26FF},
+	{"Dingbats", 0x2700, 0x27BF},
+	{"Miscellaneous Mathematical Symbols-A", 0x27C0, 0x27EF},
+	{"Supplemental Arrows-A", 0x27F0, 0x27FF},
+	{"Braille Patterns", 0x2800, 0x28FF},
+	{"Supplemental Arrows-B", 0x2900, 0x297F},
+	{"Miscellaneous Symbols and Arrows", 0x2B00, 0x2BFF},
+	{"Private Use Area", 0xE000, 0xF8FF},
+	{"Miscellaneous Symbols and Pictographs", 0x1F300, 0x1F5FF},
+	{"Emoticons", 0x1F600, 0x1F64F},
+	{"Geometric Shapes Extended", 0x1F780, 0x1F7FF},
+	{"Supplemental Arrows-C", 0x1F800, 0x1F8FF},
+	{"Symbols for Legacy Computing", 0x1FB00, 0x1FBFF},
+	{"Supplementary Private Use Area-A", 0xF0000, 0xFFFFF},
+	{"Supplementary Private Use Area-B", 0x100000, 0x10FFFF},
+}
+
+// OtherBlock names code points outside the known blocks
+const OtherBlock = "Other"
+
+// BlockOf returns the Unicode block containing r
+func BlockOf(r rune) string {
+	i := sort.Search(len(Blocks), func(i int) bool { return Blocks[i].End >= r })
+	if i < len(Blocks) && Blocks[i].Start <= r {
+		return Blocks[i].Name
+	}
+	return OtherBlock
+}
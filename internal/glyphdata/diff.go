@@ -0,0 +1,121 @@
+package glyphdata
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// DiffGlyph is a glyph that only one side of a diff has
+type DiffGlyph struct {
+	Name      string `json:"name"`
+	Codepoint string `json:"codepoint"`
+}
+
+// GlyphRename is a code point that moved to a new name
+type GlyphRename struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Codepoint string `json:"codepoint"`
+}
+
+// CodepointChange is a name whose code point changed
+type CodepointChange struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DatabaseDiff lists how the glyphs of B differ from those of A
+type DatabaseDiff struct {
+	PathA            string            `json:"pathA"`
+	PathB            string            `json:"pathB"`
+	Added            []DiffGlyph       `json:"added"`
+	Removed          []DiffGlyph       `json:"removed"`
+	Renamed          []GlyphRename     `json:"renamed"`
+	CodepointChanged []CodepointChange `json:"codepointChanged"`
+}
+
+// DiffGlyphSets compares two name -> code point maps. A removed name and an
+// added name sharing a code point, with no other glyph on either side using
+// it, count as a rename.
+func DiffGlyphSets(a, b map[string]rune) DatabaseDiff {
+	diff := DatabaseDiff{
+		Added:            []DiffGlyph{},
+		Removed:          []DiffGlyph{},
+		Renamed:          []GlyphRename{},
+		CodepointChanged: []CodepointChange{},
+	}
+
+	removed := make(map[rune][]string)
+	added := make(map[rune][]string)
+	for name, cp := range a {
+		if cpB, ok := b[name]; !ok {
+			removed[cp] = append(removed[cp], name)
+		} else if cpB != cp {
+			diff.CodepointChanged = append(diff.CodepointChanged, CodepointChange{Name: name, From: FormatCodepoint(cp), To: FormatCodepoint(cpB)})
+		}
+	}
+	for name, cp := range b {
+		if _, ok := a[name]; !ok {
+			added[cp] = append(added[cp], name)
+		}
+	}
+
+	for cp, from := range removed {
+		to := added[cp]
+		if len(from) == 1 && len(to) == 1 {
+			diff.Renamed = append(diff.Renamed, GlyphRename{From: from[0], To: to[0], Codepoint: FormatCodepoint(cp)})
+			delete(added, cp)
+			continue
+		}
+		for _, name := range from {
+			diff.Removed = append(diff.Removed, DiffGlyph{Name: name, Codepoint: FormatCodepoint(cp)})
+		}
+	}
+	for cp, names := range added {
+		for _, name := range names {
+			diff.Added = append(diff.Added, DiffGlyph{Name: name, Codepoint: FormatCodepoint(cp)})
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Name < diff.Added[j].Name })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Name < diff.Removed[j].Name })
+	sort.Slice(diff.Renamed, func(i, j int) bool { return diff.Renamed[i].From < diff.Renamed[j].From })
+	sort.Slice(diff.CodepointChanged, func(i, j int) bool { return diff.CodepointChanged[i].Name < diff.CodepointChanged[j].Name })
+	return diff
+}
+
+// WriteDiffMarkdown writes a diff as a Markdown report
+func WriteDiffMarkdown(w io.Writer, diff *DatabaseDiff) error {
+	fmt.Fprintf(w, "# Glyph diff\n\n`%s` → `%s`\n\n", diff.PathA, diff.PathB)
+	fmt.Fprintf(w, "%d added, %d removed, %d renamed, %d code points changed\n",
+		len(diff.Added), len(diff.Removed), len(diff.Renamed), len(diff.CodepointChanged))
+
+	if len(diff.Added) > 0 {
+		fmt.Fprintf(w, "\n## Added\n\n| Name | Code point |\n| --- | --- |\n")
+		for _, g := range diff.Added {
+			fmt.Fprintf(w, "| `%s` | %s |\n", g.Name, g.Codepoint)
+		}
+	}
+	if len(diff.Removed) > 0 {
+		fmt.Fprintf(w, "\n## Removed\n\n| Name | Code point |\n| --- | --- |\n")
+		for _, g := range diff.Removed {
+			fmt.Fprintf(w, "| `%s` | %s |\n", g.Name, g.Codepoint)
+		}
+	}
+	if len(diff.Renamed) > 0 {
+		fmt.Fprintf(w, "\n## Renamed\n\n| Old name | New name | Code point |\n| --- | --- | --- |\n")
+		for _, r := range diff.Renamed {
+			fmt.Fprintf(w, "| `%s` | `%s` | %s |\n", r.From, r.To, r.Codepoint)
+		}
+	}
+	if len(diff.CodepointChanged) > 0 {
+		fmt.Fprintf(w, "\n## Code point changed\n\n| Name | Old | New |\n| --- | --- | --- |\n")
+		for _, c := range diff.CodepointChanged {
+			fmt.Fprintf(w, "| `%s` | %s | %s |\n", c.Name, c.From, c.To)
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
@@ -0,0 +1,32 @@
+package glyphdata
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffGlyphSets(t *testing.T) {
+	a := map[string]rune{
+		"nf-fa-keep":     0xf000,
+		"nf-fa-old_name": 0xf001,
+		"nf-fa-gone":     0xf002,
+		"nf-fa-moved":    0xf003,
+	}
+	b := map[string]rune{
+		"nf-fa-keep":     0xf000,
+		"nf-fa-new_name": 0xf001,
+		"nf-fa-moved":    0xf0ff,
+		"nf-fa-fresh":    0xf100,
+	}
+
+	got := DiffGlyphSets(a, b)
+	want := DatabaseDiff{
+		Added:            []DiffGlyph{{Name: "nf-fa-fresh", Codepoint: "U+F100"}},
+		Removed:          []DiffGlyph{{Name: "nf-fa-gone", Codepoint: "U+F002"}},
+		Renamed:          []GlyphRename{{From: "nf-fa-old_name", To: "nf-fa-new_name", Codepoint: "U+F001"}},
+		CodepointChanged: []CodepointChange{{Name: "nf-fa-moved", From: "U+F003", To: "U+F0FF"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffGlyphSets =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,11 @@
+// Package glyphdata holds the Nerd Fonts reference data and dataset diffing
+// shared by the app and db_generator, so the database the generator writes
+// and the fallbacks the app uses for older databases can't drift apart.
+package glyphdata
+
+import "fmt"
+
+// FormatCodepoint formats a code point as e.g. "U+F0463"
+func FormatCodepoint(r rune) string {
+	return fmt.Sprintf("U+%04X", r)
+}
@@ -0,0 +1,88 @@
+package glyphdata
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+// ErrNotGlyphDatabase is returned for a SQLite file without a glyphs table
+var ErrNotGlyphDatabase = errors.New("not a glyph database")
+
+// LoadGlyphSet reads name -> code point from a glyph database or a glyphs.json
+// file. A missing file gives an error wrapping fs.ErrNotExist.
+func LoadGlyphSet(path string) (map[string]rune, error) {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return loadJSONGlyphSet(path)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("database not found: %w", err)
+	}
+	db, err := sql.Open("sqlite", readOnlyDSN(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT name, glyph FROM glyphs")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %v", path, ErrNotGlyphDatabase, err)
+	}
+	defer rows.Close()
+
+	set := make(map[string]rune)
+	for rows.Next() {
+		var name, glyph string
+		if err := rows.Scan(&name, &glyph); err != nil {
+			return nil, fmt.Errorf("failed to read glyph: %w", err)
+		}
+		set[name] = firstRune(glyph)
+	}
+	return set, rows.Err()
+}
+
+// loadJSONGlyphSet reads a glyphs.json file, keeping the first of any
+// duplicate names and skipping incomplete entries
+func loadJSONGlyphSet(path string) (map[string]rune, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read glyph file: %w", err)
+	}
+	var entries []struct {
+		Name  string `json:"name"`
+		Glyph string `json:"glyph"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse glyph file: %w", err)
+	}
+
+	set := make(map[string]rune)
+	for _, e := range entries {
+		if _, dup := set[e.Name]; !dup && e.Name != "" && e.Glyph != "" {
+			set[e.Name] = firstRune(e.Glyph)
+		}
+	}
+	return set, nil
+}
+
+// readOnlyDSN opens path read-only, escaping the characters SQLite's URI
+// filenames give a meaning to
+func readOnlyDSN(path string) string {
+	escaped := strings.NewReplacer("%", "%25", "?", "%3f", "#", "%23").Replace(filepath.ToSlash(path))
+	return "file:" + escaped + "?mode=ro&_pragma=query_only(1)"
+}
+
+// firstRune returns the first code point of a glyph, or 0 for an empty one
+func firstRune(glyph string) rune {
+	for _, r := range glyph {
+		return r
+	}
+	return 0
+}
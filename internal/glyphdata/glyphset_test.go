@@ -0,0 +1,63 @@
+package glyphdata
+
+import (
+	"database/sql"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadGlyphSetEscapesPaths(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "release #2?")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Created under a plain name, since sql.Open would read "?" as parameters
+	created := filepath.Join(t.TempDir(), "glyphs.db")
+	db, err := sql.Open("sqlite", created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE glyphs (name TEXT, glyph TEXT);
+		INSERT INTO glyphs VALUES ('nf-md-rocket', '` + "\U000F0463" + `');`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "glyphs.db")
+	if err := os.Rename(created, path); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := LoadGlyphSet(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]rune{"nf-md-rocket": 0xF0463}; !reflect.DeepEqual(set, want) {
+		t.Errorf("LoadGlyphSet = %v, want %v", set, want)
+	}
+
+	if _, err := LoadGlyphSet(filepath.Join(dir, "missing.db")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing database: got %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestLoadGlyphSetRejectsOtherDatabases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "other.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec("CREATE TABLE notes (text TEXT)")
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadGlyphSet(path); !errors.Is(err, ErrNotGlyphDatabase) {
+		t.Errorf("got %v, want ErrNotGlyphDatabase", err)
+	}
+}
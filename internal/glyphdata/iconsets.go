@@ -0,0 +1,53 @@
+package glyphdata
+
+// IconSets maps Nerd Fonts category prefixes to their human readable icon set names
+var IconSets = map[string]string{
+	"cod":         "Codicons",
+	"custom":      "Seti-UI + Custom",
+	"dev":         "Devicons",
+	"extra":       "Extra",
+	"fa":          "Font Awesome",
+	"fae":         "Font Awesome Extension",
+	"iec":         "IEC Power Symbols",
+	"indent":      "Indentation",
+	"indentation": "Indentation",
+	"linux":       "Font Logos",
+	"md":          "Material Design",
+	"oct":         "Octicons",
+	"pl":          "Powerline",
+	"ple":         "Powerline Extra",
+	"pom":         "Pomicons",
+	"seti":        "Seti-UI",
+	"weather":     "Weather Icons",
+}
+
+// License is the license an icon set is distributed under
+type License struct {
+	// License is an SPDX identifier, e.g. "MIT" or "OFL-1.1"
+	License     string
+	URL         string
+	Attribution string
+	// RequiresAttribution is set when shipping the icons means crediting their authors
+	RequiresAttribution bool
+}
+
+// Licenses maps categories to their icon set's license
+var Licenses = map[string]License{
+	"cod":         {"CC-BY-4.0", "https://github.com/microsoft/vscode-codicons", "Copyright (c) Microsoft Corporation", true},
+	"custom":      {"MIT", "https://github.com/ryanoasis/nerd-fonts", "Copyright (c) Ryan L McIntyre", true},
+	"dev":         {"MIT", "https://github.com/vorillaz/devicons", "Copyright (c) Theodore Vorillas", true},
+	"extra":       {"MIT", "https://github.com/ryanoasis/nerd-fonts", "Copyright (c) Ryan L McIntyre", true},
+	"fa":          {"CC-BY-4.0", "https://fontawesome.com/license/free", "Font Awesome Free by Fonticons, Inc.", true},
+	"fae":         {"MIT", "https://github.com/AndreLZGava/font-awesome-extension", "Copyright (c) Andre Gava", true},
+	"iec":         {"MIT", "https://unicodepowersymbol.com", "Copyright (c) Joe Loughry", true},
+	"indent":      {"MIT", "https://github.com/ryanoasis/nerd-fonts", "Copyright (c) Ryan L McIntyre", true},
+	"indentation": {"MIT", "https://github.com/ryanoasis/nerd-fonts", "Copyright (c) Ryan L McIntyre", true},
+	"linux":       {"Unlicense", "https://github.com/lukas-w/font-logos", "Font Logos by Lukas Werling", false},
+	"md":          {"Apache-2.0", "https://github.com/Templarian/MaterialDesign", "Material Design Icons by Pictogrammers", true},
+	"oct":         {"MIT", "https://github.com/primer/octicons", "Copyright (c) GitHub, Inc.", true},
+	"pl":          {"MIT", "https://github.com/powerline/powerline", "Copyright (c) Kim Silkebaekken", true},
+	"ple":         {"MIT", "https://github.com/ryanoasis/powerline-extra-symbols", "Copyright (c) Ryan L McIntyre", true},
+	"pom":         {"OFL-1.1", "https://github.com/gabrielelana/pomicons", "Pomicons by Gabriele Lana", false},
+	"seti":        {"MIT", "https://github.com/jesseweed/seti-ui", "Copyright (c) Jesse Weed", true},
+	"weather":     {"OFL-1.1", "https://github.com/erikflowers/weather-icons", "Weather Icons by Erik Flowers", false},
+}
@@ -0,0 +1,22 @@
+package glyphdata
+
+// SmartFilter is a curated theme cutting across icon sets. A glyph belongs to it
+// when it matches the search query, its name matches the pattern, or it carries one of the tags.
+type SmartFilter struct {
+	Name    string
+	Title   string
+	Query   string
+	Pattern string
+	Tags    []string
+}
+
+// SmartFilters are the themes shipped in the database, in display order
+var SmartFilters = []SmartFilter{
+	{Name: "arrows", Title: "Arrows", Pattern: `(?:^|[-_])(arrow|arrows|chevron|caret|triangle)`},
+	{Name: "brands", Title: "Brands", Pattern: `^nf-dev-|^nf-linux-|(?:^|[-_])(github|gitlab|google|apple|windows|android|docker|slack|twitter|facebook|discord|reddit|amazon|aws|microsoft|spotify|youtube)`},
+	{Name: "files", Title: "Files & folders", Pattern: `(?:^|[-_])(file|files|folder|folders|document|directory)`},
+	{Name: "weather", Title: "Weather", Pattern: `^nf-weather-|(?:^|[-_])weather`},
+	{Name: "git", Title: "Git & version control", Pattern: `(?:^|[-_])(git|branch|merge|commit|pull_request|fork|diff)`},
+	{Name: "media", Title: "Media controls", Pattern: `(?:^|[-_])(play|pause|stop|music|volume|forward|backward|shuffle|repeat)`},
+	{Name: "status", Title: "Status & alerts", Pattern: `(?:^|[-_])(check|close|error|warning|alert|info|bell|question)`, Tags: []string{"status"}},
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"log"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Jump list entry kinds
+const (
+	JumpListSearch   = "search"
+	JumpListCategory = "category"
+)
+
+// Jump list sizes: entries returned by GetJumpList by default, and entries
+// shown in the taskbar, which keeps its menu short
+const (
+	defaultJumpListSize = 10
+	maxJumpListSize     = 50
+	taskbarJumpListSize = 6
+)
+
+// jumpListUpdateDelay batches taskbar updates while the user is typing searches
+const jumpListUpdateDelay = 5 * time.Second
+
+// JumpListEntry is a recent search or category to switch back to
+type JumpListEntry struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+	// Title is the label shown in menus, e.g. "Search: git"
+	Title string `json:"title"`
+	// URL reopens the entry, e.g. "gylte://search?q=git"
+	URL    string    `json:"url"`
+	UsedAt time.Time `json:"usedAt" ts_type:"string"`
+}
+
+// JumpList debounces updates of the OS jump list
+type JumpList struct {
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+// jumpListURL returns the gylte:// link opening an entry
+func jumpListURL(kind, value string) string {
+	if kind == JumpListCategory {
+		return protocolScheme + "://category/" + url.PathEscape(value)
+	}
+	return protocolScheme + "://search?q=" + url.QueryEscape(value)
+}
+
+// recentCategories returns browsed categories with when they were last used
+func (a *App) recentCategories() map[string]time.Time {
+	result := make(map[string]time.Time)
+	if a.userDB == nil {
+		return result
+	}
+
+	rows, err := a.userDB.Query("SELECT category, CAST(strftime('%s', last_used) AS INTEGER) FROM category_usage")
+	if err != nil {
+		log.Printf("Failed to load category usage: %v", err)
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var category string
+		var lastUsed int64
+		if err := rows.Scan(&category, &lastUsed); err == nil {
+			result[category] = time.Unix(lastUsed, 0)
+		}
+	}
+	return result
+}
+
+// GetJumpList returns recent searches and categories, most recent first. A
+// limit of 0 returns the default number of entries.
+func (a *App) GetJumpList(limit int) ([]JumpListEntry, error) {
+	if limit < 0 || limit > maxJumpListSize {
+		return nil, invalidArgument("limit", "limit must be between 0 and %d", maxJumpListSize)
+	}
+	if limit == 0 {
+		limit = defaultJumpListSize
+	}
+
+	entries := []JumpListEntry{}
+	for _, term := range a.GetSearchHistory() {
+		a.history.mu.RLock()
+		at := a.history.searchedAt[term]
+		a.history.mu.RUnlock()
+		entries = append(entries, JumpListEntry{
+			Kind:   JumpListSearch,
+			Value:  term,
+			Title:  "Search: " + term,
+			URL:    jumpListURL(JumpListSearch, term),
+			UsedAt: at,
+		})
+	}
+	for category, at := range a.recentCategories() {
+		entries = append(entries, JumpListEntry{
+			Kind:   JumpListCategory,
+			Value:  category,
+			Title:  "Category: " + category,
+			URL:    jumpListURL(JumpListCategory, category),
+			UsedAt: at,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].UsedAt.Equal(entries[j].UsedAt) {
+			return entries[i].UsedAt.After(entries[j].UsedAt)
+		}
+		return entries[i].Title < entries[j].Title
+	})
+	return entries[:min(limit, len(entries))], nil
+}
+
+// scheduleJumpListUpdate refreshes the OS jump list shortly after the last change
+func (a *App) scheduleJumpListUpdate() {
+	if !jumpListSupported || a.portable {
+		return
+	}
+
+	a.jumpList.mu.Lock()
+	defer a.jumpList.mu.Unlock()
+	if a.jumpList.timer != nil {
+		a.jumpList.timer.Stop()
+	}
+	a.jumpList.timer = time.AfterFunc(jumpListUpdateDelay, a.updateJumpList)
+}
+
+// updateJumpList replaces the OS jump list with the most recent entries.
+// Portable runs leave the OS alone.
+func (a *App) updateJumpList() {
+	if !jumpListSupported || a.portable {
+		return
+	}
+	entries, err := a.GetJumpList(taskbarJumpListSize)
+	if err != nil {
+		log.Printf("Failed to build jump list: %v", err)
+		return
+	}
+	if err := setOSJumpList(entries); err != nil {
+		log.Printf("Failed to update the taskbar jump list: %v", err)
+	}
+}
+
+// flushJumpList writes a pending jump list update before the app exits
+func (a *App) flushJumpList() {
+	a.jumpList.mu.Lock()
+	pending := a.jumpList.timer != nil && a.jumpList.timer.Stop()
+	a.jumpList.mu.Unlock()
+
+	if pending {
+		a.updateJumpList()
+	}
+}
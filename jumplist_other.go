@@ -0,0 +1,11 @@
+//go:build !windows
+
+package main
+
+// jumpListSupported reports that only the Windows taskbar has a jump list
+const jumpListSupported = false
+
+// setOSJumpList does nothing; macOS and Linux docks don't offer jump lists
+func setOSJumpList(entries []JumpListEntry) error {
+	return nil
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"syscall"
+	"unsafe"
+)
+
+// jumpListSupported reports that the Windows taskbar shows a jump list
+const jumpListSupported = true
+
+var (
+	ole32                = syscall.NewLazyDLL("ole32.dll")
+	procCoInitializeEx   = ole32.NewProc("CoInitializeEx")
+	procCoUninitialize   = ole32.NewProc("CoUninitialize")
+	procCoCreateInstance = ole32.NewProc("CoCreateInstance")
+)
+
+const (
+	coinitApartmentThreaded = 0x2
+	clsctxInprocServer      = 0x1
+	vtLPWSTR                = 31
+)
+
+// Method indexes into the COM vtables used here; IUnknown takes 0-2
+const (
+	methodQueryInterface = 0
+	methodRelease        = 2
+
+	// ICustomDestinationList
+	methodBeginList      = 4
+	methodAppendCategory = 5
+	methodCommitList     = 8
+	methodAbortList      = 11
+
+	// IObjectArray and IObjectCollection
+	methodGetCount  = 3
+	methodGetAt     = 4
+	methodAddObject = 5
+
+	// IShellLinkW
+	methodSetDescription      = 7
+	methodSetWorkingDirectory = 9
+	methodGetArguments        = 10
+	methodSetArguments        = 11
+	methodSetIconLocation     = 17
+	methodSetPath             = 20
+
+	// IPropertyStore
+	methodSetValue = 6
+	methodCommit   = 7
+)
+
+// guid is a COM class or interface id
+type guid struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// propertyKey identifies a shell property such as an item's title
+type propertyKey struct {
+	fmtid guid
+	pid   uint32
+}
+
+// propVariant holds a string property value
+type propVariant struct {
+	vt       uint16
+	reserved [3]uint16
+	val      uintptr
+	pad      uintptr
+}
+
+var (
+	clsidDestinationList            = guid{0x77f10cf0, 0x3db5, 0x4966, [8]byte{0xb5, 0x20, 0xb7, 0xc5, 0x4f, 0xd3, 0x5e, 0xd6}}
+	iidCustomDestinationList        = guid{0x6332debf, 0x87b5, 0x4670, [8]byte{0x90, 0xc0, 0x5e, 0x57, 0xb4, 0x08, 0xa4, 0x9e}}
+	clsidEnumerableObjectCollection = guid{0x2d3468c1, 0x36a7, 0x43b6, [8]byte{0xac, 0x24, 0xd3, 0xf0, 0x2f, 0xd9, 0x60, 0x7a}}
+	iidObjectCollection             = guid{0x5632b1a4, 0xe38a, 0x400a, [8]byte{0x92, 0x8a, 0xd4, 0xcd, 0x63, 0x23, 0x02, 0x95}}
+	iidObjectArray                  = guid{0x92ca9dcd, 0x5622, 0x4bba, [8]byte{0xa8, 0x05, 0x5e, 0x9f, 0x54, 0x1b, 0xd8, 0xc9}}
+	clsidShellLink                  = guid{0x00021401, 0x0000, 0x0000, [8]byte{0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+	iidShellLinkW                   = guid{0x000214f9, 0x0000, 0x0000, [8]byte{0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+	iidPropertyStore                = guid{0x886d8eeb, 0x8cf2, 0x4446, [8]byte{0x8d, 0x02, 0xcd, 0xba, 0x1d, 0xbc, 0xdf, 0x99}}
+	pkeyTitle                       = propertyKey{guid{0xf29f85e0, 0x4ff9, 0x1068, [8]byte{0xab, 0x91, 0x08, 0x00, 0x2b, 0x27, 0xb3, 0xd9}}, 2}
+)
+
+// comObject is a COM interface pointer
+type comObject struct {
+	vtbl *[32]uintptr
+}
+
+// call invokes a method of the interface and returns its HRESULT
+func (o *comObject) call(method int, args ...uintptr) uintptr {
+	ret, _, _ := syscall.SyscallN(o.vtbl[method], append([]uintptr{uintptr(unsafe.Pointer(o))}, args...)...)
+	return ret
+}
+
+// release drops the reference, tolerating nil
+func (o *comObject) release() {
+	if o != nil {
+		o.call(methodRelease)
+	}
+}
+
+// hresultError returns an error for a failed HRESULT, or nil
+func hresultError(op string, hr uintptr) error {
+	if int32(hr) < 0 {
+		return fmt.Errorf("%s failed: 0x%08X", op, uint32(hr))
+	}
+	return nil
+}
+
+// createInstance creates a COM object and returns the requested interface
+func createInstance(clsid, iid *guid) (*comObject, error) {
+	var obj *comObject
+	hr, _, _ := procCoCreateInstance.Call(uintptr(unsafe.Pointer(clsid)), 0, clsctxInprocServer,
+		uintptr(unsafe.Pointer(iid)), uintptr(unsafe.Pointer(&obj)))
+	if err := hresultError("CoCreateInstance", hr); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// utf16Ptr converts s for a COM call; s never contains NUL here
+func utf16Ptr(s string) *uint16 {
+	p, _ := syscall.UTF16PtrFromString(s)
+	return p
+}
+
+// newJumpListLink creates a shell link that launches exe with the entry's
+// gylte:// link. While Gylte is running, the single instance lock hands the
+// link to the running app (onSecondInstanceLaunch) and the new process exits.
+// Otherwise the app starts from its install directory, like the Start menu
+// shortcut, so it opens the same database.
+func newJumpListLink(exe string, e JumpListEntry) (*comObject, error) {
+	link, err := createInstance(&clsidShellLink, &iidShellLinkW)
+	if err != nil {
+		return nil, err
+	}
+	path, args, title := utf16Ptr(exe), utf16Ptr(`"`+e.URL+`"`), utf16Ptr(e.Title)
+	dir := utf16Ptr(filepath.Dir(exe))
+	link.call(methodSetPath, uintptr(unsafe.Pointer(path)))
+	link.call(methodSetWorkingDirectory, uintptr(unsafe.Pointer(dir)))
+	link.call(methodSetArguments, uintptr(unsafe.Pointer(args)))
+	link.call(methodSetDescription, uintptr(unsafe.Pointer(title)))
+	link.call(methodSetIconLocation, uintptr(unsafe.Pointer(path)), 0)
+
+	// The jump list shows the title property, not the description
+	var store *comObject
+	hr := link.call(methodQueryInterface, uintptr(unsafe.Pointer(&iidPropertyStore)), uintptr(unsafe.Pointer(&store)))
+	if err := hresultError("QueryInterface(IPropertyStore)", hr); err != nil {
+		link.release()
+		return nil, err
+	}
+	defer store.release()
+	value := propVariant{vt: vtLPWSTR, val: uintptr(unsafe.Pointer(title))}
+	if err := hresultError("SetValue", store.call(methodSetValue, uintptr(unsafe.Pointer(&pkeyTitle)), uintptr(unsafe.Pointer(&value)))); err != nil {
+		link.release()
+		return nil, err
+	}
+	store.call(methodCommit)
+	goruntime.KeepAlive(path)
+	goruntime.KeepAlive(dir)
+	goruntime.KeepAlive(args)
+	goruntime.KeepAlive(title)
+	return link, nil
+}
+
+// removedArguments returns the arguments of the links the user removed from
+// the jump list; Windows rejects lists that add them back
+func removedArguments(removed *comObject) map[string]bool {
+	result := make(map[string]bool)
+	var count uint32
+	if hresultError("GetCount", removed.call(methodGetCount, uintptr(unsafe.Pointer(&count)))) != nil {
+		return result
+	}
+	buf := make([]uint16, 2048)
+	for i := uint32(0); i < count; i++ {
+		var link *comObject
+		if hresultError("GetAt", removed.call(methodGetAt, uintptr(i), uintptr(unsafe.Pointer(&iidShellLinkW)), uintptr(unsafe.Pointer(&link)))) != nil {
+			continue
+		}
+		if hresultError("GetArguments", link.call(methodGetArguments, uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))) == nil {
+			result[syscall.UTF16ToString(buf)] = true
+		}
+		link.release()
+	}
+	return result
+}
+
+// buildJumpList replaces the taskbar jump list with entries under a "Recent"
+// category; it must run on a thread that can initialize COM
+func buildJumpList(exe string, entries []JumpListEntry) error {
+	hr, _, _ := procCoInitializeEx.Call(0, coinitApartmentThreaded)
+	if err := hresultError("CoInitializeEx", hr); err != nil {
+		return err
+	}
+	defer procCoUninitialize.Call()
+
+	list, err := createInstance(&clsidDestinationList, &iidCustomDestinationList)
+	if err != nil {
+		return err
+	}
+	defer list.release()
+
+	var slots uint32
+	var removed *comObject
+	hr = list.call(methodBeginList, uintptr(unsafe.Pointer(&slots)), uintptr(unsafe.Pointer(&iidObjectArray)), uintptr(unsafe.Pointer(&removed)))
+	if err := hresultError("BeginList", hr); err != nil {
+		return err
+	}
+	skip := removedArguments(removed)
+	removed.release()
+
+	collection, err := createInstance(&clsidEnumerableObjectCollection, &iidObjectCollection)
+	if err != nil {
+		list.call(methodAbortList)
+		return err
+	}
+	defer collection.release()
+
+	added := 0
+	for _, e := range entries {
+		if skip[`"`+e.URL+`"`] || added >= int(slots) {
+			continue
+		}
+		link, err := newJumpListLink(exe, e)
+		if err != nil {
+			list.call(methodAbortList)
+			return err
+		}
+		hr := collection.call(methodAddObject, uintptr(unsafe.Pointer(link)))
+		link.release()
+		if err := hresultError("AddObject", hr); err != nil {
+			list.call(methodAbortList)
+			return err
+		}
+		added++
+	}
+
+	if added > 0 {
+		category := utf16Ptr("Recent")
+		hr = list.call(methodAppendCategory, uintptr(unsafe.Pointer(category)), uintptr(unsafe.Pointer(collection)))
+		goruntime.KeepAlive(category)
+		if err := hresultError("AppendCategory", hr); err != nil {
+			list.call(methodAbortList)
+			return err
+		}
+	}
+	return hresultError("CommitList", list.call(methodCommitList))
+}
+
+// setOSJumpList shows entries in the taskbar jump list of the running executable
+func setOSJumpList(entries []JumpListEntry) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate executable: %w", err)
+	}
+
+	// COM apartments belong to an OS thread
+	errc := make(chan error, 1)
+	go func() {
+		goruntime.LockOSThread()
+		defer goruntime.UnlockOSThread()
+		errc <- buildJumpList(exe, entries)
+	}()
+	return <-errc
+}
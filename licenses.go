@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"Gylte/internal/glyphdata"
+)
+
+// IconSetLicense is the license an icon set is distributed under
+type IconSetLicense struct {
+	Category string `json:"category"`
+	IconSet  string `json:"iconSet"`
+	// License is an SPDX identifier, e.g. "MIT" or "OFL-1.1"
+	License     string `json:"license"`
+	URL         string `json:"url,omitempty"`
+	Attribution string `json:"attribution,omitempty"`
+	// RequiresAttribution is set when shipping the icons means crediting their authors
+	RequiresAttribution bool `json:"requiresAttribution"`
+}
+
+// loadIconSetLicenses reads the icon_sets table, falling back to the built-in
+// licenses when the database doesn't have one. Results are keyed by category.
+func (a *App) loadIconSetLicenses() (map[string]IconSetLicense, error) {
+	licenses := make(map[string]IconSetLicense, len(glyphdata.Licenses))
+	for category, l := range glyphdata.Licenses {
+		licenses[category] = IconSetLicense{
+			Category:            category,
+			IconSet:             iconSetName(category),
+			License:             l.License,
+			URL:                 l.URL,
+			Attribution:         l.Attribution,
+			RequiresAttribution: l.RequiresAttribution,
+		}
+	}
+	if a.readDB == nil {
+		return licenses, nil
+	}
+	if exists, err := tableExists(a.readDB, "icon_sets"); err != nil || !exists {
+		return licenses, nil
+	}
+
+	rows, err := a.readDB.Query(`
+		SELECT category, name, license, COALESCE(url, ''), COALESCE(attribution, ''), requires_attribution
+		FROM icon_sets
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load icon set licenses: %w", err)
+	}
+	defer rows.Close()
+
+	clear(licenses)
+	for rows.Next() {
+		var l IconSetLicense
+		if err := rows.Scan(&l.Category, &l.IconSet, &l.License, &l.URL, &l.Attribution, &l.RequiresAttribution); err != nil {
+			continue
+		}
+		licenses[l.Category] = l
+	}
+	return licenses, rows.Err()
+}
+
+// exportAttribution returns the licenses of the icon sets in glyphs that must
+// be credited, ordered by icon set
+func (a *App) exportAttribution(glyphs []Glyph) ([]IconSetLicense, error) {
+	licenses, err := a.loadIconSetLicenses()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var result []IconSetLicense
+	for _, g := range glyphs {
+		l, ok := licenses[categoryOf(g)]
+		if !ok || !l.RequiresAttribution || seen[l.IconSet] {
+			continue
+		}
+		seen[l.IconSet] = true
+		result = append(result, l)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].IconSet < result[j].IconSet })
+	return result, nil
+}
+
+// attributionLines formats one credit per icon set, e.g.
+// "Octicons: Copyright (c) GitHub, Inc. (MIT, https://github.com/primer/octicons)"
+func attributionLines(licenses []IconSetLicense) []string {
+	lines := make([]string, len(licenses))
+	for i, l := range licenses {
+		terms := l.License
+		if l.URL != "" {
+			terms += ", " + l.URL
+		}
+		if l.Attribution != "" {
+			lines[i] = fmt.Sprintf("%s: %s (%s)", l.IconSet, l.Attribution, terms)
+		} else {
+			lines[i] = fmt.Sprintf("%s (%s)", l.IconSet, terms)
+		}
+	}
+	return lines
+}
+
+// attributionHeading introduces the credits in every export format
+const attributionHeading = "Icons used under the following licenses:"
+
+// attributionComment renders the credits as line comments starting with prefix,
+// or "" when nothing needs crediting
+func attributionComment(prefix string, licenses []IconSetLicense) string {
+	if len(licenses) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s\n", prefix, attributionHeading)
+	for _, line := range attributionLines(licenses) {
+		fmt.Fprintf(&b, "%s  %s\n", prefix, line)
+	}
+	return b.String()
+}
+
+// attributionText renders the credits as a plain text file for formats that
+// can't carry comments
+func attributionText(title string, licenses []IconSetLicense) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Gylte export: %s\n\n%s\n\n", title, attributionHeading)
+	for _, line := range attributionLines(licenses) {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	return b.String()
+}
+
+// GetCategoryInfo returns a category with its icon set's license and attribution requirements
+func (a *App) GetCategoryInfo(category string) (*CategoryInfo, error) {
+	for _, info := range a.GetCategories() {
+		if info.Name == category {
+			return &info, nil
+		}
+	}
+	return nil, newAppError(ErrCodeNotFound, "category %q not found", category)
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultLocalAPIPort is the loopback port the local API listens on
+	defaultLocalAPIPort = 17826
+
+	// EventLocalAPIChanged is emitted with the API status after it starts or stops
+	EventLocalAPIChanged = "localapi:changed"
+)
+
+// LocalAPIStatus describes the local HTTP API for the settings UI
+type LocalAPIStatus struct {
+	Enabled bool   `json:"enabled"`
+	Running bool   `json:"running"`
+	Port    int    `json:"port"`
+	URL     string `json:"url"`
+	Token   string `json:"token"`
+	Clients int    `json:"clients"` // connected WebSocket clients
+	Error   string `json:"error,omitempty"`
+}
+
+// LocalAPI serves the loopback HTTP API used by external tools
+type LocalAPI struct {
+	mu      sync.Mutex
+	server  *http.Server
+	port    int
+	lastErr string
+
+	// token is the API token when it couldn't be saved, e.g. in read-only mode
+	token string
+}
+
+// localAPIPort returns the configured port
+func (a *App) localAPIPort() int {
+	port := a.settings.GetInt("api.port", defaultLocalAPIPort)
+	if port <= 0 || port > 65535 {
+		port = defaultLocalAPIPort
+	}
+	return port
+}
+
+// localAPIToken returns the bearer token clients must send, creating one on
+// first use. A token that can't be saved is kept for the rest of the session.
+func (a *App) localAPIToken() string {
+	a.localAPI.mu.Lock()
+	defer a.localAPI.mu.Unlock()
+
+	if token := a.settings.Get("api.token", ""); token != "" {
+		return token
+	}
+	if a.localAPI.token != "" {
+		return a.localAPI.token
+	}
+	token := newAPIToken()
+	if a.isReadOnly() {
+		a.localAPI.token = token
+	} else if err := a.settings.Set("api.token", token); err != nil {
+		log.Printf("Failed to save API token: %v", err)
+		a.localAPI.token = token
+	}
+	return token
+}
+
+// newAPIToken returns a random token
+func newAPIToken() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		log.Printf("Failed to generate API token: %v", err)
+	}
+	return hex.EncodeToString(buf)
+}
+
+// authorized checks the request's token, sent as a bearer header or, for
+// browser sources that can't set headers, a ?token= query parameter
+func (a *App) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		token = r.URL.Query().Get("token")
+	}
+	want := a.localAPIToken()
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1
+}
+
+// requireToken wraps a handler with token authentication
+func (a *App) requireToken(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.authorized(r) {
+			writeJSONError(w, http.StatusUnauthorized, newAppError(ErrCodeInvalid, "missing or invalid token"))
+			return
+		}
+		h(w, r)
+	}
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write API response: %v", err)
+	}
+}
+
+// writeJSONError writes an error as a JSON response
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error(), "code": toAppError(err).Code})
+}
+
+// localAPIHandler builds the routes of the local API
+func (a *App) localAPIHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/status", a.requireToken(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"version": version,
+			"glyphs":  a.cache.Len(),
+			"profile": a.profile,
+		})
+	}))
+	mux.HandleFunc("GET /api/events", a.requireToken(a.serveEvents))
+	a.registerStreamDeckRoutes(mux)
+	return mux
+}
+
+// startLocalAPI listens on the loopback interface; only this machine can connect
+func (a *App) startLocalAPI() error {
+	// Create the token before the first request needs it
+	a.localAPIToken()
+
+	a.localAPI.mu.Lock()
+	defer a.localAPI.mu.Unlock()
+
+	if a.localAPI.server != nil {
+		return nil
+	}
+
+	port := a.localAPIPort()
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		a.localAPI.lastErr = err.Error()
+		return fmt.Errorf("failed to start local API on port %d: %w", port, err)
+	}
+
+	server := &http.Server{
+		Handler:           a.localAPIHandler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	a.localAPI.server = server
+	a.localAPI.port = listener.Addr().(*net.TCPAddr).Port
+	a.localAPI.lastErr = ""
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Local API stopped: %v", err)
+			a.localAPI.mu.Lock()
+			a.localAPI.lastErr = err.Error()
+			a.localAPI.mu.Unlock()
+		}
+	}()
+	log.Printf("Local API listening on 127.0.0.1:%d", a.localAPI.port)
+	return nil
+}
+
+// stopLocalAPI shuts the server down and disconnects event clients
+func (a *App) stopLocalAPI() {
+	a.localAPI.mu.Lock()
+	server := a.localAPI.server
+	a.localAPI.server = nil
+	a.localAPI.mu.Unlock()
+
+	if server == nil {
+		return
+	}
+	a.bridge.closeAll()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Failed to stop local API: %v", err)
+	}
+	log.Println("Local API stopped")
+}
+
+// GetLocalAPIStatus reports whether the local API is running and how to reach it
+func (a *App) GetLocalAPIStatus() LocalAPIStatus {
+	a.localAPI.mu.Lock()
+	running := a.localAPI.server != nil
+	port := a.localAPI.port
+	lastErr := a.localAPI.lastErr
+	a.localAPI.mu.Unlock()
+
+	if !running {
+		port = a.localAPIPort()
+	}
+	return LocalAPIStatus{
+		Enabled: a.settings.GetBool("api.enabled", false),
+		Running: running,
+		Port:    port,
+		URL:     fmt.Sprintf("http://127.0.0.1:%d", port),
+		Token:   a.localAPIToken(),
+		Clients: a.bridge.count(),
+		Error:   lastErr,
+	}
+}
+
+// SetLocalAPIEnabled starts or stops the local API and remembers the choice
+func (a *App) SetLocalAPIEnabled(enabled bool) (LocalAPIStatus, error) {
+	if err := a.checkWritable("change settings"); err != nil {
+		return LocalAPIStatus{}, err
+	}
+	if err := a.settings.Set("api.enabled", strconv.FormatBool(enabled)); err != nil {
+		return LocalAPIStatus{}, err
+	}
+
+	var err error
+	if enabled {
+		err = a.startLocalAPI()
+	} else {
+		a.stopLocalAPI()
+	}
+	status := a.GetLocalAPIStatus()
+	a.emit(EventLocalAPIChanged, status)
+	return status, err
+}
+
+// SetLocalAPIPort changes the port, restarting the API if it is running
+func (a *App) SetLocalAPIPort(port int) (LocalAPIStatus, error) {
+	if err := a.checkWritable("change settings"); err != nil {
+		return LocalAPIStatus{}, err
+	}
+	if port < 1024 || port > 65535 {
+		return LocalAPIStatus{}, newAppError(ErrCodeInvalid, "port must be between 1024 and 65535")
+	}
+	if err := a.settings.Set("api.port", strconv.Itoa(port)); err != nil {
+		return LocalAPIStatus{}, err
+	}
+
+	var err error
+	if a.settings.GetBool("api.enabled", false) {
+		a.stopLocalAPI()
+		err = a.startLocalAPI()
+	}
+	status := a.GetLocalAPIStatus()
+	a.emit(EventLocalAPIChanged, status)
+	return status, err
+}
+
+// RegenerateLocalAPIToken replaces the API token, disconnecting existing event clients
+func (a *App) RegenerateLocalAPIToken() (string, error) {
+	if err := a.checkWritable("change settings"); err != nil {
+		return "", err
+	}
+	token := newAPIToken()
+	if err := a.settings.Set("api.token", token); err != nil {
+		return "", err
+	}
+	a.bridge.closeAll()
+	return token, nil
+}
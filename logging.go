@@ -0,0 +1,35 @@
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// logPath returns the location of the application log file
+func (a *App) logPath() string {
+	return filepath.Join(a.dataDir(), "gylte.log")
+}
+
+// setupLogging mirrors log output to a file in the data directory
+func (a *App) setupLogging() {
+	f, err := os.OpenFile(a.logPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		log.Printf("Failed to open log file: %v", err)
+		return
+	}
+
+	a.logFile = f
+	log.SetOutput(io.MultiWriter(os.Stderr, f))
+}
+
+// closeLogging restores stderr logging and closes the log file
+func (a *App) closeLogging() {
+	if a.logFile == nil {
+		return
+	}
+	log.SetOutput(os.Stderr)
+	a.logFile.Close()
+	a.logFile = nil
+}
@@ -2,10 +2,15 @@ package main
 
 import (
 	"embed"
+	"flag"
+	"fmt"
+	"log"
+	"os"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	"github.com/wailsapp/wails/v2/pkg/options/linux"
 	"github.com/wailsapp/wails/v2/pkg/options/mac"
 	"github.com/wailsapp/wails/v2/pkg/options/windows"
 )
@@ -13,32 +18,90 @@ import (
 //go:embed all:frontend/dist
 var assets embed.FS
 
+// Regenerate the TypeScript bindings and fail if they drifted from the committed ones
+//go:generate go run ./bindingcheck
+
+// version is the application version, overridden at build time with
+// -ldflags "-X main.version=x.y.z"
+var version = "0.1.0"
+
+// formatError converts errors returned by bound methods into values for the frontend.
+// Every error is sent as an AppError object so the UI can branch on its code.
+func formatError(err error) any {
+	return toAppError(err)
+}
+
 func main() {
+	readOnly := flag.Bool("readonly", false, "disable all changes to favorites, collections, and settings")
+	sharedDB := flag.String("shared-db", "", "open a shared glyph database read-only and keep favorites, tags, and history in a local database")
+	portable := flag.Bool("portable", false, "don't register gylte:// links or shortcuts with the OS")
+	rpc := flag.Bool("rpc", false, "run without a window, serving JSON-RPC 2.0 on stdin/stdout")
+	filter := flag.Bool("filter", false, "read glyph names or queries on stdin and print the glyphs")
+	format := flag.String("format", "char", "output format for --filter: "+glyphFormatNames())
+	debug := flag.Bool("debug", false, "serve pprof on "+debugAddr+" and log startup phase timings")
+	flag.Usage = func() { printUsage(os.Stderr) }
+	flag.Parse()
+
 	// Create an instance of the app structure
 	app := NewApp()
+	app.forceReadOnly = *readOnly
+	app.sharedDBPath = *sharedDB
+	app.portable = *portable
+	app.debug = *debug
+	app.launchURL = protocolURLArg(flag.Args())
+
+	if *rpc {
+		if err := runRPC(app, os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("RPC: %v", err)
+		}
+		return
+	}
+	if _, ok := cliCommands[flag.Arg(0)]; ok {
+		if err := runCommand(app, flag.Arg(0), flag.Args()[1:], os.Stdout, os.Stderr); err != nil {
+			fmt.Fprintf(os.Stderr, "gylte: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *filter {
+		if err := runFilter(app, os.Stdin, os.Stdout, os.Stderr, *format); err != nil {
+			fmt.Fprintf(os.Stderr, "gylte: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	window := app.startupWindowPrefs()
+	translucent := window.Effect != WindowEffectNone
 
 	// Create application with options
 	err := wails.Run(&options.App{
 		Title:       "Gylte",
 		Width:       572,
 		Height:      900,
-		Frameless:   true,
-		AlwaysOnTop: true,
+		Frameless:   window.Frameless,
+		AlwaysOnTop: window.AlwaysOnTop,
 		AssetServer: &assetserver.Options{
 			Assets: assets,
 		},
 		BackgroundColour: &options.RGBA{R: 18, G: 18, B: 18, A: 00},
 		OnStartup:        app.startup,
+		OnDomReady:       app.domReady,
 		OnShutdown:       app.shutdown,
-		CSSDragProperty:  "--wails-draggable",
-		CSSDragValue:     "drag",
+		ErrorFormatter:   formatError,
+		SingleInstanceLock: &options.SingleInstanceLock{
+			UniqueId:               singleInstanceID,
+			OnSecondInstanceLaunch: app.onSecondInstanceLaunch,
+		},
+		CSSDragProperty: "--wails-draggable",
+		CSSDragValue:    "drag",
 		Bind: []interface{}{
 			app,
 		},
 		Windows: &windows.Options{
 			WebviewIsTransparent:              true,
-			WindowIsTranslucent:               true,
-			BackdropType:                      windows.Mica,
+			WindowIsTranslucent:               translucent,
+			BackdropType:                      windowsBackdrop(window.Effect),
 			DisablePinchZoom:                  true,
 			DisableWindowIcon:                 true,
 			DisableFramelessWindowDecorations: true,
@@ -56,12 +119,16 @@ func main() {
 			},
 			Appearance:           mac.NSAppearanceNameDarkAqua,
 			WebviewIsTransparent: true,
-			WindowIsTranslucent:  true,
+			WindowIsTranslucent:  window.Effect == WindowEffectAuto || window.Effect == WindowEffectVibrancy,
+			OnUrlOpen:            app.openURL,
 			About: &mac.AboutInfo{
 				Title:   "Gylte",
 				Message: "© 2025 Limp Cheney",
 			},
 		},
+		Linux: &linux.Options{
+			WindowIsTranslucent: translucent,
+		},
 	})
 
 	if err != nil {
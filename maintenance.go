@@ -0,0 +1,430 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Maintenance task names
+const (
+	MaintenanceOptimize = "optimize"
+	MaintenanceCompact  = "compact"
+	MaintenanceHistory  = "history"
+	MaintenanceUpdates  = "updates"
+	MaintenanceFrecency = "frecency"
+	MaintenanceVault    = "vault"
+)
+
+const (
+	// Intervals accepted by SetMaintenanceInterval, besides 0 to disable a task
+	minMaintenanceInterval = 5 * time.Minute
+	maxMaintenanceInterval = 90 * 24 * time.Hour
+
+	// maintenanceStartDelay keeps tasks that have never run from competing with startup
+	maintenanceStartDelay = 2 * time.Minute
+
+	// maintenanceJitter spreads runs by up to this fraction of the interval
+	maintenanceJitter = 0.1
+)
+
+// maintenanceTask is a periodic job run by the scheduler
+type maintenanceTask struct {
+	name  string
+	title string
+
+	// interval returns how often the task runs; 0 disables it
+	interval func(a *App) time.Duration
+	run      func(a *App) error
+}
+
+// maintenanceTasks lists the scheduled jobs in the order RunMaintenanceNow runs them
+var maintenanceTasks = []maintenanceTask{
+	{
+		name:     MaintenanceHistory,
+		title:    "Prune search history",
+		interval: maintenanceInterval(MaintenanceHistory, 6*time.Hour),
+		run: func(a *App) error {
+			a.pruneSearchHistory(a.historySettings())
+			return nil
+		},
+	},
+	{
+		name:     MaintenanceFrecency,
+		title:    "Recompute copy frecency",
+		interval: maintenanceInterval(MaintenanceFrecency, time.Hour),
+		run: func(a *App) error {
+			_, err := a.recomputeFrecency()
+			return err
+		},
+	},
+	{
+		name:     MaintenanceOptimize,
+		title:    "Analyze databases",
+		interval: maintenanceInterval(MaintenanceOptimize, 24*time.Hour),
+		run:      (*App).optimizeDatabases,
+	},
+	{
+		// Checks daily, but only rebuilds a database once enough of it is free pages
+		name:     MaintenanceCompact,
+		title:    "Compact databases",
+		interval: maintenanceInterval(MaintenanceCompact, 24*time.Hour),
+		run:      (*App).autoCompact,
+	},
+	{
+		// Writes an unlocked encrypted profile back so a crash loses little
+		name:     MaintenanceVault,
+		title:    "Save encrypted profile",
+		interval: maintenanceInterval(MaintenanceVault, 10*time.Minute),
+		run:      (*App).sealProfile,
+	},
+	{
+		// Update checks stay opt-in, and default to the older updates.intervalHours setting
+		name:  MaintenanceUpdates,
+		title: "Check for updates",
+		interval: func(a *App) time.Duration {
+			if !a.settings.GetBool("updates.autoCheck", false) {
+				return 0
+			}
+			hours := a.settings.GetInt("updates.intervalHours", 24)
+			if hours <= 0 {
+				hours = 24
+			}
+			return maintenanceInterval(MaintenanceUpdates, time.Duration(hours)*time.Hour)(a)
+		},
+		run: func(a *App) error {
+			info, err := a.CheckForUpdates()
+			if err != nil {
+				return err
+			}
+			if a.isReadOnly() {
+				return nil
+			}
+			// Releases without a deprecation list keep the current mapping
+			if _, err := a.refreshDeprecations(info); err != nil && errorCode(err) != ErrCodeNotFound {
+				return err
+			}
+			return nil
+		},
+	},
+}
+
+// maintenanceInterval reads a task's "maintenance.<name>.intervalMinutes" setting
+func maintenanceInterval(name string, fallback time.Duration) func(a *App) time.Duration {
+	return func(a *App) time.Duration {
+		minutes := a.settings.GetInt("maintenance."+name+".intervalMinutes", int(fallback/time.Minute))
+		return time.Duration(max(minutes, 0)) * time.Minute
+	}
+}
+
+// findMaintenanceTask looks up a task by name
+func findMaintenanceTask(name string) (maintenanceTask, bool) {
+	for _, task := range maintenanceTasks {
+		if task.name == name {
+			return task, true
+		}
+	}
+	return maintenanceTask{}, false
+}
+
+// MaintenanceTaskStatus describes a scheduled task
+type MaintenanceTaskStatus struct {
+	Name            string    `json:"name"`
+	Title           string    `json:"title"`
+	IntervalMinutes int       `json:"intervalMinutes"` // 0 when disabled
+	LastRun         time.Time `json:"lastRun,omitzero" ts_type:"string"`
+	LastDurationMs  float64   `json:"lastDurationMs"`
+	LastError       string    `json:"lastError,omitempty"`
+	NextRun         time.Time `json:"nextRun,omitzero" ts_type:"string"` // zero while the scheduler is stopped
+	Running         bool      `json:"running"`
+}
+
+// MaintenanceStatus reports every scheduled task
+type MaintenanceStatus struct {
+	Running bool                    `json:"running"` // whether the scheduler is active
+	Tasks   []MaintenanceTaskStatus `json:"tasks"`
+}
+
+// maintenanceRun records the outcome of a task's last run
+type maintenanceRun struct {
+	at       time.Time
+	duration time.Duration
+	err      error
+}
+
+// Maintenance runs periodic jobs one at a time in the background
+type Maintenance struct {
+	mu      sync.Mutex
+	runMu   sync.Mutex // held while a task runs
+	cancel  context.CancelFunc
+	wake    chan struct{}
+	runs    map[string]*maintenanceRun
+	next    map[string]time.Time
+	running string
+}
+
+// lastMaintenanceRun returns when a task last ran, from this session or the profile's settings
+func (a *App) lastMaintenanceRun(name string) time.Time {
+	a.maintenance.mu.Lock()
+	run := a.maintenance.runs[name]
+	a.maintenance.mu.Unlock()
+	if run != nil {
+		return run.at
+	}
+	if unix, err := strconv.ParseInt(a.settings.Get("maintenance."+name+".lastRun", ""), 10, 64); err == nil {
+		return time.Unix(unix, 0)
+	}
+	return time.Time{}
+}
+
+// scheduleMaintenanceRun picks when a task runs next: an interval after its
+// last run plus jitter, but no sooner than shortly after now, so overdue
+// tasks don't all run while the app starts
+func scheduleMaintenanceRun(interval time.Duration, last, now time.Time) time.Time {
+	jitter := time.Duration(rand.Float64() * maintenanceJitter * float64(interval))
+	earliest := now.Add(maintenanceStartDelay + min(jitter, maintenanceStartDelay))
+	if next := last.Add(interval + jitter); next.After(earliest) {
+		return next
+	}
+	return earliest
+}
+
+// dueMaintenanceTask schedules every enabled task and returns the one due first
+func (a *App) dueMaintenanceTask(now time.Time) (maintenanceTask, time.Time) {
+	var due maintenanceTask
+	var first time.Time
+	for _, task := range maintenanceTasks {
+		interval := task.interval(a)
+		last := a.lastMaintenanceRun(task.name)
+
+		a.maintenance.mu.Lock()
+		if a.maintenance.next == nil {
+			a.maintenance.next = make(map[string]time.Time)
+		}
+		at, ok := a.maintenance.next[task.name]
+		switch {
+		case interval <= 0:
+			delete(a.maintenance.next, task.name)
+		case !ok:
+			at = scheduleMaintenanceRun(interval, last, now)
+			a.maintenance.next[task.name] = at
+		}
+		a.maintenance.mu.Unlock()
+
+		if interval > 0 && (first.IsZero() || at.Before(first)) {
+			due, first = task, at
+		}
+	}
+	return due, first
+}
+
+// runMaintenanceTask runs a task now and records its outcome
+func (a *App) runMaintenanceTask(task maintenanceTask) error {
+	a.maintenance.runMu.Lock()
+	defer a.maintenance.runMu.Unlock()
+
+	a.maintenance.mu.Lock()
+	a.maintenance.running = task.name
+	a.maintenance.mu.Unlock()
+
+	start := time.Now()
+	err := task.run(a)
+	elapsed := time.Since(start)
+	if err != nil {
+		log.Printf("Maintenance %s failed: %v", task.name, err)
+	} else {
+		log.Printf("Maintenance %s finished in %v", task.name, elapsed.Round(time.Millisecond))
+	}
+
+	a.maintenance.mu.Lock()
+	if a.maintenance.runs == nil {
+		a.maintenance.runs = make(map[string]*maintenanceRun)
+	}
+	a.maintenance.runs[task.name] = &maintenanceRun{at: start, duration: elapsed, err: err}
+	delete(a.maintenance.next, task.name)
+	a.maintenance.running = ""
+	a.maintenance.mu.Unlock()
+
+	if !a.isReadOnly() {
+		if err := a.settings.Set("maintenance."+task.name+".lastRun", strconv.FormatInt(start.Unix(), 10)); err != nil {
+			log.Printf("Failed to save maintenance run: %v", err)
+		}
+	}
+	a.wakeMaintenance()
+	return err
+}
+
+// startMaintenance starts the scheduler, replacing a running one
+func (a *App) startMaintenance() {
+	a.maintenance.mu.Lock()
+	if a.maintenance.cancel != nil {
+		a.maintenance.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.maintenance.cancel = cancel
+	a.maintenance.wake = make(chan struct{}, 1)
+	wake := a.maintenance.wake
+	a.maintenance.mu.Unlock()
+
+	go func() {
+		for {
+			task, at := a.dueMaintenanceTask(time.Now())
+
+			var timer *time.Timer
+			var fire <-chan time.Time
+			if !at.IsZero() {
+				timer = time.NewTimer(max(time.Until(at), 0))
+				fire = timer.C
+			}
+			select {
+			case <-ctx.Done():
+			case <-wake:
+				// A run finished or the schedule changed
+			case <-fire:
+				a.runMaintenanceTask(task)
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+}
+
+// stopMaintenance stops the scheduler and waits for a running task to finish
+func (a *App) stopMaintenance() {
+	a.maintenance.mu.Lock()
+	if a.maintenance.cancel != nil {
+		a.maintenance.cancel()
+		a.maintenance.cancel = nil
+	}
+	a.maintenance.mu.Unlock()
+
+	a.maintenance.runMu.Lock()
+	a.maintenance.runMu.Unlock()
+}
+
+// wakeMaintenance makes the scheduler look for due tasks again
+func (a *App) wakeMaintenance() {
+	a.maintenance.mu.Lock()
+	defer a.maintenance.mu.Unlock()
+	if a.maintenance.wake == nil {
+		return
+	}
+	select {
+	case a.maintenance.wake <- struct{}{}:
+	default:
+	}
+}
+
+// rescheduleMaintenance drops the planned runs so interval or profile changes
+// take effect; clearSession also forgets this session's results
+func (a *App) rescheduleMaintenance(clearSession bool) {
+	a.maintenance.mu.Lock()
+	a.maintenance.next = nil
+	if clearSession {
+		a.maintenance.runs = nil
+	}
+	a.maintenance.mu.Unlock()
+	a.wakeMaintenance()
+}
+
+// optimizeDatabases refreshes query planner statistics
+func (a *App) optimizeDatabases() error {
+	if a.isReadOnly() {
+		return nil
+	}
+	if a.db != nil && !a.isShared() {
+		if _, err := a.db.Exec("ANALYZE; PRAGMA optimize"); err != nil {
+			return fmt.Errorf("failed to analyze gylte.db: %w", err)
+		}
+	}
+	if a.userDB != nil && a.userDB != a.db {
+		if _, err := a.userDB.Exec("ANALYZE; PRAGMA optimize"); err != nil {
+			return fmt.Errorf("failed to analyze profile database: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetMaintenanceStatus returns when each maintenance task last ran and runs next
+func (a *App) GetMaintenanceStatus() *MaintenanceStatus {
+	status := &MaintenanceStatus{Tasks: make([]MaintenanceTaskStatus, 0, len(maintenanceTasks))}
+	for _, task := range maintenanceTasks {
+		ts := MaintenanceTaskStatus{
+			Name:            task.name,
+			Title:           task.title,
+			IntervalMinutes: int(task.interval(a) / time.Minute),
+			LastRun:         a.lastMaintenanceRun(task.name),
+		}
+
+		a.maintenance.mu.Lock()
+		if run := a.maintenance.runs[task.name]; run != nil {
+			ts.LastDurationMs = float64(run.duration.Microseconds()) / 1000
+			if run.err != nil {
+				ts.LastError = run.err.Error()
+			}
+		}
+		ts.NextRun = a.maintenance.next[task.name]
+		ts.Running = a.maintenance.running == task.name
+		status.Running = a.maintenance.cancel != nil
+		a.maintenance.mu.Unlock()
+
+		status.Tasks = append(status.Tasks, ts)
+	}
+	return status
+}
+
+// RunMaintenanceNow runs one task, or every task when name is empty, and
+// returns the updated status. Disabled tasks still run when asked for by name.
+func (a *App) RunMaintenanceNow(name string) (*MaintenanceStatus, error) {
+	if name != "" {
+		task, ok := findMaintenanceTask(name)
+		if !ok {
+			return nil, newAppError(ErrCodeInvalid, "unknown maintenance task: %s", name)
+		}
+		if err := a.runMaintenanceTask(task); err != nil {
+			return nil, err
+		}
+		return a.GetMaintenanceStatus(), nil
+	}
+
+	op := a.startOperation("maintenance", "Running maintenance…")
+	for i, task := range maintenanceTasks {
+		if task.interval(a) <= 0 {
+			continue
+		}
+		op.Progress(float64(i)/float64(len(maintenanceTasks))*100, task.title+"…")
+		if err := a.runMaintenanceTask(task); err != nil {
+			return nil, op.Fail("Maintenance failed", err)
+		}
+	}
+	op.Succeed("Maintenance finished")
+	return a.GetMaintenanceStatus(), nil
+}
+
+// SetMaintenanceInterval changes how often a task runs; 0 disables it
+func (a *App) SetMaintenanceInterval(name string, minutes int) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if _, ok := findMaintenanceTask(name); !ok {
+		return newAppError(ErrCodeInvalid, "unknown maintenance task: %s", name)
+	}
+	interval := time.Duration(minutes) * time.Minute
+	if minutes != 0 && (interval < minMaintenanceInterval || interval > maxMaintenanceInterval) {
+		return newAppError(ErrCodeInvalid, "interval must be 0 (off) or between %d and %d minutes",
+			int(minMaintenanceInterval/time.Minute), int(maxMaintenanceInterval/time.Minute))
+	}
+	if err := a.settings.Set("maintenance."+name+".intervalMinutes", strconv.Itoa(minutes)); err != nil {
+		return err
+	}
+	a.rescheduleMaintenance(false)
+	return nil
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// mcpProtocolVersions are the Model Context Protocol revisions the server speaks, newest first
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+const (
+	defaultMCPSearchLimit = 10
+	maxMCPSearchLimit     = 50
+)
+
+// mcpRequest is a JSON-RPC 2.0 message from an MCP client; unlike --rpc,
+// params are a named object
+type mcpRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// mcpTool describes a tool for tools/list
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+	call        func(a *App, args json.RawMessage) (any, error)
+}
+
+// mcpContent is a block of a tool result
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// mcpToolResult is the result of tools/call
+type mcpToolResult struct {
+	Content           []mcpContent `json:"content"`
+	StructuredContent any          `json:"structuredContent,omitempty"`
+	IsError           bool         `json:"isError,omitempty"`
+}
+
+// MCPGlyph is a glyph as returned to assistants: the exact character and
+// codepoint to paste, so they never have to guess one
+type MCPGlyph struct {
+	Name        string `json:"name"`
+	Glyph       string `json:"glyph"`
+	Codepoint   string `json:"codepoint"`
+	IconSet     string `json:"iconSet"`
+	Description string `json:"description,omitempty"`
+	HTMLEntity  string `json:"htmlEntity"`
+}
+
+// mcpGlyph converts a glyph for a tool result
+func mcpGlyph(g Glyph) MCPGlyph {
+	r := codepointOf(g.Glyph)
+	return MCPGlyph{
+		Name:        g.Name,
+		Glyph:       g.Glyph,
+		Codepoint:   formatCodepoint(r),
+		IconSet:     iconSetName(categoryOf(g)),
+		Description: g.Description,
+		HTMLEntity:  fmt.Sprintf("&#x%X;", r),
+	}
+}
+
+// mcpTools are the tools offered to assistants
+var mcpTools = []mcpTool{
+	{
+		Name:        "search_glyphs",
+		Description: "Search Nerd Font glyphs by name, keyword, or description. Returns the exact character and codepoint for each match; use these instead of guessing codepoints.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query":    map[string]any{"type": "string", "description": "Search terms, e.g. \"git branch\" or \"rust\""},
+				"category": map[string]any{"type": "string", "description": "Optional icon set prefix from list_categories, e.g. \"dev\" or \"fa\""},
+				"limit":    map[string]any{"type": "integer", "minimum": 1, "maximum": maxMCPSearchLimit, "default": defaultMCPSearchLimit},
+			},
+			"required": []string{"query"},
+		},
+		call: mcpSearchGlyphs,
+	},
+	{
+		Name:        "get_glyph",
+		Description: "Look up one Nerd Font glyph by its exact name (e.g. \"nf-dev-git\") or codepoint (e.g. \"U+E702\"). Fails rather than guessing when there is no exact match.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name":      map[string]any{"type": "string", "description": "Exact glyph name"},
+				"codepoint": map[string]any{"type": "string", "description": "Codepoint as U+XXXX or 0xXXXX"},
+			},
+		},
+		call: mcpGetGlyph,
+	},
+	{
+		Name:        "list_categories",
+		Description: "List the Nerd Font icon sets with their name prefixes and glyph counts.",
+		InputSchema: map[string]any{"type": "object", "properties": map[string]any{}},
+		call:        mcpListCategories,
+	},
+}
+
+// mcpSearchGlyphs runs the search_glyphs tool. Assistant searches don't
+// touch the user's search history.
+func mcpSearchGlyphs(a *App, raw json.RawMessage) (any, error) {
+	var args struct {
+		Query    string `json:"query"`
+		Category string `json:"category"`
+		Limit    int    `json:"limit"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if strings.TrimSpace(args.Query) == "" {
+		return nil, newAppError(ErrCodeInvalid, "query is required")
+	}
+	if args.Limit <= 0 {
+		args.Limit = defaultMCPSearchLimit
+	}
+	args.Limit = min(args.Limit, maxMCPSearchLimit)
+
+	matches, didYouMean, err := a.matchGlyphs(args.Query, args.Category, ScopeAll, "", false)
+	if err != nil {
+		return nil, err
+	}
+	glyphs := make([]MCPGlyph, 0, min(len(matches), args.Limit))
+	for _, m := range matches[:min(len(matches), args.Limit)] {
+		glyphs = append(glyphs, mcpGlyph(m.Glyph))
+	}
+	return map[string]any{"glyphs": glyphs, "total": len(matches), "didYouMean": didYouMean}, nil
+}
+
+// mcpGetGlyph runs the get_glyph tool
+func mcpGetGlyph(a *App, raw json.RawMessage) (any, error) {
+	var args struct {
+		Name      string `json:"name"`
+		Codepoint string `json:"codepoint"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	switch {
+	case args.Name != "":
+		if g, ok := a.findGlyphByName(strings.TrimSpace(args.Name)); ok {
+			return mcpGlyph(g), nil
+		}
+		return nil, newAppError(ErrCodeNotFound, "no glyph named %q; use search_glyphs to find the right name", args.Name)
+	case args.Codepoint != "":
+		r, ok := parseCodepoint(strings.TrimSpace(args.Codepoint))
+		if !ok {
+			return nil, newAppError(ErrCodeInvalid, "invalid codepoint %q; use U+XXXX or 0xXXXX", args.Codepoint)
+		}
+		for _, g := range a.allGlyphs() {
+			if codepointOf(g.Glyph) == r {
+				return mcpGlyph(g), nil
+			}
+		}
+		return nil, newAppError(ErrCodeNotFound, "no glyph at %s", formatCodepoint(r))
+	}
+	return nil, newAppError(ErrCodeInvalid, "name or codepoint is required")
+}
+
+// mcpListCategories runs the list_categories tool
+func mcpListCategories(a *App, _ json.RawMessage) (any, error) {
+	type category struct {
+		Prefix string `json:"prefix"`
+		Name   string `json:"name"`
+		Count  int    `json:"count"`
+	}
+	var result []category
+	for _, c := range a.GetCategories() {
+		result = append(result, category{Prefix: c.Name, Name: c.DisplayName, Count: c.Count})
+	}
+	return map[string]any{"categories": result}, nil
+}
+
+// callMCPTool runs a tool; tool failures are reported in the result so the
+// assistant can read them, not as protocol errors
+func callMCPTool(a *App, params json.RawMessage) (*mcpToolResult, *rpcError) {
+	var call struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &call); err != nil {
+		return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+	}
+	if len(call.Arguments) == 0 {
+		call.Arguments = json.RawMessage("{}")
+	}
+
+	for _, tool := range mcpTools {
+		if tool.Name != call.Name {
+			continue
+		}
+		result, err := tool.call(a, call.Arguments)
+		if err != nil {
+			return &mcpToolResult{Content: []mcpContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+		}
+		text, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, &rpcError{Code: rpcAppError, Message: err.Error()}
+		}
+		return &mcpToolResult{Content: []mcpContent{{Type: "text", Text: string(text)}}, StructuredContent: result}, nil
+	}
+	return nil, &rpcError{Code: rpcInvalidParams, Message: "unknown tool: " + call.Name}
+}
+
+// mcpInitialize answers the initialize handshake, agreeing on the client's
+// protocol version when we support it
+func mcpInitialize(params json.RawMessage) map[string]any {
+	var req struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	json.Unmarshal(params, &req)
+
+	protocol := mcpProtocolVersions[0]
+	for _, v := range mcpProtocolVersions {
+		if v == req.ProtocolVersion {
+			protocol = v
+		}
+	}
+	return map[string]any{
+		"protocolVersion": protocol,
+		"capabilities":    map[string]any{"tools": map[string]any{}},
+		"serverInfo":      map[string]any{"name": "gylte", "version": version},
+		"instructions":    "Use search_glyphs or get_glyph to look up Nerd Font icons instead of guessing codepoints.",
+	}
+}
+
+// handleMCP runs one message and returns the response, or nil for notifications
+func handleMCP(a *App, line []byte) map[string]any {
+	var req mcpRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return rpcResponse(nil, nil, &rpcError{Code: rpcParseError, Message: err.Error()})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return rpcResponse(req.ID, nil, &rpcError{Code: rpcInvalidRequest, Message: `expected "jsonrpc": "2.0" and a method`})
+	}
+
+	var result any
+	var rerr *rpcError
+	switch req.Method {
+	case "initialize":
+		result = mcpInitialize(req.Params)
+	case "ping":
+		result = map[string]any{}
+	case "tools/list":
+		result = map[string]any{"tools": mcpTools}
+	case "tools/call":
+		result, rerr = callMCPTool(a, req.Params)
+	default:
+		rerr = &rpcError{Code: rpcMethodNotFound, Message: "unknown method: " + req.Method}
+	}
+
+	// Notifications such as notifications/initialized get no reply
+	if req.ID == nil {
+		return nil
+	}
+	return rpcResponse(req.ID, result, rerr)
+}
+
+// runMCP serves the Model Context Protocol over newline-delimited JSON on
+// stdio, so coding assistants can search glyphs
+func runMCP(a *App, in io.Reader, out io.Writer) error {
+	a.startHeadless()
+	defer a.shutdown(a.ctx)
+
+	enc := json.NewEncoder(out)
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), rpcMaxLine)
+
+	log.Printf("Serving MCP on stdio (%d tools)", len(mcpTools))
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		if resp := handleMCP(a, line); resp != nil {
+			if err := enc.Encode(resp); err != nil {
+				return fmt.Errorf("failed to write response: %w", err)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// defineMCP implements `gylte mcp`
+func defineMCP(fs *flag.FlagSet) cliRunner {
+	return func(a *App, args []string, out, errOut io.Writer) error {
+		return runMCP(a, os.Stdin, out)
+	}
+}
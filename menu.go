@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// menuPrograms are tried in order when the "menu.command" setting is unset
+var menuPrograms = [][]string{
+	{"rofi", "-dmenu", "-i", "-p", "glyph"},
+	{"wofi", "--dmenu", "-i", "-p", "glyph"},
+	{"fuzzel", "--dmenu"},
+	{"bemenu", "-i", "-p", "glyph"},
+	{"dmenu", "-i", "-l", "20", "-p", "glyph"},
+	{"fzf", "--prompt", "glyph> "},
+}
+
+// typers insert text into the focused window, for `gylte menu --type`
+var typers = []struct {
+	available func() bool
+	command   []string
+}{
+	{func() bool { return os.Getenv("WAYLAND_DISPLAY") != "" && hasCommand("wtype") }, []string{"wtype", "--"}},
+	{func() bool { return os.Getenv("DISPLAY") != "" && hasCommand("xdotool") }, []string{"xdotool", "type", "--clearmodifiers", "--"}},
+	{func() bool { return hasCommand("ydotool") }, []string{"ydotool", "type", "--"}},
+}
+
+// menuCommand returns the configured menu program, or the first one installed
+func (a *App) menuCommand(override string) ([]string, error) {
+	if override == "" {
+		override = a.settings.Get("menu.command", "")
+	}
+	if override != "" {
+		return strings.Fields(override), nil
+	}
+	for _, cmd := range menuPrograms {
+		if hasCommand(cmd[0]) {
+			return cmd, nil
+		}
+	}
+	return nil, errors.New("no menu program found; install rofi, wofi, fuzzel, bemenu, dmenu, or fzf, or set menu.command")
+}
+
+// menuLines lists quick picks first, then every other glyph, as "glyph  name"
+func (a *App) menuLines() string {
+	var b strings.Builder
+	seen := make(map[int]bool)
+	if picks, err := a.GetQuickPicks(20); err == nil {
+		for _, p := range picks {
+			seen[p.ID] = true
+			fmt.Fprintf(&b, "%s  %s\n", p.Glyph.Glyph, p.Name)
+		}
+	}
+	for _, g := range a.allGlyphs() {
+		if !seen[g.ID] {
+			fmt.Fprintf(&b, "%s  %s\n", g.Glyph, g.Name)
+		}
+	}
+	return b.String()
+}
+
+// typeText types text into the focused window with the first available typer
+func typeText(text string) error {
+	for _, t := range typers {
+		if t.available() {
+			args := append(append([]string{}, t.command[1:]...), text)
+			return exec.Command(t.command[0], args...).Run()
+		}
+	}
+	return errors.New("no typing tool found; install wtype, xdotool, or ydotool")
+}
+
+// defineMenu shows the glyph list in a menu program such as rofi, then copies
+// (or types) the selection and records it in the copy history
+func defineMenu(fs *flag.FlagSet) cliRunner {
+	command := fs.String("command", "", "menu program and arguments (default: menu.command setting, else rofi/wofi/fuzzel/bemenu/dmenu/fzf)")
+	typeIt := fs.Bool("type", false, "type the glyph into the focused window instead of copying it")
+
+	return func(a *App, args []string, out, errOut io.Writer) error {
+		a.startHeadless()
+		defer a.shutdown(a.ctx)
+
+		menu, err := a.menuCommand(*command)
+		if err != nil {
+			return err
+		}
+
+		var selection bytes.Buffer
+		cmd := exec.Command(menu[0], menu[1:]...)
+		cmd.Stdin = strings.NewReader(a.menuLines())
+		cmd.Stdout = &selection
+		cmd.Stderr = errOut
+		if err := cmd.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && selection.Len() == 0 {
+				return nil // dismissed without choosing
+			}
+			return fmt.Errorf("%s failed: %w", menu[0], err)
+		}
+
+		fields := strings.Fields(selection.String())
+		if len(fields) == 0 {
+			return nil
+		}
+		g, ok := a.resolveGlyph(fields[len(fields)-1])
+		if !ok {
+			return newAppError(ErrCodeNotFound, "no glyph matches %q", strings.TrimSpace(selection.String()))
+		}
+
+		if *typeIt {
+			if err := typeText(g.Glyph); err != nil {
+				return err
+			}
+			a.recordCopy(g.ID)
+		} else if err := a.CopyGlyph(g.ID); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, g.Glyph)
+		return nil
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// GlyphMetrics describes how a glyph sits in the reference Nerd Font, in font
+// units with y growing up from the baseline, so the preview can draw it
+// against text baselines
+type GlyphMetrics struct {
+	Font       string `json:"font"`
+	UnitsPerEm int    `json:"unitsPerEm"`
+	Advance    int    `json:"advance"`
+
+	// Ink bounding box
+	XMin int `json:"xMin"`
+	YMin int `json:"yMin"`
+	XMax int `json:"xMax"`
+	YMax int `json:"yMax"`
+
+	// Line metrics of the font, for the guides around the glyph
+	Ascent    int `json:"ascent"`
+	Descent   int `json:"descent"`
+	CapHeight int `json:"capHeight"`
+	XHeight   int `json:"xHeight"`
+
+	// VerticalOffset is how far the ink's center sits above the center of
+	// capital letters; icons centered on text are at 0
+	VerticalOffset int `json:"verticalOffset"`
+}
+
+// measureGlyph reads the metrics of the first code point of s from f, named
+// fontName. ok is false when the font lacks it.
+func measureGlyph(f *opentype.Font, fontName, s string) (*GlyphMetrics, bool) {
+	var buf sfnt.Buffer
+	idx, err := f.GlyphIndex(&buf, codepointOf(s))
+	if err != nil || idx == 0 {
+		return nil, false
+	}
+
+	// At one pixel per font unit, 26.6 values round to font units
+	upem := int(f.UnitsPerEm())
+	ppem := fixed.I(upem)
+	advance, err := f.GlyphAdvance(&buf, idx, ppem, font.HintingNone)
+	if err != nil {
+		return nil, false
+	}
+	bounds, _, err := f.GlyphBounds(&buf, idx, ppem, font.HintingNone)
+	if err != nil {
+		return nil, false
+	}
+	line, err := f.Metrics(&buf, ppem, font.HintingNone)
+	if err != nil {
+		return nil, false
+	}
+
+	// sfnt's y axis points down; flip it so ascenders are positive
+	m := &GlyphMetrics{
+		Font:       fontName,
+		UnitsPerEm: upem,
+		Advance:    advance.Round(),
+		XMin:       bounds.Min.X.Round(),
+		YMin:       -bounds.Max.Y.Round(),
+		XMax:       bounds.Max.X.Round(),
+		YMax:       -bounds.Min.Y.Round(),
+		Ascent:     line.Ascent.Round(),
+		Descent:    line.Descent.Round(),
+		CapHeight:  line.CapHeight.Round(),
+		XHeight:    line.XHeight.Round(),
+	}
+	m.VerticalOffset = (m.YMin+m.YMax)/2 - m.CapHeight/2
+	return m, true
+}
+
+// metricsMeasurer returns a function measuring glyphs in the configured Nerd
+// Font, or nil when none is installed
+func (a *App) metricsMeasurer() func(string) (*GlyphMetrics, bool) {
+	path, err := a.nerdFontPath()
+	if err != nil {
+		return nil
+	}
+	face, err := a.renderer.loadFace(path)
+	if err != nil {
+		log.Printf("Importing without glyph metrics: %v", err)
+		return nil
+	}
+	name := filepath.Base(path)
+	return func(s string) (*GlyphMetrics, bool) {
+		return measureGlyph(face.font, name, s)
+	}
+}
+
+// createMetricsTable creates the table of imported glyph metrics
+func createMetricsTable(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS glyph_metrics (
+			glyph_id INTEGER PRIMARY KEY,
+			font TEXT NOT NULL,
+			units_per_em INTEGER NOT NULL,
+			advance INTEGER NOT NULL,
+			x_min INTEGER NOT NULL,
+			y_min INTEGER NOT NULL,
+			x_max INTEGER NOT NULL,
+			y_max INTEGER NOT NULL,
+			ascent INTEGER NOT NULL,
+			descent INTEGER NOT NULL,
+			cap_height INTEGER NOT NULL,
+			x_height INTEGER NOT NULL,
+			vertical_offset INTEGER NOT NULL
+		);
+	`)
+	return err
+}
+
+// storeMetricsStmt prepares the statement saving a glyph's metrics by glyph name
+func storeMetricsStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	return tx.PrepareContext(ctx, `
+		INSERT OR REPLACE INTO glyph_metrics (glyph_id, font, units_per_em, advance, x_min, y_min, x_max, y_max, ascent, descent, cap_height, x_height, vertical_offset)
+		SELECT id, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? FROM glyphs WHERE name = ?
+	`)
+}
+
+// storeMetrics saves m for the glyph named name with a statement from storeMetricsStmt
+func storeMetrics(ctx context.Context, stmt *sql.Stmt, name string, m *GlyphMetrics) error {
+	_, err := stmt.ExecContext(ctx, m.Font, m.UnitsPerEm, m.Advance, m.XMin, m.YMin, m.XMax, m.YMax,
+		m.Ascent, m.Descent, m.CapHeight, m.XHeight, m.VerticalOffset, name)
+	return err
+}
+
+// storedMetrics reads the metrics recorded for a glyph by the last import, or nil
+func storedMetrics(db *sql.DB, glyphID int) (*GlyphMetrics, error) {
+	if exists, err := tableExists(db, "glyph_metrics"); err != nil || !exists {
+		return nil, err
+	}
+	var m GlyphMetrics
+	err := db.QueryRow(`
+		SELECT font, units_per_em, advance, x_min, y_min, x_max, y_max, ascent, descent, cap_height, x_height, vertical_offset
+		FROM glyph_metrics WHERE glyph_id = ?
+	`, glyphID).Scan(&m.Font, &m.UnitsPerEm, &m.Advance, &m.XMin, &m.YMin, &m.XMax, &m.YMax,
+		&m.Ascent, &m.Descent, &m.CapHeight, &m.XHeight, &m.VerticalOffset)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read glyph metrics: %w", err)
+	}
+	return &m, nil
+}
+
+// glyphMetrics returns the metrics recorded at import, or measures the glyph
+// in the configured font for databases imported without them. Glyphs of
+// attached sources and plugins are always measured.
+func (a *App) glyphMetrics(g Glyph) *GlyphMetrics {
+	if a.readDB != nil && g.ID < sourceIDStride {
+		m, err := storedMetrics(a.readDB, g.ID)
+		if err != nil {
+			log.Printf("%v", err)
+		}
+		if m != nil {
+			return m
+		}
+	}
+	if measure := a.metricsMeasurer(); measure != nil {
+		if m, ok := measure(g.Glyph); ok {
+			return m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// deprecatedRange is a block of code points removed in a Nerd Fonts release
+type deprecatedRange struct {
+	start, end rune
+	oldPrefix  string // name prefix of the removed glyphs
+	newPrefix  string // name prefix of their replacements
+	note       string
+}
+
+// deprecatedRanges lists the code point blocks Nerd Fonts v3 removed
+var deprecatedRanges = []deprecatedRange{
+	{
+		start: 0xF500, end: 0xFD46,
+		oldPrefix: "nf-mdi-", newPrefix: "nf-md-",
+		note: "Material Design Icons (nf-mdi-*) were removed in Nerd Fonts v3; nf-md-* replaces them at U+F0001-U+F1AF0",
+	},
+}
+
+// deprecatedNamePattern finds references to removed glyphs by name, e.g. "nf-mdi-rocket" or "mdi_rocket"
+var deprecatedNamePattern = regexp.MustCompile(`\b(?:nf-)?mdi[-_]([a-z0-9_]+)`)
+
+// DeprecatedGlyph is an entry of the deprecated_glyphs table
+type DeprecatedGlyph struct {
+	Codepoint   rune
+	Name        string
+	Replacement string
+	Note        string
+}
+
+// MigrationIssue is a use of a glyph that moved or was removed in Nerd Fonts v3
+type MigrationIssue struct {
+	Path            string `json:"path"`
+	Line            int    `json:"line"`
+	Match           string `json:"match"`
+	Codepoint       string `json:"codepoint,omitempty"`
+	OldName         string `json:"oldName,omitempty"`
+	Replacement     *Glyph `json:"replacement,omitempty"`
+	ReplacementText string `json:"replacementText,omitempty"`
+	Note            string `json:"note"`
+}
+
+// MigrationReport is the result of AuditGlyphMigration
+type MigrationReport struct {
+	Root         string           `json:"root"`
+	FilesScanned int              `json:"filesScanned"`
+	FilesSkipped int              `json:"filesSkipped"`
+	Issues       []MigrationIssue `json:"issues"`
+	Fixable      int              `json:"fixable"`
+	PatchPath    string           `json:"patchPath,omitempty"`
+}
+
+// loadDeprecatedGlyphs reads the deprecated_glyphs table, which databases
+// generated without a deprecation list don't have
+func (a *App) loadDeprecatedGlyphs() (map[rune]DeprecatedGlyph, error) {
+	result := make(map[rune]DeprecatedGlyph)
+	if a.readDB == nil {
+		return result, nil
+	}
+	if exists, err := tableExists(a.readDB, "deprecated_glyphs"); err != nil || !exists {
+		return result, err
+	}
+
+	rows, err := a.readDB.Query("SELECT codepoint, name, COALESCE(replacement, ''), COALESCE(note, '') FROM deprecated_glyphs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deprecated glyphs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d DeprecatedGlyph
+		if err := rows.Scan(&d.Codepoint, &d.Name, &d.Replacement, &d.Note); err != nil {
+			continue
+		}
+		result[d.Codepoint] = d
+	}
+	return result, rows.Err()
+}
+
+// deprecatedRangeOf returns the removed block containing r
+func deprecatedRangeOf(r rune) (deprecatedRange, bool) {
+	for _, dr := range deprecatedRanges {
+		if r >= dr.start && r <= dr.end {
+			return dr, true
+		}
+	}
+	return deprecatedRange{}, false
+}
+
+// migrationAuditor finds deprecated glyphs and resolves their replacements
+type migrationAuditor struct {
+	exact  map[rune]DeprecatedGlyph
+	byName map[string]Glyph
+	issues []MigrationIssue
+}
+
+// lookupDeprecated resolves a code point to its old name and replacement glyph.
+// Without an exact table entry the old name is guessed from a name reference on
+// the same line, which configs often keep in a comment next to the glyph.
+func (m *migrationAuditor) lookupDeprecated(r rune, line string) (oldName string, replacement *Glyph, note string, ok bool) {
+	if d, found := m.exact[r]; found {
+		if g, exists := m.byName[d.Replacement]; exists {
+			replacement = &g
+		}
+		note = d.Note
+		if note == "" {
+			note = "Removed in Nerd Fonts v3"
+		}
+		return d.Name, replacement, note, true
+	}
+
+	dr, found := deprecatedRangeOf(r)
+	if !found {
+		return "", nil, "", false
+	}
+	if ref := deprecatedNamePattern.FindStringSubmatch(line); ref != nil {
+		oldName = dr.oldPrefix + ref[1]
+		if g, exists := m.byName[dr.newPrefix+ref[1]]; exists {
+			replacement = &g
+		}
+	}
+	return oldName, replacement, dr.note, true
+}
+
+// reformatEscape writes replacement in the same escape style as the matched
+// sequence; \uXXXX can't hold code points above U+FFFF so it becomes \UXXXXXXXX
+func reformatEscape(match string, r rune) string {
+	switch {
+	case strings.HasPrefix(match, `\u{`):
+		return fmt.Sprintf(`\u{%x}`, r)
+	case strings.HasPrefix(match, "&#x"):
+		return fmt.Sprintf("&#x%x;", r)
+	case r > 0xFFFF || strings.HasPrefix(match, `\U`):
+		return fmt.Sprintf(`\U%08x`, r)
+	default:
+		return fmt.Sprintf(`\u%04x`, r)
+	}
+}
+
+// add records an issue, filling in the replacement text when a replacement exists
+func (m *migrationAuditor) add(issue MigrationIssue, replacement *Glyph, escaped bool) {
+	if replacement != nil {
+		issue.Replacement = replacement
+		issue.ReplacementText = replacement.Glyph
+		if escaped {
+			issue.ReplacementText = reformatEscape(issue.Match, codepointOf(replacement.Glyph))
+		}
+	}
+	m.issues = append(m.issues, issue)
+}
+
+// auditFile records every deprecated glyph, escape and name reference in a file
+func (m *migrationAuditor) auditFile(rel string, data []byte) {
+	forEachLine(data, func(n int, line string) {
+		for _, r := range line {
+			if oldName, repl, note, ok := m.lookupDeprecated(r, line); ok {
+				m.add(MigrationIssue{Path: rel, Line: n, Match: string(r), Codepoint: formatCodepoint(r), OldName: oldName, Note: note}, repl, false)
+			}
+		}
+
+		for _, match := range glyphEscapePattern.FindAllString(line, -1) {
+			runes := escapedRunes(match)
+			if len(runes) != 1 {
+				continue
+			}
+			if oldName, repl, note, ok := m.lookupDeprecated(runes[0], line); ok {
+				m.add(MigrationIssue{Path: rel, Line: n, Match: match, Codepoint: formatCodepoint(runes[0]), OldName: oldName, Note: note}, repl, true)
+			}
+		}
+
+		for _, ref := range deprecatedNamePattern.FindAllStringSubmatch(line, -1) {
+			if !strings.HasPrefix(ref[0], "nf-") {
+				continue // bare "mdi_" names are only used as hints for code points
+			}
+			issue := MigrationIssue{Path: rel, Line: n, Match: ref[0], OldName: ref[0], Note: deprecatedRanges[0].note}
+			if g, exists := m.byName["nf-md-"+ref[1]]; exists {
+				issue.Replacement = &g
+				issue.ReplacementText = g.Name
+			}
+			m.issues = append(m.issues, issue)
+		}
+	})
+}
+
+// sedEscape escapes text for use in a sed s/// command; pattern also escapes regex metacharacters
+func sedEscape(text string, pattern bool) string {
+	special := `\/&`
+	if pattern {
+		special += `.[]*^$`
+	}
+	var b strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune(special, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// shellQuote wraps s in single quotes for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// migrationPatch renders a shell script applying every fixable issue with sed
+func migrationPatch(root string, issues []MigrationIssue) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&b, "# Generated by Gylte: Nerd Fonts v2 -> v3 glyph migration\n# Review before running; files are edited in place.\n")
+	fmt.Fprintf(&b, "cd %s || exit 1\n\n", shellQuote(root))
+
+	seen := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.ReplacementText == "" {
+			continue
+		}
+		// -i.bak is the in-place form GNU and BSD sed both accept
+		cmd := fmt.Sprintf("sed -i.bak %s %s && rm -f %s\n",
+			shellQuote(fmt.Sprintf("s/%s/%s/g", sedEscape(issue.Match, true), sedEscape(issue.ReplacementText, false))),
+			shellQuote(issue.Path), shellQuote(issue.Path+".bak"))
+		if !seen[cmd] {
+			seen[cmd] = true
+			b.WriteString(cmd)
+		}
+	}
+	return b.String()
+}
+
+// AuditGlyphMigration scans the text files under path for glyphs that moved or
+// were removed between Nerd Fonts v2 and v3 and suggests replacements. With
+// writePatch set, a sed script applying the fixable replacements is exported.
+func (a *App) AuditGlyphMigration(path string, writePatch bool) (*MigrationReport, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return nil, newAppError(ErrCodeInvalid, "not a directory: %s", root)
+	}
+
+	op := a.startOperation("scan", "Auditing glyphs for Nerd Fonts v3…")
+
+	exact, err := a.loadDeprecatedGlyphs()
+	if err != nil {
+		return nil, op.Fail("Could not audit the directory", err)
+	}
+	auditor := &migrationAuditor{exact: exact, byName: make(map[string]Glyph)}
+	for _, g := range a.allGlyphs() {
+		auditor.byName[g.Name] = g
+	}
+
+	report := &MigrationReport{Root: root}
+	report.FilesScanned, report.FilesSkipped, err = forEachTextFile(root, auditor.auditFile)
+	if err != nil {
+		return nil, op.Fail("Could not audit the directory", err)
+	}
+
+	report.Issues = auditor.issues
+	if report.Issues == nil {
+		report.Issues = []MigrationIssue{}
+	}
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		if report.Issues[i].Path != report.Issues[j].Path {
+			return report.Issues[i].Path < report.Issues[j].Path
+		}
+		return report.Issues[i].Line < report.Issues[j].Line
+	})
+	for _, issue := range report.Issues {
+		if issue.ReplacementText != "" {
+			report.Fixable++
+		}
+	}
+
+	if writePatch && report.Fixable > 0 {
+		report.PatchPath = a.defaultExportPath("glyph-migration", ".sh")
+		if err := os.MkdirAll(filepath.Dir(report.PatchPath), 0755); err != nil {
+			return nil, op.Fail("Could not write the patch", fmt.Errorf("failed to create directory: %w", err))
+		}
+		if err := os.WriteFile(report.PatchPath, []byte(migrationPatch(root, report.Issues)), 0755); err != nil {
+			return nil, op.Fail("Could not write the patch", fmt.Errorf("failed to write patch: %w", err))
+		}
+	}
+
+	log.Printf("Migration audit of %s: %d issues, %d fixable", root, len(report.Issues), report.Fixable)
+	op.Succeed(fmt.Sprintf("Found %d deprecated glyphs (%d fixable)", len(report.Issues), report.Fixable))
+	return report, nil
+}
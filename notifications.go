@@ -0,0 +1,87 @@
+package main
+
+import (
+	"log"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Notifications sends native OS notifications and tracks whether the window is visible
+type Notifications struct {
+	mu           sync.RWMutex
+	windowHidden bool
+}
+
+// notificationsEnabled reports whether the user allows native notifications
+func (a *App) notificationsEnabled() bool {
+	return a.settings.GetBool("notifications.enabled", true)
+}
+
+// isWindowHidden reports whether the main window is hidden or minimised
+func (a *App) isWindowHidden() bool {
+	a.notifications.mu.RLock()
+	hidden := a.notifications.windowHidden
+	a.notifications.mu.RUnlock()
+
+	if hidden {
+		return true
+	}
+	return a.hasRuntime() && runtime.WindowIsMinimised(a.ctx)
+}
+
+// notifyInBackground shows a native notification only when the user can't see the window
+func (a *App) notifyInBackground(title, body string) {
+	if !a.notificationsEnabled() || !a.isWindowHidden() || notificationSupport() != nil {
+		return
+	}
+	if err := sendNotification(title, body); err != nil {
+		log.Printf("Failed to send notification: %v", err)
+	}
+}
+
+// NotifyUser shows a native OS notification
+func (a *App) NotifyUser(title string, body string) error {
+	if title == "" {
+		return newAppError(ErrCodeInvalid, "notification title is required")
+	}
+	if !a.notificationsEnabled() {
+		return nil
+	}
+	if err := notificationSupport(); err != nil {
+		// The UI already shows its own message; a missing notifier isn't a failure
+		log.Printf("Notification not shown: %v", err)
+		return nil
+	}
+	return sendNotification(title, body)
+}
+
+// HideWindow hides the main window; background operations notify natively while hidden
+func (a *App) HideWindow() error {
+	if err := a.requireWindow("window"); err != nil {
+		return err
+	}
+
+	a.notifications.mu.Lock()
+	a.notifications.windowHidden = true
+	a.notifications.mu.Unlock()
+
+	runtime.WindowHide(a.ctx)
+	return nil
+}
+
+// ShowWindow shows and focuses the main window
+func (a *App) ShowWindow() error {
+	if err := a.requireWindow("window"); err != nil {
+		return err
+	}
+
+	a.notifications.mu.Lock()
+	a.notifications.windowHidden = false
+	a.notifications.mu.Unlock()
+
+	a.rememberTargetApp()
+	runtime.WindowShow(a.ctx)
+	runtime.WindowUnminimise(a.ctx)
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// notificationSupport reports why notifications can't be shown, or nil if they can
+func notificationSupport() error {
+	if !hasCommand("osascript") {
+		return fmt.Errorf("notifications need osascript")
+	}
+	return nil
+}
+
+// sendNotification shows a Notification Center alert through osascript
+func sendNotification(title, body string) error {
+	script := "display notification " + appleScriptString(body) + " with title " + appleScriptString(title)
+	return exec.Command("osascript", "-e", script).Run()
+}
@@ -0,0 +1,25 @@
+//go:build !windows && !darwin
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// notificationSupport reports why notifications can't be shown, or nil if they can
+func notificationSupport() error {
+	if !hasCommand("notify-send") {
+		return fmt.Errorf("notifications need notify-send (libnotify)")
+	}
+	return nil
+}
+
+// sendNotification shows a desktop notification through notify-send (libnotify)
+func sendNotification(title, body string) error {
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return fmt.Errorf("notify-send not available: %w", err)
+	}
+	return exec.Command(path, "--app-name=Gylte", title, body).Run()
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// toastScript shows a toast through the WinRT notification API. Title and body
+// are passed via environment variables so they never need PowerShell escaping.
+const toastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName("text")
+$text.Item(0).AppendChild($template.CreateTextNode($env:GYLTE_TITLE)) | Out-Null
+$text.Item(1).AppendChild($template.CreateTextNode($env:GYLTE_BODY)) | Out-Null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier("Gylte").Show($toast)
+`
+
+// notificationSupport reports why notifications can't be shown, or nil if they can
+func notificationSupport() error {
+	if !hasCommand("powershell") {
+		return fmt.Errorf("notifications need PowerShell")
+	}
+	return nil
+}
+
+// sendNotification shows a Windows toast notification through PowerShell
+func sendNotification(title, body string) error {
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", toastScript)
+	cmd.Env = append(os.Environ(), "GYLTE_TITLE="+title, "GYLTE_BODY="+body)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	return cmd.Run()
+}
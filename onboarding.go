@@ -0,0 +1,134 @@
+package main
+
+import (
+	"log"
+	"time"
+)
+
+// Onboarding steps, in the order the guided setup presents them
+const (
+	StepDatabaseProvisioned = "db_provisioned"
+	StepFontDetected        = "font_detected"
+	StepHotkeyConfigured    = "hotkey_configured"
+	StepTourCompleted       = "tour_completed"
+)
+
+var onboardingSteps = []string{
+	StepDatabaseProvisioned,
+	StepFontDetected,
+	StepHotkeyConfigured,
+	StepTourCompleted,
+}
+
+// OnboardingStep reports the state of a single first-run step
+type OnboardingStep struct {
+	ID          string `json:"id"`
+	Completed   bool   `json:"completed"`
+	CompletedAt string `json:"completedAt,omitempty"`
+}
+
+// OnboardingState is the full first-run progress
+type OnboardingState struct {
+	Steps       []OnboardingStep `json:"steps"`
+	CurrentStep string           `json:"currentStep"`
+	Completed   bool             `json:"completed"`
+}
+
+// onboardingKey returns the settings key recording when a step was completed
+func onboardingKey(step string) string {
+	return "onboarding." + step
+}
+
+// isOnboardingStep reports whether id names a known step
+func isOnboardingStep(id string) bool {
+	for _, step := range onboardingSteps {
+		if step == id {
+			return true
+		}
+	}
+	return false
+}
+
+// completeOnboardingStep records a step as completed if it isn't already
+func (a *App) completeOnboardingStep(step string) error {
+	if a.settings.Get(onboardingKey(step), "") != "" {
+		return nil
+	}
+	if err := a.settings.Set(onboardingKey(step), time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	a.emit("onboarding:changed", a.GetOnboardingState())
+	return nil
+}
+
+// detectOnboardingSteps completes the steps that can be verified automatically
+func (a *App) detectOnboardingSteps() {
+	provisioned := a.cache.Loaded() && a.cache.Len() > 0
+
+	if provisioned {
+		if err := a.completeOnboardingStep(StepDatabaseProvisioned); err != nil {
+			log.Printf("Failed to record onboarding step: %v", err)
+		}
+	}
+
+	if fonts := detectNerdFonts(); len(fonts) > 0 {
+		if a.settings.Get("font.path", "") == "" {
+			if err := a.settings.Set("font.path", fonts[0]); err != nil {
+				log.Printf("Failed to save detected font: %v", err)
+			}
+		}
+		if err := a.completeOnboardingStep(StepFontDetected); err != nil {
+			log.Printf("Failed to record onboarding step: %v", err)
+		}
+	}
+}
+
+// GetOnboardingState returns first-run progress
+func (a *App) GetOnboardingState() *OnboardingState {
+	state := &OnboardingState{Steps: make([]OnboardingStep, 0, len(onboardingSteps))}
+
+	for _, id := range onboardingSteps {
+		completedAt := a.settings.Get(onboardingKey(id), "")
+		state.Steps = append(state.Steps, OnboardingStep{
+			ID:          id,
+			Completed:   completedAt != "",
+			CompletedAt: completedAt,
+		})
+		if completedAt == "" && state.CurrentStep == "" {
+			state.CurrentStep = id
+		}
+	}
+
+	state.Completed = state.CurrentStep == ""
+	return state
+}
+
+// CompleteOnboardingStep marks a first-run step as done
+func (a *App) CompleteOnboardingStep(step string) (*OnboardingState, error) {
+	if err := a.checkWritable("change settings"); err != nil {
+		return nil, err
+	}
+
+	if !isOnboardingStep(step) {
+		return nil, newAppError(ErrCodeInvalid, "unknown onboarding step: %s", step)
+	}
+	if err := a.completeOnboardingStep(step); err != nil {
+		return nil, err
+	}
+	return a.GetOnboardingState(), nil
+}
+
+// ResetOnboarding clears all first-run progress so the guided setup runs again
+func (a *App) ResetOnboarding() (*OnboardingState, error) {
+	if err := a.checkWritable("change settings"); err != nil {
+		return nil, err
+	}
+
+	for _, step := range onboardingSteps {
+		if err := a.settings.Set(onboardingKey(step), ""); err != nil {
+			return nil, err
+		}
+	}
+	a.emit("onboarding:changed", a.GetOnboardingState())
+	return a.GetOnboardingState(), nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+)
+
+// openWithSystem opens a file or folder with the OS default handler
+func openWithSystem(path string) error {
+	var cmd *exec.Cmd
+	switch goruntime.GOOS {
+	case "windows":
+		cmd = exec.Command("explorer", path)
+	case "darwin":
+		cmd = exec.Command("open", path)
+	default:
+		cmd = exec.Command("xdg-open", path)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	// Reap the opener process without blocking the caller
+	go cmd.Wait()
+	return nil
+}
+
+// OpenDataFolder opens the folder containing gylte.db
+func (a *App) OpenDataFolder() error {
+	return openWithSystem(a.dataDir())
+}
+
+// OpenLogFile opens the application log in the default text viewer
+func (a *App) OpenLogFile() error {
+	path := a.logPath()
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("log file not found: %w", err)
+	}
+	return openWithSystem(path)
+}
+
+// withinDir reports whether path resolves to a file inside dir, following
+// symlinks so a link can't point outside it
+func withinDir(dir, path string) bool {
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return false
+	}
+	target, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	if target, err = filepath.EvalSymlinks(target); err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(root, target)
+	if err != nil || rel == "." || rel == ".." {
+		return false
+	}
+	return !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// OpenExportedFile opens a previously exported file. Only files in the
+// exports folder can be opened, so the binding can't launch arbitrary programs.
+func (a *App) OpenExportedFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("exported file not found: %w", err)
+	}
+	if info.IsDir() {
+		return newAppError(ErrCodeInvalid, "%s is a directory", path)
+	}
+	if !withinDir(a.exportsDir(), path) {
+		return invalidArgument("path", "%s is not in the exports folder", path)
+	}
+	return openWithSystem(path)
+}
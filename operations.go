@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Operation event statuses
+const (
+	OperationStarted  = "started"
+	OperationProgress = "progress"
+	OperationSuccess  = "success"
+	OperationError    = "error"
+)
+
+// EventOperation is emitted whenever a long-running or destructive operation changes state.
+// The frontend renders these as toasts keyed by ID, so later events replace earlier ones;
+// the taskbar button or dock icon shows the same progress (see refreshProgressBadge).
+const EventOperation = "operation"
+
+// OperationEvent is the notification protocol payload for operation toasts
+type OperationEvent struct {
+	ID        string  `json:"id"`
+	Kind      string  `json:"kind"`
+	Status    string  `json:"status"`
+	Message   string  `json:"message"`
+	Progress  float64 `json:"progress"` // 0-100, or -1 when indeterminate
+	Error     string  `json:"error,omitempty"`
+	Timestamp int64   `json:"timestamp"`
+}
+
+// Operation tracks a single running operation and reports its state changes
+type Operation struct {
+	app   *App
+	seq   int64
+	mu    sync.Mutex
+	event OperationEvent
+}
+
+// Operations keeps the set of operations that have not finished yet
+type Operations struct {
+	mu     sync.RWMutex
+	active map[string]*Operation
+	nextID atomic.Int64
+}
+
+// NewOperations creates an empty operation tracker
+func NewOperations() *Operations {
+	return &Operations{active: make(map[string]*Operation)}
+}
+
+// startOperation begins tracking an operation of the given kind (e.g. "export")
+func (a *App) startOperation(kind, message string) *Operation {
+	seq := a.operations.nextID.Add(1)
+	op := &Operation{
+		app: a,
+		seq: seq,
+		event: OperationEvent{
+			ID:       fmt.Sprintf("%s-%d", kind, seq),
+			Kind:     kind,
+			Status:   OperationStarted,
+			Message:  message,
+			Progress: -1,
+		},
+	}
+
+	a.operations.mu.Lock()
+	a.operations.active[op.event.ID] = op
+	a.operations.mu.Unlock()
+
+	op.publish()
+	return op
+}
+
+// publish emits the current state of the operation
+func (op *Operation) publish() {
+	op.mu.Lock()
+	op.event.Timestamp = time.Now().UnixMilli()
+	event := op.event
+	op.mu.Unlock()
+
+	op.app.emit(EventOperation, event)
+	op.app.refreshProgressBadge(event.Status == OperationError)
+}
+
+// finish removes the operation from the active set and publishes its final state
+func (op *Operation) finish() {
+	op.app.operations.mu.Lock()
+	delete(op.app.operations.active, op.event.ID)
+	op.app.operations.mu.Unlock()
+
+	op.publish()
+
+	op.mu.Lock()
+	message := op.event.Message
+	op.mu.Unlock()
+	op.app.notifyInBackground("Gylte", message)
+}
+
+// Progress reports completion percentage (0-100) with an optional new message
+func (op *Operation) Progress(percent float64, message string) {
+	op.mu.Lock()
+	op.event.Status = OperationProgress
+	op.event.Progress = percent
+	if message != "" {
+		op.event.Message = message
+	}
+	op.mu.Unlock()
+
+	op.publish()
+}
+
+// Succeed marks the operation as completed
+func (op *Operation) Succeed(message string) {
+	op.mu.Lock()
+	op.event.Status = OperationSuccess
+	op.event.Progress = 100
+	op.event.Message = message
+	op.mu.Unlock()
+
+	op.finish()
+}
+
+// Fail marks the operation as failed, logging the error, and returns it for convenience
+func (op *Operation) Fail(message string, err error) error {
+	op.mu.Lock()
+	op.event.Status = OperationError
+	op.event.Message = message
+	if err != nil {
+		op.event.Error = err.Error()
+	}
+	op.mu.Unlock()
+
+	log.Printf("%s: %v", message, err)
+	op.finish()
+	return err
+}
+
+// GetActiveOperations returns operations that are still running
+func (a *App) GetActiveOperations() []OperationEvent {
+	a.operations.mu.RLock()
+	ops := make([]*Operation, 0, len(a.operations.active))
+	for _, op := range a.operations.active {
+		ops = append(ops, op)
+	}
+	a.operations.mu.RUnlock()
+
+	// In start order; ids sort wrongly as strings ("export-10" < "export-9")
+	sort.Slice(ops, func(i, j int) bool { return ops[i].seq < ops[j].seq })
+	result := make([]OperationEvent, 0, len(ops))
+	for _, op := range ops {
+		op.mu.Lock()
+		result = append(result, op.event)
+		op.mu.Unlock()
+	}
+	return result
+}
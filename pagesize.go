@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strconv"
+)
+
+// Bounds and default for the "search.pageSize" setting
+const (
+	defaultPageSize = 50
+	minPageSize     = 10
+	maxPageSize     = 500
+)
+
+// pageSizeSetting is the settings key holding the result page size
+const pageSizeSetting = "search.pageSize"
+
+// EventPageSizeChanged is emitted with the new page size after it changes
+const EventPageSizeChanged = "pagesize:changed"
+
+// PageSize describes the result page size and the values it may take
+type PageSize struct {
+	Size    int `json:"size"`
+	Min     int `json:"min"`
+	Max     int `json:"max"`
+	Default int `json:"default"`
+}
+
+// validatePageSize checks a page size against its bounds
+func validatePageSize(size int) error {
+	if size < minPageSize || size > maxPageSize {
+		return newAppError(ErrCodeInvalid, "page size must be between %d and %d", minPageSize, maxPageSize)
+	}
+	return nil
+}
+
+// pageSize returns the configured page size, falling back to the default when
+// the stored value is missing or out of bounds
+func (a *App) pageSize() int {
+	size := a.settings.GetInt(pageSizeSetting, defaultPageSize)
+	if validatePageSize(size) != nil {
+		return defaultPageSize
+	}
+	return size
+}
+
+// GetPageSize returns the number of results loaded per page and its bounds
+func (a *App) GetPageSize() PageSize {
+	return PageSize{Size: a.pageSize(), Min: minPageSize, Max: maxPageSize, Default: defaultPageSize}
+}
+
+// SetPageSize changes the number of results loaded per page
+func (a *App) SetPageSize(size int) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if err := validatePageSize(size); err != nil {
+		return err
+	}
+	if err := a.settings.Set(pageSizeSetting, strconv.Itoa(size)); err != nil {
+		return err
+	}
+	a.emit(EventPageSizeChanged, size)
+	return nil
+}
@@ -0,0 +1,659 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Gylte/internal/glyphdata"
+)
+
+// Plugin capabilities a manifest may declare
+const (
+	PluginKindSource     = "source"
+	PluginKindExporter   = "exporter"
+	PluginKindCopyFormat = "copyformat"
+)
+
+const (
+	// pluginManifestName is the manifest file inside each plugin directory
+	pluginManifestName = "plugin.json"
+
+	// pluginTimeout bounds a single plugin call
+	pluginTimeout = 15 * time.Second
+
+	// pluginMaxOutput caps what a plugin may write to stdout per call
+	pluginMaxOutput = 64 << 20
+
+	// pluginMaxFailures disables a plugin for the session after this many
+	// consecutive failed calls
+	pluginMaxFailures = 3
+
+	// pluginSlotBase keeps plugin glyph ids clear of attached database slots
+	pluginSlotBase = 100
+)
+
+// EventPluginsChanged is emitted with the plugin list after a plugin is enabled or disabled
+const EventPluginsChanged = "plugins:changed"
+
+// PluginExporter is an export format provided by a plugin
+type PluginExporter struct {
+	Name       string   `json:"name"`
+	Extensions []string `json:"extensions"`
+}
+
+// PluginManifest is a plugin's plugin.json
+type PluginManifest struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+
+	// Command runs the plugin; relative paths resolve against the plugin directory
+	Command []string `json:"command"`
+
+	Provides    []string         `json:"provides"`
+	Exporters   []PluginExporter `json:"exporters,omitempty"`
+	CopyFormats []string         `json:"copyFormats,omitempty"`
+}
+
+// provides reports whether the manifest declares a capability
+func (m PluginManifest) provides(kind string) bool {
+	for _, k := range m.Provides {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// PluginInfo describes a discovered plugin for the settings UI
+type PluginInfo struct {
+	PluginManifest
+	Dir       string `json:"dir"`
+	Enabled   bool   `json:"enabled"`
+	Failed    bool   `json:"failed"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+// pluginState is the persisted state of one plugin
+type pluginState struct {
+	Enabled bool `json:"enabled"`
+	Slot    int  `json:"slot"`
+}
+
+// plugin is a discovered plugin and its health for this session
+type plugin struct {
+	manifest  PluginManifest
+	dir       string
+	state     pluginState
+	failures  int
+	lastError string
+}
+
+// failed reports whether the plugin was disabled after repeated errors
+func (p *plugin) failed() bool {
+	return p.failures >= pluginMaxFailures
+}
+
+// Plugins hosts external glyph providers, exporters, and copy formats. Each
+// call runs the plugin's command as a subprocess, so a crashing or hanging
+// plugin can't take the app down with it.
+type Plugins struct {
+	mu      sync.Mutex
+	plugins map[string]*plugin
+	formats map[string]string // copy format -> plugin name
+
+	// rejected lists plugins that were not loaded because they share a name
+	rejected []PluginInfo
+}
+
+// pluginRequest is written to a plugin's stdin
+type pluginRequest struct {
+	Method string `json:"method"`
+	Params any    `json:"params,omitempty"`
+}
+
+// pluginResponse is read from a plugin's stdout
+type pluginResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+}
+
+// pluginGlyph is a glyph returned by a source plugin
+type pluginGlyph struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Glyph       string `json:"glyph"`
+	Description string `json:"description"`
+}
+
+// pluginExport is the result of an export call; binary formats are base64 encoded
+type pluginExport struct {
+	Output   string `json:"output"`
+	Encoding string `json:"encoding"`
+}
+
+// pluginsDir returns the directory scanned for plugins
+func (a *App) pluginsDir() string {
+	return filepath.Join(a.dataDir(), "plugins")
+}
+
+// pluginStates returns the persisted plugin states of the active profile
+func (a *App) pluginStates() map[string]pluginState {
+	states := make(map[string]pluginState)
+	if raw := a.settings.Get("plugins.state", ""); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &states); err != nil {
+			log.Printf("Failed to parse plugin state: %v", err)
+		}
+	}
+	return states
+}
+
+// savePluginStates persists the plugin states
+func (a *App) savePluginStates(states map[string]pluginState) error {
+	data, err := json.Marshal(states)
+	if err != nil {
+		return err
+	}
+	return a.settings.Set("plugins.state", string(data))
+}
+
+// readPluginManifest loads and checks the manifest in dir
+func readPluginManifest(dir string) (PluginManifest, error) {
+	var m PluginManifest
+	data, err := os.ReadFile(filepath.Join(dir, pluginManifestName))
+	if err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("invalid %s: %w", pluginManifestName, err)
+	}
+	if m.Name == "" {
+		m.Name = filepath.Base(dir)
+	}
+	if len(m.Command) == 0 {
+		return m, fmt.Errorf("plugin %s has no command", m.Name)
+	}
+	for _, kind := range m.Provides {
+		switch kind {
+		case PluginKindSource, PluginKindExporter, PluginKindCopyFormat:
+		default:
+			return m, fmt.Errorf("plugin %s provides unknown kind %q", m.Name, kind)
+		}
+	}
+	return m, nil
+}
+
+// loadPlugins discovers plugins in the plugins directory and registers the
+// exporters and copy formats of enabled ones. Glyph sources are merged by preloadCache.
+func (a *App) loadPlugins() {
+	a.plugins.mu.Lock()
+	for _, p := range a.plugins.plugins {
+		for _, e := range p.manifest.Exporters {
+			if a.pluginOwnsExporter(e.Name, p.manifest.Name) {
+				a.exporters.Unregister(e.Name)
+			}
+		}
+	}
+	a.plugins.plugins = make(map[string]*plugin)
+	a.plugins.formats = make(map[string]string)
+	a.plugins.rejected = nil
+	a.plugins.mu.Unlock()
+
+	entries, err := os.ReadDir(a.pluginsDir())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Failed to read plugins directory: %v", err)
+		}
+		return
+	}
+
+	var found []*plugin
+	dirs := make(map[string][]string) // plugin name -> directories using it
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(a.pluginsDir(), entry.Name())
+		m, err := readPluginManifest(dir)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				log.Printf("Skipping plugin %s: %v", entry.Name(), err)
+			}
+			continue
+		}
+		found = append(found, &plugin{manifest: m, dir: dir})
+		dirs[m.Name] = append(dirs[m.Name], dir)
+	}
+
+	states := a.pluginStates()
+	a.plugins.mu.Lock()
+	defer a.plugins.mu.Unlock()
+
+	for _, p := range found {
+		// Plugin state, glyph ids, and formats are keyed by name, so plugins
+		// sharing one would shadow each other; load neither
+		if same := dirs[p.manifest.Name]; len(same) > 1 {
+			msg := fmt.Sprintf("plugin name %s is used by %d plugins: %s", p.manifest.Name, len(same), strings.Join(same, ", "))
+			log.Printf("Skipping plugin %s: %s", p.dir, msg)
+			a.plugins.rejected = append(a.plugins.rejected, PluginInfo{
+				PluginManifest: p.manifest,
+				Dir:            p.dir,
+				Failed:         true,
+				LastError:      msg,
+			})
+			continue
+		}
+
+		p.state = states[p.manifest.Name]
+		a.plugins.plugins[p.manifest.Name] = p
+		if p.state.Enabled {
+			a.activatePlugin(p)
+		}
+	}
+	log.Printf("Plugins: %d found in %s", len(a.plugins.plugins), a.pluginsDir())
+}
+
+// activatePlugin registers an enabled plugin's exporters and copy formats;
+// callers hold plugins.mu
+func (a *App) activatePlugin(p *plugin) {
+	if p.manifest.provides(PluginKindExporter) {
+		for _, e := range p.manifest.Exporters {
+			if _, err := a.exporters.Get(e.Name); err == nil {
+				log.Printf("Plugin %s: export format %s already exists", p.manifest.Name, e.Name)
+				continue
+			}
+			a.exporters.Register(a.pluginExporter(p.manifest.Name, e))
+		}
+	}
+	if p.manifest.provides(PluginKindCopyFormat) {
+		for _, f := range p.manifest.CopyFormats {
+			if _, builtin := docFormats[f]; builtin {
+				log.Printf("Plugin %s: copy format %s already exists", p.manifest.Name, f)
+				continue
+			}
+			if _, taken := a.plugins.formats[f]; !taken {
+				a.plugins.formats[f] = p.manifest.Name
+			}
+		}
+	}
+}
+
+// deactivatePlugin removes a plugin's exporters and copy formats; callers hold plugins.mu
+func (a *App) deactivatePlugin(p *plugin) {
+	for _, e := range p.manifest.Exporters {
+		if a.pluginOwnsExporter(e.Name, p.manifest.Name) {
+			a.exporters.Unregister(e.Name)
+		}
+	}
+	for f, owner := range a.plugins.formats {
+		if owner == p.manifest.Name {
+			delete(a.plugins.formats, f)
+		}
+	}
+}
+
+// pluginOwnsExporter reports whether the registered exporter for format came from the named plugin
+func (a *App) pluginOwnsExporter(format, name string) bool {
+	e, err := a.exporters.Get(format)
+	if err != nil {
+		return false
+	}
+	pe, ok := e.(pluginExporter)
+	return ok && pe.plugin == name
+}
+
+// pluginExporter is an Exporter backed by a plugin subprocess
+type pluginExporter struct {
+	exporterFunc
+	plugin string
+}
+
+// pluginExporter adapts a plugin's export format to the Exporter interface
+func (a *App) pluginExporter(name string, e PluginExporter) Exporter {
+	extensions := e.Extensions
+	if len(extensions) == 0 {
+		extensions = []string{"." + e.Name}
+	}
+	return pluginExporter{
+		plugin: name,
+		exporterFunc: exporterFunc{
+			name:       e.Name,
+			extensions: extensions,
+			export: func(w io.Writer, glyphs []Glyph, opts ExportOptions) error {
+				opts.progress(fmt.Sprintf("Running plugin %s…", name))
+				var result pluginExport
+				params := map[string]any{"format": e.Name, "title": opts.Title, "glyphs": glyphs}
+				if err := a.callPlugin(name, "export", params, &result); err != nil {
+					return err
+				}
+				data := []byte(result.Output)
+				if result.Encoding == "base64" {
+					decoded, err := base64.StdEncoding.DecodeString(result.Output)
+					if err != nil {
+						return fmt.Errorf("plugin %s returned invalid base64: %w", name, err)
+					}
+					data = decoded
+				}
+				_, err := w.Write(data)
+				return err
+			},
+		},
+	}
+}
+
+// callPlugin runs one request against a plugin and decodes its result into out.
+// Failures are recorded against the plugin; after pluginMaxFailures in a row
+// it is skipped for the rest of the session.
+func (a *App) callPlugin(name, method string, params, out any) error {
+	a.plugins.mu.Lock()
+	p, ok := a.plugins.plugins[name]
+	if !ok || !p.state.Enabled {
+		a.plugins.mu.Unlock()
+		return fmt.Errorf("plugin %s is not enabled", name)
+	}
+	if p.failed() {
+		a.plugins.mu.Unlock()
+		return fmt.Errorf("plugin %s was disabled after repeated errors: %s", name, p.lastError)
+	}
+	manifest, dir := p.manifest, p.dir
+	a.plugins.mu.Unlock()
+
+	err := runPlugin(manifest, dir, method, params, out)
+
+	a.plugins.mu.Lock()
+	defer a.plugins.mu.Unlock()
+	if err != nil {
+		p.failures++
+		p.lastError = err.Error()
+		log.Printf("Plugin %s %s failed (%d/%d): %v", name, method, p.failures, pluginMaxFailures, err)
+		return err
+	}
+	p.failures = 0
+	p.lastError = ""
+	return nil
+}
+
+// runPlugin starts the plugin, writes the request to its stdin, and decodes
+// the JSON response from its stdout
+func runPlugin(m PluginManifest, dir, method string, params, out any) error {
+	request, err := json.Marshal(pluginRequest{Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+	defer cancel()
+
+	command := m.Command[0]
+	if !filepath.IsAbs(command) && strings.ContainsRune(command, filepath.Separator) {
+		command = filepath.Join(dir, command)
+	}
+	cmd := exec.CommandContext(ctx, command, m.Command[1:]...)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(request)
+	var stdout limitedBuffer
+	stdout.limit = pluginMaxOutput
+	var stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("plugin %s timed out after %s", m.Name, pluginTimeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("plugin %s failed: %w: %s", m.Name, err, lastLine(msg))
+		}
+		return fmt.Errorf("plugin %s failed: %w", m.Name, err)
+	}
+	if stdout.overflow {
+		return fmt.Errorf("plugin %s output exceeds %d bytes", m.Name, pluginMaxOutput)
+	}
+
+	var resp pluginResponse
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return fmt.Errorf("plugin %s returned invalid JSON: %w", m.Name, err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("plugin %s: %s", m.Name, resp.Error)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, out); err != nil {
+		return fmt.Errorf("plugin %s returned an unexpected result: %w", m.Name, err)
+	}
+	return nil
+}
+
+// limitedBuffer collects output up to limit bytes and drops the rest
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); len(p) > room {
+		b.overflow = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// lastLine returns the final line of multi-line output, where errors usually are
+func lastLine(s string) string {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
+
+// loadPluginGlyphs merges the glyphs of enabled source plugins. Like attached
+// databases, names already present win, and ids are offset by the plugin's slot.
+func (a *App) loadPluginGlyphs(glyphs []Glyph) []Glyph {
+	a.plugins.mu.Lock()
+	var sources []*plugin
+	for _, p := range a.plugins.plugins {
+		if p.state.Enabled && p.manifest.provides(PluginKindSource) {
+			sources = append(sources, p)
+		}
+	}
+	a.plugins.mu.Unlock()
+	if len(sources) == 0 {
+		return glyphs
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].state.Slot < sources[j].state.Slot
+	})
+
+	seen := make(map[string]bool, len(glyphs))
+	for _, g := range glyphs {
+		seen[g.Name] = true
+	}
+
+	// Call the plugins at once so a slow one delays the cache by its own
+	// timeout at most, not by the sum of all of them
+	results := make([][]pluginGlyph, len(sources))
+	ok := make([]bool, len(sources))
+	var wg sync.WaitGroup
+	for i, p := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok[i] = a.callPlugin(p.manifest.Name, "glyphs", nil, &results[i]) == nil
+		}()
+	}
+	wg.Wait()
+
+	merged := false
+	for i, p := range sources {
+		if !ok[i] {
+			continue
+		}
+		extra := results[i]
+
+		added := 0
+		for i, pg := range extra {
+			if pg.Name == "" || pg.Glyph == "" || seen[pg.Name] {
+				continue
+			}
+			id := pg.ID
+			if id <= 0 || id >= sourceIDStride {
+				id = i + 1
+			}
+			g := Glyph{
+				ID:          id + p.state.Slot*sourceIDStride,
+				Name:        pg.Name,
+				Glyph:       pg.Glyph,
+				Description: pg.Description,
+				Source:      p.manifest.Name,
+				Block:       glyphdata.BlockOf(codepointOf(pg.Glyph)),
+			}
+			if g.Description == "" {
+				g.Description = describeGlyph(g)
+			}
+			seen[g.Name] = true
+			glyphs = append(glyphs, g)
+			added++
+			merged = true
+		}
+		log.Printf("Plugin %s: %d glyphs", p.manifest.Name, added)
+	}
+
+	if merged {
+		sort.SliceStable(glyphs, func(i, j int) bool {
+			return glyphs[i].Name < glyphs[j].Name
+		})
+	}
+	return glyphs
+}
+
+// pluginCopyFormat returns the plugin providing a copy format, if any
+func (a *App) pluginCopyFormat(format string) (string, bool) {
+	a.plugins.mu.Lock()
+	defer a.plugins.mu.Unlock()
+	name, ok := a.plugins.formats[format]
+	return name, ok
+}
+
+// formatWithPlugin renders glyphs in a plugin's copy format
+func (a *App) formatWithPlugin(name, format string, glyphs []Glyph) (string, error) {
+	var text string
+	params := map[string]any{"format": format, "glyphs": glyphs}
+	if err := a.callPlugin(name, "format", params, &text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// ListPlugins returns the plugins found in the plugins directory
+func (a *App) ListPlugins() []PluginInfo {
+	a.plugins.mu.Lock()
+	defer a.plugins.mu.Unlock()
+
+	result := make([]PluginInfo, 0, len(a.plugins.plugins)+len(a.plugins.rejected))
+	result = append(result, a.plugins.rejected...)
+	for _, p := range a.plugins.plugins {
+		result = append(result, PluginInfo{
+			PluginManifest: p.manifest,
+			Dir:            p.dir,
+			Enabled:        p.state.Enabled,
+			Failed:         p.failed(),
+			LastError:      p.lastError,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Dir < result[j].Dir
+	})
+	return result
+}
+
+// EnablePlugin turns a plugin on or off. Plugins run as separate processes
+// with the user's permissions, so they are never enabled automatically.
+func (a *App) EnablePlugin(name string, enabled bool) error {
+	if err := a.checkWritable("change plugins"); err != nil {
+		return err
+	}
+
+	states := a.pluginStates()
+	a.plugins.mu.Lock()
+	p, ok := a.plugins.plugins[name]
+	if !ok {
+		a.plugins.mu.Unlock()
+		return fmt.Errorf("plugin not found: %s", name)
+	}
+
+	state := states[name]
+	if state.Slot == 0 {
+		state.Slot = pluginSlotBase
+		for _, s := range states {
+			if s.Slot >= state.Slot {
+				state.Slot = s.Slot + 1
+			}
+		}
+	}
+	state.Enabled = enabled
+	states[name] = state
+
+	p.state = state
+	p.failures = 0
+	p.lastError = ""
+	if enabled {
+		a.activatePlugin(p)
+	} else {
+		a.deactivatePlugin(p)
+	}
+	source := p.manifest.provides(PluginKindSource)
+	a.plugins.mu.Unlock()
+
+	if err := a.savePluginStates(states); err != nil {
+		return fmt.Errorf("failed to save plugin state: %w", err)
+	}
+	log.Printf("Plugin %s enabled: %v", name, enabled)
+	a.emit(EventPluginsChanged, a.ListPlugins())
+
+	if source {
+		return a.RebuildCache()
+	}
+	return nil
+}
+
+// ReloadPlugins rescans the plugins directory and reloads glyph sources
+func (a *App) ReloadPlugins() ([]PluginInfo, error) {
+	a.loadPlugins()
+	if err := a.RebuildCache(); err != nil {
+		return nil, err
+	}
+	plugins := a.ListPlugins()
+	a.emit(EventPluginsChanged, plugins)
+	return plugins, nil
+}
+
+// OpenPluginsFolder creates the plugins directory if needed and opens it in the file manager
+func (a *App) OpenPluginsFolder() error {
+	dir := a.pluginsDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create plugins directory: %w", err)
+	}
+	return openWithSystem(dir)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// previewPlaceholder marks where the glyph goes in a preview template
+const previewPlaceholder = "{g}"
+
+// maxPreviewTemplates caps the recently copied preview templates
+const maxPreviewTemplates = 10
+
+// defaultPreviewTemplates are offered before any template has been copied
+var defaultPreviewTemplates = []string{
+	"{g} main ",
+	"❯ {g} ",
+	" {g} ~/projects ",
+	"{g} {g} {g}",
+}
+
+// renderPreview substitutes a glyph into a template; templates without
+// a placeholder get the glyph prepended
+func renderPreview(tmpl, glyph string) string {
+	if !strings.Contains(tmpl, previewPlaceholder) {
+		return glyph + " " + tmpl
+	}
+	return strings.ReplaceAll(tmpl, previewPlaceholder, glyph)
+}
+
+// RenderPreview composes a glyph into a context string such as a shell
+// prompt segment, so users can see how it looks before copying
+func (a *App) RenderPreview(template string, glyphID int) (string, error) {
+	g, ok := a.findGlyph(glyphID)
+	if !ok {
+		return "", newAppError(ErrCodeNotFound, "glyph %d not found", glyphID)
+	}
+	return renderPreview(template, g.Glyph), nil
+}
+
+// CopyPreview copies the composed preview string and remembers the template
+func (a *App) CopyPreview(template string, glyphID int) (string, error) {
+	text, err := a.RenderPreview(template, glyphID)
+	if err != nil {
+		return "", err
+	}
+	if _, err := a.setClipboard(text); err != nil {
+		return "", fmt.Errorf("failed to copy preview: %w", err)
+	}
+	a.recordCopy(glyphID)
+	a.rememberPreviewTemplate(template)
+	return text, nil
+}
+
+// GetPreviewTemplates returns recently copied templates, most recent first
+func (a *App) GetPreviewTemplates() []string {
+	raw := a.settings.Get("preview.templates", "")
+	if raw == "" {
+		return defaultPreviewTemplates
+	}
+	var templates []string
+	if err := json.Unmarshal([]byte(raw), &templates); err != nil {
+		log.Printf("Failed to parse preview templates: %v", err)
+		return defaultPreviewTemplates
+	}
+	return templates
+}
+
+// rememberPreviewTemplate moves a template to the front of the recent list
+func (a *App) rememberPreviewTemplate(template string) {
+	if strings.TrimSpace(template) == "" || a.checkWritable("save preview templates") != nil {
+		return
+	}
+
+	templates := []string{template}
+	for _, t := range a.GetPreviewTemplates() {
+		if t != template && len(templates) < maxPreviewTemplates {
+			templates = append(templates, t)
+		}
+	}
+	data, err := json.Marshal(templates)
+	if err != nil {
+		return
+	}
+	if err := a.settings.Set("preview.templates", string(data)); err != nil {
+		log.Printf("Failed to save preview templates: %v", err)
+	}
+}
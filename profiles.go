@@ -0,0 +1,313 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultProfile keeps its user data in the main database for backwards compatibility
+const defaultProfile = "default"
+
+// profileNamePattern restricts profile names to safe file names
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 _-]{0,63}$`)
+
+// Profile describes a named set of user data
+type Profile struct {
+	Name      string `json:"name"`
+	Active    bool   `json:"active"`
+	Encrypted bool   `json:"encrypted"`
+	Unlocked  bool   `json:"unlocked"`
+}
+
+// profilesDir returns the directory holding per-profile databases
+func (a *App) profilesDir() string {
+	return filepath.Join(a.dataDir(), "profiles")
+}
+
+// profilePath returns the database file for a non-default profile
+func (a *App) profilePath(name string) string {
+	return filepath.Join(a.profilesDir(), name+".db")
+}
+
+// profileExists reports whether a profile has a plaintext or encrypted database
+func (a *App) profileExists(name string) bool {
+	if _, err := os.Stat(a.profilePath(name)); err == nil {
+		return true
+	}
+	return a.profileEncrypted(name)
+}
+
+// activeProfile reads the last used profile from the main database
+func (a *App) activeProfile() string {
+	return a.activeProfileIn(a.homeDB())
+}
+
+// activeProfileIn reads the last used profile from db, falling back to the
+// default profile when it's unset or its database is gone
+func (a *App) activeProfileIn(db *sql.DB) string {
+	var name string
+	err := db.QueryRow("SELECT value FROM settings WHERE key = 'profiles.active'").Scan(&name)
+	if err != nil || name == "" {
+		return defaultProfile
+	}
+	if name != defaultProfile {
+		if _, err := os.Stat(a.profilePath(name)); err != nil {
+			return defaultProfile
+		}
+	}
+	return name
+}
+
+// initUserTables creates the per-profile tables in the user database
+func (a *App) initUserTables() error {
+	if err := a.initSettingsTable(); err != nil {
+		return fmt.Errorf("failed to initialize settings: %w", err)
+	}
+	if err := a.initFavoritesTable(); err != nil {
+		return fmt.Errorf("failed to initialize favorites: %w", err)
+	}
+	if err := a.initCollectionsTables(); err != nil {
+		return fmt.Errorf("failed to initialize collections: %w", err)
+	}
+	if err := a.initCategoryUsageTable(); err != nil {
+		return fmt.Errorf("failed to initialize category usage: %w", err)
+	}
+	if err := a.initCopyHistoryTable(); err != nil {
+		return fmt.Errorf("failed to initialize copy history: %w", err)
+	}
+	if err := a.initSearchHistoryTable(); err != nil {
+		return fmt.Errorf("failed to initialize search history: %w", err)
+	}
+	if err := a.initSearchFeedbackTable(); err != nil {
+		return fmt.Errorf("failed to initialize search feedback: %w", err)
+	}
+	if err := a.initHotkeysTable(); err != nil {
+		return fmt.Errorf("failed to initialize hotkeys: %w", err)
+	}
+	if err := a.initTagTables(); err != nil {
+		return fmt.Errorf("failed to initialize tags: %w", err)
+	}
+	if err := a.initTagRulesTable(); err != nil {
+		return fmt.Errorf("failed to initialize tag rules: %w", err)
+	}
+	if err := a.initCategoryGroupsTable(); err != nil {
+		return fmt.Errorf("failed to initialize category groups: %w", err)
+	}
+	if err := a.initCopyRulesTable(); err != nil {
+		return fmt.Errorf("failed to initialize copy rules: %w", err)
+	}
+	return nil
+}
+
+// closeUserDB closes the user database unless it is shared with the glyph database
+func (a *App) closeUserDB() {
+	if a.userDB != nil && a.userDB != a.db && a.userDB != a.localDB {
+		a.userDB.Close()
+	}
+	a.userDB = nil
+}
+
+// openProfile switches all user data to the named profile and reloads it
+func (a *App) openProfile(name string) error {
+	var userDB *sql.DB
+	if name == defaultProfile {
+		userDB = a.homeDB()
+	} else {
+		if err := os.MkdirAll(a.profilesDir(), 0755); err != nil {
+			return fmt.Errorf("failed to create profiles directory: %w", err)
+		}
+		path, err := a.profileDBPath(name)
+		if err != nil {
+			return err
+		}
+		db, err := openSQLite(path, false)
+		if err != nil {
+			return fmt.Errorf("failed to open profile %s: %w", name, err)
+		}
+		userDB = db
+	}
+
+	a.flushSession()
+	// Leaving an unlocked encrypted profile writes it back first
+	if name != a.profile {
+		if err := a.sealProfile(); err != nil {
+			if userDB != a.db {
+				userDB.Close()
+			}
+			return fmt.Errorf("failed to save encrypted profile: %w", err)
+		}
+	}
+	a.closeUserDB()
+	a.userDB = userDB
+	a.profile = name
+	a.settings.db = userDB
+
+	if err := a.initUserTables(); err != nil {
+		return err
+	}
+
+	a.loadSettings()
+	a.settings.setReadOnly(a.isReadOnly())
+	a.checkScoringProfileSetting()
+	a.loadLocale()
+	start := time.Now()
+	if err := a.favorites.Open(userDB); err != nil {
+		log.Printf("%v", err)
+	}
+	a.recordStartup(StartupPhaseFavorites, start)
+
+	a.loadLastSession()
+	a.loadSearchHistory()
+	a.loadPlugins()
+	a.frecency.reset()
+	a.rescheduleMaintenance(true)
+
+	a.registerGlyphHotkeys()
+	a.restartClipboardWatch()
+	a.bulkEdits.clear()
+	a.refreshGlyphMetadata()
+
+	log.Printf("Profile: %s", name)
+	return nil
+}
+
+// ListProfiles returns the default profile plus every profile database on disk
+func (a *App) ListProfiles() ([]Profile, error) {
+	names := []string{defaultProfile}
+
+	entries, err := os.ReadDir(a.profilesDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+	encrypted := make(map[string]bool)
+	for _, entry := range entries {
+		switch {
+		case entry.IsDir():
+		case strings.HasSuffix(entry.Name(), encryptedProfileExt):
+			name := strings.TrimSuffix(entry.Name(), encryptedProfileExt)
+			encrypted[name] = true
+			names = append(names, name)
+		case filepath.Ext(entry.Name()) == ".db":
+			names = append(names, strings.TrimSuffix(entry.Name(), ".db"))
+		}
+	}
+	sort.Strings(names[1:])
+
+	profiles := make([]Profile, 0, len(names))
+	for _, name := range names {
+		profiles = append(profiles, Profile{
+			Name:      name,
+			Active:    name == a.profile,
+			Encrypted: encrypted[name],
+			Unlocked:  encrypted[name] && a.vaultUnlocked(name),
+		})
+	}
+	return profiles, nil
+}
+
+// GetActiveProfile returns the name of the active profile
+func (a *App) GetActiveProfile() string {
+	return a.profile
+}
+
+// CreateProfile creates an empty profile without switching to it
+func (a *App) CreateProfile(name string) error {
+	if err := a.checkWritable("create profiles"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+
+	name = strings.TrimSpace(name)
+	if !profileNamePattern.MatchString(name) || strings.EqualFold(name, defaultProfile) {
+		return newAppError(ErrCodeInvalid, "invalid profile name: %q", name)
+	}
+	if a.profileExists(name) {
+		return newAppError(ErrCodeConflict, "profile %q already exists", name)
+	}
+
+	if err := os.MkdirAll(a.profilesDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+	db, err := openSQLite(a.profilePath(name), false)
+	if err != nil {
+		return fmt.Errorf("failed to create profile: %w", err)
+	}
+	defer db.Close()
+
+	// Creating the settings table materializes the database file
+	if _, err := db.Exec("CREATE TABLE IF NOT EXISTS settings (key TEXT PRIMARY KEY, value TEXT NOT NULL, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP)"); err != nil {
+		return fmt.Errorf("failed to create profile: %w", err)
+	}
+
+	a.emit("profiles:changed", name)
+	return nil
+}
+
+// SwitchProfile makes another profile active and reloads its favorites, collections, and settings
+func (a *App) SwitchProfile(name string) error {
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+	if name != defaultProfile && !a.profileExists(name) {
+		return newAppError(ErrCodeNotFound, "profile %q not found", name)
+	}
+	if name == a.profile {
+		return nil
+	}
+
+	if err := a.openProfile(name); err != nil {
+		return err
+	}
+
+	if a.isReadOnly() {
+		a.emit("profile:changed", name)
+		return nil
+	}
+
+	_, err := a.homeDB().Exec(`
+		INSERT INTO settings (key, value) VALUES ('profiles.active', ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = CURRENT_TIMESTAMP
+	`, name)
+	if err != nil {
+		log.Printf("Failed to remember active profile: %v", err)
+	}
+
+	a.emit("profile:changed", name)
+	return nil
+}
+
+// DeleteProfile removes a profile's data; the active and default profiles can't be deleted
+func (a *App) DeleteProfile(name string) error {
+	if err := a.checkWritable("delete profiles"); err != nil {
+		return err
+	}
+
+	if name == defaultProfile || name == a.profile {
+		return newAppError(ErrCodeInvalid, "cannot delete profile %q", name)
+	}
+	if !profileNamePattern.MatchString(name) {
+		return newAppError(ErrCodeInvalid, "invalid profile name: %q", name)
+	}
+	path := a.profilePath(name)
+	if a.profileEncrypted(name) {
+		path = a.encryptedProfilePath(name)
+		if a.vaultUnlocked(name) {
+			a.forgetVault()
+		}
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+
+	a.emit("profiles:changed", name)
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Taskbar progress states, mirroring the Windows taskbar button's
+const (
+	TaskbarProgressNone          = "none"
+	TaskbarProgressIndeterminate = "indeterminate"
+	TaskbarProgressNormal        = "normal"
+	TaskbarProgressError         = "error"
+)
+
+// taskbarErrorHold is how long a failed operation keeps the taskbar button red
+const taskbarErrorHold = 5 * time.Second
+
+// TaskbarProgress is what the taskbar button or dock icon shows for the
+// running operations
+type TaskbarProgress struct {
+	State string `json:"state"`
+	// Percent is the mean progress of the operations that report one
+	Percent int `json:"percent"`
+	// Count is the number of running operations
+	Count int `json:"count"`
+}
+
+// badgeLabel is the dock badge text: the progress of a single operation, or
+// how many are running
+func (p TaskbarProgress) badgeLabel() string {
+	switch {
+	case p.State == TaskbarProgressError:
+		return "!"
+	case p.Count == 0:
+		return ""
+	case p.Count == 1 && p.State == TaskbarProgressNormal:
+		return fmt.Sprintf("%d%%", p.Percent)
+	}
+	return strconv.Itoa(p.Count)
+}
+
+// ProgressBadge mirrors the operation toasts on the taskbar button or dock
+// icon, so progress is visible while the window is in the background
+type ProgressBadge struct {
+	mu          sync.Mutex
+	shown       TaskbarProgress
+	failedUntil time.Time
+	timer       *time.Timer
+}
+
+// taskbarProgressEnabled reports whether operations show on the taskbar or dock
+func (a *App) taskbarProgressEnabled() bool {
+	return a.settings.GetBool("taskbar.progress", true)
+}
+
+// SetTaskbarProgress turns the taskbar progress bar and dock badge on or off
+func (a *App) SetTaskbarProgress(enabled bool) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if err := a.settings.Set("taskbar.progress", strconv.FormatBool(enabled)); err != nil {
+		return err
+	}
+	a.refreshProgressBadge(false)
+	return nil
+}
+
+// operationsProgress sums up the running operations
+func (a *App) operationsProgress() TaskbarProgress {
+	active := a.GetActiveOperations()
+	p := TaskbarProgress{State: TaskbarProgressNone, Count: len(active)}
+	if len(active) == 0 {
+		return p
+	}
+
+	var total float64
+	determinate := 0
+	for _, op := range active {
+		if op.Progress >= 0 {
+			total += op.Progress
+			determinate++
+		}
+	}
+	if determinate == 0 {
+		p.State = TaskbarProgressIndeterminate
+		return p
+	}
+	p.State = TaskbarProgressNormal
+	p.Percent = int(math.Round(total / float64(determinate)))
+	return p
+}
+
+// refreshProgressBadge shows the current operations on the taskbar or dock;
+// failed marks an operation that just failed. Only windowed runs have a
+// taskbar button, and unchanged progress isn't sent again.
+func (a *App) refreshProgressBadge(failed bool) {
+	if !progressBadgeSupported || !a.hasRuntime() {
+		return
+	}
+
+	a.progressBadge.mu.Lock()
+	defer a.progressBadge.mu.Unlock()
+
+	now := time.Now()
+	if failed {
+		a.progressBadge.failedUntil = now.Add(taskbarErrorHold)
+		if a.progressBadge.timer != nil {
+			a.progressBadge.timer.Stop()
+		}
+		a.progressBadge.timer = time.AfterFunc(taskbarErrorHold, func() { a.refreshProgressBadge(false) })
+	}
+
+	p := TaskbarProgress{State: TaskbarProgressNone}
+	if a.taskbarProgressEnabled() {
+		p = a.operationsProgress()
+		if now.Before(a.progressBadge.failedUntil) {
+			p.State = TaskbarProgressError
+		}
+	}
+	if p == a.progressBadge.shown {
+		return
+	}
+	if err := setProgressBadge(p); err != nil {
+		log.Printf("Failed to update taskbar progress: %v", err)
+		return
+	}
+	a.progressBadge.shown = p
+}
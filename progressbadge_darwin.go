@@ -0,0 +1,36 @@
+//go:build darwin && cgo
+
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+#import <Cocoa/Cocoa.h>
+#include <stdlib.h>
+
+// gylte_set_badge sets the dock icon's badge; an empty label removes it. cgo
+// threads have no autorelease pool, so the label gets one of its own; the
+// block retains it until the main queue has used it.
+static void gylte_set_badge(const char *label) {
+	@autoreleasepool {
+		NSString *text = label[0] ? [NSString stringWithUTF8String:label] : nil;
+		dispatch_async(dispatch_get_main_queue(), ^{
+			[[NSApp dockTile] setBadgeLabel:text];
+		});
+	}
+}
+*/
+import "C"
+
+import "unsafe"
+
+// progressBadgeSupported reports that the dock icon shows a badge
+const progressBadgeSupported = true
+
+// setProgressBadge shows the progress as a dock badge, e.g. "42%" or "2"
+func setProgressBadge(p TaskbarProgress) error {
+	label := C.CString(p.badgeLabel())
+	defer C.free(unsafe.Pointer(label))
+	C.gylte_set_badge(label)
+	return nil
+}
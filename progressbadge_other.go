@@ -0,0 +1,12 @@
+//go:build !windows && !(darwin && cgo)
+
+package main
+
+// progressBadgeSupported reports that this build can't badge its launcher
+// icon; Linux docks have no common API for it
+const progressBadgeSupported = false
+
+// setProgressBadge does nothing on this platform
+func setProgressBadge(p TaskbarProgress) error {
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	goruntime "runtime"
+	"syscall"
+	"unsafe"
+)
+
+// progressBadgeSupported reports that the taskbar button shows progress
+const progressBadgeSupported = true
+
+// ITaskbarList3 methods after IUnknown
+const (
+	methodHrInit           = 3
+	methodSetProgressValue = 9
+	methodSetProgressState = 10
+)
+
+// Taskbar progress flags (TBPFLAG)
+const (
+	tbpfNoProgress    = 0x0
+	tbpfIndeterminate = 0x1
+	tbpfNormal        = 0x2
+	tbpfError         = 0x4
+)
+
+var (
+	clsidTaskbarList = guid{0x56fdf344, 0xfd6d, 0x11d0, [8]byte{0x95, 0x8a, 0x00, 0x60, 0x97, 0xc9, 0xa0, 0x90}}
+	iidTaskbarList3  = guid{0xea1afb91, 0x9e28, 0x4b86, [8]byte{0x90, 0xe9, 0x9e, 0x9f, 0x8a, 0x5e, 0xef, 0xaf}}
+)
+
+// showTaskbarProgress sets the main window's taskbar button progress; it must
+// run on a thread that can initialize COM
+func showTaskbarProgress(p TaskbarProgress) error {
+	title, err := syscall.UTF16PtrFromString(windowTitleGylte)
+	if err != nil {
+		return err
+	}
+	hwnd, _, _ := procFindWindowW.Call(0, uintptr(unsafe.Pointer(title)))
+	if hwnd == 0 {
+		return newAppError(ErrCodeNotFound, "main window not found")
+	}
+
+	hr, _, _ := procCoInitializeEx.Call(0, coinitApartmentThreaded)
+	if err := hresultError("CoInitializeEx", hr); err != nil {
+		return err
+	}
+	defer procCoUninitialize.Call()
+
+	taskbar, err := createInstance(&clsidTaskbarList, &iidTaskbarList3)
+	if err != nil {
+		return err
+	}
+	defer taskbar.release()
+	if err := hresultError("HrInit", taskbar.call(methodHrInit)); err != nil {
+		return err
+	}
+
+	state, percent := uintptr(tbpfNoProgress), p.Percent
+	switch p.State {
+	case TaskbarProgressIndeterminate:
+		state = tbpfIndeterminate
+	case TaskbarProgressNormal:
+		state = tbpfNormal
+	case TaskbarProgressError:
+		// With nothing left running, show the failure as a full red bar
+		state = tbpfError
+		if p.Count == 0 {
+			percent = 100
+		}
+	}
+	if state == tbpfNormal || state == tbpfError {
+		if err := hresultError("SetProgressValue", taskbar.call(methodSetProgressValue, hwnd, uintptr(percent), 100)); err != nil {
+			return err
+		}
+	}
+	return hresultError("SetProgressState", taskbar.call(methodSetProgressState, hwnd, state))
+}
+
+// setProgressBadge shows the progress on the taskbar button
+func setProgressBadge(p TaskbarProgress) error {
+	errc := make(chan error, 1)
+	go func() {
+		goruntime.LockOSThread()
+		defer goruntime.UnlockOSThread()
+		errc <- showTaskbarProgress(p)
+	}()
+	return <-errc
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Prompt and status bar tools supported by GeneratePromptConfig
+const (
+	PromptToolStarship      = "starship"
+	PromptToolPowerlevel10k = "powerlevel10k"
+	PromptToolPolybar       = "polybar"
+)
+
+// unicodeEscape writes each rune as \uXXXX, or \UXXXXXXXX outside the BMP
+// (the syntax TOML and zsh $'...' strings share)
+func unicodeEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r > 0xFFFF {
+			fmt.Fprintf(&b, "\\U%08X", r)
+		} else {
+			fmt.Fprintf(&b, "\\u%04X", r)
+		}
+	}
+	return b.String()
+}
+
+// identifierFor turns a snippet trigger into a config identifier (":fa-rocket:" -> "fa_rocket")
+func identifierFor(trigger string) string {
+	return strings.NewReplacer("-", "_", " ", "_").Replace(strings.Trim(trigger, ":"))
+}
+
+// starshipConfig renders one symbol per glyph as TOML. Each symbol sits in its
+// own [custom.<name>] table so the keys don't repeat; custom modules stay
+// hidden until given a command, so the whole fragment can be pasted as is.
+func starshipConfig(snippets []snippet) string {
+	var b strings.Builder
+	b.WriteString("# Generated by Gylte: copy a symbol into the module it belongs to\n")
+	for _, s := range snippets {
+		fmt.Fprintf(&b, "\n# %s %s\n[custom.%s]\nsymbol = \"%s \"\n", s.Glyph.Name, s.Glyph.Glyph, identifierFor(s.Trigger), unicodeEscape(s.Glyph.Glyph))
+	}
+	return b.String()
+}
+
+// powerlevel10kConfig renders one icon override per glyph for ~/.p10k.zsh
+func powerlevel10kConfig(snippets []snippet) string {
+	var b strings.Builder
+	b.WriteString("# Generated by Gylte: add to ~/.p10k.zsh\n")
+	for _, s := range snippets {
+		name := strings.ToUpper(identifierFor(s.Trigger))
+		fmt.Fprintf(&b, "typeset -g POWERLEVEL9K_%s_ICON=$'%s'  # %s\n", name, unicodeEscape(s.Glyph.Glyph), s.Glyph.Name)
+	}
+	return b.String()
+}
+
+// polybarConfig renders a [glyphs] section. Polybar has no escape syntax, so the
+// glyphs are written literally and referenced as ${glyphs.<name>}.
+func polybarConfig(snippets []snippet) string {
+	var b strings.Builder
+	b.WriteString("; Generated by Gylte: reference icons as ${glyphs.<name>}\n[glyphs]\n")
+	for _, s := range snippets {
+		fmt.Fprintf(&b, "; %s\n%s = %s\n", s.Glyph.Name, strings.ReplaceAll(identifierFor(s.Trigger), "_", "-"), s.Glyph.Glyph)
+	}
+	return b.String()
+}
+
+// GeneratePromptConfig returns a ready-to-paste config fragment for the selected
+// glyphs, with the glyphs escaped the way the target tool expects
+func (a *App) GeneratePromptConfig(glyphIDs []int, tool string) (string, error) {
+	var render func([]snippet) string
+	switch strings.ToLower(tool) {
+	case PromptToolStarship:
+		render = starshipConfig
+	case PromptToolPowerlevel10k, "p10k":
+		render = powerlevel10kConfig
+	case PromptToolPolybar:
+		render = polybarConfig
+	default:
+		return "", newAppError(ErrCodeInvalid, "unsupported prompt tool: %s", tool)
+	}
+
+	found := a.lookupGlyphs(glyphIDs)
+	glyphs := make([]Glyph, 0, len(glyphIDs))
+	for _, id := range glyphIDs {
+		g, ok := found[id]
+		if !ok {
+			return "", newAppError(ErrCodeNotFound, "glyph %d not found", id)
+		}
+		glyphs = append(glyphs, g)
+	}
+	if len(glyphs) == 0 {
+		return "", newAppError(ErrCodeInvalid, "no glyphs selected")
+	}
+
+	return render(buildSnippets(glyphs)), nil
+}
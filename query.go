@@ -0,0 +1,130 @@
+package main
+
+import (
+	"database/sql"
+	"log"
+	"slices"
+	"strings"
+)
+
+// queryCodepointPrefix starts a code point range filter, e.g. "cp:f000..f2ff"
+const queryCodepointPrefix = "cp:"
+
+// queryWidthPrefix starts a width class filter, "width:single" or "width:double"
+const queryWidthPrefix = "width:"
+
+// codepointRange is an inclusive range of code points
+type codepointRange struct {
+	Lo, Hi rune
+}
+
+// contains reports whether r is in the range
+func (c codepointRange) contains(r rune) bool {
+	return r >= c.Lo && r <= c.Hi
+}
+
+// searchQuery is a search term split into its filters and the text left to match
+type searchQuery struct {
+	Text string
+	// Range limits matches to glyphs whose first code point falls in it
+	Range *codepointRange
+	// Width limits matches to glyphs of one width class
+	Width string
+}
+
+// parseRangeBound reads a hex code point, bare or with a "U+" or "0x" prefix
+func parseRangeBound(s string) (rune, error) {
+	if r, ok := parseCodepoint(s); ok {
+		return r, nil
+	}
+	if r, ok := parseCodepoint("0x" + s); ok {
+		return r, nil
+	}
+	return 0, invalidArgument("query", "invalid code point %q", s)
+}
+
+// parseCodepointRange reads "f000..f2ff" or a single code point like "f135"
+func parseCodepointRange(s string) (codepointRange, error) {
+	lo, hi, isRange := strings.Cut(s, "..")
+	start, err := parseRangeBound(lo)
+	if err != nil {
+		return codepointRange{}, err
+	}
+	end := start
+	if isRange {
+		if end, err = parseRangeBound(hi); err != nil {
+			return codepointRange{}, err
+		}
+	}
+	if end < start {
+		return codepointRange{}, invalidArgument("query", "code point range %s ends before it starts", s)
+	}
+	return codepointRange{Lo: start, Hi: end}, nil
+}
+
+// parseSearchQuery pulls filters out of a search term. "cp:f000..f2ff" keeps
+// glyphs in that code point range and "width:single" glyphs that fit one
+// terminal cell; every other word is matched as usual.
+func parseSearchQuery(term string) (searchQuery, error) {
+	var q searchQuery
+	var words []string
+	for _, word := range strings.Fields(term) {
+		if class, ok := strings.CutPrefix(strings.ToLower(word), queryWidthPrefix); ok {
+			if q.Width != "" {
+				return searchQuery{}, invalidArgument("query", "only one %s filter is allowed", queryWidthPrefix)
+			}
+			if class != WidthSingle && class != WidthDouble {
+				return searchQuery{}, invalidArgument("query", "width must be %s or %s", WidthSingle, WidthDouble)
+			}
+			q.Width = class
+			continue
+		}
+		spec, ok := strings.CutPrefix(strings.ToLower(word), queryCodepointPrefix)
+		if !ok {
+			words = append(words, word)
+			continue
+		}
+		if q.Range != nil {
+			return searchQuery{}, invalidArgument("query", "only one %s filter is allowed", queryCodepointPrefix)
+		}
+		r, err := parseCodepointRange(spec)
+		if err != nil {
+			return searchQuery{}, err
+		}
+		q.Range = &r
+	}
+	q.Text = strings.Join(words, " ")
+	return q, nil
+}
+
+// filterCodepoints keeps the glyphs whose first code point is in r
+func filterCodepoints(glyphs []Glyph, r codepointRange) []Glyph {
+	var kept []Glyph
+	for _, g := range glyphs {
+		if r.contains(codepointOf(g.Glyph)) {
+			kept = append(kept, g)
+		}
+	}
+	return kept
+}
+
+// sortByCodepoint orders matches by their first code point, for browsing a range
+func sortByCodepoint(matches []GlyphMatch) {
+	slices.SortStableFunc(matches, func(x, y GlyphMatch) int {
+		return int(codepointOf(x.Glyph.Glyph) - codepointOf(y.Glyph.Glyph))
+	})
+}
+
+// backfillCodepoints fills the codepoint column of glyphs that lack it, so
+// range queries can use its index
+func backfillCodepoints(db *sql.DB) error {
+	res, err := db.Exec("UPDATE glyphs SET codepoint = unicode(glyph) WHERE codepoint IS NULL")
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		log.Printf("Indexed code points of %d glyphs", n)
+	}
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS idx_codepoint ON glyphs(codepoint)")
+	return err
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// frecencyHalfLife is how long it takes a copy to lose half its weight
+	frecencyHalfLife = 3 * 24 * time.Hour
+
+	// frecencyWindowDays bounds how far back copy history is considered
+	frecencyWindowDays = 90
+)
+
+// initCopyHistoryTable creates the copy_history table if it doesn't exist
+func (a *App) initCopyHistoryTable() error {
+	_, err := a.userDB.Exec(`
+		CREATE TABLE IF NOT EXISTS copy_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			glyph_id INTEGER NOT NULL,
+			copied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_copy_history_copied ON copy_history(copied_at);
+	`)
+	return err
+}
+
+// recordCopy adds a glyph to the copy history, runs the on_copy hook, and
+// tells event bridge clients
+func (a *App) recordCopy(glyphID int) {
+	a.runHook(HookOnCopy, glyphID, nil)
+	a.broadcastGlyph(BridgeEventGlyphCopied, glyphID, nil)
+	if a.userDB == nil || a.isReadOnly() {
+		return
+	}
+
+	if _, err := a.userDB.Exec("INSERT INTO copy_history (glyph_id) VALUES (?)", glyphID); err != nil {
+		log.Printf("Failed to record copy: %v", err)
+		return
+	}
+	a.frecency.add(glyphID, time.Now())
+}
+
+// FrecencyCache holds copy frecency so searches don't scan the copy history on
+// every keystroke. Scores are decayed to computedAt; a copy made later weighs
+// more than 1, which keeps rankings exact until the maintenance scheduler
+// recomputes them.
+type FrecencyCache struct {
+	mu         sync.RWMutex
+	scores     map[int]float64 // replaced, never modified, so callers may keep it
+	computedAt time.Time
+}
+
+// add counts a new copy in the cached scores, if they have been computed
+func (c *FrecencyCache) add(glyphID int, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.scores == nil {
+		return
+	}
+	scores := make(map[int]float64, len(c.scores)+1)
+	for id, score := range c.scores {
+		scores[id] = score
+	}
+	scores[glyphID] += math.Pow(0.5, -float64(at.Sub(c.computedAt))/float64(frecencyHalfLife))
+	c.scores = scores
+}
+
+// reset drops the cached scores, e.g. when the profile changes
+func (c *FrecencyCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scores = nil
+}
+
+// cachedFrecency returns the frecency scores, computing them on first use
+func (a *App) cachedFrecency() (map[int]float64, error) {
+	a.frecency.mu.RLock()
+	scores := a.frecency.scores
+	a.frecency.mu.RUnlock()
+	if scores != nil {
+		return scores, nil
+	}
+	return a.recomputeFrecency()
+}
+
+// recomputeFrecency rebuilds the cached scores from the copy history
+func (a *App) recomputeFrecency() (map[int]float64, error) {
+	now := time.Now()
+	scores, err := a.frecencyScores(now)
+	if err != nil {
+		return nil, err
+	}
+	a.frecency.mu.Lock()
+	defer a.frecency.mu.Unlock()
+	a.frecency.scores = scores
+	a.frecency.computedAt = now
+	return scores, nil
+}
+
+// frecencyScores weights each copy by how recent it is, summed per glyph
+func (a *App) frecencyScores(now time.Time) (map[int]float64, error) {
+	rows, err := a.userDB.Query(`
+		SELECT glyph_id, CAST(strftime('%s', copied_at) AS INTEGER)
+		FROM copy_history
+		WHERE copied_at >= datetime('now', ?)
+	`, fmt.Sprintf("-%d days", frecencyWindowDays))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query copy history: %w", err)
+	}
+	defer rows.Close()
+
+	scores := make(map[int]float64)
+	for rows.Next() {
+		var glyphID int
+		var copiedAt int64
+		if err := rows.Scan(&glyphID, &copiedAt); err != nil {
+			continue
+		}
+		age := now.Sub(time.Unix(copiedAt, 0))
+		scores[glyphID] += math.Pow(0.5, float64(age)/float64(frecencyHalfLife))
+	}
+	return scores, rows.Err()
+}
+
+// Ranking boosts added to search scores
+const (
+	// favoriteBoost exceeds any other score, so favorites always rank first
+	favoriteBoost = 100000
+
+	// frequencyBoostPerCopy is the default boost per (decayed) copy, capped at maxFrequencyBoost
+	frequencyBoostPerCopy = 100
+	maxFrequencyBoost     = 1000
+)
+
+// rankingBoosts holds per-search data for boosting often copied glyphs
+type rankingBoosts struct {
+	frecency map[int]float64
+	profile  ScoringProfile
+}
+
+// rankingBoosts loads copy frecency unless the "search.frequencyBoost" setting disables it
+func (a *App) rankingBoosts(profile ScoringProfile) rankingBoosts {
+	if a.userDB == nil || !a.settings.GetBool("search.frequencyBoost", true) {
+		return rankingBoosts{profile: profile}
+	}
+	scores, err := a.cachedFrecency()
+	if err != nil {
+		log.Printf("Failed to load copy frequency: %v", err)
+	}
+	return rankingBoosts{frecency: scores, profile: profile}
+}
+
+// frequency returns the ranking boost for a glyph
+func (r rankingBoosts) frequency(glyphID int) int {
+	if r.frecency == nil {
+		return 0
+	}
+	return r.profile.FrequencyBoost(r.frecency[glyphID])
+}
+
+// CopyGlyph copies a glyph to the clipboard, in the focused app's format when
+// smart copy is on, and records it in the copy history
+func (a *App) CopyGlyph(id int) error {
+	g, ok := a.findGlyph(id)
+	if !ok {
+		return newAppError(ErrCodeNotFound, "glyph %d not found", id)
+	}
+
+	if _, err := a.setClipboard(a.smartCopyText(g)); err != nil {
+		return fmt.Errorf("failed to copy glyph: %w", err)
+	}
+	a.recordCopy(id)
+	return nil
+}
+
+// GetQuickPicks returns the glyphs with the highest frecency (copy frequency
+// decayed by recency), so recently and often copied glyphs come first
+func (a *App) GetQuickPicks(limit int) ([]GlyphMatch, error) {
+	if err := validatePage(limit, 0); err != nil {
+		return nil, err
+	}
+	if a.userDB == nil {
+		return []GlyphMatch{}, nil
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	scores, err := a.cachedFrecency()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	found := a.lookupGlyphs(ids)
+	favorites := a.favorites.Snapshot()
+	picks := make([]GlyphMatch, 0, limit)
+	for _, id := range ids {
+		g, ok := found[id]
+		if !ok {
+			continue
+		}
+		picks = append(picks, GlyphMatch{
+			Glyph:      g,
+			Score:      int(math.Round(scores[id] * 100)),
+			IsFavorite: favorites[id],
+		})
+		if len(picks) == limit {
+			break
+		}
+	}
+	a.annotateDeprecations(picks)
+	return picks, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ReadOnlyError is returned by mutating bindings while the app is in read-only mode
+type ReadOnlyError struct {
+	Operation string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("read-only mode: cannot %s", e.Operation)
+}
+
+// isReadOnly reports whether mutations are disabled, either by the --readonly
+// flag or by the readOnly setting
+func (a *App) isReadOnly() bool {
+	return a.forceReadOnly || a.settings.GetBool("readOnly", false)
+}
+
+// checkWritable returns a ReadOnlyError describing op when mutations are disabled
+func (a *App) checkWritable(op string) error {
+	if a.isReadOnly() {
+		return &ReadOnlyError{Operation: op}
+	}
+	return nil
+}
+
+// SetReadOnlyMode turns read-only mode on or off. Mode forced by the --readonly
+// flag cannot be turned off at runtime.
+func (a *App) SetReadOnlyMode(enabled bool) error {
+	if !enabled && a.forceReadOnly {
+		return &ReadOnlyError{Operation: "leave read-only mode started with --readonly"}
+	}
+
+	// Settings refuse to persist while read-only, so lift the guard to save this one
+	a.settings.setReadOnly(false)
+	err := a.settings.Set("readOnly", strconv.FormatBool(enabled))
+	a.settings.setReadOnly(a.forceReadOnly || enabled)
+	if err != nil {
+		return err
+	}
+
+	a.emit("readonly:changed", enabled)
+	return nil
+}
+
+// IsReadOnly reports whether the app is in read-only mode
+func (a *App) IsReadOnly() bool {
+	return a.isReadOnly()
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// EventReleaseGlyphsAdded is emitted with the release tag and count when an import adds glyphs
+const EventReleaseGlyphsAdded = "release:glyphsAdded"
+
+// RecentlyAdded lists the glyphs a Nerd Fonts release added, for a "What's new" gallery
+type RecentlyAdded struct {
+	ReleaseTag string       `json:"releaseTag"`
+	Total      int          `json:"total"`
+	Glyphs     []GlyphMatch `json:"glyphs"`
+}
+
+// recordReleaseGlyphs stores the glyphs an import added under releaseTag and
+// returns how many there were. It runs inside the import transaction and
+// compares against temp.import_existing, the ids present before the import.
+func recordReleaseGlyphs(ctx context.Context, tx *sql.Tx, releaseTag string) (int, error) {
+	var added int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM glyphs WHERE id NOT IN (SELECT id FROM temp.import_existing)`).Scan(&added); err != nil {
+		return 0, fmt.Errorf("failed to count new glyphs: %w", err)
+	}
+	if releaseTag == "" || added == 0 {
+		return added, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS release_glyphs (
+			release_tag TEXT NOT NULL,
+			glyph_id INTEGER NOT NULL,
+			added_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (release_tag, glyph_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_release_glyphs_added ON release_glyphs(added_at);
+	`); err != nil {
+		return 0, fmt.Errorf("failed to create release table: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT OR IGNORE INTO release_glyphs (release_tag, glyph_id)
+		SELECT ?, id FROM glyphs WHERE id NOT IN (SELECT id FROM temp.import_existing)
+	`, releaseTag); err != nil {
+		return 0, fmt.Errorf("failed to record new glyphs: %w", err)
+	}
+	return added, nil
+}
+
+// latestReleaseTag returns the release that most recently added glyphs, or ""
+func latestReleaseTag(db *sql.DB) (string, error) {
+	var tag string
+	err := db.QueryRow(`SELECT release_tag FROM release_glyphs ORDER BY added_at DESC, rowid DESC LIMIT 1`).Scan(&tag)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return tag, err
+}
+
+// GetRecentlyAddedGlyphs lists the glyphs an import of releaseTag added, in
+// code point order; an empty tag picks the latest release that added any.
+// Glyphs removed by a later import are left out.
+func (a *App) GetRecentlyAddedGlyphs(releaseTag string) (*RecentlyAdded, error) {
+	db := a.readDB
+	if db == nil {
+		return nil, newAppError(ErrCodeDBMissing, "database not open")
+	}
+	releaseTag = strings.TrimSpace(releaseTag)
+	result := &RecentlyAdded{ReleaseTag: releaseTag, Glyphs: []GlyphMatch{}}
+
+	if exists, err := tableExists(db, "release_glyphs"); err != nil || !exists {
+		return result, err
+	}
+	if releaseTag == "" {
+		tag, err := latestReleaseTag(db)
+		if err != nil || tag == "" {
+			return result, err
+		}
+		result.ReleaseTag = tag
+	}
+
+	rows, err := db.Query(`
+		SELECT r.glyph_id FROM release_glyphs r JOIN glyphs g ON g.id = r.glyph_id
+		WHERE r.release_tag = ?
+		ORDER BY unicode(g.glyph), g.name
+	`, result.ReleaseTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load new glyphs: %w", err)
+	}
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load new glyphs: %w", err)
+	}
+
+	favorites := a.favorites.Snapshot()
+	for _, id := range ids {
+		if g, ok := a.findGlyph(id); ok {
+			result.Glyphs = append(result.Glyphs, GlyphMatch{Glyph: g, IsFavorite: favorites[id]})
+		}
+	}
+	a.annotateDeprecations(result.Glyphs)
+	result.Total = len(result.Glyphs)
+	return result, nil
+}
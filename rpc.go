@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcAppError       = -32000
+)
+
+// rpcMaxLine caps a single request line (imports can carry large payloads)
+const rpcMaxLine = 16 << 20
+
+// rpcRequest is a JSON-RPC 2.0 request; params are positional, in the same
+// order as the Wails binding's arguments
+type rpcRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id,omitempty"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcError is the error member of a JSON-RPC response
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// RPCMethod describes a callable method for rpc.methods
+type RPCMethod struct {
+	Name   string   `json:"name"`
+	Params []string `json:"params"`
+}
+
+// rpcMethods returns the exported methods of App, the same set Wails binds
+func rpcMethods(a *App) map[string]reflect.Value {
+	v := reflect.ValueOf(a)
+	t := v.Type()
+	methods := make(map[string]reflect.Value, t.NumMethod())
+	for i := 0; i < t.NumMethod(); i++ {
+		methods[t.Method(i).Name] = v.Method(i)
+	}
+	return methods
+}
+
+// listRPCMethods describes every method with its parameter types
+func listRPCMethods(methods map[string]reflect.Value) []RPCMethod {
+	result := make([]RPCMethod, 0, len(methods))
+	for name, m := range methods {
+		t := m.Type()
+		params := make([]string, t.NumIn())
+		for i := range params {
+			params[i] = t.In(i).String()
+		}
+		result = append(result, RPCMethod{Name: name, Params: params})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// callRPC decodes positional params into the method's argument types and calls it.
+// A trailing error return becomes the JSON-RPC error; the first other return is the result.
+func callRPC(method reflect.Value, params []json.RawMessage) (any, *rpcError) {
+	t := method.Type()
+	if len(params) != t.NumIn() {
+		return nil, &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("expected %d params, got %d", t.NumIn(), len(params))}
+	}
+
+	args := make([]reflect.Value, t.NumIn())
+	for i, raw := range params {
+		arg := reflect.New(t.In(i))
+		if err := json.Unmarshal(raw, arg.Interface()); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("param %d: %v", i, err)}
+		}
+		args[i] = arg.Elem()
+	}
+
+	out := method.Call(args)
+	if n := len(out); n > 0 && t.Out(n-1) == reflect.TypeOf((*error)(nil)).Elem() {
+		if err, _ := out[n-1].Interface().(error); err != nil {
+			return nil, &rpcError{Code: rpcAppError, Message: err.Error(), Data: formatError(err)}
+		}
+		out = out[:n-1]
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out[0].Interface(), nil
+}
+
+// handleRPC runs one request line and returns the response, or nil for notifications
+func handleRPC(methods map[string]reflect.Value, line []byte) map[string]any {
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return rpcResponse(nil, nil, &rpcError{Code: rpcParseError, Message: err.Error()})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return rpcResponse(req.ID, nil, &rpcError{Code: rpcInvalidRequest, Message: `expected "jsonrpc": "2.0" and a method`})
+	}
+
+	var result any
+	var rerr *rpcError
+	if req.Method == "rpc.methods" {
+		result = listRPCMethods(methods)
+	} else if method, ok := methods[req.Method]; ok {
+		result, rerr = callRPC(method, req.Params)
+	} else {
+		rerr = &rpcError{Code: rpcMethodNotFound, Message: "unknown method: " + req.Method}
+	}
+
+	if req.ID == nil {
+		return nil
+	}
+	return rpcResponse(req.ID, result, rerr)
+}
+
+// rpcResponse builds a response with either a result or an error member
+func rpcResponse(id json.RawMessage, result any, rerr *rpcError) map[string]any {
+	resp := map[string]any{"jsonrpc": "2.0", "id": id}
+	if rerr != nil {
+		resp["error"] = rerr
+	} else {
+		resp["result"] = result
+	}
+	return resp
+}
+
+// runRPC serves JSON-RPC 2.0 over newline-delimited JSON until in closes,
+// exposing the same methods as the GUI bindings without starting a window
+func runRPC(a *App, in io.Reader, out io.Writer) error {
+	a.startHeadless()
+	defer a.shutdown(a.ctx)
+
+	methods := rpcMethods(a)
+	enc := json.NewEncoder(out)
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), rpcMaxLine)
+
+	log.Printf("Serving JSON-RPC on stdio (%d methods)", len(methods))
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		if resp := handleRPC(methods, line); resp != nil {
+			if err := enc.Encode(resp); err != nil {
+				return fmt.Errorf("failed to write response: %w", err)
+			}
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// EventRuntimeFailed is emitted when a clipboard, window, or dialog operation fails
+const EventRuntimeFailed = "runtime:failed"
+
+// RuntimeError is returned when an OS operation such as copying or opening a
+// dialog fails. Message is written for users; Err keeps the technical cause.
+type RuntimeError struct {
+	Operation string `json:"operation"`
+	Message   string `json:"message"`
+	Err       error  `json:"-"`
+}
+
+func (e *RuntimeError) Error() string {
+	return e.Message
+}
+
+func (e *RuntimeError) Unwrap() error {
+	return e.Err
+}
+
+// runtimeFailure logs the cause of a failed OS operation, tells the frontend,
+// and returns a RuntimeError carrying the user-facing message
+func (a *App) runtimeFailure(op, message string, err error) error {
+	if err != nil {
+		log.Printf("%s failed: %v", op, err)
+	} else {
+		log.Printf("%s failed: %s", op, message)
+	}
+	rerr := &RuntimeError{Operation: op, Message: message, Err: err}
+	a.emit(EventRuntimeFailed, rerr)
+	return rerr
+}
+
+// requireWindow fails op when there is no window to act on (e.g. headless runs)
+func (a *App) requireWindow(op string) error {
+	if a.hasRuntime() {
+		return nil
+	}
+	return a.runtimeFailure(op, "The Gylte window isn't available", nil)
+}
+
+// ChooseSavePath asks the user where to save a file, returning "" if they cancel
+func (a *App) ChooseSavePath(title, defaultFilename string) (string, error) {
+	if err := a.requireWindow("dialog"); err != nil {
+		return "", err
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:            title,
+		DefaultDirectory: a.dataDir(),
+		DefaultFilename:  defaultFilename,
+	})
+	if err != nil {
+		return "", a.runtimeFailure("dialog", fmt.Sprintf("Couldn't open the save dialog: %v", err), err)
+	}
+	return path, nil
+}
+
+// ChooseOpenPath asks the user for a file to open, returning "" if they cancel
+func (a *App) ChooseOpenPath(title string) (string, error) {
+	if err := a.requireWindow("dialog"); err != nil {
+		return "", err
+	}
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:            title,
+		DefaultDirectory: a.dataDir(),
+	})
+	if err != nil {
+		return "", a.runtimeFailure("dialog", fmt.Sprintf("Couldn't open the file dialog: %v", err), err)
+	}
+	return path, nil
+}
+
+// MinimiseWindow minimises the main window
+func (a *App) MinimiseWindow() error {
+	if err := a.requireWindow("window"); err != nil {
+		return err
+	}
+	runtime.WindowMinimise(a.ctx)
+	return nil
+}
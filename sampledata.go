@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+)
+
+// sampleCollection is the collection SeedSampleData creates
+const sampleCollection = "Getting started"
+
+// sampleGlyphNames are popular glyphs seeded for new users, in collection order
+var sampleGlyphNames = []string{
+	"nf-dev-git_branch",
+	"nf-fa-folder",
+	"nf-fa-rocket",
+	"nf-fa-battery_full",
+	"nf-dev-github",
+	"nf-fa-terminal",
+	"nf-fa-code",
+	"nf-linux-tux",
+	"nf-fa-bug",
+	"nf-fa-check",
+	"nf-fa-heart",
+	"nf-fa-star",
+}
+
+// sampleFavorites is how many of the sample glyphs are also favorited
+const sampleFavorites = 4
+
+// EventSampleDataChanged is emitted with whether sample data is present after it is seeded or removed
+const EventSampleDataChanged = "sampledata:changed"
+
+// HasSampleData reports whether the sample collection and favorites are present
+func (a *App) HasSampleData() bool {
+	return a.settings.Get("sampleData.favorites", "") != ""
+}
+
+// SeedSampleData creates a "Getting started" collection with a dozen popular glyphs
+// and favorites a few of them, so a new profile doesn't open to empty panels
+func (a *App) SeedSampleData() error {
+	if err := a.checkWritable("seed sample data"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+	if a.HasSampleData() {
+		return newAppError(ErrCodeConflict, "sample data has already been added")
+	}
+
+	var ids []int
+	for _, name := range sampleGlyphNames {
+		if g, ok := a.findGlyphByName(name); ok {
+			ids = append(ids, g.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return newAppError(ErrCodeNotFound, "none of the sample glyphs are in this database")
+	}
+
+	tx, err := a.userDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("INSERT INTO collections (name) VALUES (?)", sampleCollection)
+	if err != nil {
+		return fmt.Errorf("failed to create sample collection: %w", err)
+	}
+	collectionID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if _, err := tx.Exec("INSERT INTO collection_glyphs (collection_id, glyph_id, position) VALUES (?, ?, ?)", collectionID, id, i); err != nil {
+			return fmt.Errorf("failed to add sample glyph: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// Only favorites added here are recorded, so removal leaves the user's own alone
+	added, err := a.favorites.Add(ids[:min(sampleFavorites, len(ids))])
+	if err != nil {
+		return fmt.Errorf("failed to add sample favorites: %w", err)
+	}
+
+	data, _ := json.Marshal(added)
+	if err := a.settings.Set("sampleData.favorites", string(data)); err != nil {
+		log.Printf("Failed to record sample favorites: %v", err)
+	}
+
+	a.emit("collections:changed", sampleCollection)
+	a.emit(EventSampleDataChanged, true)
+	return nil
+}
+
+// RemoveSampleData deletes the sample collection and the favorites SeedSampleData added
+func (a *App) RemoveSampleData() error {
+	if err := a.checkWritable("remove sample data"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+	if !a.HasSampleData() {
+		return newAppError(ErrCodeNotFound, "no sample data to remove")
+	}
+
+	var added []int
+	if err := json.Unmarshal([]byte(a.settings.Get("sampleData.favorites", "")), &added); err != nil {
+		log.Printf("Failed to parse sample favorites: %v", err)
+	}
+
+	// The user may have deleted the collection already
+	if _, err := a.collectionID(sampleCollection); err == nil {
+		if err := a.DeleteCollection(sampleCollection); err != nil {
+			return err
+		}
+	}
+
+	if err := a.favorites.Remove(added); err != nil {
+		return fmt.Errorf("failed to remove sample favorites: %w", err)
+	}
+
+	if err := a.settings.Set("sampleData.favorites", ""); err != nil {
+		return err
+	}
+	a.emit(EventSampleDataChanged, false)
+	return nil
+}
+
+// ShouldOfferSampleData reports whether the UI should offer to seed sample data:
+// the profile is writable, has no favorites or collections, and the offer wasn't dismissed
+func (a *App) ShouldOfferSampleData() bool {
+	if a.isReadOnly() || a.HasSampleData() || a.settings.GetBool("sampleData.dismissed", false) {
+		return false
+	}
+	if a.favorites.Count() > 0 {
+		return false
+	}
+	collections, err := a.ListCollections()
+	return err == nil && len(collections) == 0
+}
+
+// DismissSampleData stops offering sample data for this profile
+func (a *App) DismissSampleData() error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	return a.settings.Set("sampleData.dismissed", strconv.FormatBool(true))
+}
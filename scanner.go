@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode"
+	"unicode/utf16"
+)
+
+const (
+	// scanMaxFileSize skips files too large to be hand-written config
+	scanMaxFileSize = 2 << 20
+
+	// scanMaxLocations caps how many locations are reported per glyph
+	scanMaxLocations = 20
+)
+
+// scanSkipDirs are directories that never contain hand-written config
+var scanSkipDirs = map[string]bool{
+	".git":         true,
+	".hg":          true,
+	".svn":         true,
+	"node_modules": true,
+	".cache":       true,
+}
+
+// glyphEscapePattern matches escape sequences commonly used for icons in config
+// files: \u{f135}, \U000f0463, \uf135 (or a \udb81\udc63 surrogate pair) and &#xf135;
+var glyphEscapePattern = regexp.MustCompile(`\\u\{([0-9a-fA-F]{4,6})\}|\\U([0-9a-fA-F]{8})|\\u([0-9a-fA-F]{4})|&#x([0-9a-fA-F]{4,6});`)
+
+// GlyphLocation is a line in a scanned file that uses a glyph
+type GlyphLocation struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+}
+
+// GlyphUsage reports how often a known glyph appears in a scanned directory
+type GlyphUsage struct {
+	Glyph
+	Count     int             `json:"count"`
+	Locations []GlyphLocation `json:"locations"`
+}
+
+// UnknownGlyphUsage reports a private use character with no matching glyph
+type UnknownGlyphUsage struct {
+	Codepoint string          `json:"codepoint"`
+	Count     int             `json:"count"`
+	Locations []GlyphLocation `json:"locations"`
+}
+
+// GlyphScanReport is the result of ScanDirectoryForGlyphs
+type GlyphScanReport struct {
+	Root         string              `json:"root"`
+	FilesScanned int                 `json:"filesScanned"`
+	FilesSkipped int                 `json:"filesSkipped"`
+	Glyphs       []GlyphUsage        `json:"glyphs"`
+	Unknown      []UnknownGlyphUsage `json:"unknown"`
+}
+
+// glyphScanner accumulates glyph usage across files
+type glyphScanner struct {
+	byRune  map[rune]Glyph
+	known   map[int]*GlyphUsage
+	unknown map[rune]*UnknownGlyphUsage
+}
+
+// isPrivateUse reports whether r is in a Unicode private use area, where icon fonts live
+func isPrivateUse(r rune) bool {
+	return unicode.In(r, unicode.Co)
+}
+
+// record counts one use of r at a location. Escapes only count when they name a
+// known glyph, since \uXXXX is also used for ordinary characters.
+func (s *glyphScanner) record(r rune, loc GlyphLocation, escaped bool) {
+	if g, ok := s.byRune[r]; ok {
+		usage := s.known[g.ID]
+		if usage == nil {
+			usage = &GlyphUsage{Glyph: g}
+			s.known[g.ID] = usage
+		}
+		usage.Count++
+		if len(usage.Locations) < scanMaxLocations {
+			usage.Locations = append(usage.Locations, loc)
+		}
+		return
+	}
+	if escaped || !isPrivateUse(r) {
+		return
+	}
+
+	usage := s.unknown[r]
+	if usage == nil {
+		usage = &UnknownGlyphUsage{Codepoint: formatCodepoint(r)}
+		s.unknown[r] = usage
+	}
+	usage.Count++
+	if len(usage.Locations) < scanMaxLocations {
+		usage.Locations = append(usage.Locations, loc)
+	}
+}
+
+// isBinary reports whether data looks like a binary file
+func isBinary(data []byte) bool {
+	return bytes.IndexByte(data[:min(len(data), 512)], 0) >= 0
+}
+
+// forEachTextFile calls visit with the contents of every text file under root,
+// skipping VCS and dependency directories, binaries and oversized files
+func forEachTextFile(root string, visit func(rel string, data []byte)) (scanned, skipped int, err error) {
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			skipped++
+			return nil
+		}
+		if d.IsDir() {
+			if p != root && scanSkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err != nil || info.Size() > scanMaxFileSize {
+			skipped++
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil || isBinary(data) {
+			skipped++
+			return nil
+		}
+
+		rel, _ := filepath.Rel(root, p)
+		visit(filepath.ToSlash(rel), data)
+		scanned++
+		return nil
+	})
+	return scanned, skipped, err
+}
+
+// forEachLine calls visit for each line of data with its 1-based number
+func forEachLine(data []byte, visit func(n int, line string)) {
+	lines := bufio.NewScanner(bytes.NewReader(data))
+	lines.Buffer(make([]byte, 0, 64*1024), scanMaxFileSize)
+	for n := 1; lines.Scan(); n++ {
+		visit(n, lines.Text())
+	}
+}
+
+// escapedRunes returns the code points written as escape sequences in line
+func escapedRunes(line string) []rune {
+	var runes []rune
+	for _, m := range glyphEscapePattern.FindAllStringSubmatch(line, -1) {
+		for _, hex := range m[1:] {
+			if hex == "" {
+				continue
+			}
+			cp, err := strconv.ParseUint(hex, 16, 32)
+			if err != nil {
+				continue
+			}
+			// \uD83D\uDE80-style surrogate pairs spell one code point
+			if n := len(runes); n > 0 && utf16.IsSurrogate(runes[n-1]) && utf16.IsSurrogate(rune(cp)) {
+				if r := utf16.DecodeRune(runes[n-1], rune(cp)); r != unicode.ReplacementChar {
+					runes[n-1] = r
+					continue
+				}
+			}
+			runes = append(runes, rune(cp))
+		}
+	}
+	return runes
+}
+
+// scanFile records the glyphs used in a text file
+func (s *glyphScanner) scanFile(rel string, data []byte) {
+	forEachLine(data, func(n int, line string) {
+		loc := GlyphLocation{Path: rel, Line: n}
+		for _, r := range line {
+			if r >= 0x80 {
+				s.record(r, loc, false)
+			}
+		}
+		for _, r := range escapedRunes(line) {
+			s.record(r, loc, true)
+		}
+	})
+}
+
+// glyphsByRune indexes cached glyphs by their first code point
+func (a *App) glyphsByRune() map[rune]Glyph {
+	byRune := make(map[rune]Glyph)
+	for _, g := range a.allGlyphs() {
+		if r := codepointOf(g.Glyph); r != 0 {
+			if _, dup := byRune[r]; !dup {
+				byRune[r] = g
+			}
+		}
+	}
+	return byRune
+}
+
+// ScanDirectoryForGlyphs walks the text files under path and reports which
+// glyphs they use, whether written literally or as escape sequences
+func (a *App) ScanDirectoryForGlyphs(path string) (*GlyphScanReport, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return nil, newAppError(ErrCodeInvalid, "not a directory: %s", root)
+	}
+
+	op := a.startOperation("scan", "Scanning for glyphs…")
+
+	scanner := &glyphScanner{
+		byRune:  a.glyphsByRune(),
+		known:   make(map[int]*GlyphUsage),
+		unknown: make(map[rune]*UnknownGlyphUsage),
+	}
+
+	report := &GlyphScanReport{Root: root}
+	var seen int
+	report.FilesScanned, report.FilesSkipped, err = forEachTextFile(root, func(rel string, data []byte) {
+		scanner.scanFile(rel, data)
+		if seen++; seen%100 == 0 {
+			op.Progress(-1, fmt.Sprintf("Scanning for glyphs… %d files", seen))
+		}
+	})
+	if err != nil {
+		return nil, op.Fail("Could not scan the directory", err)
+	}
+
+	report.Glyphs = make([]GlyphUsage, 0, len(scanner.known))
+	for _, usage := range scanner.known {
+		report.Glyphs = append(report.Glyphs, *usage)
+	}
+	sort.Slice(report.Glyphs, func(i, j int) bool {
+		if report.Glyphs[i].Count != report.Glyphs[j].Count {
+			return report.Glyphs[i].Count > report.Glyphs[j].Count
+		}
+		return report.Glyphs[i].Name < report.Glyphs[j].Name
+	})
+
+	// Codepoint strings don't sort numerically ("U+10000" < "U+E000"), so order by rune
+	codepoints := make([]rune, 0, len(scanner.unknown))
+	for r := range scanner.unknown {
+		codepoints = append(codepoints, r)
+	}
+	sort.Slice(codepoints, func(i, j int) bool { return codepoints[i] < codepoints[j] })
+	report.Unknown = make([]UnknownGlyphUsage, 0, len(codepoints))
+	for _, r := range codepoints {
+		report.Unknown = append(report.Unknown, *scanner.unknown[r])
+	}
+
+	log.Printf("Scanned %d files under %s: %d glyphs, %d unknown", report.FilesScanned, root, len(report.Glyphs), len(report.Unknown))
+	op.Succeed(fmt.Sprintf("Found %d glyphs in %d files", len(report.Glyphs), report.FilesScanned))
+	return report, nil
+}
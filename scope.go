@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+)
+
+// Search scopes accepted by GetGlyphs
+const (
+	ScopeAll              = "all"
+	ScopeFavorites        = "favorites"
+	ScopeCollectionPrefix = "collection:"
+	ScopeCategoryPrefix   = "category:"
+	ScopeSmartPrefix      = "smart:"
+)
+
+// scopeIDs returns the glyph ids a search scope is restricted to.
+// A nil map means the scope is unrestricted.
+func (a *App) scopeIDs(scope string) (map[int]bool, error) {
+	switch {
+	case scope == "" || scope == ScopeAll:
+		return nil, nil
+
+	case scope == ScopeFavorites:
+		return a.favorites.Snapshot(), nil
+
+	case strings.HasPrefix(scope, ScopeCollectionPrefix):
+		glyphIDs, err := a.collectionGlyphIDs(strings.TrimPrefix(scope, ScopeCollectionPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make(map[int]bool, len(glyphIDs))
+		for _, id := range glyphIDs {
+			ids[id] = true
+		}
+		return ids, nil
+
+	case strings.HasPrefix(scope, ScopeCategoryPrefix):
+		category := strings.TrimPrefix(scope, ScopeCategoryPrefix)
+		glyphIDs, ok := a.cache.Snapshot().categoryIDs(a.categoryMembers(category))
+		if a.searchMode() == SearchModeDirect {
+			var err error
+			if glyphIDs, err = a.directCategoryIDs(category); err != nil {
+				return nil, err
+			}
+			ok = len(glyphIDs) > 0
+		}
+		if !ok {
+			return nil, newAppError(ErrCodeNotFound, "category %q not found", category)
+		}
+
+		ids := make(map[int]bool, len(glyphIDs))
+		for _, id := range glyphIDs {
+			ids[id] = true
+		}
+		return ids, nil
+
+	case strings.HasPrefix(scope, ScopeSmartPrefix):
+		filter, err := a.findSmartFilter(strings.TrimPrefix(scope, ScopeSmartPrefix))
+		if err != nil {
+			return nil, err
+		}
+		return a.smartFilterIDs(filter)
+	}
+
+	return nil, newAppError(ErrCodeInvalid, "unknown search scope: %s", scope)
+}
+
+// scopeGlyphs returns the glyphs in a scope. Collections keep their saved
+// order; other scopes follow the cache order.
+func (a *App) scopeGlyphs(scope string) ([]Glyph, error) {
+	if strings.HasPrefix(scope, ScopeCollectionPrefix) {
+		glyphIDs, err := a.collectionGlyphIDs(strings.TrimPrefix(scope, ScopeCollectionPrefix))
+		if err != nil {
+			return nil, err
+		}
+		found := a.lookupGlyphs(glyphIDs)
+		glyphs := make([]Glyph, 0, len(glyphIDs))
+		for _, id := range glyphIDs {
+			if g, ok := found[id]; ok {
+				glyphs = append(glyphs, g)
+			}
+		}
+		return glyphs, nil
+	}
+
+	ids, err := a.scopeIDs(scope)
+	if err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		return a.allGlyphs(), nil
+	}
+	return a.glyphsIn(ids), nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"log"
+	"sort"
+)
+
+// Scoring profile names
+const (
+	ScoringBalanced       = "balanced"
+	ScoringStrict         = "strict"
+	ScoringFuzzy          = "fuzzy"
+	ScoringFrequencyHeavy = "frequency-heavy"
+)
+
+// ScoringProfile is a matcher configuration that decides which names match a
+// query and how matches rank
+type ScoringProfile interface {
+	// Name identifies the profile in settings and queries
+	Name() string
+
+	// Description explains when to use the profile
+	Description() string
+
+	// Match scores folded text against a folded pattern
+	Match(pattern, text []rune) (ScoreBreakdown, bool)
+
+	// WantTypos reports whether to add near misses, given whether any name, alias or tag contained the query
+	WantTypos(direct bool) bool
+
+	// FrequencyBoost converts a glyph's copy frecency into a score boost
+	FrequencyBoost(frecency float64) int
+}
+
+// fuzzyProfile matches names containing the pattern's characters in order (fzf style)
+type fuzzyProfile struct {
+	name, description string
+	alwaysTypos       bool
+	perCopy, maxBoost int
+}
+
+func (p fuzzyProfile) Name() string        { return p.name }
+func (p fuzzyProfile) Description() string { return p.description }
+
+func (p fuzzyProfile) Match(pattern, text []rune) (ScoreBreakdown, bool) {
+	return fuzzyScore(pattern, text)
+}
+
+func (p fuzzyProfile) WantTypos(direct bool) bool {
+	return p.alwaysTypos || !direct
+}
+
+func (p fuzzyProfile) FrequencyBoost(frecency float64) int {
+	return min(int(frecency*float64(p.perCopy)), p.maxBoost)
+}
+
+// strictProfile only matches names containing the query verbatim
+type strictProfile struct{}
+
+func (strictProfile) Name() string { return ScoringStrict }
+func (strictProfile) Description() string {
+	return "Only names containing the query exactly; no typo or frequency adjustments"
+}
+
+func (strictProfile) Match(pattern, text []rune) (ScoreBreakdown, bool) {
+	b, ok := fuzzyScore(pattern, text)
+	if !ok || b.MatchType == MatchFuzzy {
+		return ScoreBreakdown{}, false
+	}
+	return b, true
+}
+
+func (strictProfile) WantTypos(direct bool) bool          { return false }
+func (strictProfile) FrequencyBoost(frecency float64) int { return 0 }
+
+// scoringProfiles lists the built-in profiles by name
+var scoringProfiles = map[string]ScoringProfile{
+	ScoringBalanced: fuzzyProfile{
+		name:        ScoringBalanced,
+		description: "Fuzzy matching with typo suggestions when nothing matches directly",
+		perCopy:     frequencyBoostPerCopy,
+		maxBoost:    maxFrequencyBoost,
+	},
+	ScoringStrict: strictProfile{},
+	ScoringFuzzy: fuzzyProfile{
+		name:        ScoringFuzzy,
+		description: "Loosest matching for browsing: subsequences plus near misses on every query",
+		alwaysTypos: true,
+		perCopy:     frequencyBoostPerCopy,
+		maxBoost:    maxFrequencyBoost,
+	},
+	ScoringFrequencyHeavy: fuzzyProfile{
+		name:        ScoringFrequencyHeavy,
+		description: "Fuzzy matching that ranks the glyphs you copy most far above the rest",
+		perCopy:     10 * frequencyBoostPerCopy,
+		maxBoost:    9 * maxFrequencyBoost,
+	},
+}
+
+// ScoringProfileInfo describes a scoring profile for settings screens
+type ScoringProfileInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Active      bool   `json:"active"`
+}
+
+// scoringProfile returns the named profile, or the one chosen in settings when name is empty
+func (a *App) scoringProfile(name string) (ScoringProfile, error) {
+	if name == "" {
+		return a.defaultScoringProfile(), nil
+	}
+	profile, ok := scoringProfiles[name]
+	if !ok {
+		return nil, newAppError(ErrCodeInvalid, "unknown scoring profile: %s", name)
+	}
+	return profile, nil
+}
+
+// defaultScoringProfile returns the profile named by the search.profile
+// setting. An unknown name (from an older version or an imported settings
+// table) searches with the balanced profile rather than failing every query;
+// checkScoringProfileSetting reports it when the profile opens.
+func (a *App) defaultScoringProfile() ScoringProfile {
+	if profile, ok := scoringProfiles[a.settings.Get("search.profile", ScoringBalanced)]; ok {
+		return profile
+	}
+	return scoringProfiles[ScoringBalanced]
+}
+
+// checkScoringProfileSetting logs a search.profile setting that names no profile
+func (a *App) checkScoringProfileSetting() {
+	if name := a.settings.Get("search.profile", ScoringBalanced); scoringProfiles[name] == nil {
+		log.Printf("Unknown scoring profile %q in settings; searching with %s", name, ScoringBalanced)
+	}
+}
+
+// ListScoringProfiles returns the available scoring profiles
+func (a *App) ListScoringProfiles() []ScoringProfileInfo {
+	active := a.defaultScoringProfile().Name()
+	result := make([]ScoringProfileInfo, 0, len(scoringProfiles))
+	for name, p := range scoringProfiles {
+		result = append(result, ScoringProfileInfo{Name: name, Description: p.Description(), Active: name == active})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// SetScoringProfile changes the default scoring profile used when a query doesn't name one
+func (a *App) SetScoringProfile(name string) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if _, ok := scoringProfiles[name]; !ok {
+		return newAppError(ErrCodeInvalid, "unknown scoring profile: %s", name)
+	}
+	return a.settings.Set("search.profile", name)
+}
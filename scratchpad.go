@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxScratchpadGlyphs caps the scratchpad length
+const maxScratchpadGlyphs = 500
+
+// EventScratchpadChanged is emitted with the scratchpad glyphs after every change
+const EventScratchpadChanged = "scratchpad:changed"
+
+// Scratchpad accumulates picked glyphs during a session, e.g. to assemble a prompt segment
+type Scratchpad struct {
+	mu     sync.Mutex
+	ids    []int
+	loaded bool
+}
+
+// scratchpadPersisted reports whether the scratchpad survives restarts
+func (a *App) scratchpadPersisted() bool {
+	return a.settings.GetBool("scratchpad.persist", false)
+}
+
+// loadScratchpad restores a persisted scratchpad once; callers hold scratchpad.mu
+func (a *App) loadScratchpad() {
+	if a.scratchpad.loaded {
+		return
+	}
+	a.scratchpad.loaded = true
+
+	if !a.scratchpadPersisted() {
+		return
+	}
+	raw := a.settings.Get("scratchpad.glyphs", "")
+	if raw == "" {
+		return
+	}
+	if err := json.Unmarshal([]byte(raw), &a.scratchpad.ids); err != nil {
+		log.Printf("Failed to parse saved scratchpad: %v", err)
+	}
+}
+
+// scratchpadChanged persists the scratchpad when enabled and notifies the frontend;
+// callers hold scratchpad.mu
+func (a *App) scratchpadChanged() []Glyph {
+	if a.scratchpadPersisted() {
+		data, _ := json.Marshal(a.scratchpad.ids)
+		if err := a.settings.Set("scratchpad.glyphs", string(data)); err != nil {
+			log.Printf("Failed to save scratchpad: %v", err)
+		}
+	}
+
+	glyphs := a.scratchpadGlyphs()
+	a.emit(EventScratchpadChanged, glyphs)
+	return glyphs
+}
+
+// scratchpadGlyphs resolves the scratchpad ids, skipping glyphs no longer in the
+// database; callers hold scratchpad.mu
+func (a *App) scratchpadGlyphs() []Glyph {
+	glyphs := make([]Glyph, 0, len(a.scratchpad.ids))
+	for _, id := range a.scratchpad.ids {
+		if g, ok := a.findGlyph(id); ok {
+			glyphs = append(glyphs, g)
+		}
+	}
+	return glyphs
+}
+
+// GetScratchpad returns the picked glyphs in order
+func (a *App) GetScratchpad() []Glyph {
+	a.scratchpad.mu.Lock()
+	defer a.scratchpad.mu.Unlock()
+
+	a.loadScratchpad()
+	return a.scratchpadGlyphs()
+}
+
+// AddToScratchpad appends a glyph to the scratchpad; a glyph may appear more than once
+func (a *App) AddToScratchpad(glyphID int) ([]Glyph, error) {
+	if _, ok := a.findGlyph(glyphID); !ok {
+		return nil, newAppError(ErrCodeNotFound, "glyph %d not found", glyphID)
+	}
+
+	a.scratchpad.mu.Lock()
+	defer a.scratchpad.mu.Unlock()
+
+	a.loadScratchpad()
+	if len(a.scratchpad.ids) >= maxScratchpadGlyphs {
+		return nil, newAppError(ErrCodeInvalid, "scratchpad is full (%d glyphs)", maxScratchpadGlyphs)
+	}
+	a.scratchpad.ids = append(a.scratchpad.ids, glyphID)
+	return a.scratchpadChanged(), nil
+}
+
+// RemoveFromScratchpad removes the glyph at index
+func (a *App) RemoveFromScratchpad(index int) ([]Glyph, error) {
+	a.scratchpad.mu.Lock()
+	defer a.scratchpad.mu.Unlock()
+
+	a.loadScratchpad()
+	if index < 0 || index >= len(a.scratchpad.ids) {
+		return nil, newAppError(ErrCodeInvalid, "scratchpad index %d out of range", index)
+	}
+	a.scratchpad.ids = append(a.scratchpad.ids[:index], a.scratchpad.ids[index+1:]...)
+	return a.scratchpadChanged(), nil
+}
+
+// ClearScratchpad empties the scratchpad
+func (a *App) ClearScratchpad() {
+	a.scratchpad.mu.Lock()
+	defer a.scratchpad.mu.Unlock()
+
+	a.scratchpad.loaded = true
+	a.scratchpad.ids = nil
+	a.scratchpadChanged()
+}
+
+// CopyScratchpad copies the scratchpad glyphs joined by separator and returns the text
+func (a *App) CopyScratchpad(separator string) (string, error) {
+	a.scratchpad.mu.Lock()
+	a.loadScratchpad()
+	glyphs := a.scratchpadGlyphs()
+	a.scratchpad.mu.Unlock()
+
+	if len(glyphs) == 0 {
+		return "", newAppError(ErrCodeInvalid, "scratchpad is empty")
+	}
+
+	parts := make([]string, len(glyphs))
+	for i, g := range glyphs {
+		parts[i] = g.Glyph
+	}
+	text := strings.Join(parts, separator)
+
+	if _, err := a.setClipboard(text); err != nil {
+		return "", fmt.Errorf("failed to copy scratchpad: %w", err)
+	}
+	return text, nil
+}
+
+// SetScratchpadPersist controls whether the scratchpad is kept across restarts
+func (a *App) SetScratchpadPersist(enabled bool) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if err := a.settings.Set("scratchpad.persist", strconv.FormatBool(enabled)); err != nil {
+		return err
+	}
+
+	a.scratchpad.mu.Lock()
+	defer a.scratchpad.mu.Unlock()
+
+	if !enabled {
+		return a.settings.Set("scratchpad.glyphs", "")
+	}
+	a.loadScratchpad()
+	a.scratchpadChanged()
+	return nil
+}
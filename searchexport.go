@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Search export formats
+const (
+	ExportFormatJSON     = "json"
+	ExportFormatCSV      = "csv"
+	ExportFormatMarkdown = "markdown"
+)
+
+// exportFormatExt maps each search export format to its file extension
+var exportFormatExt = map[string]string{
+	ExportFormatJSON:     ".json",
+	ExportFormatCSV:      ".csv",
+	ExportFormatMarkdown: ".md",
+}
+
+// exportedGlyph is one row of a search export
+type exportedGlyph struct {
+	Name      string `json:"name"`
+	Glyph     string `json:"glyph"`
+	Codepoint string `json:"codepoint"`
+	IconSet   string `json:"iconSet"`
+}
+
+// exportRow flattens a glyph for export
+func exportRow(g Glyph) exportedGlyph {
+	return exportedGlyph{
+		Name:      g.Name,
+		Glyph:     g.Glyph,
+		Codepoint: formatCodepoint(codepointOf(g.Glyph)),
+		IconSet:   iconSetName(categoryOf(g)),
+	}
+}
+
+// markdownCell escapes a value for a Markdown table cell
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// writeSearchResults streams glyphs to w in the given format
+func writeSearchResults(w io.Writer, format string, glyphs []Glyph, attribution []IconSetLicense) error {
+	switch format {
+	case ExportFormatJSON:
+		// Written row by row so large result sets aren't buffered twice
+		if _, err := io.WriteString(w, "[\n"); err != nil {
+			return err
+		}
+		for i, g := range glyphs {
+			data, err := json.Marshal(exportRow(g))
+			if err != nil {
+				return err
+			}
+			sep := ",\n"
+			if i == len(glyphs)-1 {
+				sep = "\n"
+			}
+			if _, err := fmt.Fprintf(w, "  %s%s", data, sep); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, "]\n")
+		return err
+
+	case ExportFormatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"name", "glyph", "codepoint", "iconSet"})
+		for _, g := range glyphs {
+			row := exportRow(g)
+			if err := cw.Write([]string{row.Name, row.Glyph, row.Codepoint, row.IconSet}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case ExportFormatMarkdown:
+		if _, err := io.WriteString(w, "| Icon | Name | Codepoint | Icon set |\n| --- | --- | --- | --- |\n"); err != nil {
+			return err
+		}
+		for _, g := range glyphs {
+			row := exportRow(g)
+			if _, err := fmt.Fprintf(w, "| %s | `%s` | %s | %s |\n", markdownCell(row.Glyph), row.Name, row.Codepoint, markdownCell(row.IconSet)); err != nil {
+				return err
+			}
+		}
+		if len(attribution) > 0 {
+			if _, err := fmt.Fprintf(w, "\n## Attribution\n\n%s\n\n", attributionHeading); err != nil {
+				return err
+			}
+			for _, line := range attributionLines(attribution) {
+				if _, err := fmt.Fprintf(w, "- %s\n", line); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return newAppError(ErrCodeInvalid, "unknown export format: %s", format)
+}
+
+// searchExporter exports rows of name, glyph, codepoint, and icon set
+func searchExporter(format string) Exporter {
+	return exporterFunc{
+		name:       format,
+		extensions: []string{exportFormatExt[format]},
+		export: func(w io.Writer, glyphs []Glyph, opts ExportOptions) error {
+			return writeSearchResults(w, format, glyphs, opts.Attribution)
+		},
+	}
+}
+
+// ExportSearchResults runs a search without pagination and writes every match
+// in any registered format (e.g. "json", "csv", "markdown"), returning the file's
+// path. An empty path exports to the default exports directory.
+func (a *App) ExportSearchResults(query, category, format, path string) (string, error) {
+	op := a.startOperation("export", "Exporting search results…")
+	matches, _, err := a.matchGlyphs(query, category, ScopeAll, "", false)
+	if err != nil {
+		return "", op.Fail("Could not export search results", err)
+	}
+
+	glyphs := make([]Glyph, len(matches))
+	for i, m := range matches {
+		glyphs[i] = m.Glyph
+	}
+	name := sanitizeFileName(query)
+	if name == "" {
+		name = "glyphs"
+	}
+	return a.exportGlyphs(op, format, "search-"+name, path, fmt.Sprintf("search %q", query), glyphs)
+}
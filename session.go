@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+// sessionSaveDelay batches session writes while the user is typing
+const sessionSaveDelay = time.Second
+
+// SessionState is the last UI query state, restored on the next launch
+type SessionState struct {
+	SearchTerm string    `json:"searchTerm"`
+	Category   string    `json:"category"`
+	Scope      string    `json:"scope"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"pageSize"`
+	ScrollTop  int       `json:"scrollTop"`
+	UpdatedAt  time.Time `json:"updatedAt" ts_type:"string"`
+}
+
+// Session tracks the current UI query state and persists it lazily
+type Session struct {
+	mu    sync.Mutex
+	state *SessionState
+	// restored is the state the previous run saved, read before this run's
+	// first search replaces it
+	restored *SessionState
+	timer    *time.Timer
+}
+
+// sessionRestoreEnabled reports whether the app should reopen where the user left off
+func (a *App) sessionRestoreEnabled() bool {
+	return a.settings.GetBool("session.restore", true)
+}
+
+// recordSession updates the session after a search and schedules a save
+func (a *App) recordSession(searchTerm, category, scope string, limit, offset int) {
+	if !a.sessionRestoreEnabled() {
+		return
+	}
+
+	a.session.mu.Lock()
+	defer a.session.mu.Unlock()
+
+	scrollTop := 0
+	if a.session.state != nil && a.session.state.SearchTerm == searchTerm &&
+		a.session.state.Category == category && a.session.state.Scope == scope {
+		scrollTop = a.session.state.ScrollTop
+	}
+
+	page := 0
+	if limit > 0 {
+		page = offset / limit
+	}
+
+	a.session.state = &SessionState{
+		SearchTerm: searchTerm,
+		Category:   category,
+		Scope:      scope,
+		Page:       page,
+		PageSize:   limit,
+		ScrollTop:  scrollTop,
+		UpdatedAt:  time.Now(),
+	}
+	a.scheduleSessionSave()
+}
+
+// scheduleSessionSave persists the session after a short delay; callers hold session.mu
+func (a *App) scheduleSessionSave() {
+	if a.session.timer != nil {
+		a.session.timer.Stop()
+	}
+	a.session.timer = time.AfterFunc(sessionSaveDelay, a.saveSession)
+}
+
+// saveSession writes the current session to settings
+func (a *App) saveSession() {
+	a.session.mu.Lock()
+	state := a.session.state
+	a.session.mu.Unlock()
+
+	if state == nil {
+		return
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	if err := a.settings.Set("session.last", string(data)); err != nil {
+		log.Printf("Failed to save session: %v", err)
+	}
+}
+
+// flushSession stops any pending save and writes the session immediately
+func (a *App) flushSession() {
+	a.session.mu.Lock()
+	pending := a.session.timer != nil && a.session.timer.Stop()
+	a.session.mu.Unlock()
+
+	if pending {
+		a.saveSession()
+	}
+}
+
+// loadLastSession reads the state the previous run saved and starts a new
+// session; it runs when a profile opens, before any search is recorded
+func (a *App) loadLastSession() {
+	var restored *SessionState
+	if raw := a.settings.Get("session.last", ""); raw != "" {
+		var state SessionState
+		if err := json.Unmarshal([]byte(raw), &state); err != nil {
+			log.Printf("Failed to parse saved session: %v", err)
+		} else {
+			restored = &state
+		}
+	}
+
+	a.session.mu.Lock()
+	a.session.state = nil
+	a.session.restored = restored
+	a.session.mu.Unlock()
+}
+
+// GetLastSession returns the UI state the previous run ended with, or nil when
+// restore is disabled or nothing was saved
+func (a *App) GetLastSession() *SessionState {
+	if !a.sessionRestoreEnabled() {
+		return nil
+	}
+
+	a.session.mu.Lock()
+	defer a.session.mu.Unlock()
+
+	if a.session.restored == nil {
+		return nil
+	}
+	state := *a.session.restored
+	return &state
+}
+
+// SaveSessionScroll records the grid scroll position for the current session
+func (a *App) SaveSessionScroll(scrollTop int) {
+	if !a.sessionRestoreEnabled() {
+		return
+	}
+
+	a.session.mu.Lock()
+	defer a.session.mu.Unlock()
+
+	if a.session.state == nil {
+		a.session.state = &SessionState{Scope: ScopeAll}
+	}
+	a.session.state.ScrollTop = scrollTop
+	a.session.state.UpdatedAt = time.Now()
+	a.scheduleSessionSave()
+}
+
+// ClearLastSession forgets the saved UI state
+func (a *App) ClearLastSession() error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+
+	a.session.mu.Lock()
+	if a.session.timer != nil {
+		a.session.timer.Stop()
+	}
+	a.session.state = nil
+	a.session.restored = nil
+	a.session.mu.Unlock()
+
+	return a.settings.Set("session.last", "")
+}
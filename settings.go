@@ -0,0 +1,158 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+)
+
+// Settings is a persistent key/value store for user preferences
+type Settings struct {
+	mu     sync.RWMutex
+	values map[string]string
+	db     *sql.DB
+
+	// readOnly keeps changes in memory only
+	readOnly bool
+}
+
+// initSettingsTable creates the settings table if it doesn't exist
+func (a *App) initSettingsTable() error {
+	_, err := a.userDB.Exec(`
+		CREATE TABLE IF NOT EXISTS settings (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	return err
+}
+
+// readSettingsTable returns every key/value pair in a database's settings table
+func readSettingsTable(db *sql.DB) (map[string]string, error) {
+	rows, err := db.Query("SELECT key, value FROM settings")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			continue
+		}
+		values[key] = value
+	}
+	return values, rows.Err()
+}
+
+// loadSettings loads all settings from database
+func (a *App) loadSettings() {
+	values, err := readSettingsTable(a.userDB)
+	if err != nil {
+		log.Printf("Failed to load settings: %v", err)
+		return
+	}
+
+	a.settings.mu.Lock()
+	defer a.settings.mu.Unlock()
+
+	a.settings.values = values
+	log.Printf("Loaded %d settings", len(a.settings.values))
+}
+
+// Get returns the value stored under key, or def if it is unset
+func (s *Settings) Get(key, def string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if v, ok := s.values[key]; ok {
+		return v
+	}
+	return def
+}
+
+// GetBool returns a boolean setting, or def if it is unset or invalid
+func (s *Settings) GetBool(key string, def bool) bool {
+	v, err := strconv.ParseBool(s.Get(key, ""))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// GetInt returns an integer setting, or def if it is unset or invalid
+func (s *Settings) GetInt(key string, def int) int {
+	v, err := strconv.Atoi(s.Get(key, ""))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// setReadOnly controls whether Set persists changes
+func (s *Settings) setReadOnly(readOnly bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readOnly = readOnly
+}
+
+// Set stores a setting in memory and persists it when a writable database is available
+func (s *Settings) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db != nil && !s.readOnly {
+		_, err := s.db.Exec(`
+			INSERT INTO settings (key, value, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at
+		`, key, value)
+		if err != nil {
+			return fmt.Errorf("failed to save setting %s: %w", key, err)
+		}
+	}
+
+	s.values[key] = value
+	return nil
+}
+
+// All returns a copy of every stored setting
+func (s *Settings) All() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string]string, len(s.values))
+	for k, v := range s.values {
+		result[k] = v
+	}
+	return result
+}
+
+// GetSettings returns all stored settings
+func (a *App) GetSettings() map[string]string {
+	return a.settings.All()
+}
+
+// SetSetting updates a single setting
+func (a *App) SetSetting(key string, value string) error {
+	if key == "" {
+		return newAppError(ErrCodeInvalid, "setting key is required")
+	}
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	switch key {
+	case pageSizeSetting:
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			return newAppError(ErrCodeInvalid, "invalid page size: %s", value)
+		}
+		return a.SetPageSize(size)
+	case "search.profile":
+		return a.SetScoringProfile(value)
+	}
+	return a.settings.Set(key, value)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// localUserDBName holds the default profile's user data when the glyph database is shared
+const localUserDBName = "user.db"
+
+// SharedDatabaseInfo describes shared database mode
+type SharedDatabaseInfo struct {
+	Enabled bool `json:"enabled"`
+	// Path is the shared glyph database, opened read-only
+	Path string `json:"path,omitempty"`
+	// LocalPath holds this user's favorites, tags, and history
+	LocalPath string `json:"localPath,omitempty"`
+}
+
+// isShared reports whether the glyph database is a shared, read-only one
+func (a *App) isShared() bool {
+	return a.sharedDBPath != ""
+}
+
+// localUserPath returns the local database of the default profile in shared mode
+func (a *App) localUserPath() string {
+	return filepath.Join(a.dataDir(), localUserDBName)
+}
+
+// homeDB returns the database holding the default profile and the active
+// profile name: the glyph database, or the local user database in shared mode
+func (a *App) homeDB() *sql.DB {
+	if a.localDB != nil {
+		return a.localDB
+	}
+	return a.db
+}
+
+// homeDBPath returns the file behind homeDB
+func (a *App) homeDBPath() string {
+	if a.isShared() {
+		return a.localUserPath()
+	}
+	return a.dbPath
+}
+
+// openSharedGlyphDatabase opens a glyph database on a network share without
+// ever writing to it. It can't be migrated in place, so it must already have
+// the current schema.
+func openSharedGlyphDatabase(path string) (*sql.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("shared database not found: %w", err)
+	}
+
+	db, err := openSQLite(path, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open shared database: %w", err)
+	}
+	if err := validateGlyphDatabase(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	for _, column := range []string{"description", "block"} {
+		exists, err := columnExists(db, "glyphs", column)
+		if err != nil || !exists {
+			db.Close()
+			return nil, fmt.Errorf("shared database lacks glyphs.%s; open it once without --shared-db to migrate it", column)
+		}
+	}
+	return db, nil
+}
+
+// openDatabases opens the glyph database and its read pool. In shared mode the
+// glyph database is read-only and the default profile lives in a local
+// database next to the other profiles.
+func (a *App) openDatabases() error {
+	if !a.isShared() {
+		db, err := openGlyphDatabase(a.dbPath)
+		if err != nil {
+			return err
+		}
+		a.db = db
+		a.readDB = openReadPool(a.dbPath, db)
+		return nil
+	}
+
+	if abs, err := filepath.Abs(a.sharedDBPath); err == nil {
+		a.sharedDBPath = abs
+	}
+	db, err := openSharedGlyphDatabase(a.sharedDBPath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(a.dataDir(), 0755); err != nil {
+		db.Close()
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	local, err := openSQLite(a.localUserPath(), false)
+	if err != nil {
+		db.Close()
+		return fmt.Errorf("failed to open local user database: %w", err)
+	}
+	a.db, a.readDB, a.localDB = db, db, local
+	a.dbPath = a.sharedDBPath
+	log.Printf("Shared database: %s (user data in %s)", a.sharedDBPath, a.localUserPath())
+	return nil
+}
+
+// checkGlyphsWritable refuses changes to the glyph database itself in shared mode
+func (a *App) checkGlyphsWritable(op string) error {
+	if a.isShared() {
+		return newAppError(ErrCodeReadOnly, "cannot %s: the glyph database is shared", op)
+	}
+	return nil
+}
+
+// GetSharedDatabase reports whether the glyph database is shared and where user data is kept
+func (a *App) GetSharedDatabase() SharedDatabaseInfo {
+	if !a.isShared() {
+		return SharedDatabaseInfo{}
+	}
+	return SharedDatabaseInfo{Enabled: true, Path: a.sharedDBPath, LocalPath: a.localUserPath()}
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+)
+
+// Single glyph copy formats smart copy rules can pick, besides the
+// documentation and plugin formats
+const (
+	CopyFormatRaw       = "raw"       // the character itself
+	CopyFormatEscape    = "escape"    // \uF135, as in JavaScript, TypeScript and JSON strings
+	CopyFormatHTML      = "html"      // &#xF135;
+	CopyFormatCodepoint = "codepoint" // U+F135
+	CopyFormatName      = "name"      // nf-fa-rocket
+)
+
+// EventSmartCopy is emitted with the target app and format when smart copy picks a format
+const EventSmartCopy = "copy:smart"
+
+// ForegroundApp is the application focused when a glyph is copied
+type ForegroundApp struct {
+	// Name is the display or window class name, e.g. "Code"
+	Name string `json:"name"`
+	// ID is the bundle id, executable path or window instance, e.g. "com.microsoft.VSCode"
+	ID  string `json:"id"`
+	PID int    `json:"pid"`
+}
+
+// CopyRule picks the copy format when the focused app's name or id matches
+// AppPattern (a case-insensitive regular expression)
+type CopyRule struct {
+	ID         int       `json:"id"`
+	AppPattern string    `json:"appPattern"`
+	Format     string    `json:"format"`
+	Enabled    bool      `json:"enabled"`
+	CreatedAt  time.Time `json:"createdAt" ts_type:"string"`
+}
+
+// CopyTarget reports the app smart copy would copy for and the format it would use
+type CopyTarget struct {
+	App    *ForegroundApp `json:"app,omitempty"`
+	Format string         `json:"format"`
+	RuleID int            `json:"ruleId,omitempty"`
+}
+
+// defaultCopyRules are created with a profile's user data: code literals for
+// editors, the character itself for terminals. Short, common names only match
+// as a whole name or executable, the others as whole words.
+var defaultCopyRules = []CopyRule{
+	{AppPattern: `(^|[/\\])(code|code-oss|cursor|vscodium)(\.exe)?$|\b(vscode|jetbrains|goland|pycharm|intellij|sublime_text|sublimetext)\b`, Format: CopyFormatEscape},
+	{AppPattern: `(^|[/\\])(foot|footclient)$|\b(terminal|alacritty|kitty|wezterm|iterm2?|konsole|ghostty|xterm)\b`, Format: CopyFormatRaw},
+}
+
+// SmartCopy remembers the last app other than Gylte that had focus, since
+// copying from Gylte's own window targets the app it was summoned from
+type SmartCopy struct {
+	mu      sync.Mutex
+	lastApp *ForegroundApp
+}
+
+// initCopyRulesTable creates the smart copy rules table, seeding the defaults
+// the first time a writable profile opens it
+func (a *App) initCopyRulesTable() error {
+	if _, err := a.userDB.Exec(`
+		CREATE TABLE IF NOT EXISTS copy_rules (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			app_pattern TEXT NOT NULL,
+			format TEXT NOT NULL,
+			enabled INTEGER NOT NULL DEFAULT 1,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+	`); err != nil {
+		return err
+	}
+	if a.isReadOnly() {
+		return nil
+	}
+	// sqlite_sequence keeps a row for the table once any rule was added, so
+	// rules the user deleted aren't seeded again
+	var seeded int
+	if err := a.userDB.QueryRow("SELECT COUNT(*) FROM sqlite_sequence WHERE name = 'copy_rules'").Scan(&seeded); err != nil {
+		return err
+	}
+	if seeded > 0 {
+		return nil
+	}
+	for _, r := range defaultCopyRules {
+		if _, err := a.userDB.Exec("INSERT INTO copy_rules (app_pattern, format) VALUES (?, ?)", r.AppPattern, r.Format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// smartCopyEnabled reports whether copies pick their format from the focused app
+func (a *App) smartCopyEnabled() bool {
+	return a.settings.GetBool("copy.smart", false)
+}
+
+// SetSmartCopy turns choosing the copy format by focused app on or off
+func (a *App) SetSmartCopy(enabled bool) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if enabled {
+		if err := foregroundAppSupport(); err != nil {
+			return newAppError(ErrCodeUnsupported, "smart copy can't detect the focused app: %v", err)
+		}
+	}
+	return a.settings.Set("copy.smart", strconv.FormatBool(enabled))
+}
+
+// validCopyFormat reports whether smart copy can produce format
+func (a *App) validCopyFormat(format string) bool {
+	switch format {
+	case CopyFormatRaw, CopyFormatEscape, CopyFormatHTML, CopyFormatCodepoint, CopyFormatName:
+		return true
+	}
+	if _, ok := docFormats[format]; ok {
+		return true
+	}
+	_, ok := a.pluginCopyFormat(format)
+	return ok
+}
+
+// codeEscape writes s as \uXXXX UTF-16 escapes, with surrogate pairs outside
+// the BMP, which JavaScript, TypeScript, JSON, Java and C# all read
+func codeEscape(s string) string {
+	var b strings.Builder
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "\\u%04X", u)
+	}
+	return b.String()
+}
+
+// formatGlyphAs renders one glyph in a copy format
+func (a *App) formatGlyphAs(g Glyph, format string) (string, error) {
+	switch format {
+	case CopyFormatRaw:
+		return g.Glyph, nil
+	case CopyFormatEscape:
+		return codeEscape(g.Glyph), nil
+	case CopyFormatHTML:
+		return fmt.Sprintf("&#x%X;", codepointOf(g.Glyph)), nil
+	case CopyFormatCodepoint:
+		return formatCodepoint(codepointOf(g.Glyph)), nil
+	case CopyFormatName:
+		return g.Name, nil
+	}
+	return a.FormatGlyphs([]int{g.ID}, format)
+}
+
+// ListCopyRules returns the smart copy rules in the order they are tried
+func (a *App) ListCopyRules() ([]CopyRule, error) {
+	rules := []CopyRule{}
+	if a.userDB == nil {
+		return rules, nil
+	}
+
+	rows, err := a.userDB.Query("SELECT id, app_pattern, format, enabled, created_at FROM copy_rules ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list copy rules: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r CopyRule
+		if err := rows.Scan(&r.ID, &r.AppPattern, &r.Format, &r.Enabled, &r.CreatedAt); err != nil {
+			log.Printf("Error scanning copy rule: %v", err)
+			continue
+		}
+		rules = append(rules, r)
+	}
+	return rules, rows.Err()
+}
+
+// validateCopyRule checks a rule's pattern and format before it is stored
+func (a *App) validateCopyRule(appPattern, format string) error {
+	if err := a.checkWritable("change copy rules"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+	if _, err := compileTagPattern(appPattern); err != nil {
+		return invalidArgument("appPattern", "%v", err)
+	}
+	if !a.validCopyFormat(format) {
+		return invalidArgument("format", "unknown copy format: %s", format)
+	}
+	return nil
+}
+
+// CreateCopyRule adds a rule copying in format when the focused app matches appPattern
+func (a *App) CreateCopyRule(appPattern, format string) (int, error) {
+	if err := a.validateCopyRule(appPattern, format); err != nil {
+		return 0, err
+	}
+	res, err := a.userDB.Exec("INSERT INTO copy_rules (app_pattern, format) VALUES (?, ?)", appPattern, format)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create copy rule: %w", err)
+	}
+	id, err := res.LastInsertId()
+	return int(id), err
+}
+
+// UpdateCopyRule changes a rule's pattern, format and whether it is enabled
+func (a *App) UpdateCopyRule(id int, appPattern, format string, enabled bool) error {
+	if err := a.validateCopyRule(appPattern, format); err != nil {
+		return err
+	}
+	res, err := a.userDB.Exec("UPDATE copy_rules SET app_pattern = ?, format = ?, enabled = ? WHERE id = ?", appPattern, format, enabled, id)
+	if err != nil {
+		return fmt.Errorf("failed to update copy rule: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return newAppError(ErrCodeNotFound, "copy rule %d not found", id)
+	}
+	return nil
+}
+
+// DeleteCopyRule removes a smart copy rule
+func (a *App) DeleteCopyRule(id int) error {
+	if err := a.checkWritable("change copy rules"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+
+	res, err := a.userDB.Exec("DELETE FROM copy_rules WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete copy rule: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return newAppError(ErrCodeNotFound, "copy rule %d not found", id)
+	}
+	return nil
+}
+
+// matchCopyRule returns the first enabled rule matching app, skipping rules
+// whose pattern no longer compiles
+func matchCopyRule(rules []CopyRule, app ForegroundApp) (CopyRule, bool) {
+	for _, r := range rules {
+		if !r.Enabled {
+			continue
+		}
+		re, err := compileTagPattern(r.AppPattern)
+		if err != nil {
+			log.Printf("Skipping copy rule %d: %v", r.ID, err)
+			continue
+		}
+		if re.MatchString(app.Name) || (app.ID != "" && re.MatchString(app.ID)) {
+			return r, true
+		}
+	}
+	return CopyRule{}, false
+}
+
+// isOwnWindow reports whether app is this process
+func isOwnWindow(app *ForegroundApp) bool {
+	return app.PID == os.Getpid()
+}
+
+// rememberTargetApp records the focused app before Gylte's window takes focus
+func (a *App) rememberTargetApp() {
+	if !a.smartCopyEnabled() {
+		return
+	}
+	if app, err := foregroundApp(); err == nil && !isOwnWindow(app) {
+		a.smartCopy.mu.Lock()
+		a.smartCopy.lastApp = app
+		a.smartCopy.mu.Unlock()
+	}
+}
+
+// copyTarget works out which app a copy is for and the format its rule picks,
+// raw when no rule matches or the app can't be detected
+func (a *App) copyTarget() CopyTarget {
+	target := CopyTarget{Format: CopyFormatRaw}
+
+	app, err := foregroundApp()
+	a.smartCopy.mu.Lock()
+	if err == nil && !isOwnWindow(app) {
+		a.smartCopy.lastApp = app
+	} else {
+		app = a.smartCopy.lastApp
+	}
+	a.smartCopy.mu.Unlock()
+	if app == nil {
+		if err != nil {
+			log.Printf("Smart copy: %v", err)
+		}
+		return target
+	}
+	target.App = app
+
+	rules, err := a.ListCopyRules()
+	if err != nil {
+		log.Printf("Failed to load copy rules: %v", err)
+		return target
+	}
+	if r, ok := matchCopyRule(rules, *app); ok && a.validCopyFormat(r.Format) {
+		target.Format, target.RuleID = r.Format, r.ID
+	}
+	return target
+}
+
+// GetCopyTarget reports the focused app and the format a smart copy would use now
+func (a *App) GetCopyTarget() (*CopyTarget, error) {
+	if err := foregroundAppSupport(); err != nil {
+		return nil, newAppError(ErrCodeUnsupported, "can't detect the focused app: %v", err)
+	}
+	target := a.copyTarget()
+	return &target, nil
+}
+
+// smartCopyText returns the text to copy for g: in the focused app's format
+// when smart copy is on, otherwise the character itself
+func (a *App) smartCopyText(g Glyph) string {
+	if !a.smartCopyEnabled() {
+		return g.Glyph
+	}
+	target := a.copyTarget()
+	if target.Format == CopyFormatRaw {
+		return g.Glyph
+	}
+	text, err := a.formatGlyphAs(g, target.Format)
+	if err != nil {
+		log.Printf("Smart copy as %s failed, copying the glyph: %v", target.Format, err)
+		return g.Glyph
+	}
+	a.emit(EventSmartCopy, target)
+	return strings.TrimRight(text, "\n")
+}
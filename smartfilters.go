@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"Gylte/internal/glyphdata"
+)
+
+// SmartFilter is a curated theme cutting across icon sets. A glyph belongs to it
+// when it matches the search query, its name matches the pattern, or it carries one of the tags.
+type SmartFilter struct {
+	Name    string   `json:"name"`
+	Title   string   `json:"title"`
+	Query   string   `json:"query,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	Count   int      `json:"count"`
+	Sample  string   `json:"sample"`
+}
+
+// loadSmartFilters reads the smart_filters table, falling back to the built-in
+// filters when the database doesn't have one
+func (a *App) loadSmartFilters() ([]SmartFilter, error) {
+	filters := make([]SmartFilter, len(glyphdata.SmartFilters))
+	for i, f := range glyphdata.SmartFilters {
+		filters[i] = SmartFilter{Name: f.Name, Title: f.Title, Query: f.Query, Pattern: f.Pattern, Tags: f.Tags}
+	}
+	if a.readDB == nil {
+		return filters, nil
+	}
+	if exists, err := tableExists(a.readDB, "smart_filters"); err != nil || !exists {
+		return filters, nil
+	}
+
+	rows, err := a.readDB.Query(`
+		SELECT name, title, COALESCE(query, ''), COALESCE(pattern, ''), COALESCE(tags, '')
+		FROM smart_filters
+		ORDER BY position, name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load smart filters: %w", err)
+	}
+	defer rows.Close()
+
+	filters = filters[:0]
+	for rows.Next() {
+		var f SmartFilter
+		var tags string
+		if err := rows.Scan(&f.Name, &f.Title, &f.Query, &f.Pattern, &tags); err != nil {
+			continue
+		}
+		f.Tags = normalizeTags(strings.Split(tags, ","))
+		filters = append(filters, f)
+	}
+	return filters, rows.Err()
+}
+
+// findSmartFilter returns the smart filter with the given name
+func (a *App) findSmartFilter(name string) (SmartFilter, error) {
+	filters, err := a.loadSmartFilters()
+	if err != nil {
+		return SmartFilter{}, err
+	}
+	for _, f := range filters {
+		if f.Name == name {
+			return f, nil
+		}
+	}
+	return SmartFilter{}, newAppError(ErrCodeNotFound, "smart filter %q not found", name)
+}
+
+// smartFilterIDs returns the ids of the glyphs in a smart filter
+func (a *App) smartFilterIDs(f SmartFilter) (map[int]bool, error) {
+	return a.smartFilterIDsIn(f, a.allGlyphs())
+}
+
+// smartFilterIDsIn is smartFilterIDs matching patterns and tags against glyphs,
+// so callers checking several filters read the glyphs once
+func (a *App) smartFilterIDsIn(f SmartFilter, glyphs []Glyph) (map[int]bool, error) {
+	ids := make(map[int]bool)
+
+	if f.Query != "" {
+		matches, _, err := a.matchGlyphs(f.Query, "", ScopeAll, "", false)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			ids[m.ID] = true
+		}
+	}
+
+	var pattern *regexp.Regexp
+	if f.Pattern != "" {
+		var err error
+		if pattern, err = regexp.Compile("(?i)" + f.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern for smart filter %s: %w", f.Name, err)
+		}
+	}
+	tags := make(map[string]bool, len(f.Tags))
+	for _, tag := range f.Tags {
+		tags[tag] = true
+	}
+
+	for _, g := range glyphs {
+		if pattern != nil && pattern.MatchString(g.Name) {
+			ids[g.ID] = true
+			continue
+		}
+		for _, tag := range strings.Split(g.Tags, ",") {
+			if tags[tag] {
+				ids[g.ID] = true
+				break
+			}
+		}
+	}
+	return ids, nil
+}
+
+// GetSmartFilters returns the curated themes with their glyph counts and a sample glyph
+func (a *App) GetSmartFilters() ([]SmartFilter, error) {
+	filters, err := a.loadSmartFilters()
+	if err != nil {
+		return nil, err
+	}
+
+	glyphs := a.allGlyphs()
+	for i := range filters {
+		ids, err := a.smartFilterIDsIn(filters[i], glyphs)
+		if err != nil {
+			return nil, err
+		}
+		filters[i].Count = len(ids)
+		for _, g := range glyphs {
+			if ids[g.ID] {
+				filters[i].Sample = g.Glyph
+				break
+			}
+		}
+	}
+	return filters, nil
+}
+
+// ApplySmartFilter returns one page of a smart filter's glyphs; a limit of 0 uses
+// the configured page size. The same results are available from GetGlyphs with
+// the "smart:<name>" scope, which also narrows them by a search term.
+func (a *App) ApplySmartFilter(name string, limit int, offset int) (*SearchResponse, error) {
+	return a.GetGlyphs(SearchRequest{Limit: limit, Offset: offset, Scope: ScopeSmartPrefix + name})
+}
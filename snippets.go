@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// snippet tools supported by ExportSnippets
+const (
+	SnippetToolEspanso    = "espanso"
+	SnippetToolAutoHotkey = "autohotkey"
+)
+
+// snippet maps a text expander trigger to a glyph
+type snippet struct {
+	Trigger string
+	Glyph   Glyph
+}
+
+// snippetTrigger builds a ":name:" trigger from the glyph name without its
+// "nf-<category>-" prefix, qualifying it with the category when qualified is set
+func snippetTrigger(g Glyph, qualified bool) string {
+	category := glyphCategory(g.Name)
+	short := strings.TrimPrefix(g.Name, "nf-"+category+"-")
+	if qualified && category != "" {
+		short = category + "-" + short
+	}
+	return ":" + short + ":"
+}
+
+// buildSnippets assigns each glyph a trigger, falling back to category-qualified
+// triggers where short names collide (e.g. fa and md both have "rocket")
+func buildSnippets(glyphs []Glyph) []snippet {
+	counts := make(map[string]int)
+	for _, g := range glyphs {
+		counts[snippetTrigger(g, false)]++
+	}
+
+	snippets := make([]snippet, 0, len(glyphs))
+	for _, g := range glyphs {
+		trigger := snippetTrigger(g, false)
+		if counts[trigger] > 1 {
+			trigger = snippetTrigger(g, true)
+		}
+		snippets = append(snippets, snippet{Trigger: trigger, Glyph: g})
+	}
+	return snippets
+}
+
+// espansoConfig renders snippets as an espanso match file
+func espansoConfig(source string, attribution []IconSetLicense, snippets []snippet) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by Gylte from %s\n", source)
+	b.WriteString(attributionComment("# ", attribution))
+	b.WriteString("matches:\n")
+	for _, s := range snippets {
+		fmt.Fprintf(&b, "  - trigger: %s\n    replace: %s\n", strconv.Quote(s.Trigger), strconv.Quote(s.Glyph.Glyph))
+	}
+	return b.String()
+}
+
+// autoHotkeyScript renders snippets as AutoHotkey v2 hotstrings. Colons in the
+// trigger and backticks in the replacement are escaped with a backtick.
+func autoHotkeyScript(source string, attribution []IconSetLicense, snippets []snippet) string {
+	escape := strings.NewReplacer("`", "``", ":", "`:").Replace
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "; Generated by Gylte from %s\n", source)
+	b.WriteString(attributionComment("; ", attribution))
+	b.WriteString("#Requires AutoHotkey v2.0\n\n")
+	for _, s := range snippets {
+		fmt.Fprintf(&b, ":*T:%s::%s\n", escape(s.Trigger), strings.ReplaceAll(s.Glyph.Glyph, "`", "``"))
+	}
+	return b.String()
+}
+
+// snippetExporter exports ":name:" triggers for a text expander
+func snippetExporter(tool string) Exporter {
+	render, ext := espansoConfig, ".yml"
+	if tool == SnippetToolAutoHotkey {
+		render, ext = autoHotkeyScript, ".ahk"
+	}
+	return exporterFunc{
+		name:       tool,
+		extensions: []string{ext},
+		export: func(w io.Writer, glyphs []Glyph, opts ExportOptions) error {
+			_, err := io.WriteString(w, render(opts.Title, opts.Attribution, buildSnippets(glyphs)))
+			return err
+		},
+	}
+}
+
+// ExportSnippets writes an espanso or AutoHotkey config mapping ":name:" triggers
+// to the glyphs of a collection (favorites when collection is empty) and returns its path
+func (a *App) ExportSnippets(collection string, tool string) (string, error) {
+	tool = strings.ToLower(tool)
+	if tool == "ahk" {
+		tool = SnippetToolAutoHotkey
+	}
+	if tool != SnippetToolEspanso && tool != SnippetToolAutoHotkey {
+		return "", newAppError(ErrCodeInvalid, "unsupported snippet tool: %s", tool)
+	}
+
+	op := a.startOperation("export", "Exporting snippets…")
+
+	var glyphs []Glyph
+	source, name := "favorites", "favorites"
+	if collection == "" {
+		for _, f := range a.favoriteGlyphs() {
+			glyphs = append(glyphs, f.Glyph)
+		}
+	} else {
+		ids, err := a.collectionGlyphIDs(collection)
+		if err != nil {
+			return "", op.Fail("Could not export snippets", err)
+		}
+		found := a.lookupGlyphs(ids)
+		for _, id := range ids {
+			if g, ok := found[id]; ok {
+				glyphs = append(glyphs, g)
+			}
+		}
+		source, name = fmt.Sprintf("collection %q", collection), collection
+	}
+
+	return a.exportGlyphs(op, tool, fmt.Sprintf("snippets-%s-%s", tool, sanitizeFileName(name)), "", source, glyphs)
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"Gylte/internal/glyphdata"
+)
+
+// sourceIDStride separates the id ranges of attached databases so glyph ids stay
+// unique (and favorites stable) after merging
+const sourceIDStride = 10_000_000
+
+// AttachedSource is an extra glyph database merged into search results
+type AttachedSource struct {
+	Path string `json:"path"`
+	Slot int    `json:"slot"`
+}
+
+// SourceInfo describes a glyph source for display
+type SourceInfo struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Primary    bool   `json:"primary"`
+	GlyphCount int    `json:"glyphCount"`
+}
+
+// sourceName derives a display name from a database path (e.g. "/x/corp-icons.db" -> "corp-icons")
+func sourceName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// attachedSourceName names an attached database by its file name, adding its
+// slot when the primary or another attached database has the same file name
+// (e.g. "icons-2"), so their results stay apart
+func attachedSourceName(primary string, sources []AttachedSource, src AttachedSource) string {
+	name := sourceName(src.Path)
+	if name == sourceName(primary) {
+		return fmt.Sprintf("%s-%d", name, src.Slot)
+	}
+	for _, other := range sources {
+		if other.Slot != src.Slot && sourceName(other.Path) == name {
+			return fmt.Sprintf("%s-%d", name, src.Slot)
+		}
+	}
+	return name
+}
+
+// glyphColumns returns the SELECT list for glyph rows read with scanGlyph.
+// Read-only databases from older generators can't be migrated, so missing
+// columns are tolerated.
+func glyphColumns(db *sql.DB) string {
+	description := "COALESCE(description, '')"
+	if ok, _ := columnExists(db, "glyphs", "description"); !ok {
+		description = "''"
+	}
+	block := "COALESCE(block, '')"
+	if ok, _ := columnExists(db, "glyphs", "block"); !ok {
+		block = "''"
+	}
+	width := "COALESCE(width, '')"
+	if ok, _ := columnExists(db, "glyphs", "width"); !ok {
+		width = "''"
+	}
+	return "id, name, glyph, " + description + ", " + block + ", " + width
+}
+
+// scanGlyph reads a glyph row selected with glyphColumns, offsetting its id by
+// slot and tagging it with source
+func scanGlyph(rows interface{ Scan(...any) error }, slot int, source string) (Glyph, error) {
+	var g Glyph
+	if err := rows.Scan(&g.ID, &g.Name, &g.Glyph, &g.Description, &g.Block, &g.Width); err != nil {
+		return Glyph{}, err
+	}
+	g.ID += slot * sourceIDStride
+	g.Source = source
+	if g.Description == "" {
+		g.Description = describeGlyph(g)
+	}
+	if g.Block == "" {
+		g.Block = glyphdata.BlockOf(codepointOf(g.Glyph))
+	}
+	if g.Width == "" {
+		g.Width = unicodeWidthClass(g.Glyph)
+	}
+	return g, nil
+}
+
+// queryGlyphs loads every glyph from db, offsetting ids by slot and tagging them with source
+func queryGlyphs(db *sql.DB, slot int, source string) ([]Glyph, error) {
+	rows, err := db.Query("SELECT " + glyphColumns(db) + " FROM glyphs ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var glyphs []Glyph
+	for rows.Next() {
+		g, err := scanGlyph(rows, slot, source)
+		if err != nil {
+			log.Printf("Error scanning glyph: %v", err)
+			continue
+		}
+		glyphs = append(glyphs, g)
+	}
+	return glyphs, rows.Err()
+}
+
+// attachedSources returns the extra databases configured for the active profile
+func (a *App) attachedSources() []AttachedSource {
+	var sources []AttachedSource
+	if raw := a.settings.Get("sources.attached", ""); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &sources); err != nil {
+			log.Printf("Failed to parse attached sources: %v", err)
+		}
+	}
+	return sources
+}
+
+// saveAttachedSources persists the attached database list
+func (a *App) saveAttachedSources(sources []AttachedSource) error {
+	data, err := json.Marshal(sources)
+	if err != nil {
+		return err
+	}
+	return a.settings.Set("sources.attached", string(data))
+}
+
+// loadAttachedGlyphs reads all attached databases. Glyphs whose name already
+// exists in an earlier source are skipped, so the primary database wins ties.
+func (a *App) loadAttachedGlyphs(glyphs []Glyph) []Glyph {
+	sources := a.attachedSources()
+	if len(sources) == 0 {
+		return glyphs
+	}
+
+	seen := make(map[string]bool, len(glyphs))
+	for _, g := range glyphs {
+		seen[g.Name] = true
+	}
+
+	merged := false
+	for _, src := range sources {
+		db, err := openSQLite(src.Path, true)
+		if err != nil {
+			log.Printf("Failed to open attached database %s: %v", src.Path, err)
+			continue
+		}
+		extra, err := queryGlyphs(db, src.Slot, attachedSourceName(a.dbPath, sources, src))
+		db.Close()
+		if err != nil {
+			log.Printf("Failed to load attached database %s: %v", src.Path, err)
+			continue
+		}
+
+		duplicates := 0
+		for _, g := range extra {
+			if seen[g.Name] {
+				duplicates++
+				continue
+			}
+			seen[g.Name] = true
+			glyphs = append(glyphs, g)
+			merged = true
+		}
+		log.Printf("Attached %s: %d glyphs (%d duplicates skipped)", src.Path, len(extra)-duplicates, duplicates)
+	}
+
+	if merged {
+		sort.SliceStable(glyphs, func(i, j int) bool {
+			return glyphs[i].Name < glyphs[j].Name
+		})
+	}
+	return glyphs
+}
+
+// AttachDatabase merges another glyph database into search results
+func (a *App) AttachDatabase(path string) error {
+	if err := a.checkWritable("attach databases"); err != nil {
+		return err
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if abs == a.GetCurrentDatabase() {
+		return newAppError(ErrCodeConflict, "%s is already the primary database", abs)
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return fmt.Errorf("database not found: %w", err)
+	}
+
+	db, err := openSQLite(abs, true)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	err = validateGlyphDatabase(db)
+	db.Close()
+	if err != nil {
+		return err
+	}
+
+	sources := a.attachedSources()
+	slot := 1
+	for _, src := range sources {
+		if src.Path == abs {
+			return newAppError(ErrCodeConflict, "%s is already attached", abs)
+		}
+		if src.Slot >= slot {
+			slot = src.Slot + 1
+		}
+	}
+
+	if err := a.saveAttachedSources(append(sources, AttachedSource{Path: abs, Slot: slot})); err != nil {
+		return err
+	}
+	return a.RebuildCache()
+}
+
+// DetachDatabase removes an attached glyph database from search results
+func (a *App) DetachDatabase(path string) error {
+	if err := a.checkWritable("detach databases"); err != nil {
+		return err
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	sources := a.attachedSources()
+	kept := sources[:0]
+	for _, src := range sources {
+		if src.Path != abs {
+			kept = append(kept, src)
+		}
+	}
+	if len(kept) == len(sources) {
+		return newAppError(ErrCodeNotFound, "%s is not attached", abs)
+	}
+
+	if err := a.saveAttachedSources(kept); err != nil {
+		return err
+	}
+	return a.RebuildCache()
+}
+
+// ListSources returns the primary and attached glyph databases with their glyph counts
+func (a *App) ListSources() []SourceInfo {
+	primary := a.GetCurrentDatabase()
+	counts := make(map[int]int)
+
+	// Count by slot, which the glyph id encodes. Direct search mode only
+	// serves the primary database.
+	for _, g := range a.cache.Snapshot().glyphs {
+		counts[g.ID/sourceIDStride]++
+	}
+	if a.searchMode() == SearchModeDirect {
+		counts[0] = a.directGlyphCount()
+	}
+
+	result := []SourceInfo{{
+		Name:       sourceName(primary),
+		Path:       primary,
+		Primary:    true,
+		GlyphCount: counts[0],
+	}}
+	sources := a.attachedSources()
+	for _, src := range sources {
+		result = append(result, SourceInfo{
+			Name:       attachedSourceName(primary, sources, src),
+			Path:       src.Path,
+			GlyphCount: counts[src.Slot],
+		})
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+)
+
+// busyTimeoutMs is how long a connection waits on a locked database before failing
+const busyTimeoutMs = 5000
+
+// sqliteDSN builds a modernc.org/sqlite DSN applying the app's pragmas on every connection
+func sqliteDSN(path string, readOnly bool) string {
+	escaped := strings.NewReplacer("%", "%25", "?", "%3f", "#", "%23").Replace(filepath.ToSlash(path))
+
+	params := url.Values{}
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", busyTimeoutMs))
+	params.Add("_pragma", "foreign_keys(1)")
+	if readOnly {
+		params.Set("mode", "ro")
+		params.Add("_pragma", "query_only(1)")
+	} else {
+		params.Add("_pragma", "journal_mode(WAL)")
+		params.Add("_pragma", "synchronous(NORMAL)")
+	}
+	return "file:" + escaped + "?" + params.Encode()
+}
+
+// openSQLite opens a database with hardened pragmas. Writers get a single
+// connection so mutations are serialized instead of failing with SQLITE_BUSY;
+// read-only pools allow one connection per CPU.
+func openSQLite(path string, readOnly bool) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", sqliteDSN(path, readOnly))
+	if err != nil {
+		return nil, err
+	}
+
+	if readOnly {
+		db.SetMaxOpenConns(goruntime.NumCPU())
+	} else {
+		db.SetMaxOpenConns(1)
+	}
+	return db, nil
+}
+
+// PragmaState reports the effective SQLite configuration of a connection pool
+type PragmaState struct {
+	JournalMode   string `json:"journalMode"`
+	BusyTimeout   int    `json:"busyTimeout"`
+	ForeignKeys   bool   `json:"foreignKeys"`
+	Synchronous   int    `json:"synchronous"`
+	QueryOnly     bool   `json:"queryOnly"`
+	PageSize      int    `json:"pageSize"`
+	PageCount     int    `json:"pageCount"`
+	FreelistCount int    `json:"freelistCount"`
+	OpenConns     int    `json:"openConns"`
+	InUse         int    `json:"inUse"`
+	MaxOpenConns  int    `json:"maxOpenConns"`
+}
+
+// readPragmas collects pragma values and pool statistics from db
+func readPragmas(db *sql.DB) (*PragmaState, error) {
+	state := &PragmaState{}
+	var foreignKeys, queryOnly int
+
+	queries := []struct {
+		pragma string
+		dest   interface{}
+	}{
+		{"journal_mode", &state.JournalMode},
+		{"busy_timeout", &state.BusyTimeout},
+		{"foreign_keys", &foreignKeys},
+		{"synchronous", &state.Synchronous},
+		{"query_only", &queryOnly},
+		{"page_size", &state.PageSize},
+		{"page_count", &state.PageCount},
+		{"freelist_count", &state.FreelistCount},
+	}
+	for _, q := range queries {
+		if err := db.QueryRow("PRAGMA " + q.pragma).Scan(q.dest); err != nil {
+			return nil, fmt.Errorf("failed to read pragma %s: %w", q.pragma, err)
+		}
+	}
+	state.ForeignKeys = foreignKeys == 1
+	state.QueryOnly = queryOnly == 1
+
+	stats := db.Stats()
+	state.OpenConns = stats.OpenConnections
+	state.InUse = stats.InUse
+	state.MaxOpenConns = stats.MaxOpenConnections
+	return state, nil
+}
+
+// DatabaseDiagnostics reports pragma state for the writer and reader pools
+type DatabaseDiagnostics struct {
+	Path   string       `json:"path"`
+	Writer *PragmaState `json:"writer,omitempty"`
+	Reader *PragmaState `json:"reader,omitempty"`
+	User   *PragmaState `json:"user,omitempty"`
+}
+
+// GetDatabaseDiagnostics returns the effective SQLite configuration of each connection pool
+func (a *App) GetDatabaseDiagnostics() (*DatabaseDiagnostics, error) {
+	if a.db == nil {
+		return nil, newAppError(ErrCodeDBMissing, "database not open")
+	}
+
+	diag := &DatabaseDiagnostics{Path: a.GetCurrentDatabase()}
+
+	var err error
+	if diag.Writer, err = readPragmas(a.db); err != nil {
+		return nil, err
+	}
+	if a.readDB != nil {
+		if diag.Reader, err = readPragmas(a.readDB); err != nil {
+			return nil, err
+		}
+	}
+	if a.userDB != nil && a.userDB != a.db {
+		if diag.User, err = readPragmas(a.userDB); err != nil {
+			return nil, err
+		}
+	}
+	return diag, nil
+}
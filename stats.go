@@ -0,0 +1,194 @@
+package main
+
+import (
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// searchLatencyWindow is how many recent searches the rolling average covers
+const searchLatencyWindow = 100
+
+// SearchStats keeps a rolling window of search latencies
+type SearchStats struct {
+	mu      sync.Mutex
+	samples []float64
+	next    int
+	count   int64
+}
+
+// Record adds a search duration to the rolling window
+func (s *SearchStats) Record(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ms := float64(d.Microseconds()) / 1000
+	if len(s.samples) < searchLatencyWindow {
+		s.samples = append(s.samples, ms)
+	} else {
+		s.samples[s.next] = ms
+	}
+	s.next = (s.next + 1) % searchLatencyWindow
+	s.count++
+}
+
+// SearchLatencyStats summarizes the latency of recent searches
+type SearchLatencyStats struct {
+	Count           int64   `json:"count"`
+	Window          int     `json:"window"`
+	AvgMs           float64 `json:"avgMs"`
+	P50Ms           float64 `json:"p50Ms"`
+	P95Ms           float64 `json:"p95Ms"`
+	MaxMs           float64 `json:"maxMs"`
+	SlowThresholdMs int     `json:"slowThresholdMs"`
+}
+
+// percentile returns the p-th percentile (0-100) of sorted samples using nearest rank
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
+
+// Latency summarizes the samples in the window
+func (s *SearchStats) Latency() SearchLatencyStats {
+	s.mu.Lock()
+	sorted := append([]float64(nil), s.samples...)
+	count := s.count
+	s.mu.Unlock()
+
+	sort.Float64s(sorted)
+	stats := SearchLatencyStats{Count: count, Window: len(sorted)}
+	if len(sorted) == 0 {
+		return stats
+	}
+
+	var sum float64
+	for _, ms := range sorted {
+		sum += ms
+	}
+	stats.AvgMs = sum / float64(len(sorted))
+	stats.P50Ms = percentile(sorted, 50)
+	stats.P95Ms = percentile(sorted, 95)
+	stats.MaxMs = sorted[len(sorted)-1]
+	return stats
+}
+
+// defaultSlowSearchMs is the latency above which searches are logged
+const defaultSlowSearchMs = 100
+
+// slowSearchThreshold returns the configured slow-query threshold
+func (a *App) slowSearchThreshold() time.Duration {
+	ms := a.settings.GetInt("search.slowQueryMs", defaultSlowSearchMs)
+	if ms <= 0 {
+		ms = defaultSlowSearchMs
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// redactedSearchTerm replaces incognito search terms in the slow-search log
+const redactedSearchTerm = "(incognito)"
+
+// recordSearchLatency adds a search to the latency window and logs it when it was slow
+func (a *App) recordSearchLatency(elapsed time.Duration, searchTerm, category, scope string, limit, offset, total int) {
+	a.searchStats.Record(elapsed)
+
+	if elapsed >= a.slowSearchThreshold() {
+		log.Printf("Slow search (%.1fms): term=%q category=%q scope=%q limit=%d offset=%d matches=%d",
+			float64(elapsed.Microseconds())/1000, searchTerm, category, scope, limit, offset, total)
+	}
+}
+
+// GetSearchLatencyStats returns p50/p95/max latency over recent searches
+func (a *App) GetSearchLatencyStats() SearchLatencyStats {
+	stats := a.searchStats.Latency()
+	stats.SlowThresholdMs = int(a.slowSearchThreshold().Milliseconds())
+	return stats
+}
+
+// Stats is the data behind the diagnostics and stats panels
+type Stats struct {
+	TotalGlyphs      int               `json:"totalGlyphs"`
+	TotalFavorites   int               `json:"totalFavorites"`
+	TotalCategories  int               `json:"totalCategories"`
+	Categories       map[string]int    `json:"categories"`
+	Sources          map[string]int    `json:"sources"`
+	CacheLoaded      bool              `json:"cacheLoaded"`
+	CacheMemoryBytes int64             `json:"cacheMemoryBytes"`
+	CacheTimings     *CacheLoadTimings `json:"cacheTimings,omitempty"`
+	// SearchMode is "cache", or "direct" when searches query SQLite instead
+	SearchMode    string    `json:"searchMode"`
+	DataMode      string    `json:"dataMode"`
+	DBSizeBytes   int64     `json:"dbSizeBytes"`
+	AvgSearchMs   float64   `json:"avgSearchMs"`
+	SearchCount   int64     `json:"searchCount"`
+	StartedAt     time.Time `json:"startedAt" ts_type:"string"`
+	UptimeSeconds float64   `json:"uptimeSeconds"`
+}
+
+// memoryEstimate approximates the bytes held by the snapshot: glyph structs and
+// their strings, plus the id index and category lists
+func (s *cacheSnapshot) memoryEstimate() int64 {
+	size := int64(cap(s.glyphs)) * int64(unsafe.Sizeof(Glyph{}))
+	for _, g := range s.glyphs {
+		size += int64(len(g.Name) + len(g.Glyph) + len(g.Description) + len(g.Source))
+	}
+
+	// Rough per-entry map overhead: key, value, and bucket bookkeeping
+	size += int64(len(s.index)) * 24
+	for category, ids := range s.categories {
+		size += int64(len(category)) + int64(cap(ids))*int64(unsafe.Sizeof(0)) + 48
+	}
+	return size
+}
+
+// GetStats returns app statistics
+func (a *App) GetStats() *Stats {
+	snap := a.cache.Snapshot()
+
+	totalFavorites := a.favorites.Count()
+
+	stats := &Stats{
+		TotalGlyphs:      len(snap.glyphs),
+		TotalFavorites:   totalFavorites,
+		TotalCategories:  len(snap.categories),
+		Categories:       make(map[string]int, len(snap.categories)),
+		Sources:          make(map[string]int),
+		CacheLoaded:      a.cache.Loaded(),
+		CacheMemoryBytes: snap.memoryEstimate(),
+		CacheTimings:     a.cache.Timings(),
+		SearchMode:       a.searchMode(),
+		DataMode:         a.GetDataStatus().Mode,
+		StartedAt:        a.startedAt,
+		UptimeSeconds:    time.Since(a.startedAt).Seconds(),
+	}
+	latency := a.searchStats.Latency()
+	stats.AvgSearchMs, stats.SearchCount = latency.AvgMs, latency.Count
+
+	if stats.SearchMode == SearchModeDirect {
+		stats.Categories, _ = a.directCategories()
+		stats.TotalCategories = len(stats.Categories)
+		stats.TotalGlyphs = a.directGlyphCount()
+		stats.Sources[sourceName(a.dbPath)] = stats.TotalGlyphs
+	} else {
+		for category, ids := range snap.categories {
+			stats.Categories[category] = len(ids)
+		}
+		for _, g := range snap.glyphs {
+			stats.Sources[g.Source]++
+		}
+	}
+
+	if a.db != nil {
+		if info, err := os.Stat(a.dbPath); err == nil {
+			stats.DBSizeBytes = info.Size()
+		}
+	}
+	return stats
+}
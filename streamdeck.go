@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// streamDeckKeySize is the pixel size of a standard Stream Deck key;
+	// XL and high-DPI devices ask for 144
+	streamDeckKeySize = 72
+
+	maxStreamDeckButtons = 64
+)
+
+// StreamDeckButton is a glyph offered to a Stream Deck plugin as a key
+type StreamDeckButton struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Glyph     string `json:"glyph"`
+	Codepoint string `json:"codepoint"`
+	ImageURL  string `json:"imageUrl"` // relative to the API root; append the token
+}
+
+// streamDeckButton describes a glyph as a key
+func streamDeckButton(g Glyph) StreamDeckButton {
+	return StreamDeckButton{
+		ID:        g.ID,
+		Name:      g.Name,
+		Glyph:     g.Glyph,
+		Codepoint: formatCodepoint(codepointOf(g.Glyph)),
+		ImageURL:  fmt.Sprintf("/api/glyphs/%d/png", g.ID),
+	}
+}
+
+// registerStreamDeckRoutes adds the endpoints backing the Stream Deck plugin:
+//
+//	GET  /api/streamdeck/buttons?source=quickpicks|favorites&limit=N
+//	GET  /api/glyphs/{id}/png?size=72&fg=ffffff&bg=00000000&padding=0.15&mono=1
+//	POST /api/glyphs/{id}/copy
+func (a *App) registerStreamDeckRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/streamdeck/buttons", a.requireToken(a.serveStreamDeckButtons))
+	mux.HandleFunc("GET /api/glyphs/{id}/png", a.requireToken(a.serveGlyphPNG))
+	mux.HandleFunc("POST /api/glyphs/{id}/copy", a.requireToken(a.serveCopyGlyph))
+}
+
+// glyphFromPath resolves the {id} path value of a request
+func (a *App) glyphFromPath(r *http.Request) (Glyph, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		return Glyph{}, newAppError(ErrCodeInvalid, "invalid glyph id: %s", r.PathValue("id"))
+	}
+	g, ok := a.findGlyph(id)
+	if !ok {
+		return Glyph{}, newAppError(ErrCodeNotFound, "glyph %d not found", id)
+	}
+	return g, nil
+}
+
+// serveStreamDeckButtons lists the most-used glyphs (or favorites) to lay out on keys
+func (a *App) serveStreamDeckButtons(w http.ResponseWriter, r *http.Request) {
+	limit := 15
+	if s := r.URL.Query().Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 || n > maxStreamDeckButtons {
+			writeJSONError(w, http.StatusBadRequest, newAppError(ErrCodeInvalid, "limit must be between 1 and %d", maxStreamDeckButtons))
+			return
+		}
+		limit = n
+	}
+
+	var glyphs []GlyphMatch
+	var err error
+	switch source := r.URL.Query().Get("source"); source {
+	case "", "quickpicks":
+		glyphs, err = a.GetQuickPicks(limit)
+	case "favorites":
+		glyphs = a.favoriteGlyphs()
+		if len(glyphs) > limit {
+			glyphs = glyphs[:limit]
+		}
+	default:
+		writeJSONError(w, http.StatusBadRequest, newAppError(ErrCodeInvalid, "unknown source: %s", source))
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	buttons := make([]StreamDeckButton, len(glyphs))
+	for i, g := range glyphs {
+		buttons[i] = streamDeckButton(g.Glyph)
+	}
+	writeJSON(w, buttons)
+}
+
+// serveGlyphPNG renders a glyph as a PNG key image
+func (a *App) serveGlyphPNG(w http.ResponseWriter, r *http.Request) {
+	g, err := a.glyphFromPath(r)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, err)
+		return
+	}
+
+	q := r.URL.Query()
+	opts := RenderOptions{Size: streamDeckKeySize, Padding: 0.15}
+	if s := q.Get("size"); s != "" {
+		if opts.Size, err = strconv.Atoi(s); err != nil {
+			writeJSONError(w, http.StatusBadRequest, newAppError(ErrCodeInvalid, "invalid size: %s", s))
+			return
+		}
+	}
+	if s := q.Get("padding"); s != "" {
+		if opts.Padding, err = strconv.ParseFloat(s, 64); err != nil || opts.Padding < 0 || opts.Padding >= 0.5 {
+			writeJSONError(w, http.StatusBadRequest, newAppError(ErrCodeInvalid, "padding must be between 0 and 0.5"))
+			return
+		}
+	}
+	if s := q.Get("fg"); s != "" {
+		if opts.Foreground, err = parseHexColor(s); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if s := q.Get("bg"); s != "" {
+		if opts.Background, err = parseHexColor(s); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	opts.Monochrome = q.Get("mono") == "1"
+
+	data, err := a.renderGlyphPNG(g, opts)
+	if err != nil {
+		writeJSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.Write(data)
+}
+
+// serveCopyGlyph copies a glyph to the clipboard when a key is pressed
+func (a *App) serveCopyGlyph(w http.ResponseWriter, r *http.Request) {
+	g, err := a.glyphFromPath(r)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, err)
+		return
+	}
+	if err := a.CopyGlyph(g.ID); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, streamDeckButton(g))
+}
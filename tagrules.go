@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxRulePreviewSamples is how many matching names a rule preview lists
+const maxRulePreviewSamples = 5
+
+// TagRule tags every glyph whose name matches Pattern (a case-insensitive regular expression)
+type TagRule struct {
+	ID        int       `json:"id"`
+	Pattern   string    `json:"pattern"`
+	Tags      []string  `json:"tags"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"createdAt" ts_type:"string"`
+}
+
+// TagRulePreview reports how many glyphs a rule affects
+type TagRulePreview struct {
+	Rule    TagRule  `json:"rule"`
+	Matches int      `json:"matches"`
+	Samples []string `json:"samples"`
+}
+
+// compiledTagRule is an enabled rule ready to match names
+type compiledTagRule struct {
+	pattern *regexp.Regexp
+	tags    []string
+}
+
+// initTagRulesTable creates the auto-tagging rules table
+func (a *App) initTagRulesTable() error {
+	_, err := a.userDB.Exec(`
+		CREATE TABLE IF NOT EXISTS tag_rules (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			pattern TEXT NOT NULL,
+			tags TEXT NOT NULL,
+			enabled INTEGER NOT NULL DEFAULT 1,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	return err
+}
+
+// compileTagPattern compiles a rule pattern case-insensitively (e.g. "arrow|chevron")
+func compileTagPattern(pattern string) (*regexp.Regexp, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return nil, newAppError(ErrCodeInvalid, "pattern is empty")
+	}
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// ListTagRules returns all auto-tagging rules in creation order
+func (a *App) ListTagRules() ([]TagRule, error) {
+	rules := []TagRule{}
+	if a.userDB == nil {
+		return rules, nil
+	}
+
+	rows, err := a.userDB.Query("SELECT id, pattern, tags, enabled, created_at FROM tag_rules ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tag rules: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r TagRule
+		var tags string
+		if err := rows.Scan(&r.ID, &r.Pattern, &tags, &r.Enabled, &r.CreatedAt); err != nil {
+			log.Printf("Error scanning tag rule: %v", err)
+			continue
+		}
+		r.Tags = strings.Split(tags, ",")
+		rules = append(rules, r)
+	}
+	return rules, rows.Err()
+}
+
+// enabledTagRules compiles the enabled rules, skipping any whose pattern no longer compiles
+func (a *App) enabledTagRules() []compiledTagRule {
+	rules, err := a.ListTagRules()
+	if err != nil {
+		log.Printf("Failed to load tag rules: %v", err)
+		return nil
+	}
+
+	var compiled []compiledTagRule
+	for _, r := range rules {
+		if !r.Enabled {
+			continue
+		}
+		re, err := compileTagPattern(r.Pattern)
+		if err != nil {
+			log.Printf("Skipping tag rule %d: %v", r.ID, err)
+			continue
+		}
+		compiled = append(compiled, compiledTagRule{pattern: re, tags: r.Tags})
+	}
+	return compiled
+}
+
+// ruleTags returns the tags rules assign to a glyph name
+func ruleTags(rules []compiledTagRule, name string) []string {
+	var tags []string
+	for _, r := range rules {
+		if r.pattern.MatchString(name) {
+			tags = append(tags, r.tags...)
+		}
+	}
+	return tags
+}
+
+// saveTagRule validates a rule and refreshes cached tags after fn stores it
+func (a *App) saveTagRule(pattern string, tags []string, fn func(tags string) error) error {
+	if err := a.checkWritable("change tag rules"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+	if _, err := compileTagPattern(pattern); err != nil {
+		return err
+	}
+	tags = normalizeTags(tags)
+	if len(tags) == 0 {
+		return newAppError(ErrCodeInvalid, "a rule needs at least one tag")
+	}
+
+	if err := fn(strings.Join(tags, ",")); err != nil {
+		return err
+	}
+	a.refreshGlyphMetadata()
+	a.emit(EventTagsChanged, "rules")
+	return nil
+}
+
+// CreateTagRule adds a rule tagging glyphs whose names match pattern
+func (a *App) CreateTagRule(pattern string, tags []string) (int, error) {
+	var id int64
+	err := a.saveTagRule(pattern, tags, func(tags string) error {
+		res, err := a.userDB.Exec("INSERT INTO tag_rules (pattern, tags) VALUES (?, ?)", pattern, tags)
+		if err != nil {
+			return fmt.Errorf("failed to create tag rule: %w", err)
+		}
+		id, err = res.LastInsertId()
+		return err
+	})
+	return int(id), err
+}
+
+// UpdateTagRule changes a rule's pattern, tags and whether it is enabled
+func (a *App) UpdateTagRule(id int, pattern string, tags []string, enabled bool) error {
+	return a.saveTagRule(pattern, tags, func(tags string) error {
+		res, err := a.userDB.Exec("UPDATE tag_rules SET pattern = ?, tags = ?, enabled = ? WHERE id = ?", pattern, tags, enabled, id)
+		if err != nil {
+			return fmt.Errorf("failed to update tag rule: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return newAppError(ErrCodeNotFound, "tag rule %d not found", id)
+		}
+		return nil
+	})
+}
+
+// DeleteTagRule removes a rule; glyphs keep tags that were assigned explicitly
+func (a *App) DeleteTagRule(id int) error {
+	if err := a.checkWritable("change tag rules"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+
+	res, err := a.userDB.Exec("DELETE FROM tag_rules WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag rule: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return newAppError(ErrCodeNotFound, "tag rule %d not found", id)
+	}
+	a.refreshGlyphMetadata()
+	a.emit(EventTagsChanged, "rules")
+	return nil
+}
+
+// previewRule counts the cached glyphs a pattern matches
+func (a *App) previewRule(rule TagRule) (TagRulePreview, error) {
+	re, err := compileTagPattern(rule.Pattern)
+	if err != nil {
+		return TagRulePreview{}, err
+	}
+
+	preview := TagRulePreview{Rule: rule, Samples: []string{}}
+	for _, g := range a.allGlyphs() {
+		if re.MatchString(g.Name) {
+			preview.Matches++
+			if len(preview.Samples) < maxRulePreviewSamples {
+				preview.Samples = append(preview.Samples, g.Name)
+			}
+		}
+	}
+	return preview, nil
+}
+
+// PreviewTagRule shows how many glyphs an unsaved pattern would tag
+func (a *App) PreviewTagRule(pattern string, tags []string) (*TagRulePreview, error) {
+	preview, err := a.previewRule(TagRule{Pattern: pattern, Tags: normalizeTags(tags), Enabled: true})
+	if err != nil {
+		return nil, err
+	}
+	return &preview, nil
+}
+
+// PreviewTagRules shows how many glyphs each saved rule affects
+func (a *App) PreviewTagRules() ([]TagRulePreview, error) {
+	rules, err := a.ListTagRules()
+	if err != nil {
+		return nil, err
+	}
+
+	previews := make([]TagRulePreview, 0, len(rules))
+	for _, r := range rules {
+		preview, err := a.previewRule(r)
+		if err != nil {
+			// Keep invalid rules visible so they can be fixed
+			preview = TagRulePreview{Rule: r, Samples: []string{}}
+		}
+		previews = append(previews, preview)
+	}
+	return previews, nil
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxUndoEdits is how many bulk edits UndoBulkEdit can revert
+const maxUndoEdits = 20
+
+// EventTagsChanged is emitted after tags or assigned categories change
+const EventTagsChanged = "tags:changed"
+
+// TagCount is a tag with the number of glyphs carrying it
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// bulkEdit is a reversible bulk change to tags or categories
+type bulkEdit struct {
+	description string
+	revert      func(tx *sql.Tx) error
+}
+
+// BulkEdits keeps recent bulk edits so they can be undone
+type BulkEdits struct {
+	mu   sync.Mutex
+	undo []bulkEdit
+}
+
+// push records an edit, dropping the oldest beyond maxUndoEdits
+func (b *BulkEdits) push(edit bulkEdit) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.undo = append(b.undo, edit)
+	if len(b.undo) > maxUndoEdits {
+		b.undo = b.undo[len(b.undo)-maxUndoEdits:]
+	}
+}
+
+// pop removes and returns the most recent edit
+func (b *BulkEdits) pop() (bulkEdit, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.undo) == 0 {
+		return bulkEdit{}, false
+	}
+	edit := b.undo[len(b.undo)-1]
+	b.undo = b.undo[:len(b.undo)-1]
+	return edit, true
+}
+
+// clear forgets all edits, e.g. when switching profiles
+func (b *BulkEdits) clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.undo = nil
+}
+
+// initTagTables creates the user tag and category assignment tables
+func (a *App) initTagTables() error {
+	_, err := a.userDB.Exec(`
+		CREATE TABLE IF NOT EXISTS glyph_tags (
+			glyph_id INTEGER NOT NULL,
+			tag TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (glyph_id, tag)
+		);
+		CREATE INDEX IF NOT EXISTS idx_glyph_tags_tag ON glyph_tags(tag);
+
+		CREATE TABLE IF NOT EXISTS glyph_categories (
+			glyph_id INTEGER PRIMARY KEY,
+			category TEXT NOT NULL
+		);
+	`)
+	return err
+}
+
+// normalizeTags lowercases, trims and de-duplicates tags (e.g. " Navigation" -> "navigation")
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	var result []string
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] || strings.Contains(t, ",") {
+			continue
+		}
+		seen[t] = true
+		result = append(result, t)
+	}
+	return result
+}
+
+// userGlyphMetadata loads the tags and assigned categories of the active profile
+func (a *App) userGlyphMetadata() (tags map[int][]string, categories map[int]string) {
+	tags, categories = make(map[int][]string), make(map[int]string)
+	if a.userDB == nil {
+		return tags, categories
+	}
+
+	rows, err := a.userDB.Query("SELECT glyph_id, tag FROM glyph_tags ORDER BY tag")
+	if err != nil {
+		log.Printf("Failed to load tags: %v", err)
+		return tags, categories
+	}
+	for rows.Next() {
+		var id int
+		var tag string
+		if err := rows.Scan(&id, &tag); err == nil {
+			tags[id] = append(tags[id], tag)
+		}
+	}
+	rows.Close()
+
+	rows, err = a.userDB.Query("SELECT glyph_id, category FROM glyph_categories")
+	if err != nil {
+		log.Printf("Failed to load assigned categories: %v", err)
+		return tags, categories
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		var category string
+		if err := rows.Scan(&id, &category); err == nil {
+			categories[id] = category
+		}
+	}
+	return tags, categories
+}
+
+// applyGlyphMetadata sets user tags, rule tags and categories on glyphs in place
+func (a *App) applyGlyphMetadata(glyphs []Glyph) {
+	tags, categories := a.userGlyphMetadata()
+	rules := a.enabledTagRules()
+	for i := range glyphs {
+		glyphTags := tags[glyphs[i].ID]
+		if len(rules) > 0 {
+			glyphTags = normalizeTags(append(glyphTags, ruleTags(rules, glyphs[i].Name)...))
+		}
+		glyphs[i].Tags = strings.Join(glyphTags, ",")
+		glyphs[i].Category = categories[glyphs[i].ID]
+	}
+}
+
+// refreshGlyphMetadata rebuilds the cache with the current tags and categories
+func (a *App) refreshGlyphMetadata() {
+	a.cache.refreshMu.Lock()
+	defer a.cache.refreshMu.Unlock()
+
+	snap := a.cache.Snapshot()
+	if len(snap.glyphs) == 0 {
+		return
+	}
+	glyphs := make([]Glyph, len(snap.glyphs))
+	copy(glyphs, snap.glyphs)
+	a.applyGlyphMetadata(glyphs)
+	a.cache.Swap(newCacheSnapshot(glyphs))
+}
+
+// runBulkEdit applies change in one transaction, records its undo and refreshes the cache
+func (a *App) runBulkEdit(description string, change func(tx *sql.Tx) (revert func(tx *sql.Tx) error, err error)) error {
+	if err := a.checkWritable("change tags"); err != nil {
+		return err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return err
+	}
+
+	tx, err := a.userDB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	revert, err := change(tx)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit %s: %w", description, err)
+	}
+
+	a.bulkEdits.push(bulkEdit{description: description, revert: revert})
+	a.refreshGlyphMetadata()
+	a.emit(EventTagsChanged, description)
+	log.Printf("Bulk edit: %s", description)
+	return nil
+}
+
+// BulkTag adds tags to every glyph in ids in one transaction
+func (a *App) BulkTag(ids []int, tags []string) (int, error) {
+	tags = normalizeTags(tags)
+	if len(tags) == 0 {
+		return 0, invalidArgument("tags", "no tags given")
+	}
+	if err := a.validateGlyphIDs(ids); err != nil {
+		return 0, err
+	}
+
+	type pair struct {
+		id  int
+		tag string
+	}
+	var added []pair
+
+	err := a.runBulkEdit(fmt.Sprintf("tag %d glyphs with %s", len(ids), strings.Join(tags, ", ")), func(tx *sql.Tx) (func(*sql.Tx) error, error) {
+		stmt, err := tx.Prepare("INSERT OR IGNORE INTO glyph_tags (glyph_id, tag) VALUES (?, ?)")
+		if err != nil {
+			return nil, err
+		}
+		defer stmt.Close()
+
+		for _, id := range ids {
+			for _, tag := range tags {
+				res, err := stmt.Exec(id, tag)
+				if err != nil {
+					return nil, fmt.Errorf("failed to tag glyph %d: %w", id, err)
+				}
+				if n, _ := res.RowsAffected(); n > 0 {
+					added = append(added, pair{id, tag})
+				}
+			}
+		}
+
+		// Undo removes only the tags this edit added
+		return func(tx *sql.Tx) error {
+			for _, p := range added {
+				if _, err := tx.Exec("DELETE FROM glyph_tags WHERE glyph_id = ? AND tag = ?", p.id, p.tag); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	})
+	return len(added), err
+}
+
+// BulkUntag removes tags from every glyph in ids in one transaction
+func (a *App) BulkUntag(ids []int, tags []string) (int, error) {
+	tags = normalizeTags(tags)
+	if len(tags) == 0 {
+		return 0, invalidArgument("tags", "no tags given")
+	}
+	if err := a.validateGlyphIDs(ids); err != nil {
+		return 0, err
+	}
+
+	type pair struct {
+		id  int
+		tag string
+	}
+	var removed []pair
+
+	err := a.runBulkEdit(fmt.Sprintf("remove %s from %d glyphs", strings.Join(tags, ", "), len(ids)), func(tx *sql.Tx) (func(*sql.Tx) error, error) {
+		for _, id := range ids {
+			for _, tag := range tags {
+				res, err := tx.Exec("DELETE FROM glyph_tags WHERE glyph_id = ? AND tag = ?", id, tag)
+				if err != nil {
+					return nil, fmt.Errorf("failed to untag glyph %d: %w", id, err)
+				}
+				if n, _ := res.RowsAffected(); n > 0 {
+					removed = append(removed, pair{id, tag})
+				}
+			}
+		}
+
+		return func(tx *sql.Tx) error {
+			for _, p := range removed {
+				if _, err := tx.Exec("INSERT OR IGNORE INTO glyph_tags (glyph_id, tag) VALUES (?, ?)", p.id, p.tag); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	})
+	return len(removed), err
+}
+
+// BulkAssignCategory moves every glyph in ids to category in one transaction.
+// An empty category restores the category derived from each glyph's name.
+func (a *App) BulkAssignCategory(ids []int, category string) error {
+	category = strings.ToLower(strings.TrimSpace(category))
+	if err := a.validateGlyphIDs(ids); err != nil {
+		return err
+	}
+	if err := validateQuery("category", category); err != nil {
+		return err
+	}
+
+	description := fmt.Sprintf("move %d glyphs to %s", len(ids), category)
+	if category == "" {
+		description = fmt.Sprintf("reset the category of %d glyphs", len(ids))
+	}
+
+	return a.runBulkEdit(description, func(tx *sql.Tx) (func(*sql.Tx) error, error) {
+		previous := make(map[int]string, len(ids))
+		for _, id := range ids {
+			var old string
+			err := tx.QueryRow("SELECT category FROM glyph_categories WHERE glyph_id = ?", id).Scan(&old)
+			if err != nil && err != sql.ErrNoRows {
+				return nil, err
+			}
+			previous[id] = old
+
+			if category == "" {
+				_, err = tx.Exec("DELETE FROM glyph_categories WHERE glyph_id = ?", id)
+			} else {
+				_, err = tx.Exec(`
+					INSERT INTO glyph_categories (glyph_id, category) VALUES (?, ?)
+					ON CONFLICT(glyph_id) DO UPDATE SET category = excluded.category
+				`, id, category)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to assign category to glyph %d: %w", id, err)
+			}
+		}
+
+		return func(tx *sql.Tx) error {
+			for id, old := range previous {
+				var err error
+				if old == "" {
+					_, err = tx.Exec("DELETE FROM glyph_categories WHERE glyph_id = ?", id)
+				} else {
+					_, err = tx.Exec("INSERT OR REPLACE INTO glyph_categories (glyph_id, category) VALUES (?, ?)", id, old)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	})
+}
+
+// TagSearchResults applies tags to every glyph matching a search, as GetGlyphs would
+// return it without pagination, and reports how many glyphs matched
+func (a *App) TagSearchResults(searchTerm string, category string, scope string, tags []string) (int, error) {
+	matches, _, err := a.matchGlyphs(searchTerm, category, scope, "", false)
+	if err != nil {
+		return 0, err
+	}
+	if len(matches) == 0 {
+		return 0, newAppError(ErrCodeNotFound, "no glyphs match the search")
+	}
+
+	ids := make([]int, len(matches))
+	for i, m := range matches {
+		ids[i] = m.ID
+	}
+	if _, err := a.BulkTag(ids, tags); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// UndoBulkEdit reverts the most recent bulk tag or category edit and returns its description
+func (a *App) UndoBulkEdit() (string, error) {
+	if err := a.checkWritable("change tags"); err != nil {
+		return "", err
+	}
+	if err := a.requireUserDB(); err != nil {
+		return "", err
+	}
+
+	edit, ok := a.bulkEdits.pop()
+	if !ok {
+		return "", newAppError(ErrCodeNotFound, "nothing to undo")
+	}
+
+	tx, err := a.userDB.Begin()
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := edit.revert(tx); err != nil {
+		return "", fmt.Errorf("failed to undo %s: %w", edit.description, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to undo %s: %w", edit.description, err)
+	}
+
+	a.refreshGlyphMetadata()
+	a.emit(EventTagsChanged, "undo "+edit.description)
+	log.Printf("Undid bulk edit: %s", edit.description)
+	return edit.description, nil
+}
+
+// ListTags returns every tag in use with its glyph count, most used first
+func (a *App) ListTags() ([]TagCount, error) {
+	if a.userDB == nil {
+		return []TagCount{}, nil
+	}
+
+	rows, err := a.userDB.Query("SELECT tag, COUNT(*) FROM glyph_tags GROUP BY tag")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []TagCount{}
+	for rows.Next() {
+		var t TagCount
+		if err := rows.Scan(&t.Tag, &t.Count); err != nil {
+			continue
+		}
+		tags = append(tags, t)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+	return tags, rows.Err()
+}
@@ -0,0 +1,31 @@
+package main
+
+// GetTheme returns the active UI theme ("dark" or "light")
+func (a *App) GetTheme() string {
+	return a.settings.Get("theme", "dark")
+}
+
+// SetTheme changes the UI theme and notifies the frontend
+func (a *App) SetTheme(theme string) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+
+	if theme != "dark" && theme != "light" {
+		return newAppError(ErrCodeInvalid, "unknown theme: %s", theme)
+	}
+	if err := a.settings.Set("theme", theme); err != nil {
+		return err
+	}
+	a.emit("theme:changed", theme)
+	return nil
+}
+
+// ToggleTheme switches between the dark and light themes
+func (a *App) ToggleTheme() (string, error) {
+	theme := "light"
+	if a.GetTheme() == "light" {
+		theme = "dark"
+	}
+	return theme, a.SetTheme(theme)
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// maxTypoSuggestions caps the "did you mean" terms returned with a search
+const maxTypoSuggestions = 5
+
+// Typo matches rank below substring matches (5000 and up) but above loose
+// subsequence matches, which rarely mean what a misspelled term intended
+const (
+	typoBaseScore     = 4000
+	typoDistanceScore = 500
+)
+
+// maxTypoDistance is the edit distance tolerated for a term of n runes: none
+// for very short terms, where almost everything is one edit away
+func maxTypoDistance(n int) int {
+	switch {
+	case n < 4:
+		return 0
+	case n < 7:
+		return 1
+	}
+	return 2
+}
+
+// editDistance returns the optimal string alignment (Damerau-Levenshtein with
+// adjacent transpositions) distance between a and b, or limit+1 once it is
+// certain to exceed limit
+func editDistance(a, b []rune, limit int) int {
+	if d := len(a) - len(b); d > limit || -d > limit {
+		return limit + 1
+	}
+
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
+
+// nameWords splits a glyph name into the words a user might type
+// (e.g. "nf-cod-arrow_small_up" -> "arrow_small_up", "arrow", "small", "up")
+func nameWords(name string) []string {
+	rest := name
+	if category := glyphCategory(name); category != "" {
+		rest = strings.TrimPrefix(name, "nf-"+category+"-")
+	}
+	words := []string{rest}
+	parts := strings.FieldsFunc(rest, func(r rune) bool { return r == '-' || r == '_' || r == ',' || unicode.IsSpace(r) })
+	if len(parts) > 1 {
+		words = append(words, parts...)
+	}
+	return words
+}
+
+// mergeMatches adds extra matches and their breakdowns, keeping the higher
+// scoring breakdown for glyphs in both
+func mergeMatches(matches []GlyphMatch, breakdowns []ScoreBreakdown, extra []GlyphMatch, extraBreakdowns []ScoreBreakdown) ([]GlyphMatch, []ScoreBreakdown) {
+	index := make(map[int]int, len(matches))
+	for i, m := range matches {
+		index[m.ID] = i
+	}
+	for j, m := range extra {
+		if i, ok := index[m.ID]; ok {
+			if extraBreakdowns[j].Score > breakdowns[i].Score {
+				breakdowns[i] = extraBreakdowns[j]
+			}
+			continue
+		}
+		matches = append(matches, m)
+		breakdowns = append(breakdowns, extraBreakdowns[j])
+	}
+	return matches, breakdowns
+}
+
+// typoSuggestion is a vocabulary word close to the search term
+type typoSuggestion struct {
+	word     string
+	distance int
+	uses     int
+}
+
+// typoMatches finds glyphs with a name word, tag or localized keyword within a
+// small edit distance of term, for searches without a direct hit.
+// It returns the matches with their score breakdowns and the corrected words,
+// closest and most common first.
+func (a *App) typoMatches(term string, glyphs []Glyph, stripDiacritics bool) ([]GlyphMatch, []ScoreBreakdown, []string) {
+	folded := foldText(term, stripDiacritics)
+	limit := maxTypoDistance(len(folded))
+	if limit == 0 {
+		return nil, nil, nil
+	}
+
+	suggestions := make(map[string]*typoSuggestion)
+	distances := make(map[string]int)
+	wordDistance := func(word string) int {
+		if d, ok := distances[word]; ok {
+			return d
+		}
+		d := editDistance(folded, foldText(word, stripDiacritics), limit)
+		distances[word] = d
+		return d
+	}
+	suggest := func(word string, d int) {
+		if s, ok := suggestions[word]; ok {
+			s.uses++
+			return
+		}
+		suggestions[word] = &typoSuggestion{word: word, distance: d, uses: 1}
+	}
+
+	// Localized keywords stand in for their English aliases
+	var aliasPatterns [][]rune
+	for keyword, targets := range a.i18n.Keywords() {
+		if d := wordDistance(keyword); d <= limit {
+			suggest(keyword, d)
+			for _, t := range targets {
+				aliasPatterns = append(aliasPatterns, foldText(t, stripDiacritics))
+			}
+		}
+	}
+
+	var matches []GlyphMatch
+	var breakdowns []ScoreBreakdown
+	typoMatch := func(g Glyph, distance int, matchedOn string) {
+		b := ScoreBreakdown{MatchType: MatchTypo, MatchedOn: matchedOn, Base: typoBaseScore, Typo: -distance * typoDistanceScore}
+		b.total()
+		matches = append(matches, GlyphMatch{Glyph: g})
+		breakdowns = append(breakdowns, b)
+	}
+
+	for _, g := range glyphs {
+		best := limit + 1
+		matchedOn := "name"
+		for _, w := range nameWords(g.Name) {
+			if d := wordDistance(w); d <= limit {
+				suggest(w, d)
+				best = min(best, d)
+			}
+		}
+		if g.Tags != "" {
+			for _, w := range strings.Split(g.Tags, ",") {
+				if d := wordDistance(w); d <= limit {
+					suggest(w, d)
+					if d < best {
+						best, matchedOn = d, "tags"
+					}
+				}
+			}
+		}
+
+		if best <= limit {
+			typoMatch(g, best, matchedOn)
+		} else if aliasPatterns != nil {
+			if _, ok := matchAny(scoringProfiles[ScoringBalanced], aliasPatterns, foldText(g.Name, stripDiacritics)); ok {
+				typoMatch(g, limit, "alias")
+			}
+		}
+	}
+
+	ranked := make([]*typoSuggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		ranked = append(ranked, s)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].distance != ranked[j].distance {
+			return ranked[i].distance < ranked[j].distance
+		}
+		if ranked[i].uses != ranked[j].uses {
+			return ranked[i].uses > ranked[j].uses
+		}
+		return ranked[i].word < ranked[j].word
+	})
+
+	didYouMean := make([]string, 0, min(len(ranked), maxTypoSuggestions))
+	for _, s := range ranked[:min(len(ranked), maxTypoSuggestions)] {
+		didYouMean = append(didYouMean, s.word)
+	}
+	return matches, breakdowns, didYouMean
+}
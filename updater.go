@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const releasesURL = "https://api.github.com/repos/limpdev/Gylte/releases/latest"
+
+// ReleaseAsset is a downloadable file attached to a release
+type ReleaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Size int64  `json:"size"`
+}
+
+// UpdateInfo describes the result of the last update check
+type UpdateInfo struct {
+	CurrentVersion string         `json:"currentVersion"`
+	LatestVersion  string         `json:"latestVersion"`
+	Available      bool           `json:"available"`
+	ReleaseName    string         `json:"releaseName"`
+	ReleaseNotes   string         `json:"releaseNotes"`
+	ReleaseURL     string         `json:"releaseUrl"`
+	PublishedAt    string         `json:"publishedAt"`
+	Assets         []ReleaseAsset `json:"assets"`
+	CheckedAt      time.Time      `json:"checkedAt" ts_type:"string"`
+}
+
+// UpdateChecker queries GitHub releases for newer versions
+type UpdateChecker struct {
+	mu     sync.RWMutex
+	client *http.Client
+	info   *UpdateInfo
+}
+
+// githubRelease is the subset of the GitHub releases API response we use
+type githubRelease struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Body        string `json:"body"`
+	HTMLURL     string `json:"html_url"`
+	PublishedAt string `json:"published_at"`
+	Draft       bool   `json:"draft"`
+	Prerelease  bool   `json:"prerelease"`
+	Assets      []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+		Size               int64  `json:"size"`
+	} `json:"assets"`
+}
+
+// NewUpdateChecker creates an update checker with a bounded HTTP client
+func NewUpdateChecker() *UpdateChecker {
+	return &UpdateChecker{
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// parseVersion splits "v1.2.3-beta" into numeric components [1 2 3]
+func parseVersion(v string) []int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	v = strings.SplitN(v, "-", 2)[0]
+	v = strings.SplitN(v, "+", 2)[0]
+
+	var parts []int
+	for _, p := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			n = 0
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// compareVersions returns -1, 0 or 1 when a is older, equal or newer than b
+func compareVersions(a, b string) int {
+	pa, pb := parseVersion(a), parseVersion(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// Check fetches the latest release and compares it to the running version
+func (u *UpdateChecker) Check(ctx context.Context) (*UpdateInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, releasesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "Gylte/"+version)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query releases: %s", resp.Status)
+	}
+
+	var release githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+
+	info := &UpdateInfo{
+		CurrentVersion: version,
+		LatestVersion:  strings.TrimPrefix(release.TagName, "v"),
+		Available:      !release.Draft && !release.Prerelease && compareVersions(release.TagName, version) > 0,
+		ReleaseName:    release.Name,
+		ReleaseNotes:   release.Body,
+		ReleaseURL:     release.HTMLURL,
+		PublishedAt:    release.PublishedAt,
+		CheckedAt:      time.Now(),
+	}
+	for _, asset := range release.Assets {
+		info.Assets = append(info.Assets, ReleaseAsset{
+			Name: asset.Name,
+			URL:  asset.BrowserDownloadURL,
+			Size: asset.Size,
+		})
+	}
+
+	u.mu.Lock()
+	u.info = info
+	u.mu.Unlock()
+
+	return info, nil
+}
+
+// Info returns the result of the last check, or nil if none has run
+func (u *UpdateChecker) Info() *UpdateInfo {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return u.info
+}
+
+// platformAsset picks the release asset matching the current OS
+func platformAsset(assets []ReleaseAsset) (ReleaseAsset, bool) {
+	keywords := map[string][]string{
+		"windows": {"installer.exe", ".exe", "windows"},
+		"darwin":  {".dmg", "darwin", "macos"},
+		"linux":   {".appimage", "linux"},
+	}[goruntime.GOOS]
+
+	for _, keyword := range keywords {
+		for _, asset := range assets {
+			if strings.Contains(strings.ToLower(asset.Name), keyword) {
+				return asset, true
+			}
+		}
+	}
+	return ReleaseAsset{}, false
+}
+
+// progressWriter reports download progress as bytes are written
+type progressWriter struct {
+	total      int64
+	written    int64
+	lastReport time.Time
+	report     func(written, total int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	p.written += int64(len(b))
+	if time.Since(p.lastReport) > 100*time.Millisecond || p.written == p.total {
+		p.lastReport = time.Now()
+		p.report(p.written, p.total)
+	}
+	return len(b), nil
+}
+
+// Download fetches a release asset into dir, reporting progress as it goes
+func (u *UpdateChecker) Download(ctx context.Context, asset ReleaseAsset, dir string, report func(written, total int64)) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create download directory: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "Gylte/"+version)
+
+	// Downloads can be large, so don't apply the API client's timeout
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download update: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download update: %s", resp.Status)
+	}
+
+	dest := filepath.Join(dir, filepath.Base(asset.Name))
+	tmp := dest + ".part"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+
+	total := resp.ContentLength
+	if total <= 0 {
+		total = asset.Size
+	}
+	pw := &progressWriter{total: total, report: report}
+
+	if _, err := io.Copy(f, io.TeeReader(resp.Body, pw)); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to download update: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+
+	if err := os.Rename(tmp, dest); err != nil {
+		return "", fmt.Errorf("failed to save update: %w", err)
+	}
+	return dest, nil
+}
+
+// CheckForUpdates queries GitHub for a newer release
+func (a *App) CheckForUpdates() (*UpdateInfo, error) {
+	info, err := a.updater.Check(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Available {
+		log.Printf("Update available: %s -> %s", info.CurrentVersion, info.LatestVersion)
+		a.emit("update:available", info)
+		a.notifyInBackground("Gylte update available", fmt.Sprintf("Version %s is ready to download", info.LatestVersion))
+	}
+	return info, nil
+}
+
+// GetUpdateInfo returns the result of the last update check
+func (a *App) GetUpdateInfo() *UpdateInfo {
+	if info := a.updater.Info(); info != nil {
+		return info
+	}
+	return &UpdateInfo{CurrentVersion: version}
+}
+
+// SetAutoUpdateCheck enables or disables scheduled update checks (opt-in)
+func (a *App) SetAutoUpdateCheck(enabled bool) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+
+	if err := a.settings.Set("updates.autoCheck", strconv.FormatBool(enabled)); err != nil {
+		return err
+	}
+
+	a.rescheduleMaintenance(false)
+	return nil
+}
+
+// DownloadUpdate downloads the release asset for this platform, emitting
+// update:progress events, and returns the downloaded file path
+func (a *App) DownloadUpdate() (string, error) {
+	info := a.updater.Info()
+	if info == nil || !info.Available {
+		return "", fmt.Errorf("no update available")
+	}
+
+	asset, ok := platformAsset(info.Assets)
+	if !ok {
+		return "", fmt.Errorf("no download available for %s", goruntime.GOOS)
+	}
+
+	op := a.startOperation("update", fmt.Sprintf("Downloading Gylte %s…", info.LatestVersion))
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	dir := filepath.Join(cacheDir, "Gylte", "updates")
+
+	path, err := a.updater.Download(context.Background(), asset, dir, func(written, total int64) {
+		percent := 0.0
+		if total > 0 {
+			percent = float64(written) / float64(total) * 100
+		}
+		a.emit("update:progress", map[string]interface{}{
+			"downloaded": written,
+			"total":      total,
+			"percent":    percent,
+		})
+		op.Progress(percent, "")
+	})
+	if err != nil {
+		return "", op.Fail("Could not download the update", err)
+	}
+
+	log.Printf("Update downloaded to %s", path)
+	op.Succeed(fmt.Sprintf("Gylte %s downloaded", info.LatestVersion))
+	a.emit("update:downloaded", path)
+	return path, nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Bounds checked before bound methods touch the cache or the database
+const (
+	// maxQueryLength is the longest search term or category in characters;
+	// glyph names are far shorter, so anything longer is a frontend bug
+	maxQueryLength = 256
+	// maxResultLimit is the most results one page may hold
+	maxResultLimit = maxPageSize
+	// maxResultOffset keeps offset+limit from overflowing
+	maxResultOffset = 1 << 24
+	// maxBatchSize is the most glyphs one bulk call may change, enough for
+	// every glyph of several attached sources
+	maxBatchSize = 100000
+)
+
+// invalidArgument returns an invalid_argument error naming the offending field
+func invalidArgument(field, format string, args ...any) *AppError {
+	err := newAppError(ErrCodeInvalid, format, args...)
+	err.Details = map[string]string{"field": field}
+	return err
+}
+
+// validateQuery rejects search terms and names that are too long or not UTF-8
+func validateQuery(field, value string) error {
+	// Check the byte length first so a huge string is rejected without scanning it
+	if len(value) > maxQueryLength*utf8.UTFMax || utf8.RuneCountInString(value) > maxQueryLength {
+		return invalidArgument(field, "%s is longer than %d characters", field, maxQueryLength)
+	}
+	if !utf8.ValidString(value) {
+		return invalidArgument(field, "%s is not valid UTF-8", field)
+	}
+	return nil
+}
+
+// validateSearch checks the free-text inputs of a search
+func validateSearch(searchTerm, category string) error {
+	if err := validateQuery("query", searchTerm); err != nil {
+		return err
+	}
+	return validateQuery("category", category)
+}
+
+// validatePage checks a limit and offset; a limit of 0 means the default page size
+func validatePage(limit, offset int) error {
+	if limit < 0 || limit > maxResultLimit {
+		return invalidArgument("limit", "limit must be between 0 (default) and %d", maxResultLimit)
+	}
+	if offset < 0 || offset > maxResultOffset {
+		return invalidArgument("offset", "offset must be between 0 and %d", maxResultOffset)
+	}
+	return nil
+}
+
+// validateChoice checks that value is one of allowed
+func validateChoice(field, value string, allowed ...string) error {
+	if !slices.Contains(allowed, value) {
+		quoted := make([]string, len(allowed))
+		for i, v := range allowed {
+			quoted[i] = strconv.Quote(v)
+		}
+		return invalidArgument(field, "unknown %s %q (expected %s)", field, value, strings.Join(quoted, ", "))
+	}
+	return nil
+}
+
+// validateGlyphID checks that a glyph exists
+func (a *App) validateGlyphID(id int) error {
+	if _, ok := a.findGlyph(id); !ok {
+		err := newAppError(ErrCodeNotFound, "glyph %d not found", id)
+		err.Details = map[string]string{"field": "id"}
+		return err
+	}
+	return nil
+}
+
+// validateGlyphIDs checks a bulk selection: non-empty, bounded, and every glyph exists
+func (a *App) validateGlyphIDs(ids []int) error {
+	if len(ids) == 0 {
+		return invalidArgument("ids", "no glyphs given")
+	}
+	if len(ids) > maxBatchSize {
+		return invalidArgument("ids", "at most %d glyphs can be changed at once", maxBatchSize)
+	}
+	found := a.lookupGlyphs(ids)
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			err := newAppError(ErrCodeNotFound, "glyph %d not found", id)
+			err.Details = map[string]string{"field": "ids"}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"log"
+	"math"
+	"strconv"
+
+	"github.com/wailsapp/wails/v2/pkg/options/windows"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Window effects. Mica, acrylic and tabbed are Windows backdrops; vibrancy is
+// the macOS translucent material. Auto picks each platform's default.
+const (
+	WindowEffectAuto     = "auto"
+	WindowEffectNone     = "none"
+	WindowEffectMica     = "mica"
+	WindowEffectAcrylic  = "acrylic"
+	WindowEffectTabbed   = "tabbed"
+	WindowEffectVibrancy = "vibrancy"
+)
+
+// minWindowOpacity keeps the window from becoming invisible
+const minWindowOpacity = 0.2
+
+// EventWindowOpacity is emitted with the new opacity so the frontend can apply it
+const EventWindowOpacity = "window:opacity"
+
+// WindowPrefs are the window appearance settings. Effect and Frameless are
+// applied when the window is created, so changing them needs a restart.
+type WindowPrefs struct {
+	Effect      string  `json:"effect"`
+	Opacity     float64 `json:"opacity"`
+	Frameless   bool    `json:"frameless"`
+	AlwaysOnTop bool    `json:"alwaysOnTop"`
+}
+
+// isWindowEffect reports whether effect names a known window effect
+func isWindowEffect(effect string) bool {
+	switch effect {
+	case WindowEffectAuto, WindowEffectNone, WindowEffectMica, WindowEffectAcrylic, WindowEffectTabbed, WindowEffectVibrancy:
+		return true
+	}
+	return false
+}
+
+// windowPrefsFrom reads window settings, falling back to the defaults for missing or invalid values
+func windowPrefsFrom(s *Settings) WindowPrefs {
+	prefs := WindowPrefs{
+		Effect:      s.Get("window.effect", WindowEffectAuto),
+		Opacity:     1,
+		Frameless:   s.GetBool("window.frameless", true),
+		AlwaysOnTop: s.GetBool("window.alwaysOnTop", true),
+	}
+	if !isWindowEffect(prefs.Effect) || windowEffectSupport(prefs.Effect) != nil {
+		prefs.Effect = WindowEffectAuto
+	}
+	if v, err := strconv.ParseFloat(s.Get("window.opacity", ""), 64); err == nil {
+		prefs.Opacity = clampOpacity(v)
+	}
+	return prefs
+}
+
+// clampOpacity limits opacity to [minWindowOpacity, 1]; NaN, which min and max
+// pass through, reads as fully opaque
+func clampOpacity(v float64) float64 {
+	if math.IsNaN(v) {
+		return 1
+	}
+	return max(minWindowOpacity, min(v, 1))
+}
+
+// startupWindowPrefs reads the window settings of the active profile before the
+// window exists. Any failure falls back to the defaults.
+func (a *App) startupWindowPrefs() WindowPrefs {
+	settings := &Settings{values: make(map[string]string)}
+
+	db, err := openSQLite(a.homeDBPath(), true)
+	if err != nil {
+		return windowPrefsFrom(settings)
+	}
+	defer db.Close()
+
+	if profile := a.activeProfileIn(db); profile != defaultProfile {
+		profileDB, err := openSQLite(a.profilePath(profile), true)
+		if err != nil {
+			return windowPrefsFrom(settings)
+		}
+		defer profileDB.Close()
+		db = profileDB
+	}
+
+	if values, err := readSettingsTable(db); err == nil {
+		settings.values = values
+	}
+	return windowPrefsFrom(settings)
+}
+
+// windowsBackdrop maps a window effect to the Windows backdrop type
+func windowsBackdrop(effect string) windows.BackdropType {
+	switch effect {
+	case WindowEffectNone:
+		return windows.None
+	case WindowEffectAcrylic:
+		return windows.Acrylic
+	case WindowEffectTabbed:
+		return windows.Tabbed
+	case WindowEffectMica, WindowEffectAuto:
+		return windows.Mica
+	}
+	return windows.Auto
+}
+
+// GetWindowPrefs returns the window appearance settings
+func (a *App) GetWindowPrefs() WindowPrefs {
+	return windowPrefsFrom(a.settings)
+}
+
+// SetWindowEffect changes the window backdrop (takes effect after a restart)
+func (a *App) SetWindowEffect(effect string) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if !isWindowEffect(effect) {
+		return newAppError(ErrCodeInvalid, "unknown window effect: %s", effect)
+	}
+	if err := windowEffectSupport(effect); err != nil {
+		return newAppError(ErrCodeUnsupported, "%w", err)
+	}
+	return a.settings.Set("window.effect", effect)
+}
+
+// SetFrameless toggles the native window frame (takes effect after a restart)
+func (a *App) SetFrameless(frameless bool) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	return a.settings.Set("window.frameless", strconv.FormatBool(frameless))
+}
+
+// SetAlwaysOnTop keeps the window above other windows
+func (a *App) SetAlwaysOnTop(onTop bool) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+	if err := a.settings.Set("window.alwaysOnTop", strconv.FormatBool(onTop)); err != nil {
+		return err
+	}
+	if a.hasRuntime() {
+		runtime.WindowSetAlwaysOnTop(a.ctx, onTop)
+	}
+	return nil
+}
+
+// SetOpacity changes the window opacity (0.2-1) immediately and persists it
+func (a *App) SetOpacity(opacity float64) error {
+	if err := a.checkWritable("change settings"); err != nil {
+		return err
+	}
+
+	if math.IsNaN(opacity) || math.IsInf(opacity, 0) {
+		return invalidArgument("opacity", "opacity must be a number between %.1f and 1", minWindowOpacity)
+	}
+	opacity = clampOpacity(opacity)
+	if err := a.settings.Set("window.opacity", strconv.FormatFloat(opacity, 'f', 2, 64)); err != nil {
+		return err
+	}
+
+	log.Printf("Window opacity: %.2f", opacity)
+	a.emit(EventWindowOpacity, opacity)
+	return nil
+}
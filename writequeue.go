@@ -0,0 +1,88 @@
+package main
+
+import (
+	"database/sql"
+	"log"
+	"sync"
+)
+
+// queuedWrite is one statement waiting in a WriteQueue
+type queuedWrite struct {
+	query string
+	args  []any
+}
+
+// WriteQueue holds small user data writes (favorites, category usage) while a
+// glyph import's transaction holds the only writer connection of the default
+// profile, which shares the glyph database. Without it those writes wait for
+// the whole import. Queued writes run in order, in one transaction, when the
+// import finishes.
+type WriteQueue struct {
+	mu      sync.Mutex
+	held    bool
+	pending []queuedWrite
+}
+
+// hold starts queueing writes
+func (q *WriteQueue) hold() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.held = true
+}
+
+// release stops queueing and runs the queued writes on db
+func (q *WriteQueue) release(db *sql.DB) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.held = false
+	pending := q.pending
+	q.pending = nil
+	if len(pending) == 0 {
+		return
+	}
+	if err := execWrites(db, pending); err != nil {
+		log.Printf("Failed to apply %d writes queued during import: %v", len(pending), err)
+		return
+	}
+	log.Printf("Applied %d writes queued during import", len(pending))
+}
+
+// Exec runs a statement on db, or queues it while an import holds the writer
+func (q *WriteQueue) Exec(db *sql.DB, query string, args ...any) error {
+	return q.ExecBatch(db, []queuedWrite{{query, args}})
+}
+
+// ExecBatch runs statements on db in one transaction, or queues them while an
+// import holds the writer
+func (q *WriteQueue) ExecBatch(db *sql.DB, writes []queuedWrite) error {
+	q.mu.Lock()
+	if q.held {
+		q.pending = append(q.pending, writes...)
+		q.mu.Unlock()
+		return nil
+	}
+	q.mu.Unlock()
+
+	if len(writes) == 1 {
+		_, err := db.Exec(writes[0].query, writes[0].args...)
+		return err
+	}
+	return execWrites(db, writes)
+}
+
+// execWrites runs statements in one transaction
+func execWrites(db *sql.DB, writes []queuedWrite) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, w := range writes {
+		if _, err := tx.Exec(w.query, w.args...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}